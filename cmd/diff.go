@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astnormalization"
+	"github.com/wundergraph/graphql-go-tools/pkg/astparser"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+	"github.com/wundergraph/graphql-go-tools/pkg/schemadiff"
+)
+
+var (
+	oldSchemaFile  string
+	newSchemaFile  string
+	operationsPath string
+)
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compares two schemas and reports breaking changes",
+	Long: `diff compares an old with a new schema and prints all changes as JSON.
+Each change is classified as BREAKING, DANGEROUS or SAFE.
+The command fails if there is at least one breaking change, so that it can be used to block deploys in CI.`,
+	Example:       `graphql-go-tools diff --old ./schema.graphql --new ./schema.next.graphql`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		oldSchema, err := loadSchemaFile(oldSchemaFile)
+		if err != nil {
+			return err
+		}
+		newSchema, err := loadSchemaFile(newSchemaFile)
+		if err != nil {
+			return err
+		}
+
+		if operationsPath != "" {
+			return checkUsage(cmd, oldSchema, newSchema)
+		}
+
+		changes := schemadiff.Diff(oldSchema, newSchema)
+
+		out, err := json.MarshalIndent(changes, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(out))
+
+		if changes.HasBreaking() {
+			return fmt.Errorf("found %d breaking changes", len(changes.Filter(schemadiff.CriticalityBreaking)))
+		}
+		return nil
+	},
+}
+
+func checkUsage(cmd *cobra.Command, oldSchema, newSchema *ast.Document) error {
+	operations, err := schemadiff.LoadOperations(operationsPath)
+	if err != nil {
+		return err
+	}
+	report, err := schemadiff.CheckUsage(oldSchema, newSchema, operations)
+	if err != nil {
+		return err
+	}
+
+	out, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), string(out))
+
+	if report.IsBreaking() {
+		return fmt.Errorf("%d of %d operations break", len(report.BrokenOperations), len(operations))
+	}
+	return nil
+}
+
+// loadSchemaFile parses a schema and merges its type extensions
+func loadSchemaFile(fileName string) (*ast.Document, error) {
+	content, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, report := astparser.ParseGraphqlDocumentBytes(content)
+	if report.HasErrors() {
+		return nil, fmt.Errorf("parse %s: %w", fileName, report)
+	}
+
+	report = operationreport.Report{}
+	astnormalization.NormalizeDefinition(&doc, &report)
+	if report.HasErrors() {
+		return nil, fmt.Errorf("normalize %s: %w", fileName, report)
+	}
+	return &doc, nil
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+
+	diffCmd.Flags().StringVar(&oldSchemaFile, "old", "", "old is the path to the schema which is currently deployed (required)")
+	_ = diffCmd.MarkFlagRequired("old")
+
+	diffCmd.Flags().StringVar(&newSchemaFile, "new", "", "new is the path to the schema which should be deployed (required)")
+	_ = diffCmd.MarkFlagRequired("new")
+
+	diffCmd.Flags().StringVar(&operationsPath, "operations", "", "operations is a directory of .graphql files or a JSON manifest which maps names to queries, only changes which break these operations fail the command (optional)")
+}
@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astparser"
+	"github.com/wundergraph/graphql-go-tools/pkg/astprinter"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+var (
+	fmtWrite         bool
+	fmtList          bool
+	fmtMaxLineLength int
+	fmtDescriptions  string
+	fmtSort          bool
+	fmtIndent        string
+)
+
+// fmtCmd represents the fmt command
+var fmtCmd = &cobra.Command{
+	Use:   "fmt [path ...]",
+	Short: "Formats GraphQL documents",
+	Long: `fmt formats schemas and operations the same way gofmt formats Go code.
+Paths can be files or directories, directories are searched recursively for .graphql files.
+Comments are preserved, by default the formatted documents are printed to stdout.
+Without paths the document is read from stdin.`,
+	Example:       `graphql-go-tools fmt -w --max-line-length 100 ./schema`,
+	Args:          cobra.ArbitraryArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		options, err := formatOptions()
+		if err != nil {
+			return err
+		}
+
+		if len(args) == 0 {
+			if fmtWrite {
+				return fmt.Errorf("cannot use -w with stdin")
+			}
+			content, err := ioutil.ReadAll(cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+			formatted, err := formatDocument("<stdin>", content, options)
+			if err != nil {
+				return err
+			}
+			if fmtList {
+				if formatted != string(content) {
+					fmt.Fprintln(cmd.OutOrStdout(), "<stdin>")
+				}
+				return nil
+			}
+			_, err = fmt.Fprint(cmd.OutOrStdout(), formatted)
+			return err
+		}
+
+		files, err := graphqlFiles(args)
+		if err != nil {
+			return err
+		}
+		for _, fileName := range files {
+			if err := formatFile(cmd, fileName, options); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func formatOptions() (astprinter.FormatOptions, error) {
+	options := astprinter.FormatOptions{
+		Indent:        fmtIndent,
+		MaxLineLength: fmtMaxLineLength,
+		Sort:          fmtSort,
+	}
+	switch fmtDescriptions {
+	case "preserve":
+		options.Descriptions = astprinter.DescriptionStylePreserve
+	case "block":
+		options.Descriptions = astprinter.DescriptionStyleBlock
+	case "single-line":
+		options.Descriptions = astprinter.DescriptionStyleSingleLine
+	default:
+		return options, fmt.Errorf("invalid descriptions style %q, must be one of preserve, block, single-line", fmtDescriptions)
+	}
+	return options, nil
+}
+
+func formatFile(cmd *cobra.Command, fileName string, options astprinter.FormatOptions) error {
+	content, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+	formatted, err := formatDocument(fileName, content, options)
+	if err != nil {
+		return err
+	}
+
+	changed := formatted != string(content)
+	if fmtList && changed {
+		fmt.Fprintln(cmd.OutOrStdout(), fileName)
+	}
+	if fmtWrite {
+		if !changed {
+			return nil
+		}
+		info, err := os.Stat(fileName)
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(fileName, []byte(formatted), info.Mode().Perm())
+	}
+	if !fmtList {
+		_, err = fmt.Fprint(cmd.OutOrStdout(), formatted)
+	}
+	return err
+}
+
+// formatDocument parses a document including its comments and formats it
+func formatDocument(fileName string, content []byte, options astprinter.FormatOptions) (string, error) {
+	doc := ast.NewDocument()
+	doc.Input.ResetInputBytes(content)
+
+	parser := astparser.NewParser()
+	parser.KeepComments(true)
+	report := operationreport.Report{}
+	parser.Parse(doc, &report)
+	if report.HasErrors() {
+		return "", fmt.Errorf("parse %s: %w", fileName, report)
+	}
+
+	return astprinter.FormatString(doc, options)
+}
+
+// graphqlFiles returns the files of paths, directories are walked for files with the .graphql extension
+func graphqlFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		err = filepath.Walk(path, func(fileName string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !info.IsDir() && strings.HasSuffix(fileName, ".graphql") {
+				files = append(files, fileName)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+func init() {
+	rootCmd.AddCommand(fmtCmd)
+
+	fmtCmd.Flags().BoolVarP(&fmtWrite, "write", "w", false, "write the result to the source files instead of stdout")
+	fmtCmd.Flags().BoolVarP(&fmtList, "list", "l", false, "list the files whose formatting differs instead of printing them")
+	fmtCmd.Flags().IntVar(&fmtMaxLineLength, "max-line-length", 0, "max-line-length wraps arguments, variables and union members of longer lines, 0 disables wrapping")
+	fmtCmd.Flags().StringVar(&fmtDescriptions, "descriptions", "preserve", "descriptions is the style of descriptions, one of preserve, block, single-line")
+	fmtCmd.Flags().BoolVar(&fmtSort, "sort", false, "sort sorts type definitions, directive definitions, fields and input fields alphabetically")
+	fmtCmd.Flags().StringVar(&fmtIndent, "indent", "  ", "indent is the indentation of one level")
+}
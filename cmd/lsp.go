@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/languageserver"
+)
+
+var lspSchemaFiles []string
+
+// lspCmd represents the lsp command
+var lspCmd = &cobra.Command{
+	Use:   "lsp",
+	Short: "Starts a GraphQL language server on stdio",
+	Long: `lsp starts a Language Server Protocol server which communicates with the editor via stdin and stdout.
+It publishes diagnostics and provides go-to-definition, hover, completion and formatting for schemas and operations.
+Opened documents without operations or fragments are part of the schema, additional schema files can be loaded with --schema.`,
+	Example:       `graphql-go-tools lsp --schema ./schema.graphql`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		server := languageserver.NewServer()
+		if err := server.LoadSchemaFiles(lspSchemaFiles...); err != nil {
+			return err
+		}
+		return server.Serve(cmd.InOrStdin(), cmd.OutOrStdout())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(lspCmd)
+
+	lspCmd.Flags().StringSliceVar(&lspSchemaFiles, "schema", nil, "schema is the path to a schema file which is always part of the schema, the flag can be repeated (optional)")
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astparser"
+	"github.com/wundergraph/graphql-go-tools/pkg/asttransform"
+	"github.com/wundergraph/graphql-go-tools/pkg/codegen"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+var (
+	operationsSchemaFile  string
+	operationsPackageName string
+	operationsOutFile     string
+	operationsScalarTypes map[string]string
+	operationsEngine      bool
+)
+
+// operationsCmd represents the operations command
+var operationsCmd = &cobra.Command{
+	Use:   "operations [path ...]",
+	Short: "Generates a typed go client for GraphQL operations",
+	Long: `operations generates go structs for the variables and responses of named operations and funcs to execute them.
+Paths can be files or directories, directories are searched recursively for .graphql files.
+Interfaces and unions get a pointer field per possible type selected using fragments, which is set based on __typename.
+Custom scalars are mapped to go types using --scalar, unmapped custom scalars are generated as json.RawMessage.`,
+	Example:       `graphql-go-tools gen operations -s ./schema.graphql -p client -o ./client/operations.go --scalar DateTime=time.Time ./operations`,
+	Args:          cobra.MinimumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		schemaContent, err := ioutil.ReadFile(operationsSchemaFile)
+		if err != nil {
+			return err
+		}
+		schema, err := parseDocument(operationsSchemaFile, schemaContent)
+		if err != nil {
+			return err
+		}
+		if err := asttransform.MergeDefinitionWithBaseSchema(schema); err != nil {
+			return err
+		}
+
+		files, err := graphqlFiles(args)
+		if err != nil {
+			return err
+		}
+		operationsContent := bytes.Buffer{}
+		for _, fileName := range files {
+			content, err := ioutil.ReadFile(fileName)
+			if err != nil {
+				return err
+			}
+			// parse each file on its own to report syntax errors with the name of the file
+			if _, err := parseDocument(fileName, content); err != nil {
+				return err
+			}
+			operationsContent.Write(content)
+			operationsContent.WriteByte('\n')
+		}
+		operations, err := parseDocument("operations", operationsContent.Bytes())
+		if err != nil {
+			return err
+		}
+
+		var out io.Writer
+		if operationsOutFile == "" {
+			out = cmd.OutOrStdout()
+		} else {
+			o, err := os.Create(operationsOutFile)
+			if err != nil {
+				return err
+			}
+			defer o.Close()
+			out = o
+		}
+
+		config := codegen.OperationsConfig{
+			PackageName:     operationsPackageName,
+			ScalarTypes:     operationsScalarTypes,
+			ExecutionEngine: operationsEngine,
+		}
+
+		gen := codegen.NewOperations(schema, operations, config)
+		_, err = gen.Generate(out)
+		return err
+	},
+}
+
+func parseDocument(fileName string, content []byte) (*ast.Document, error) {
+	doc := ast.NewDocument()
+	doc.Input.ResetInputBytes(content)
+	report := operationreport.Report{}
+	astparser.NewParser().Parse(doc, &report)
+	if report.HasErrors() {
+		return nil, fmt.Errorf("parse %s: %w", fileName, report)
+	}
+	return doc, nil
+}
+
+func init() {
+	genCmd.AddCommand(operationsCmd)
+
+	operationsCmd.Flags().StringVarP(&operationsSchemaFile, "schema", "s", "", "schema is the file of the schema the operations are validated against (required)")
+	_ = operationsCmd.MarkFlagRequired("schema")
+
+	operationsCmd.Flags().StringVarP(&operationsPackageName, "packageName", "p", "", "packageName is the package for the generated code (required)")
+	_ = operationsCmd.MarkFlagRequired("packageName")
+
+	operationsCmd.Flags().StringVarP(&operationsOutFile, "outFile", "o", "", "outFile is a flag to redirect the output directly into a file (optional)")
+	operationsCmd.Flags().StringToStringVar(&operationsScalarTypes, "scalar", nil, "scalar maps a custom scalar to a go type, e.g. DateTime=time.Time or Decimal=github.com/shopspring/decimal.Decimal (optional, repeatable)")
+	operationsCmd.Flags().BoolVar(&operationsEngine, "engine", false, "engine additionally generates funcs to execute the operations using graphql.ExecutionEngineV2 (optional)")
+}
@@ -49,6 +49,7 @@ type Document struct {
 	OperationDefinitions         []OperationDefinition
 	VariableDefinitions          []VariableDefinition
 	FragmentDefinitions          []FragmentDefinition
+	Comments                     []Comment
 	BooleanValues                [2]BooleanValue
 	Refs                         [][8]int
 	RefIndex                     int
@@ -147,6 +148,7 @@ func (d *Document) Reset() {
 	d.OperationDefinitions = d.OperationDefinitions[:0]
 	d.VariableDefinitions = d.VariableDefinitions[:0]
 	d.FragmentDefinitions = d.FragmentDefinitions[:0]
+	d.Comments = d.Comments[:0]
 
 	d.RefIndex = -1
 	d.Index.Reset()
@@ -202,6 +204,15 @@ func (d *Document) DeleteRootNode(node Node) {
 	}
 }
 
+// DeleteDefinition removes a definition from the root nodes and from the index.
+// The nodes of the definition stay in the document, use astimport to compact it.
+func (d *Document) DeleteDefinition(node Node) {
+	d.DeleteRootNode(node)
+	if name := d.NodeNameBytes(node); len(name) != 0 {
+		d.Index.RemoveNodeBytes(name, node)
+	}
+}
+
 func (d *Document) RemoveMergedTypeExtensions() {
 	for _, node := range d.Index.MergedTypeExtensions {
 		d.RemoveRootNode(node)
@@ -16,18 +16,21 @@ type ArgumentList struct {
 }
 
 type Argument struct {
-	Name  ByteSliceReference // e.g. foo
-	Colon position.Position  // :
-	Value Value              // e.g. 100 or "Bar"
+	Name     ByteSliceReference // e.g. foo
+	Colon    position.Position  // :
+	Value    Value              // e.g. 100 or "Bar"
+	Position position.Position  // start and end of the node in the document
 }
 
 func (d *Document) CopyArgument(ref int) int {
 	return d.AddArgument(Argument{
 		Name: d.copyByteSliceReference(d.Arguments[ref].Name),
 		Value: Value{
-			Kind: d.Arguments[ref].Value.Kind,
-			Ref:  d.copyValueRef(d.Arguments[ref].Value.Kind, d.Arguments[ref].Value.Ref),
+			Kind:     d.Arguments[ref].Value.Kind,
+			Ref:      d.copyValueRef(d.Arguments[ref].Value.Kind, d.Arguments[ref].Value.Ref),
+			Position: d.Arguments[ref].Value.Position,
 		},
+		Position: d.Arguments[ref].Position,
 	})
 }
 
@@ -0,0 +1,27 @@
+package ast
+
+import (
+	"github.com/wundergraph/graphql-go-tools/internal/pkg/unsafebytes"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/position"
+)
+
+// Comment is a # comment of the source document
+// Comments are only retained by parsers which keep comments, see astparser.Parser.KeepComments.
+// Consecutive comment lines are lexed as a single comment.
+type Comment struct {
+	Content  ByteSliceReference // e.g. # foo, including the leading #
+	Position position.Position
+}
+
+func (d *Document) CommentBytes(ref int) ByteSlice {
+	return d.Input.ByteSlice(d.Comments[ref].Content)
+}
+
+func (d *Document) CommentString(ref int) string {
+	return unsafebytes.BytesToString(d.CommentBytes(ref))
+}
+
+func (d *Document) AddComment(comment Comment) (ref int) {
+	d.Comments = append(d.Comments, comment)
+	return len(d.Comments) - 1
+}
@@ -16,7 +16,8 @@ type Directive struct {
 	At           position.Position  // @
 	Name         ByteSliceReference // e.g. include
 	HasArguments bool
-	Arguments    ArgumentList // e.g. (if: true)
+	Arguments    ArgumentList      // e.g. (if: true)
+	Position     position.Position // start and end of the node in the document
 }
 
 func (l *DirectiveList) HasDirectiveByName(document *Document, name string) bool {
@@ -50,6 +51,7 @@ func (d *Document) CopyDirective(ref int) int {
 		Name:         d.copyByteSliceReference(d.Directives[ref].Name),
 		HasArguments: d.Directives[ref].HasArguments,
 		Arguments:    arguments,
+		Position:     d.Directives[ref].Position,
 	})
 }
 
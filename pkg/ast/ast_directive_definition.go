@@ -20,6 +20,7 @@ type DirectiveDefinition struct {
 	On                      position.Position        // on
 	DirectiveLocations      DirectiveLocations       // e.g. FIELD
 	Repeatable              Repeatable
+	Position                position.Position // start and end of the node in the document
 }
 
 type Repeatable struct {
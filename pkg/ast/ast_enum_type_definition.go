@@ -9,12 +9,13 @@ import (
 
 // EnumTypeDefinition
 // example:
-// enum Direction {
-//  NORTH
-//  EAST
-//  SOUTH
-//  WEST
-// }
+//
+//	enum Direction {
+//	 NORTH
+//	 EAST
+//	 SOUTH
+//	 WEST
+//	}
 type EnumTypeDefinition struct {
 	Description             Description        // optional, describes enum
 	EnumLiteral             position.Position  // enum
@@ -23,6 +24,7 @@ type EnumTypeDefinition struct {
 	Directives              DirectiveList // optional, e.g. @foo
 	HasEnumValuesDefinition bool
 	EnumValuesDefinition    EnumValueDefinitionList // optional, e.g. { NORTH EAST }
+	Position                position.Position       // start and end of the node in the document
 }
 
 func (d *Document) EnumTypeDefinitionNameBytes(ref int) ByteSlice {
@@ -20,7 +20,8 @@ type EnumValueDefinition struct {
 	Description   Description        // optional, describes enum value
 	EnumValue     ByteSliceReference // e.g. NORTH (Name but not true, false or null
 	HasDirectives bool
-	Directives    DirectiveList // optional, e.g. @foo
+	Directives    DirectiveList     // optional, e.g. @foo
+	Position      position.Position // start and end of the node in the document
 }
 
 func (d *Document) EnumValueDefinitionNameBytes(ref int) ByteSlice {
@@ -16,7 +16,7 @@ type Field struct {
 	Directives    DirectiveList // optional
 	SelectionSet  int           // optional
 	HasSelections bool
-	Position      position.Position
+	Position      position.Position // start and end of the node in the document
 }
 
 func (d *Document) CopyField(ref int) int {
@@ -41,6 +41,7 @@ func (d *Document) CopyField(ref int) int {
 		Directives:    directives,
 		HasSelections: d.Fields[ref].HasSelections,
 		SelectionSet:  selectionSet,
+		Position:      d.Fields[ref].Position,
 	}).Ref
 }
 
@@ -74,6 +75,30 @@ func (d *Document) AddArgumentToField(fieldRef, argRef int) {
 	d.Fields[fieldRef].Arguments.Refs = append(d.Fields[fieldRef].Arguments.Refs, argRef)
 }
 
+// AddFieldArgument adds an argument with the given name and value to the field.
+// If the field already has an argument with this name, its value is replaced instead.
+func (d *Document) AddFieldArgument(field int, name string, value Value) (ref int) {
+	if existing, exists := d.FieldArgument(field, unsafebytes.StringToBytes(name)); exists {
+		d.Arguments[existing].Value = value
+		return existing
+	}
+	ref = d.ImportArgument(name, value)
+	d.AddArgumentToField(field, ref)
+	return ref
+}
+
+// AddSelectionSetToField returns the selection set of the field.
+// An empty selection set gets created if the field has none yet.
+func (d *Document) AddSelectionSetToField(field int) (set int) {
+	if d.Fields[field].HasSelections {
+		return d.Fields[field].SelectionSet
+	}
+	set = d.AddSelectionSet().Ref
+	d.Fields[field].SelectionSet = set
+	d.Fields[field].HasSelections = true
+	return set
+}
+
 func (d *Document) FieldArguments(ref int) []int {
 	return d.Fields[ref].Arguments.Refs
 }
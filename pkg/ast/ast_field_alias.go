@@ -41,6 +41,11 @@ func (d *Document) FieldAliasIsDefined(ref int) bool {
 	return d.Fields[ref].Alias.IsDefined
 }
 
+func (d *Document) SetFieldAlias(ref int, alias string) {
+	d.Fields[ref].Alias.IsDefined = true
+	d.Fields[ref].Alias.Name = d.Input.AppendInputString(alias)
+}
+
 func (d *Document) RemoveFieldAlias(ref int) {
 	d.Fields[ref].Alias.IsDefined = false
 	d.Fields[ref].Alias.Name.Start = 0
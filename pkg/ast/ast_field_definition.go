@@ -22,7 +22,8 @@ type FieldDefinition struct {
 	Colon                   position.Position        // :
 	Type                    int                      // e.g. String
 	HasDirectives           bool
-	Directives              DirectiveList // e.g. @foo
+	Directives              DirectiveList     // e.g. @foo
+	Position                position.Position // start and end of the node in the document
 }
 
 func (d *Document) FieldDefinitionNameBytes(ref int) ByteSlice {
@@ -17,11 +17,12 @@ type TypeCondition struct {
 
 // FragmentDefinition
 // example:
-// fragment friendFields on User {
-//  id
-//  name
-//  profilePic(size: 50)
-// }
+//
+//	fragment friendFields on User {
+//	 id
+//	 name
+//	 profilePic(size: 50)
+//	}
 type FragmentDefinition struct {
 	FragmentLiteral position.Position  // fragment
 	Name            ByteSliceReference // Name but not on, e.g. friendFields
@@ -29,6 +30,7 @@ type FragmentDefinition struct {
 	Directives      DirectiveList      // optional, e.g. @foo
 	SelectionSet    int                // e.g. { id }
 	HasSelections   bool
+	Position        position.Position // start and end of the node in the document
 }
 
 func (d *Document) FragmentDefinitionRef(byName ByteSlice) (ref int, exists bool) {
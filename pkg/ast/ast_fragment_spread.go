@@ -12,7 +12,8 @@ type FragmentSpread struct {
 	Spread        position.Position  // ...
 	FragmentName  ByteSliceReference // Name but not on, e.g. MyFragment
 	HasDirectives bool
-	Directives    DirectiveList // optional, e.g. @foo
+	Directives    DirectiveList     // optional, e.g. @foo
+	Position      position.Position // start and end of the node in the document
 }
 
 func (d *Document) CopyFragmentSpread(ref int) int {
@@ -24,6 +25,7 @@ func (d *Document) CopyFragmentSpread(ref int) int {
 		FragmentName:  d.copyByteSliceReference(d.FragmentSpreads[ref].FragmentName),
 		HasDirectives: d.FragmentSpreads[ref].HasDirectives,
 		Directives:    directives,
+		Position:      d.FragmentSpreads[ref].Position,
 	})
 }
 
@@ -63,6 +65,8 @@ func (d *Document) ReplaceFragmentSpreadWithInlineFragment(selectionSet int, spr
 		TypeCondition: typeCondition,
 		SelectionSet:  replaceWithSelectionSet,
 		HasSelections: len(d.SelectionSets[replaceWithSelectionSet].SelectionRefs) != 0,
+		HasDirectives: d.FragmentSpreads[spreadRef].HasDirectives,
+		Directives:    d.FragmentSpreads[spreadRef].Directives,
 	})
 	ref := len(d.InlineFragments) - 1
 	d.Selections = append(d.Selections, Selection{
@@ -7,11 +7,12 @@ import (
 
 // InlineFragment
 // example:
-// ... on User {
-//      friends {
-//        count
-//      }
-//    }
+//
+//	... on User {
+//	     friends {
+//	       count
+//	     }
+//	   }
 type InlineFragment struct {
 	Spread        position.Position // ...
 	TypeCondition TypeCondition     // on NamedType, e.g. on User
@@ -19,6 +20,7 @@ type InlineFragment struct {
 	Directives    DirectiveList // optional, e.g. @foo
 	SelectionSet  int           // optional, e.g. { nextField }
 	HasSelections bool
+	Position      position.Position // start and end of the node in the document
 }
 
 func (d *Document) CopyInlineFragment(ref int) int {
@@ -36,6 +38,7 @@ func (d *Document) CopyInlineFragment(ref int) int {
 		Directives:    directives,
 		SelectionSet:  selectionSet,
 		HasSelections: d.InlineFragments[ref].HasSelections,
+		Position:      d.InlineFragments[ref].Position,
 	})
 }
 
@@ -69,3 +72,19 @@ func (d *Document) AddInlineFragment(fragment InlineFragment) int {
 	d.InlineFragments = append(d.InlineFragments, fragment)
 	return len(d.InlineFragments) - 1
 }
+
+// ImportInlineFragment creates an inline fragment with the given selection set.
+// An empty typeCondition creates an inline fragment without type condition, e.g. ... { id }
+func (d *Document) ImportInlineFragment(typeCondition string, selectionSet int) (ref int) {
+	fragment := InlineFragment{
+		TypeCondition: TypeCondition{
+			Type: -1,
+		},
+		SelectionSet:  selectionSet,
+		HasSelections: true,
+	}
+	if typeCondition != "" {
+		fragment.TypeCondition.Type = d.AddNamedType(unsafebytes.StringToBytes(typeCondition))
+	}
+	return d.AddInlineFragment(fragment)
+}
@@ -15,6 +15,7 @@ type InputObjectTypeDefinition struct {
 	Directives               DirectiveList // optional, e.g. @foo
 	HasInputFieldsDefinition bool
 	InputFieldsDefinition    InputValueDefinitionList // e.g. x:Float
+	Position                 position.Position        // start and end of the node in the document
 }
 
 func (d *Document) InputObjectTypeDefinitionNameBytes(ref int) ByteSlice {
@@ -26,7 +26,8 @@ type InputValueDefinition struct {
 	Type          int                // e.g. String
 	DefaultValue  DefaultValue       // e.g. = "Bar"
 	HasDirectives bool
-	Directives    DirectiveList // e.g. @baz
+	Directives    DirectiveList     // e.g. @baz
+	Position      position.Position // start and end of the node in the document
 }
 
 func (d *Document) InputValueDefinitionNameBytes(ref int) ByteSlice {
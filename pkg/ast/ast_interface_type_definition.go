@@ -10,9 +10,10 @@ import (
 
 // InterfaceTypeDefinition
 // example:
-// interface NamedEntity {
-// 	name: String
-// }
+//
+//	interface NamedEntity {
+//		name: String
+//	}
 type InterfaceTypeDefinition struct {
 	Description          Description        // optional, describes the interface
 	InterfaceLiteral     position.Position  // interface
@@ -22,6 +23,7 @@ type InterfaceTypeDefinition struct {
 	Directives           DirectiveList // optional, e.g. @foo
 	HasFieldDefinitions  bool
 	FieldsDefinition     FieldDefinitionList // optional, e.g. { name: String }
+	Position             position.Position   // start and end of the node in the document
 }
 
 func (d *Document) InterfaceTypeDefinitionNameBytes(ref int) ByteSlice {
@@ -6,6 +6,7 @@ import (
 	"log"
 
 	"github.com/wundergraph/graphql-go-tools/internal/pkg/unsafebytes"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/position"
 )
 
 type Node struct {
@@ -60,11 +61,81 @@ func (d *Document) NodeNameBytes(node Node) ByteSlice {
 		ref = d.UnionTypeExtensions[node.Ref].Name
 	case NodeKindEnumTypeExtension:
 		ref = d.EnumTypeExtensions[node.Ref].Name
+	case NodeKindInputObjectTypeExtension:
+		ref = d.InputObjectTypeExtensions[node.Ref].Name
+	case NodeKindScalarTypeExtension:
+		ref = d.ScalarTypeExtensions[node.Ref].Name
 	}
 
 	return d.Input.ByteSlice(ref)
 }
 
+// NodePosition returns the start and end of a node in the document
+// Definitions start after their description, which has a position of its own.
+// Nodes which were not parsed from the input, e.g. added during normalization, return the zero position.
+func (d *Document) NodePosition(node Node) position.Position {
+	switch node.Kind {
+	case NodeKindSchemaDefinition:
+		return d.SchemaDefinitions[node.Ref].Position
+	case NodeKindSchemaExtension:
+		return d.SchemaExtensions[node.Ref].Position
+	case NodeKindObjectTypeDefinition:
+		return d.ObjectTypeDefinitions[node.Ref].Position
+	case NodeKindObjectTypeExtension:
+		return d.ObjectTypeExtensions[node.Ref].Position
+	case NodeKindInterfaceTypeDefinition:
+		return d.InterfaceTypeDefinitions[node.Ref].Position
+	case NodeKindInterfaceTypeExtension:
+		return d.InterfaceTypeExtensions[node.Ref].Position
+	case NodeKindUnionTypeDefinition:
+		return d.UnionTypeDefinitions[node.Ref].Position
+	case NodeKindUnionTypeExtension:
+		return d.UnionTypeExtensions[node.Ref].Position
+	case NodeKindUnionMemberType:
+		return d.Types[node.Ref].Position
+	case NodeKindEnumTypeDefinition:
+		return d.EnumTypeDefinitions[node.Ref].Position
+	case NodeKindEnumTypeExtension:
+		return d.EnumTypeExtensions[node.Ref].Position
+	case NodeKindEnumValueDefinition:
+		return d.EnumValueDefinitions[node.Ref].Position
+	case NodeKindInputObjectTypeDefinition:
+		return d.InputObjectTypeDefinitions[node.Ref].Position
+	case NodeKindInputObjectTypeExtension:
+		return d.InputObjectTypeExtensions[node.Ref].Position
+	case NodeKindInputValueDefinition:
+		return d.InputValueDefinitions[node.Ref].Position
+	case NodeKindScalarTypeDefinition:
+		return d.ScalarTypeDefinitions[node.Ref].Position
+	case NodeKindScalarTypeExtension:
+		return d.ScalarTypeExtensions[node.Ref].Position
+	case NodeKindDirectiveDefinition:
+		return d.DirectiveDefinitions[node.Ref].Position
+	case NodeKindOperationDefinition:
+		return d.OperationDefinitions[node.Ref].Position
+	case NodeKindSelectionSet:
+		return d.SelectionSets[node.Ref].Position
+	case NodeKindField:
+		return d.Fields[node.Ref].Position
+	case NodeKindFieldDefinition:
+		return d.FieldDefinitions[node.Ref].Position
+	case NodeKindFragmentSpread:
+		return d.FragmentSpreads[node.Ref].Position
+	case NodeKindInlineFragment:
+		return d.InlineFragments[node.Ref].Position
+	case NodeKindFragmentDefinition:
+		return d.FragmentDefinitions[node.Ref].Position
+	case NodeKindArgument:
+		return d.Arguments[node.Ref].Position
+	case NodeKindDirective:
+		return d.Directives[node.Ref].Position
+	case NodeKindVariableDefinition:
+		return d.VariableDefinitions[node.Ref].Position
+	default:
+		return position.Position{}
+	}
+}
+
 func (n Node) NameBytes(definition *Document) []byte {
 	return definition.NodeNameBytes(n)
 }
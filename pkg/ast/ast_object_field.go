@@ -11,18 +11,21 @@ import (
 // example:
 // lon: 12.43
 type ObjectField struct {
-	Name  ByteSliceReference // e.g. lon
-	Colon position.Position  // :
-	Value Value              // e.g. 12.43
+	Name     ByteSliceReference // e.g. lon
+	Colon    position.Position  // :
+	Value    Value              // e.g. 12.43
+	Position position.Position  // start and end of the node in the document
 }
 
 func (d *Document) CopyObjectField(ref int) int {
 	return d.AddObjectField(ObjectField{
 		Name: d.copyByteSliceReference(d.ObjectFields[ref].Name),
 		Value: Value{
-			Kind: d.ObjectFields[ref].Value.Kind,
-			Ref:  d.copyValueRef(d.ObjectFields[ref].Value.Kind, d.ObjectFields[ref].Value.Ref),
+			Kind:     d.ObjectFields[ref].Value.Kind,
+			Ref:      d.copyValueRef(d.ObjectFields[ref].Value.Kind, d.ObjectFields[ref].Value.Ref),
+			Position: d.ObjectFields[ref].Value.Position,
 		},
+		Position: d.ObjectFields[ref].Position,
 	})
 }
 
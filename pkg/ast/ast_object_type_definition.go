@@ -20,6 +20,7 @@ type ObjectTypeDefinition struct {
 	Directives           DirectiveList // e.g. @foo
 	HasFieldDefinitions  bool
 	FieldsDefinition     FieldDefinitionList // { foo:Bar bar(baz:String) }
+	Position             position.Position   // start and end of the node in the document
 }
 
 func (d *Document) ObjectTypeDefinitionNameBytes(ref int) ByteSlice {
@@ -26,6 +26,7 @@ type OperationDefinition struct {
 	Directives             DirectiveList // optional, e.g. @foo
 	SelectionSet           int           // e.g. {field}
 	HasSelections          bool
+	Position               position.Position // start and end of the node in the document
 }
 
 func (d *Document) OperationDefinitionHasVariableDefinition(ref int, variableName string) bool {
@@ -19,6 +19,7 @@ type RootOperationTypeDefinition struct {
 	OperationType OperationType     // one of query, mutation, subscription
 	Colon         position.Position // :
 	NamedType     Type              // e.g. Query
+	Position      position.Position // start and end of the node in the document
 }
 
 func (d *Document) RootOperationTypeDefinitionNameString(ref int) string {
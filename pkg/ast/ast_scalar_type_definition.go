@@ -13,7 +13,8 @@ type ScalarTypeDefinition struct {
 	ScalarLiteral position.Position  // scalar
 	Name          ByteSliceReference // e.g. JSON
 	HasDirectives bool
-	Directives    DirectiveList // optional, e.g. @foo
+	Directives    DirectiveList     // optional, e.g. @foo
+	Position      position.Position // start and end of the node in the document
 }
 
 func (d *Document) ScalarTypeDefinitionNameBytes(ref int) ByteSlice {
@@ -7,6 +7,7 @@ type SchemaDefinition struct {
 	HasDirectives                bool
 	Directives                   DirectiveList                   // optional, e.g. @foo
 	RootOperationTypeDefinitions RootOperationTypeDefinitionList // e.g. query: Query, mutation: Mutation, subscription: Subscription
+	Position                     position.Position               // start and end of the node in the document
 }
 
 func (s *SchemaDefinition) AddRootOperationTypeDefinitionRefs(refs ...int) {
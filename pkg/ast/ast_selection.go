@@ -21,6 +21,7 @@ type SelectionSet struct {
 	LBrace        position.Position
 	RBrace        position.Position
 	SelectionRefs []int
+	Position      position.Position // start and end of the node in the document
 }
 
 type Selection struct {
@@ -53,6 +54,7 @@ func (d *Document) CopySelectionSet(ref int) int {
 	}
 	return d.AddSelectionSetToDocument(SelectionSet{
 		SelectionRefs: refs,
+		Position:      d.SelectionSets[ref].Position,
 	})
 }
 
@@ -179,3 +181,57 @@ func (d *Document) SelectionSetHasFieldSelectionWithNameOrAliasBytes(set int, na
 func (d *Document) SelectionSetHasFieldSelectionWithNameOrAliasString(set int, nameOrAlias string) bool {
 	return d.SelectionSetHasFieldSelectionWithNameOrAliasBytes(set, unsafebytes.StringToBytes(nameOrAlias))
 }
+
+// AddFieldToSelectionSet creates a field with the given name and appends it to the selection set.
+func (d *Document) AddFieldToSelectionSet(set int, name string) (field int) {
+	field = d.AddField(Field{
+		Name:         d.Input.AppendInputString(name),
+		SelectionSet: -1,
+	}).Ref
+	d.AddSelection(set, Selection{
+		Kind: SelectionKindField,
+		Ref:  field,
+	})
+	return field
+}
+
+// RemoveFieldFromSelectionSet removes the first field selection whose alias or name equals aliasOrName.
+// It returns false if the selection set contains no such field.
+func (d *Document) RemoveFieldFromSelectionSet(set int, aliasOrName string) bool {
+	for i, j := range d.SelectionSets[set].SelectionRefs {
+		if d.Selections[j].Kind != SelectionKindField {
+			continue
+		}
+		if d.FieldAliasOrNameString(d.Selections[j].Ref) == aliasOrName {
+			d.RemoveFromSelectionSet(set, i)
+			return true
+		}
+	}
+	return false
+}
+
+// WrapSelectionInInlineFragment replaces the selection at index with an inline fragment containing that selection.
+func (d *Document) WrapSelectionInInlineFragment(set, index int, typeCondition string) (inlineFragment int) {
+	inner := d.AddSelectionSet().Ref
+	d.SelectionSets[inner].SelectionRefs = append(d.SelectionSets[inner].SelectionRefs, d.SelectionSets[set].SelectionRefs[index])
+	inlineFragment = d.ImportInlineFragment(typeCondition, inner)
+	d.SelectionSets[set].SelectionRefs[index] = d.AddSelectionToDocument(Selection{
+		Kind: SelectionKindInlineFragment,
+		Ref:  inlineFragment,
+	})
+	return inlineFragment
+}
+
+// WrapSelectionSetInInlineFragment moves all selections of the selection set into an inline fragment
+// which then becomes the only selection of the set, e.g. { id } becomes { ... on User { id } }
+func (d *Document) WrapSelectionSetInInlineFragment(set int, typeCondition string) (inlineFragment int) {
+	inner := d.AddSelectionSet().Ref
+	d.AppendSelectionSet(inner, set)
+	inlineFragment = d.ImportInlineFragment(typeCondition, inner)
+	d.EmptySelectionSet(set)
+	d.AddSelection(set, Selection{
+		Kind: SelectionKindInlineFragment,
+		Ref:  inlineFragment,
+	})
+	return inlineFragment
+}
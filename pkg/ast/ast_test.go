@@ -248,6 +248,153 @@ fragment namedFragment on SomeType {
 	assert.Equal(t, expected, out)
 }
 
+func TestMutating(t *testing.T) {
+	run := func(operation string, mutate func(t *testing.T, doc *ast.Document), expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			doc := unsafeparser.ParseGraphqlDocumentString(operation)
+			mutate(t, &doc)
+			out, err := astprinter.PrintStringIndent(&doc, nil, "  ")
+			assert.NoError(t, err)
+			assert.Equal(t, expected, out)
+		}
+	}
+	fieldByName := func(doc *ast.Document, name string) int {
+		for ref := range doc.Fields {
+			if doc.FieldNameString(ref) == name {
+				return ref
+			}
+		}
+		return -1
+	}
+
+	t.Run("add fields to selection set", run(`
+		query {
+			user {
+				id
+			}
+		}`,
+		func(t *testing.T, doc *ast.Document) {
+			set := doc.Fields[fieldByName(doc, "user")].SelectionSet
+			doc.AddFieldToSelectionSet(set, "name")
+			friends := doc.AddFieldToSelectionSet(set, "friends")
+			doc.SetFieldAlias(friends, "bestFriends")
+			doc.AddFieldToSelectionSet(doc.AddSelectionSetToField(friends), "id")
+		}, `{
+    user {
+        id
+        name
+        bestFriends: friends {
+            id
+        }
+    }
+}`))
+	t.Run("remove fields from selection set", run(`
+		query {
+			user {
+				id
+				name: fullName
+				email
+			}
+		}`,
+		func(t *testing.T, doc *ast.Document) {
+			set := doc.Fields[fieldByName(doc, "user")].SelectionSet
+			assert.True(t, doc.RemoveFieldFromSelectionSet(set, "name"))
+			assert.True(t, doc.RemoveFieldFromSelectionSet(set, "email"))
+			assert.False(t, doc.RemoveFieldFromSelectionSet(set, "fullName"))
+		}, `{
+    user {
+        id
+    }
+}`))
+	t.Run("add arguments to field", run(`
+		query {
+			user(id: 1) {
+				id
+			}
+		}`,
+		func(t *testing.T, doc *ast.Document) {
+			user := fieldByName(doc, "user")
+			doc.AddFieldArgument(user, "id", ast.Value{
+				Kind: ast.ValueKindInteger,
+				Ref:  doc.ImportIntValue([]byte("2"), false),
+			})
+			doc.AddFieldArgument(user, "filter", ast.Value{
+				Kind: ast.ValueKindString,
+				Ref:  doc.ImportStringValue([]byte("active"), false),
+			})
+			doc.AddFieldArgument(fieldByName(doc, "id"), "format", ast.Value{
+				Kind: ast.ValueKindEnum,
+				Ref:  doc.ImportEnumValue([]byte("SHORT")),
+			})
+		}, `{
+    user(id: 2, filter: "active"){
+        id(format: SHORT)
+    }
+}`))
+	t.Run("wrap selection set in inline fragment", run(`
+		query {
+			_entities {
+				id
+				name
+			}
+		}`,
+		func(t *testing.T, doc *ast.Document) {
+			doc.WrapSelectionSetInInlineFragment(doc.Fields[fieldByName(doc, "_entities")].SelectionSet, "User")
+		}, `{
+    _entities {
+        ... on User {
+            id
+            name
+        }
+    }
+}`))
+	t.Run("wrap selection in inline fragment", run(`
+		query {
+			user {
+				id
+				name
+				email
+			}
+		}`,
+		func(t *testing.T, doc *ast.Document) {
+			set := doc.Fields[fieldByName(doc, "user")].SelectionSet
+			doc.WrapSelectionInInlineFragment(set, 1, "")
+			doc.AddFieldToSelectionSet(doc.InlineFragments[doc.WrapSelectionInInlineFragment(set, 2, "Admin")].SelectionSet, "role")
+		}, `{
+    user {
+        id
+        ...{
+            name
+        }
+        ... on Admin {
+            email
+            role
+        }
+    }
+}`))
+	t.Run("delete definition", run(`
+		type Query {
+			user: User
+		}
+		type User {
+			id: ID
+		}
+		scalar Unused`,
+		func(t *testing.T, doc *ast.Document) {
+			node, exists := doc.NodeByNameStr("Unused")
+			assert.True(t, exists)
+			doc.DeleteDefinition(node)
+			_, exists = doc.NodeByNameStr("Unused")
+			assert.False(t, exists)
+		}, `type Query {
+    user: User
+}
+
+type User {
+    id: ID
+}`))
+}
+
 func TestKinds(t *testing.T) {
 	expectedArray := func(start, count int) (out []int) {
 		for i := start; i < start+count; i++ {
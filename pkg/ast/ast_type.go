@@ -25,6 +25,7 @@ type Type struct {
 	Close    position.Position  // ] (only on ListType)
 	Bang     position.Position  // ! (only on NonNullType)
 	OfType   int
+	Position position.Position // start and end of the node in the document
 }
 
 func (d *Document) TypeNameBytes(ref int) ByteSlice {
@@ -21,6 +21,7 @@ type UnionTypeDefinition struct {
 	UnionMemberTypes    TypeList // optional, e.g. Photo | Person
 	HasFieldDefinitions bool
 	FieldsDefinition    FieldDefinitionList // contains a single field: { __typename: String! }
+	Position            position.Position   // start and end of the node in the document
 }
 
 func (d *Document) UnionTypeDefinitionNameBytes(ref int) ByteSlice {
@@ -10,6 +10,7 @@ import (
 	"github.com/wundergraph/graphql-go-tools/internal/pkg/quotes"
 	"github.com/wundergraph/graphql-go-tools/internal/pkg/unsafebytes"
 	"github.com/wundergraph/graphql-go-tools/pkg/lexer/literal"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/position"
 )
 
 type ValueKind int
@@ -28,14 +29,16 @@ const (
 )
 
 type Value struct {
-	Kind ValueKind // e.g. 100 or "Bar"
-	Ref  int
+	Kind     ValueKind // e.g. 100 or "Bar"
+	Ref      int
+	Position position.Position // start and end of the node in the document
 }
 
 func (d *Document) CopyValue(ref int) int {
 	return d.AddValue(Value{
-		Kind: d.Values[ref].Kind,
-		Ref:  d.copyValueRef(d.Values[ref].Kind, d.Values[ref].Ref),
+		Kind:     d.Values[ref].Kind,
+		Ref:      d.copyValueRef(d.Values[ref].Kind, d.Values[ref].Ref),
+		Position: d.Values[ref].Position,
 	})
 }
 
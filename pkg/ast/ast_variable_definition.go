@@ -22,7 +22,8 @@ type VariableDefinition struct {
 	Type          int               // e.g. String
 	DefaultValue  DefaultValue      // optional, e.g. = "Default"
 	HasDirectives bool
-	Directives    DirectiveList // optional, e.g. @foo
+	Directives    DirectiveList     // optional, e.g. @foo
+	Position      position.Position // start and end of the node in the document
 }
 
 func (d *Document) VariableDefinitionNameBytes(ref int) ByteSlice {
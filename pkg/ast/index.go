@@ -125,6 +125,29 @@ func (i *Index) RemoveNodeByName(name []byte) {
 	}
 }
 
+// RemoveNodeBytes removes a single node from the nodes with the given name
+func (i *Index) RemoveNodeBytes(name []byte, node Node) {
+	hash := xxhash.Sum64(name)
+	nodes, ok := i.nodes[hash]
+	if !ok {
+		return
+	}
+
+	for j := range nodes {
+		if nodes[j].Kind != node.Kind || nodes[j].Ref != node.Ref {
+			continue
+		}
+		nodes = append(nodes[:j], nodes[j+1:]...)
+		break
+	}
+
+	if len(nodes) == 0 {
+		delete(i.nodes, hash)
+		return
+	}
+	i.nodes[hash] = nodes
+}
+
 func (i *Index) ReplaceNode(name []byte, oldNode Node, newNode Node) {
 	nodes, ok := i.nodes[xxhash.Sum64(name)]
 	if !ok {
@@ -6,6 +6,7 @@ import (
 	"github.com/wundergraph/graphql-go-tools/internal/pkg/unsafebytes"
 	"github.com/wundergraph/graphql-go-tools/pkg/lexer/literal"
 	"github.com/wundergraph/graphql-go-tools/pkg/lexer/position"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/runes"
 )
 
 // Input is a raw graphql document containing the raw input + meta data
@@ -80,6 +81,30 @@ func (i *Input) ByteSliceReferenceContentEquals(left, right ByteSliceReference)
 	return true
 }
 
+// ByteSliceReferencePosition returns the text position of the referenced bytes, e.g. to report the location of a name
+// Lines and characters start at 1 like the positions of the lexer.
+func (i *Input) ByteSliceReferencePosition(reference ByteSliceReference) (pos position.Position) {
+	pos.Reset()
+	for _, b := range i.RawBytes[:reference.Start] {
+		if b == runes.LINETERMINATOR {
+			pos.LineStart++
+			pos.CharStart = 1
+			continue
+		}
+		pos.CharStart++
+	}
+	pos.LineEnd, pos.CharEnd = pos.LineStart, pos.CharStart
+	for _, b := range i.RawBytes[reference.Start:reference.End] {
+		if b == runes.LINETERMINATOR {
+			pos.LineEnd++
+			pos.CharEnd = 1
+			continue
+		}
+		pos.CharEnd++
+	}
+	return pos
+}
+
 // ByteSlice is an alias for []byte
 type ByteSlice []byte
 
@@ -20,7 +20,10 @@ const (
 type PathItem struct {
 	Kind       PathKind
 	ArrayIndex int
-	FieldName  ByteSlice
+	FieldName  ByteSlice // the response key of the field, which is the alias if defined
+	// ParentTypeName is the resolved name of the type the field is selected on, e.g. User in { user { ... on User { name } } }
+	// It is only set for paths built while walking operations and doesn't take part in comparison or marshalling.
+	ParentTypeName ByteSlice
 }
 
 type Path []PathItem
@@ -26,6 +26,14 @@ func (i *Importer) ImportDirective(ref int, from, to *ast.Document) int {
 	})
 }
 
+func (i *Importer) ImportDirectives(refs []int, from, to *ast.Document) []int {
+	directives := make([]int, len(refs))
+	for j, k := range refs {
+		directives[j] = i.ImportDirective(k, from, to)
+	}
+	return directives
+}
+
 func (i *Importer) ImportDirectiveWithRename(ref int, renameTo string, from, to *ast.Document) int {
 	args := i.ImportArguments(from.Directives[ref].Arguments.Refs, from, to)
 	return to.AddDirective(ast.Directive{
@@ -207,16 +215,19 @@ func (i *Importer) ImportVariableDefinitions(refs []int, from, to *ast.Document)
 	return definitions
 }
 
+// ImportField imports a field with its arguments and directives but without its selection set.
+// Use ImportSelectionSet to import a field including its selections.
 func (i *Importer) ImportField(ref int, from, to *ast.Document) int {
 	field := ast.Field{
 		Alias: ast.Alias{
 			IsDefined: from.FieldAliasIsDefined(ref),
 		},
-		Name:         to.Input.AppendInputBytes(from.FieldNameBytes(ref)),
-		HasArguments: from.FieldHasArguments(ref),
-		// HasDirectives: from.FieldHasDirectives(ref), // HasDirectives: false, //TODO: implement import directives
+		Name:          to.Input.AppendInputBytes(from.FieldNameBytes(ref)),
+		HasArguments:  from.FieldHasArguments(ref),
+		HasDirectives: from.FieldHasDirectives(ref),
 		SelectionSet:  -1,
 		HasSelections: false,
+		Position:      from.Fields[ref].Position,
 	}
 	if field.Alias.IsDefined {
 		field.Alias.Name = to.Input.AppendInputBytes(from.FieldAliasBytes(ref))
@@ -224,6 +235,153 @@ func (i *Importer) ImportField(ref int, from, to *ast.Document) int {
 	if field.HasArguments {
 		field.Arguments.Refs = i.ImportArguments(from.FieldArguments(ref), from, to)
 	}
+	if field.HasDirectives {
+		field.Directives.Refs = i.ImportDirectives(from.FieldDirectives(ref), from, to)
+	}
 	to.Fields = append(to.Fields, field)
 	return len(to.Fields) - 1
 }
+
+// ImportSelectionSet imports a selection set including all nested fields, inline fragments and fragment spreads.
+// Fragment definitions referenced by spreads are not imported, use ImportFragmentDefinition for them.
+func (i *Importer) ImportSelectionSet(ref int, from, to *ast.Document) int {
+	set := to.AddSelectionSet().Ref
+	for _, selectionRef := range from.SelectionSets[ref].SelectionRefs {
+		to.SelectionSets[set].SelectionRefs = append(to.SelectionSets[set].SelectionRefs, i.ImportSelection(selectionRef, from, to))
+	}
+	to.SelectionSets[set].Position = from.SelectionSets[ref].Position
+	return set
+}
+
+func (i *Importer) ImportSelection(ref int, from, to *ast.Document) int {
+	selection := ast.Selection{
+		Kind: from.Selections[ref].Kind,
+		Ref:  -1,
+	}
+	switch selection.Kind {
+	case ast.SelectionKindField:
+		selection.Ref = i.ImportField(from.Selections[ref].Ref, from, to)
+		if from.FieldHasSelections(from.Selections[ref].Ref) {
+			to.Fields[selection.Ref].HasSelections = true
+			to.Fields[selection.Ref].SelectionSet = i.ImportSelectionSet(from.Fields[from.Selections[ref].Ref].SelectionSet, from, to)
+		}
+	case ast.SelectionKindInlineFragment:
+		selection.Ref = i.ImportInlineFragment(from.Selections[ref].Ref, from, to)
+	case ast.SelectionKindFragmentSpread:
+		selection.Ref = i.ImportFragmentSpread(from.Selections[ref].Ref, from, to)
+	}
+	return to.AddSelectionToDocument(selection)
+}
+
+func (i *Importer) ImportInlineFragment(ref int, from, to *ast.Document) int {
+	fragment := ast.InlineFragment{
+		TypeCondition: ast.TypeCondition{
+			Type: -1,
+		},
+		HasDirectives: from.InlineFragmentHasDirectives(ref),
+		SelectionSet:  -1,
+		HasSelections: from.InlineFragments[ref].HasSelections,
+		Position:      from.InlineFragments[ref].Position,
+	}
+	if from.InlineFragmentHasTypeCondition(ref) {
+		fragment.TypeCondition.Type = i.ImportType(from.InlineFragments[ref].TypeCondition.Type, from, to)
+	}
+	if fragment.HasDirectives {
+		fragment.Directives.Refs = i.ImportDirectives(from.InlineFragments[ref].Directives.Refs, from, to)
+	}
+	if fragment.HasSelections {
+		fragment.SelectionSet = i.ImportSelectionSet(from.InlineFragments[ref].SelectionSet, from, to)
+	}
+	return to.AddInlineFragment(fragment)
+}
+
+func (i *Importer) ImportFragmentSpread(ref int, from, to *ast.Document) int {
+	spread := ast.FragmentSpread{
+		FragmentName:  to.Input.AppendInputBytes(from.FragmentSpreadNameBytes(ref)),
+		HasDirectives: from.FragmentSpreads[ref].HasDirectives,
+		Position:      from.FragmentSpreads[ref].Position,
+	}
+	if spread.HasDirectives {
+		spread.Directives.Refs = i.ImportDirectives(from.FragmentSpreads[ref].Directives.Refs, from, to)
+	}
+	return to.AddFragmentSpread(spread)
+}
+
+// ImportOperationDefinition imports an operation definition including its selections and adds it to the root nodes of to.
+func (i *Importer) ImportOperationDefinition(ref int, from, to *ast.Document) int {
+	operation := from.OperationDefinitions[ref]
+	definition := ast.OperationDefinition{
+		OperationType:          operation.OperationType,
+		HasVariableDefinitions: operation.HasVariableDefinitions,
+		HasDirectives:          operation.HasDirectives,
+		SelectionSet:           -1,
+		HasSelections:          operation.HasSelections,
+		Position:               operation.Position,
+	}
+	if operation.Name.Length() != 0 {
+		definition.Name = to.Input.AppendInputBytes(from.OperationDefinitionNameBytes(ref))
+	}
+	if definition.HasVariableDefinitions {
+		definition.VariableDefinitions.Refs = i.ImportVariableDefinitions(operation.VariableDefinitions.Refs, from, to)
+		// ImportVariableDefinition drops directives on purpose, e.g. to not forward them upstream, but a cloned operation keeps them
+		for j, k := range operation.VariableDefinitions.Refs {
+			if !from.VariableDefinitions[k].HasDirectives {
+				continue
+			}
+			variableDefinition := definition.VariableDefinitions.Refs[j]
+			to.VariableDefinitions[variableDefinition].HasDirectives = true
+			to.VariableDefinitions[variableDefinition].Directives.Refs = i.ImportDirectives(from.VariableDefinitions[k].Directives.Refs, from, to)
+		}
+	}
+	if definition.HasDirectives {
+		definition.Directives.Refs = i.ImportDirectives(operation.Directives.Refs, from, to)
+	}
+	if definition.HasSelections {
+		definition.SelectionSet = i.ImportSelectionSet(operation.SelectionSet, from, to)
+	}
+	return to.AddOperationDefinitionToRootNodes(definition).Ref
+}
+
+// ImportFragmentDefinition imports a fragment definition including its selections and adds it to the root nodes of to.
+func (i *Importer) ImportFragmentDefinition(ref int, from, to *ast.Document) int {
+	fragment := from.FragmentDefinitions[ref]
+	definition := ast.FragmentDefinition{
+		Name: to.Input.AppendInputBytes(from.FragmentDefinitionNameBytes(ref)),
+		TypeCondition: ast.TypeCondition{
+			Type: i.ImportType(fragment.TypeCondition.Type, from, to),
+		},
+		SelectionSet:  -1,
+		HasSelections: fragment.HasSelections,
+		Position:      fragment.Position,
+	}
+	if len(fragment.Directives.Refs) != 0 {
+		definition.Directives.Refs = i.ImportDirectives(fragment.Directives.Refs, from, to)
+	}
+	if definition.HasSelections {
+		definition.SelectionSet = i.ImportSelectionSet(fragment.SelectionSet, from, to)
+	}
+	to.FragmentDefinitions = append(to.FragmentDefinitions, definition)
+	to.RootNodes = append(to.RootNodes, ast.Node{Kind: ast.NodeKindFragmentDefinition, Ref: len(to.FragmentDefinitions) - 1})
+	return len(to.FragmentDefinitions) - 1
+}
+
+// CompactExecutableDocument drops all nodes which are no longer reachable from the root nodes,
+// e.g. after removing fields or deleting definitions, by importing the operations and fragments into a fresh document.
+// Refs into the document are invalidated. Documents containing type system definitions can't be compacted.
+func (i *Importer) CompactExecutableDocument(document *ast.Document) error {
+	compacted := ast.NewDocument()
+	for _, node := range document.RootNodes {
+		switch node.Kind {
+		case ast.NodeKindOperationDefinition:
+			i.ImportOperationDefinition(node.Ref, document, compacted)
+		case ast.NodeKindFragmentDefinition:
+			i.ImportFragmentDefinition(node.Ref, document, compacted)
+		case ast.NodeKindUnknown:
+			// removed root node
+		default:
+			return fmt.Errorf("astimport.Importer.CompactExecutableDocument: unexpected root node of kind: %s", node.Kind)
+		}
+	}
+	*document = *compacted
+	return nil
+}
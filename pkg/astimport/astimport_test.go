@@ -6,8 +6,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/wundergraph/graphql-go-tools/internal/pkg/unsafeparser"
 	"github.com/wundergraph/graphql-go-tools/pkg/ast"
 	"github.com/wundergraph/graphql-go-tools/pkg/astparser"
+	"github.com/wundergraph/graphql-go-tools/pkg/astprinter"
 	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
 )
 
@@ -318,3 +320,107 @@ func TestImporter_ImportVariableDefinitions(t *testing.T) {
 		[]int{0, 1},
 	))
 }
+
+func TestImporter_ImportOperationDefinition(t *testing.T) {
+	from := unsafeparser.ParseGraphqlDocumentString(`
+		query Users($first: Int = 10 @deprecated, $filter: UserFilter) @cached(ttl: 60) {
+			users(first: $first, filter: $filter) @include(if: true) {
+				id
+				fullName: name
+				... on Admin {
+					role
+				}
+				...UserDetails
+			}
+		}
+		fragment UserDetails on User @skip(if: false) {
+			email
+			friends {
+				...UserDetails @defer
+			}
+		}`)
+	to := ast.NewDocument()
+	to.Input.AppendInputString("unrelated input")
+
+	importer := &Importer{}
+	importer.ImportOperationDefinition(0, &from, to)
+	importer.ImportFragmentDefinition(0, &from, to)
+
+	expected, err := astprinter.PrintString(&from, nil)
+	require.NoError(t, err)
+	out, err := astprinter.PrintString(to, nil)
+	require.NoError(t, err)
+	assert.Equal(t, expected, out)
+
+	assert.Equal(t, "skip", to.DirectiveNameString(to.FragmentDefinitions[0].Directives.Refs[0]))
+	assert.Equal(t, "defer", to.DirectiveNameString(to.FragmentSpreads[1].Directives.Refs[0]))
+}
+
+func TestImporter_ImportSelectionSet(t *testing.T) {
+	from := unsafeparser.ParseGraphqlDocumentString(`
+		query {
+			user(id: 1) {
+				id
+				... on Admin {
+					role
+				}
+			}
+		}`)
+	to := unsafeparser.ParseGraphqlDocumentString(`
+		query {
+			me {
+				name
+			}
+		}`)
+
+	importer := &Importer{}
+	set := importer.ImportSelectionSet(from.OperationDefinitions[0].SelectionSet, &from, &to)
+	to.AppendSelectionSet(to.Fields[0].SelectionSet, set)
+
+	out, err := astprinter.PrintString(&to, nil)
+	require.NoError(t, err)
+	assert.Equal(t, `{me {name user(id: 1){id ... on Admin {role}}}}`, out)
+}
+
+func TestImporter_CompactExecutableDocument(t *testing.T) {
+	t.Run("drops unreachable nodes", func(t *testing.T) {
+		doc := unsafeparser.ParseGraphqlDocumentString(`
+			query Q($id: ID!) {
+				user(id: $id) {
+					id
+					name
+					friends {
+						id
+						name
+					}
+				}
+			}
+			fragment Unused on User {
+				id
+			}`)
+
+		user := doc.Selections[doc.SelectionSets[doc.OperationDefinitions[0].SelectionSet].SelectionRefs[0]].Ref
+		userSet := doc.Fields[user].SelectionSet
+		assert.True(t, doc.RemoveFieldFromSelectionSet(userSet, "friends"))
+		doc.DeleteDefinition(ast.Node{Kind: ast.NodeKindFragmentDefinition, Ref: 0})
+		doc.WrapSelectionSetInInlineFragment(userSet, "User")
+
+		importer := &Importer{}
+		require.NoError(t, importer.CompactExecutableDocument(&doc))
+
+		assert.Len(t, doc.Fields, 3)
+		assert.Len(t, doc.SelectionSets, 3)
+		assert.Len(t, doc.FragmentDefinitions, 0)
+		assert.Len(t, doc.InlineFragments, 1)
+
+		out, err := astprinter.PrintString(&doc, nil)
+		require.NoError(t, err)
+		assert.Equal(t, `query Q($id: ID!){user(id: $id){... on User {id name}}}`, out)
+	})
+	t.Run("rejects type system definitions", func(t *testing.T) {
+		doc := unsafeparser.ParseGraphqlDocumentString(`type Query { hello: String }`)
+
+		importer := &Importer{}
+		assert.Error(t, importer.CompactExecutableDocument(&doc))
+	})
+}
@@ -157,14 +157,21 @@ func WithNormalizeDefinition() Option {
 	}
 }
 
+// setupOperationWalkers composes the rules into as few passes as possible.
+// Fragment spreads get inlined when leaving the document and the rules coercing and merging the selections
+// rely on extracted variables for the whole document, so these have to run as separate passes before all other rules.
+// Skipping or stopping single visitors doesn't help here, the later passes depend on the document the earlier ones produce,
+// so an operation is normalized in up to three passes instead of one.
 func (o *OperationNormalizer) setupOperationWalkers() {
 	fragmentInline := astvisitor.NewWalker(48)
 	fragmentSpreadInline(&fragmentInline)
 	directiveIncludeSkip(&fragmentInline)
+	o.operationWalkers = append(o.operationWalkers, &fragmentInline)
 
-	extractVariablesWalker := astvisitor.NewWalker(48)
 	if o.options.extractVariables {
+		extractVariablesWalker := astvisitor.NewWalker(48)
 		o.variablesExtraction = extractVariables(&extractVariablesWalker)
+		o.operationWalkers = append(o.operationWalkers, &extractVariablesWalker)
 	}
 
 	other := astvisitor.NewWalker(48)
@@ -180,11 +187,8 @@ func (o *OperationNormalizer) setupOperationWalkers() {
 	if o.options.removeUnusedVariables {
 		deleteUnusedVariables(&other)
 	}
-
-	injectInputDefaultWalker := astvisitor.NewWalker(48)
-	injectInputFieldDefaults(&injectInputDefaultWalker)
-
-	o.operationWalkers = append(o.operationWalkers, &fragmentInline, &extractVariablesWalker, &other, &injectInputDefaultWalker)
+	injectInputFieldDefaults(&other)
+	o.operationWalkers = append(o.operationWalkers, &other)
 }
 
 func (o *OperationNormalizer) prepareDefinition(definition *ast.Document, report *operationreport.Report) {
@@ -105,6 +105,14 @@ func TestNormalizeOperation(t *testing.T) {
 			`{"a":{"fieldB":"dupa","fieldA":"VALUE_A"}}`,
 		)
 	})
+	t.Run("inject default into extracted argument default value", func(t *testing.T) {
+		run(t,
+			injectDefaultValueDefinition, `
+			query{elDefaultQuery}`,
+			`query($a: elInput){elDefaultQuery(input: $a)}`, "",
+			`{"a":{"fieldB":"default","fieldA":"VALUE_A"}}`,
+		)
+	})
 	t.Run("fragments", func(t *testing.T) {
 		run(t, testDefinition, `
 				query conflictingBecauseAlias ($unused: String) {
@@ -284,7 +292,7 @@ schema {
 		assert.True(t, report.HasErrors())
 		assert.Equal(t, 1, len(report.ExternalErrors))
 		assert.Equal(t, 0, len(report.InternalErrors))
-		assert.Equal(t, "external: field: nam not defined on type: Country, locations: [], path: [query,country,nam]", report.Error())
+		assert.Equal(t, "external: field: nam not defined on type: Country, locations: [{Line:4 Column:3}], path: [query,country,nam]", report.Error())
 	})
 }
 
@@ -701,6 +709,7 @@ extend type Subscription {
 const injectDefaultValueDefinition = `
 type Query {
   elQuery(input: elInput): Boolean!
+  elDefaultQuery(input: elInput = {fieldB: "default"}): Boolean!
 }
 
 type Mutation{
@@ -48,14 +48,15 @@ func (f *fragmentSpreadInlineVisitor) EnterFragmentSpread(ref int) {
 	fragmentDefinitionRef, exists := f.operation.FragmentDefinitionRef(f.operation.FragmentSpreadNameBytes(ref))
 	if !exists {
 		fragmentName := f.operation.FragmentSpreadNameBytes(ref)
-		f.StopWithExternalErr(operationreport.ErrFragmentUndefined(fragmentName))
+		f.StopWithExternalErr(operationreport.ErrFragmentUndefined(fragmentName, f.operation.FragmentSpreads[ref].Position))
 		return
 	}
 
 	fragmentTypeName := f.operation.FragmentDefinitionTypeName(fragmentDefinitionRef)
 	fragmentNode, exists := f.definition.NodeByName(fragmentTypeName)
 	if !exists {
-		f.StopWithExternalErr(operationreport.ErrTypeUndefined(fragmentTypeName))
+		typeCondition := f.operation.FragmentDefinitions[fragmentDefinitionRef].TypeCondition.Type
+		f.StopWithExternalErr(operationreport.ErrTypeUndefined(fragmentTypeName, f.operation.Types[typeCondition].Position))
 		return
 	}
 
@@ -106,7 +107,13 @@ func (f *fragmentSpreadInlineVisitor) EnterFragmentSpread(ref int) {
 	replaceWith := f.operation.FragmentDefinitions[fragmentDefinitionRef].SelectionSet
 	typeCondition := f.operation.FragmentDefinitions[fragmentDefinitionRef].TypeCondition
 
+	// deferred fragments must keep their directive, so they get replaced with an inline fragment
+	isDeferred := f.operation.FragmentSpreads[ref].HasDirectives &&
+		f.operation.FragmentSpreads[ref].Directives.HasDirectiveByName(f.operation, "defer")
+
 	switch {
+	case isDeferred:
+		f.transformer.ReplaceFragmentSpreadWithInlineFragment(precedence, selectionSet, ref, replaceWith, typeCondition)
 	case fragmentTypeEqualsParentType || enclosingTypeImplementsFragmentType:
 		f.transformer.ReplaceFragmentSpread(precedence, selectionSet, ref, replaceWith)
 	case fragmentTypeImplementsEnclosingType || fragmentTypeIsMemberOfEnclosingUnionType || enclosingTypeIsMemberOfFragmentUnion || fragmentUnionIntersectsEnclosingInterface || fragmentInterfaceIntersectsEnclosingUnion:
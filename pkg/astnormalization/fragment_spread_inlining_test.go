@@ -440,4 +440,27 @@ func TestInlineFragments(t *testing.T) {
 					name
 				}`)
 	})
+	t.Run("deferred fragment spreads keep their directives", func(t *testing.T) {
+		run(fragmentSpreadInline, testDefinition, `
+				{
+					dog {
+						name
+						...dogFields @defer(label: "dog")
+					}
+				}
+				fragment dogFields on Dog {
+					nickname
+				}`, `
+				{
+					dog {
+						name
+						... on Dog @defer(label: "dog") {
+							nickname
+						}
+					}
+				}
+				fragment dogFields on Dog {
+					nickname
+				}`)
+	})
 }
@@ -14,7 +14,7 @@ func injectInputFieldDefaults(walker *astvisitor.Walker) *inputFieldDefaultInjec
 		jsonPath: make([]string, 0),
 	}
 	walker.RegisterEnterDocumentVisitor(visitor)
-	walker.RegisterVariableDefinitionVisitor(visitor)
+	walker.RegisterLeaveOperationVisitor(visitor)
 	return visitor
 }
 
@@ -32,33 +32,47 @@ func (v *inputFieldDefaultInjectionVisitor) EnterDocument(operation, definition
 	v.operation, v.definition = operation, definition
 }
 
-func (v *inputFieldDefaultInjectionVisitor) EnterVariableDefinition(ref int) {
+// LeaveOperationDefinition injects the defaults once all variable definitions of the operation are known,
+// including the ones added while walking the selection set, e.g. when extracting default values of arguments.
+func (v *inputFieldDefaultInjectionVisitor) LeaveOperationDefinition(ref int) {
+	for _, i := range v.operation.OperationDefinitions[ref].VariableDefinitions.Refs {
+		ok := v.injectVariableDefaults(i)
+		v.variableName = ""
+		v.jsonPath = make([]string, 0)
+		if !ok {
+			return
+		}
+	}
+}
+
+func (v *inputFieldDefaultInjectionVisitor) injectVariableDefaults(ref int) bool {
 	v.variableName = v.operation.VariableDefinitionNameString(ref)
 
 	variableVal, _, _, err := jsonparser.Get(v.operation.Input.Variables, v.variableName)
 	if err == jsonparser.KeyPathNotFoundError {
-		return
+		return true
 	}
 	if err != nil {
 		v.StopWithInternalErr(err)
-		return
+		return false
 	}
 
 	typeRef := v.operation.VariableDefinitions[ref].Type
 	if v.isScalarTypeOrExtension(typeRef, v.operation) {
-		return
+		return true
 	}
 	newVal, err := v.processObjectOrListInput(typeRef, variableVal, v.operation)
 	if err != nil {
 		v.StopWithInternalErr(err)
-		return
+		return false
 	}
 	newVariables, err := jsonparser.Set(v.operation.Input.Variables, newVal, v.variableName)
 	if err != nil {
 		v.StopWithInternalErr(err)
-		return
+		return false
 	}
 	v.operation.Input.Variables = newVariables
+	return true
 }
 
 func (v *inputFieldDefaultInjectionVisitor) recursiveInjectInputFields(inputObjectRef int, varValue []byte) ([]byte, error) {
@@ -204,7 +218,3 @@ func (v *inputFieldDefaultInjectionVisitor) jsonWalker(fieldType int, defaultVal
 	}
 
 }
-func (v *inputFieldDefaultInjectionVisitor) LeaveVariableDefinition(ref int) {
-	v.variableName = ""
-	v.jsonPath = make([]string, 0)
-}
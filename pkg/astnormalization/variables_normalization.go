@@ -0,0 +1,113 @@
+package astnormalization
+
+import (
+	"bytes"
+
+	"github.com/buger/jsonparser"
+	"github.com/tidwall/sjson"
+
+	"github.com/wundergraph/graphql-go-tools/internal/pkg/unsafebytes"
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+// VariablesNormalizer applies the rules of the OperationNormalizer which depend on the values of the variables
+// It's meant to be used with an operation that has already been normalized, e.g. a cached one,
+// so that the variables of subsequent requests can be normalized without normalizing the operation again.
+// The operation itself doesn't get modified, only operation.Input.Variables.
+type VariablesNormalizer struct {
+	walker        *astvisitor.Walker
+	defaultValues *variableDefaultValuesVisitor
+}
+
+// NewVariablesNormalizer creates a new VariablesNormalizer
+// A VariablesNormalizer is not safe for concurrent use.
+func NewVariablesNormalizer() *VariablesNormalizer {
+	walker := astvisitor.NewWalker(48)
+	inputCoercionForList(&walker)
+	defaultValues := variableDefaultValues(&walker)
+	injectInputFieldDefaults(&walker)
+
+	return &VariablesNormalizer{
+		walker:        &walker,
+		defaultValues: defaultValues,
+	}
+}
+
+// NormalizeOperationVariables coerces list variables, sets variables which weren't provided to their default value
+// and injects the default values of input object fields, in the same order as the OperationNormalizer does.
+// As the default values of variable definitions are removed from the operation during normalization,
+// they have to be provided as JSON object, see VariableDefaultValues.
+func (v *VariablesNormalizer) NormalizeOperationVariables(operation, definition *ast.Document, variableDefaultValues []byte, report *operationreport.Report) {
+	v.defaultValues.values = variableDefaultValues
+	v.walker.Walk(operation, definition, report)
+}
+
+func variableDefaultValues(walker *astvisitor.Walker) *variableDefaultValuesVisitor {
+	visitor := &variableDefaultValuesVisitor{
+		Walker: walker,
+	}
+	walker.RegisterEnterDocumentVisitor(visitor)
+	walker.RegisterLeaveOperationVisitor(visitor)
+	return visitor
+}
+
+// variableDefaultValuesVisitor sets the variables which weren't provided to their default value
+// List variables get coerced when entering their definitions, so the default values get set when leaving the operation,
+// before the default values of input object fields get injected.
+type variableDefaultValuesVisitor struct {
+	*astvisitor.Walker
+	operation *ast.Document
+	values    []byte
+}
+
+func (v *variableDefaultValuesVisitor) EnterDocument(operation, _ *ast.Document) {
+	v.operation = operation
+}
+
+func (v *variableDefaultValuesVisitor) LeaveOperationDefinition(_ int) {
+	if len(v.values) == 0 {
+		return
+	}
+	err := jsonparser.ObjectEach(v.values, func(key []byte, value []byte, dataType jsonparser.ValueType, _ int) (err error) {
+		variableName := unsafebytes.BytesToString(key)
+		if _, _, _, err = jsonparser.Get(v.operation.Input.Variables, variableName); err == nil {
+			return nil
+		}
+		if dataType == jsonparser.String {
+			// string values are returned without quotes, but still escaped
+			value = append(append([]byte{'"'}, value...), '"')
+		}
+		v.operation.Input.Variables, err = sjson.SetRawBytes(v.operation.Input.Variables, variableName, value)
+		return err
+	})
+	if err != nil {
+		v.StopWithInternalErr(err)
+	}
+}
+
+// VariableDefaultValues returns the default values of the variable definitions as JSON object
+// If operationName is empty the variable definitions of all operations are considered.
+// It has to be called before normalizing the operation, as normalization removes the default values from the operation.
+func VariableDefaultValues(operation *ast.Document, operationName []byte) (defaultValues []byte, err error) {
+	for i := range operation.OperationDefinitions {
+		if len(operationName) != 0 && !bytes.Equal(operation.OperationDefinitionNameBytes(i), operationName) {
+			continue
+		}
+		for _, ref := range operation.OperationDefinitions[i].VariableDefinitions.Refs {
+			if !operation.VariableDefinitionHasDefaultValue(ref) {
+				continue
+			}
+			valueBytes, err := operation.ValueToJSON(operation.VariableDefinitionDefaultValue(ref))
+			if err != nil {
+				return nil, err
+			}
+			defaultValues, err = sjson.SetRawBytes(defaultValues, operation.VariableDefinitionNameString(ref), valueBytes)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return defaultValues, nil
+}
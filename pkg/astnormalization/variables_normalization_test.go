@@ -0,0 +1,75 @@
+package astnormalization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wundergraph/graphql-go-tools/internal/pkg/unsafeparser"
+	"github.com/wundergraph/graphql-go-tools/pkg/asttransform"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+func TestVariablesNormalizer_NormalizeOperationVariables(t *testing.T) {
+	// run normalizes the operation once with the initial variables and afterwards normalizes
+	// the variables of a subsequent request with the VariablesNormalizer on the already normalized operation
+	run := func(definition, operation, operationName, initialVariables, variables, expectedVariables string) func(t *testing.T) {
+		return func(t *testing.T) {
+			definitionDocument := unsafeparser.ParseGraphqlDocumentString(definition)
+			require.NoError(t, asttransform.MergeDefinitionWithBaseSchema(&definitionDocument))
+
+			operationDocument := unsafeparser.ParseGraphqlDocumentString(operation)
+			operationDocument.Input.Variables = []byte(initialVariables)
+
+			defaultValues, err := VariableDefaultValues(&operationDocument, []byte(operationName))
+			require.NoError(t, err)
+
+			report := operationreport.Report{}
+			NewWithOpts(WithExtractVariables(), WithRemoveFragmentDefinitions()).NormalizeNamedOperation(&operationDocument, &definitionDocument, []byte(operationName), &report)
+			require.False(t, report.HasErrors(), report.Error())
+
+			operationDocument.Input.Variables = []byte(variables)
+			NewVariablesNormalizer().NormalizeOperationVariables(&operationDocument, &definitionDocument, defaultValues, &report)
+			require.False(t, report.HasErrors(), report.Error())
+
+			assert.Equal(t, expectedVariables, string(operationDocument.Input.Variables))
+		}
+	}
+
+	t.Run("list coercion", run(inputCoercionForListDefinition, `
+		query q($ids: [Int]) {
+		  charactersByIds(ids: $ids) {
+			id
+		  }
+		}`, "q", `{"ids":[1,2]}`, `{"ids":3}`, `{"ids":[3]}`))
+
+	t.Run("variable default value", run(variablesDefaultValueExtractionDefinition, `
+		mutation simple($in: String = "bar") {
+		  simple(input: $in)
+		}`, "simple", `{"in":"foo"}`, `{}`, `{"in":"bar"}`))
+
+	t.Run("provided variable overrides default value", run(variablesDefaultValueExtractionDefinition, `
+		mutation simple($in: String = "bar") {
+		  simple(input: $in)
+		}`, "simple", `{}`, `{"in":"foo"}`, `{"in":"foo"}`))
+
+	t.Run("input object field default values", run(testInputDefaultSchema, `
+		mutation testDefaultValueSimple($a: SimpleTestInput! = {thirdField: 3}) {
+		  testDefaultValueSimple(data: $a)
+		}`, "testDefaultValueSimple", `{"a":{"thirdField":1}}`, `{}`, `{"a":{"thirdField":3,"firstField":"firstField","secondField":1}}`))
+}
+
+func TestVariableDefaultValues(t *testing.T) {
+	operation := unsafeparser.ParseGraphqlDocumentString(`
+		query a($a: String = "a\"b", $b: Int, $c: [Int] = [1, 2]) { a }
+		query b($d: Boolean = true) { b }`)
+
+	defaultValues, err := VariableDefaultValues(&operation, []byte("a"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"a":"a\"b","c":[1,2]}`, string(defaultValues))
+
+	defaultValues, err = VariableDefaultValues(&operation, nil)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"a":"a\"b","c":[1,2],"d":true}`, string(defaultValues))
+}
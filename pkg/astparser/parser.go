@@ -3,6 +3,7 @@ package astparser
 
 import (
 	"fmt"
+	"io"
 	"runtime"
 
 	"github.com/wundergraph/graphql-go-tools/internal/pkg/unsafebytes"
@@ -36,6 +37,18 @@ func ParseGraphqlDocumentBytes(input []byte) (ast.Document, operationreport.Repo
 	return doc, report
 }
 
+// ParseGraphqlDocumentReader takes a raw GraphQL document from reader and parses it into an AST.
+// The document is streamed into the AST's input instead of being read into a separate buffer upfront.
+// The AST references the input, so the whole document is still kept in memory.
+// This function creates a new parser as well as a new AST for every call.
+func ParseGraphqlDocumentReader(reader io.Reader) (ast.Document, operationreport.Report) {
+	parser := NewParser()
+	doc := *ast.NewDocument()
+	report := operationreport.Report{}
+	parser.ParseReader(reader, &doc, &report)
+	return doc, report
+}
+
 // Parser takes a raw input and turns it into an AST
 // use NewParser() to create a parser
 // Don't create new parsers in the hot path, re-use them.
@@ -45,6 +58,9 @@ type Parser struct {
 	tokenizer            *Tokenizer
 	shouldIndex          bool
 	reportInternalErrors bool
+	keepComments         bool
+	// recovery holds the state of ParseWithRecovery, it's nil for Parse
+	recovery *recovery
 }
 
 // NewParser returns a new parser with all values properly initialized
@@ -56,6 +72,12 @@ func NewParser() *Parser {
 	}
 }
 
+// KeepComments configures the parser to retain the # comments of the input in Document.Comments
+// Comments are dropped by default because only printers which preserve formatting are interested in them.
+func (p *Parser) KeepComments(keep bool) {
+	p.keepComments = keep
+}
+
 // PrepareImport prepares the Parser for importing new Nodes into an AST without directly parsing the content
 func (p *Parser) PrepareImport(document *ast.Document, report *operationreport.Report) {
 	p.document = document
@@ -71,60 +93,98 @@ func (p *Parser) Parse(document *ast.Document, report *operationreport.Report) {
 	p.parse()
 }
 
+// ParseReader parses the document streamed from reader into the Document, replacing the Document.Input
+// The input is read incrementally while tokenizing, so it isn't copied from a separate buffer.
+// The whole document ends up in Document.Input, see lexer.Lexer.SetInputReader.
+// Errors of the reader are reported as internal errors.
+func (p *Parser) ParseReader(reader io.Reader, document *ast.Document, report *operationreport.Report) {
+	p.document = document
+	p.report = report
+	if err := p.tokenizer.TokenizeReader(&p.document.Input, reader); err != nil {
+		p.report.AddInternalError(fmt.Errorf("read input: %w", err))
+		return
+	}
+	if p.keepComments {
+		p.collectComments()
+	}
+	p.parse()
+}
+
 func (p *Parser) tokenize() {
 	p.tokenizer.Tokenize(&p.document.Input)
+	if p.keepComments {
+		p.collectComments()
+	}
+}
+
+func (p *Parser) collectComments() {
+	for _, tok := range p.tokenizer.tokens[:p.tokenizer.maxTokens] {
+		if tok.Keyword != keyword.COMMENT {
+			continue
+		}
+		p.document.AddComment(ast.Comment{
+			Content:  tok.Literal,
+			Position: tok.TextPosition,
+		})
+	}
 }
 
 func (p *Parser) parse() {
 	for {
 		key, literalReference := p.peekLiteral()
-
-		switch key {
-		case keyword.EOF:
+		if key == keyword.EOF {
 			p.read()
 			return
-		case keyword.LBRACE:
-			p.parseOperationDefinition()
-		case keyword.STRING, keyword.BLOCKSTRING:
-			p.parseRootDescription()
-		case keyword.IDENT:
-			keyIdent := p.identKeywordSliceRef(literalReference)
-			switch keyIdent {
-			case identkeyword.ENUM:
-				p.parseEnumTypeDefinition(nil)
-			case identkeyword.TYPE:
-				p.parseObjectTypeDefinition(nil)
-			case identkeyword.UNION:
-				p.parseUnionTypeDefinition(nil)
-			case identkeyword.QUERY, identkeyword.MUTATION, identkeyword.SUBSCRIPTION:
-				p.parseOperationDefinition()
-			case identkeyword.INPUT:
-				p.parseInputObjectTypeDefinition(nil)
-			case identkeyword.EXTEND:
-				p.parseExtension()
-			case identkeyword.SCHEMA:
-				p.parseSchemaDefinition()
-			case identkeyword.SCALAR:
-				p.parseScalarTypeDefinition(nil)
-			case identkeyword.FRAGMENT:
-				p.parseFragmentDefinition()
-			case identkeyword.INTERFACE:
-				p.parseInterfaceTypeDefinition(nil)
-			case identkeyword.DIRECTIVE:
-				p.parseDirectiveDefinition(nil)
-			default:
-				p.errUnexpectedIdentKey(p.read(), keyIdent, identkeyword.ENUM, identkeyword.TYPE, identkeyword.UNION, identkeyword.QUERY, identkeyword.INPUT, identkeyword.EXTEND, identkeyword.SCHEMA, identkeyword.SCALAR, identkeyword.FRAGMENT, identkeyword.INTERFACE, identkeyword.DIRECTIVE)
-			}
-		default:
-			p.errUnexpectedToken(p.read(), keyword.EOF, keyword.LBRACE, keyword.COMMENT, keyword.STRING, keyword.BLOCKSTRING, keyword.IDENT)
 		}
 
+		p.parseDefinition(key, literalReference)
+
 		if p.report.HasErrors() {
 			return
 		}
 	}
 }
 
+// parseDefinition parses the definition starting with the next token
+func (p *Parser) parseDefinition(key keyword.Keyword, literalReference ast.ByteSliceReference) {
+	switch key {
+	case keyword.LBRACE:
+		p.parseOperationDefinition()
+	case keyword.STRING, keyword.BLOCKSTRING:
+		p.parseRootDescription()
+	case keyword.IDENT:
+		keyIdent := p.identKeywordSliceRef(literalReference)
+		switch keyIdent {
+		case identkeyword.ENUM:
+			p.parseEnumTypeDefinition(nil)
+		case identkeyword.TYPE:
+			p.parseObjectTypeDefinition(nil)
+		case identkeyword.UNION:
+			p.parseUnionTypeDefinition(nil)
+		case identkeyword.QUERY, identkeyword.MUTATION, identkeyword.SUBSCRIPTION:
+			p.parseOperationDefinition()
+		case identkeyword.INPUT:
+			p.parseInputObjectTypeDefinition(nil)
+		case identkeyword.EXTEND:
+			p.parseExtension()
+		case identkeyword.SCHEMA:
+			p.parseSchemaDefinition()
+		case identkeyword.SCALAR:
+			p.parseScalarTypeDefinition(nil)
+		case identkeyword.FRAGMENT:
+			p.parseFragmentDefinition()
+		case identkeyword.INTERFACE:
+			p.parseInterfaceTypeDefinition(nil)
+		case identkeyword.DIRECTIVE:
+			p.parseDirectiveDefinition(nil)
+		default:
+			p.errUnexpectedIdentKey(p.read(), keyIdent, identkeyword.ENUM, identkeyword.TYPE, identkeyword.UNION, identkeyword.QUERY, identkeyword.INPUT, identkeyword.EXTEND, identkeyword.SCHEMA, identkeyword.SCALAR, identkeyword.FRAGMENT, identkeyword.INTERFACE, identkeyword.DIRECTIVE)
+		}
+	default:
+		p.errUnexpectedToken(p.read(), keyword.EOF, keyword.LBRACE, keyword.COMMENT, keyword.STRING, keyword.BLOCKSTRING, keyword.IDENT)
+	}
+}
+
 func (p *Parser) identKeywordToken(token token.Token) identkeyword.IdentKeyword {
 	return identkeyword.KeywordFromLiteral(p.document.Input.ByteSlice(token.Literal))
 }
@@ -139,8 +199,9 @@ func (p *Parser) errUnexpectedIdentKey(unexpected token.Token, unexpectedKey ide
 		return
 	}
 
+	message := fmt.Sprintf("unexpected literal - got: %s want one of: %v", unexpectedKey, expectedKeywords)
 	p.report.AddExternalError(operationreport.ExternalError{
-		Message: fmt.Sprintf("unexpected literal - got: %s want one of: %v", unexpectedKey, expectedKeywords),
+		Message: message,
 		Locations: []graphqlerrors.Location{
 			{
 				Line:   unexpected.TextPosition.LineStart,
@@ -148,6 +209,9 @@ func (p *Parser) errUnexpectedIdentKey(unexpected token.Token, unexpectedKey ide
 			},
 		},
 	})
+	if p.recovery != nil {
+		p.addDiagnostic(message, unexpected)
+	}
 
 	if !p.reportInternalErrors {
 		return
@@ -186,8 +250,9 @@ func (p *Parser) errUnexpectedToken(unexpected token.Token, expectedKeywords ...
 		return
 	}
 
+	message := fmt.Sprintf("unexpected token - got: %s want one of: %v", unexpected.Keyword, expectedKeywords)
 	p.report.AddExternalError(operationreport.ExternalError{
-		Message: fmt.Sprintf("unexpected token - got: %s want one of: %v", unexpected.Keyword, expectedKeywords),
+		Message: message,
 		Locations: []graphqlerrors.Location{
 			{
 				Line:   unexpected.TextPosition.LineStart,
@@ -195,6 +260,9 @@ func (p *Parser) errUnexpectedToken(unexpected token.Token, expectedKeywords ...
 			},
 		},
 	})
+	if p.recovery != nil {
+		p.addDiagnostic(message, unexpected)
+	}
 
 	if !p.reportInternalErrors {
 		return
@@ -241,6 +309,7 @@ func (p *Parser) parseSchemaDefinition() {
 	}
 
 	p.parseRootOperationTypeDefinitionList(&schemaDefinition.RootOperationTypeDefinitions)
+	schemaDefinition.Position = p.positionFrom(schemaLiteral.TextPosition)
 
 	p.document.SchemaDefinitions = append(p.document.SchemaDefinitions, schemaDefinition)
 
@@ -270,7 +339,7 @@ func (p *Parser) parseRootOperationTypeDefinitionList(list *ast.RootOperationTyp
 			return
 		case keyword.IDENT:
 
-			_, operationType := p.mustReadOneOf(identkeyword.QUERY, identkeyword.MUTATION, identkeyword.SUBSCRIPTION)
+			operationTypeToken, operationType := p.mustReadOneOf(identkeyword.QUERY, identkeyword.MUTATION, identkeyword.SUBSCRIPTION)
 			colon := p.mustRead(keyword.COLON)
 			namedType := p.mustRead(keyword.IDENT)
 
@@ -281,7 +350,9 @@ func (p *Parser) parseRootOperationTypeDefinitionList(list *ast.RootOperationTyp
 					TypeKind: ast.TypeKindNamed,
 					Name:     namedType.Literal,
 					OfType:   -1,
+					Position: namedType.TextPosition,
 				},
+				Position: p.positionFrom(operationTypeToken.TextPosition),
 			}
 
 			p.document.RootOperationTypeDefinitions = append(p.document.RootOperationTypeDefinitions, rootOperationTypeDefinition)
@@ -352,6 +423,7 @@ func (p *Parser) parseDirectiveList() (list ast.DirectiveList) {
 			directive.Arguments = p.parseArgumentList()
 			directive.HasArguments = len(directive.Arguments.Refs) > 0
 		}
+		directive.Position = p.positionFrom(at.TextPosition)
 
 		p.document.Directives = append(p.document.Directives, directive)
 		ref := len(p.document.Directives) - 1
@@ -389,9 +461,10 @@ Loop:
 		value := p.ParseValue()
 
 		argument := ast.Argument{
-			Name:  name.Literal,
-			Colon: colon.TextPosition,
-			Value: value,
+			Name:     name.Literal,
+			Colon:    colon.TextPosition,
+			Value:    value,
+			Position: p.positionFrom(name.TextPosition),
 		}
 
 		p.document.Arguments = append(p.document.Arguments, argument)
@@ -418,6 +491,11 @@ Loop:
 
 func (p *Parser) ParseValue() (value ast.Value) {
 
+	start := p.peekPosition()
+	defer func() {
+		value.Position = p.positionFrom(start)
+	}()
+
 	next, literal := p.peekLiteral()
 
 	switch next {
@@ -490,11 +568,13 @@ func (p *Parser) parseObjectValue() int {
 }
 
 func (p *Parser) parseObjectField() int {
+	name := p.mustRead(keyword.IDENT)
 	objectField := ast.ObjectField{
-		Name:  p.mustRead(keyword.IDENT).Literal,
+		Name:  name.Literal,
 		Colon: p.mustRead(keyword.COLON).TextPosition,
 		Value: p.ParseValue(),
 	}
+	objectField.Position = p.positionFrom(name.TextPosition)
 	p.document.ObjectFields = append(p.document.ObjectFields, objectField)
 	return len(p.document.ObjectFields) - 1
 }
@@ -665,6 +745,7 @@ func (p *Parser) parseObjectTypeDefinition(description *ast.Description) {
 		objectTypeDefinition.FieldsDefinition = p.parseFieldDefinitionList()
 		objectTypeDefinition.HasFieldDefinitions = len(objectTypeDefinition.FieldsDefinition.Refs) > 0
 	}
+	objectTypeDefinition.Position = p.positionFrom(objectTypeDefinition.TypeLiteral)
 	p.document.ObjectTypeDefinitions = append(p.document.ObjectTypeDefinitions, objectTypeDefinition)
 	ref := len(p.document.ObjectTypeDefinitions) - 1
 	node := ast.Node{
@@ -678,6 +759,11 @@ func (p *Parser) parseObjectTypeDefinition(description *ast.Description) {
 }
 
 func (p *Parser) indexNode(key ast.ByteSliceReference, value ast.Node) {
+	if p.report.HasErrors() {
+		// the name might be a different token than an IDENT, e.g. an unterminated block string
+		// the definition is invalid, so it doesn't get indexed
+		return
+	}
 	name := p.document.Input.ByteSlice(key)
 	p.document.Index.AddNodeBytes(name, value)
 }
@@ -739,6 +825,7 @@ func (p *Parser) parseImplementsInterfaces() (list ast.TypeList) {
 				acceptAnd = true
 				name := p.read()
 				ref := p.document.AddNamedTypeByNameRef(name.Literal)
+				p.document.Types[ref].Position = name.TextPosition
 				if cap(list.Refs) == 0 {
 					list.Refs = p.document.Refs[p.document.NextRefIndex()][:0]
 				}
@@ -824,6 +911,7 @@ func (p *Parser) parseFieldDefinition() int {
 		fieldDefinition.Directives = p.parseDirectiveList()
 		fieldDefinition.HasDirectives = len(fieldDefinition.Directives.Refs) > 0
 	}
+	fieldDefinition.Position = p.positionFrom(nameToken.TextPosition)
 
 	p.document.FieldDefinitions = append(p.document.FieldDefinitions, fieldDefinition)
 	return len(p.document.FieldDefinitions) - 1
@@ -832,15 +920,19 @@ func (p *Parser) parseFieldDefinition() int {
 func (p *Parser) parseNamedType() (ref int) {
 	ident := p.mustRead(keyword.IDENT)
 
-	return p.document.AddNamedTypeByNameRef(ident.Literal)
+	ref = p.document.AddNamedTypeByNameRef(ident.Literal)
+	p.document.Types[ref].Position = ident.TextPosition
+	return ref
 }
 
 func (p *Parser) ParseType() (ref int) {
 
+	start := p.peekPosition()
 	first := p.peek()
 
 	if first == keyword.IDENT {
 		ref = p.document.AddNamedTypeByNameRef(p.read().Literal)
+		p.document.Types[ref].Position = p.positionFrom(start)
 	} else if first == keyword.LBRACK {
 
 		openList := p.read()
@@ -848,6 +940,7 @@ func (p *Parser) ParseType() (ref int) {
 		closeList := p.mustRead(keyword.RBRACK)
 
 		ref = p.document.AddListTypeWithPosition(ofType, openList.TextPosition, closeList.TextPosition)
+		p.document.Types[ref].Position = p.positionFrom(start)
 	} else {
 		p.errUnexpectedToken(p.read(), keyword.IDENT, keyword.LBRACK)
 		return
@@ -862,6 +955,7 @@ func (p *Parser) ParseType() (ref int) {
 		}
 
 		ref = p.document.AddNonNullTypeWithPosition(ref, bangPosition)
+		p.document.Types[ref].Position = p.positionFrom(start)
 	}
 
 	return
@@ -919,7 +1013,8 @@ func (p *Parser) parseInputValueDefinition() int {
 		return -1
 	}
 
-	inputValueDefinition.Name = p.read().Literal
+	nameToken := p.read()
+	inputValueDefinition.Name = nameToken.Literal
 	inputValueDefinition.Colon = p.mustRead(keyword.COLON).TextPosition
 	inputValueDefinition.Type = p.ParseType()
 	if p.peekEquals(keyword.EQUALS) {
@@ -932,6 +1027,7 @@ func (p *Parser) parseInputValueDefinition() int {
 		inputValueDefinition.Directives = p.parseDirectiveList()
 		inputValueDefinition.HasDirectives = len(inputValueDefinition.Directives.Refs) > 0
 	}
+	inputValueDefinition.Position = p.positionFrom(nameToken.TextPosition)
 
 	p.document.InputValueDefinitions = append(p.document.InputValueDefinitions, inputValueDefinition)
 	return len(p.document.InputValueDefinitions) - 1
@@ -952,6 +1048,7 @@ func (p *Parser) parseInputObjectTypeDefinition(description *ast.Description) {
 		inputObjectTypeDefinition.InputFieldsDefinition = p.parseInputValueDefinitionList(keyword.RBRACE)
 		inputObjectTypeDefinition.HasInputFieldsDefinition = len(inputObjectTypeDefinition.InputFieldsDefinition.Refs) > 0
 	}
+	inputObjectTypeDefinition.Position = p.positionFrom(inputObjectTypeDefinition.InputLiteral)
 	p.document.InputObjectTypeDefinitions = append(p.document.InputObjectTypeDefinitions, inputObjectTypeDefinition)
 	ref := len(p.document.InputObjectTypeDefinitions) - 1
 	node := ast.Node{
@@ -975,6 +1072,7 @@ func (p *Parser) parseScalarTypeDefinition(description *ast.Description) {
 		scalarTypeDefinition.Directives = p.parseDirectiveList()
 		scalarTypeDefinition.HasDirectives = len(scalarTypeDefinition.Directives.Refs) > 0
 	}
+	scalarTypeDefinition.Position = p.positionFrom(scalarTypeDefinition.ScalarLiteral)
 	p.document.ScalarTypeDefinitions = append(p.document.ScalarTypeDefinitions, scalarTypeDefinition)
 	ref := len(p.document.ScalarTypeDefinitions) - 1
 	node := ast.Node{
@@ -1005,6 +1103,7 @@ func (p *Parser) parseInterfaceTypeDefinition(description *ast.Description) {
 		interfaceTypeDefinition.FieldsDefinition = p.parseFieldDefinitionList()
 		interfaceTypeDefinition.HasFieldDefinitions = len(interfaceTypeDefinition.FieldsDefinition.Refs) > 0
 	}
+	interfaceTypeDefinition.Position = p.positionFrom(interfaceTypeDefinition.InterfaceLiteral)
 	p.document.InterfaceTypeDefinitions = append(p.document.InterfaceTypeDefinitions, interfaceTypeDefinition)
 	ref := len(p.document.InterfaceTypeDefinitions) - 1
 	node := ast.Node{
@@ -1033,6 +1132,7 @@ func (p *Parser) parseUnionTypeDefinition(description *ast.Description) {
 		unionTypeDefinition.UnionMemberTypes = p.parseUnionMemberTypes()
 		unionTypeDefinition.HasUnionMemberTypes = len(unionTypeDefinition.UnionMemberTypes.Refs) > 0
 	}
+	unionTypeDefinition.Position = p.positionFrom(unionTypeDefinition.UnionLiteral)
 	p.document.UnionTypeDefinitions = append(p.document.UnionTypeDefinitions, unionTypeDefinition)
 	ref := len(p.document.UnionTypeDefinitions) - 1
 	node := ast.Node{
@@ -1073,6 +1173,7 @@ func (p *Parser) parseUnionMemberTypes() (list ast.TypeList) {
 				ident := p.read()
 
 				ref := p.document.AddNamedTypeByNameRef(ident.Literal)
+				p.document.Types[ref].Position = ident.TextPosition
 
 				if cap(list.Refs) == 0 {
 					list.Refs = p.document.Refs[p.document.NextRefIndex()][:0]
@@ -1109,6 +1210,7 @@ func (p *Parser) parseEnumTypeDefinition(description *ast.Description) {
 		enumTypeDefinition.EnumValuesDefinition = p.parseEnumValueDefinitionList()
 		enumTypeDefinition.HasEnumValuesDefinition = len(enumTypeDefinition.EnumValuesDefinition.Refs) > 0
 	}
+	enumTypeDefinition.Position = p.positionFrom(enumTypeDefinition.EnumLiteral)
 	p.document.EnumTypeDefinitions = append(p.document.EnumTypeDefinitions, enumTypeDefinition)
 	ref := len(p.document.EnumTypeDefinitions) - 1
 	node := ast.Node{
@@ -1161,11 +1263,13 @@ func (p *Parser) parseEnumValueDefinition() int {
 		return -1
 	}
 
-	enumValueDefinition.EnumValue = p.mustRead(keyword.IDENT).Literal
+	name := p.mustRead(keyword.IDENT)
+	enumValueDefinition.EnumValue = name.Literal
 	if p.peekEquals(keyword.AT) {
 		enumValueDefinition.Directives = p.parseDirectiveList()
 		enumValueDefinition.HasDirectives = len(enumValueDefinition.Directives.Refs) > 0
 	}
+	enumValueDefinition.Position = p.positionFrom(name.TextPosition)
 
 	p.document.EnumValueDefinitions = append(p.document.EnumValueDefinitions, enumValueDefinition)
 	return len(p.document.EnumValueDefinitions) - 1
@@ -1191,6 +1295,7 @@ func (p *Parser) parseDirectiveDefinition(description *ast.Description) {
 
 	directiveDefinition.On = p.mustReadIdentKey(identkeyword.ON).TextPosition
 	p.parseDirectiveLocations(&directiveDefinition.DirectiveLocations)
+	directiveDefinition.Position = p.positionFrom(directiveDefinition.DirectiveLiteral)
 	p.document.DirectiveDefinitions = append(p.document.DirectiveDefinitions, directiveDefinition)
 	ref := len(p.document.DirectiveDefinitions) - 1
 	node := ast.Node{
@@ -1220,8 +1325,9 @@ func (p *Parser) parseDirectiveLocations(locations *ast.DirectiveLocations) {
 				raw := p.document.Input.ByteSlice(ident.Literal)
 				err := locations.SetFromRaw(raw)
 				if err != nil {
+					message := fmt.Sprintf("invalid directive location: %s", unsafebytes.BytesToString(raw))
 					p.report.AddExternalError(operationreport.ExternalError{
-						Message: fmt.Sprintf("invalid directive location: %s", unsafebytes.BytesToString(raw)),
+						Message: message,
 						Locations: []graphqlerrors.Location{
 							{
 								Line:   ident.TextPosition.LineStart,
@@ -1229,6 +1335,9 @@ func (p *Parser) parseDirectiveLocations(locations *ast.DirectiveLocations) {
 							},
 						},
 					})
+					if p.recovery != nil {
+						p.addDiagnostic(message, ident)
+					}
 					if p.reportInternalErrors {
 						p.report.AddInternalError(err)
 					}
@@ -1268,19 +1377,21 @@ func (p *Parser) parseSelectionSet() (int, bool) {
 	set.SelectionRefs = p.document.Refs[p.document.NextRefIndex()][:0]
 	lbraceToken := p.mustRead(keyword.LBRACE)
 	set.LBrace = lbraceToken.TextPosition
+	if p.report.HasErrors() {
+		return -1, false
+	}
 
 	for {
+		if p.recovery != nil && p.selectionSetIsUnterminated() {
+			return p.addSelectionSet(set)
+		}
+		selectionStart, selections := p.tokenizer.currentToken+1, len(set.SelectionRefs)
+
 		switch p.peek() {
 		case keyword.RBRACE:
 			rbraceToken := p.read()
 			set.RBrace = rbraceToken.TextPosition
-
-			if len(set.SelectionRefs) == 0 {
-				return 0, false
-			}
-
-			p.document.SelectionSets = append(p.document.SelectionSets, set)
-			return len(p.document.SelectionSets) - 1, true
+			return p.addSelectionSet(set)
 
 		case keyword.IDENT, keyword.SPREAD:
 			if cap(set.SelectionRefs) == 0 {
@@ -1293,11 +1404,29 @@ func (p *Parser) parseSelectionSet() (int, bool) {
 		}
 
 		if p.report.HasErrors() {
-			return -1, false
+			if p.recovery == nil {
+				return -1, false
+			}
+			// drop the invalid selection and continue with the next one
+			set.SelectionRefs = set.SelectionRefs[:selections]
+			if !p.recoverSelection(selectionStart) {
+				return p.addSelectionSet(set)
+			}
 		}
 	}
 }
 
+func (p *Parser) addSelectionSet(set ast.SelectionSet) (int, bool) {
+	if len(set.SelectionRefs) == 0 {
+		return 0, false
+	}
+
+	set.Position = p.positionFrom(set.LBrace)
+
+	p.document.SelectionSets = append(p.document.SelectionSets, set)
+	return len(p.document.SelectionSets) - 1, true
+}
+
 func (p *Parser) parseSelection() int {
 	next := p.peek()
 	switch next {
@@ -1364,7 +1493,6 @@ func (p *Parser) parseField() int {
 	} else {
 		field.Name = firstToken.Literal
 	}
-	field.Position = firstToken.TextPosition
 
 	if p.peekEquals(keyword.LPAREN) {
 		field.Arguments = p.parseArgumentList()
@@ -1377,6 +1505,7 @@ func (p *Parser) parseField() int {
 	if p.peekEquals(keyword.LBRACE) {
 		field.SelectionSet, field.HasSelections = p.parseSelectionSet()
 	}
+	field.Position = p.positionFrom(firstToken.TextPosition)
 
 	p.document.Fields = append(p.document.Fields, field)
 	return len(p.document.Fields) - 1
@@ -1390,6 +1519,7 @@ func (p *Parser) parseFragmentSpread(spread position.Position) int {
 		fragmentSpread.Directives = p.parseDirectiveList()
 		fragmentSpread.HasDirectives = len(fragmentSpread.Directives.Refs) > 0
 	}
+	fragmentSpread.Position = p.positionFrom(spread)
 	p.document.FragmentSpreads = append(p.document.FragmentSpreads, fragmentSpread)
 	return len(p.document.FragmentSpreads) - 1
 }
@@ -1411,6 +1541,7 @@ func (p *Parser) parseInlineFragment(spread position.Position) int {
 	if p.peekEquals(keyword.LBRACE) {
 		fragment.SelectionSet, fragment.HasSelections = p.parseSelectionSet()
 	}
+	fragment.Position = p.positionFrom(spread)
 	p.document.InlineFragments = append(p.document.InlineFragments, fragment)
 	return len(p.document.InlineFragments) - 1
 }
@@ -1425,6 +1556,7 @@ func (p *Parser) parseOperationDefinition() {
 
 	var operationDefinition ast.OperationDefinition
 
+	start := p.peekPosition()
 	next, literal := p.peekLiteral()
 	switch next {
 	case keyword.IDENT:
@@ -1446,6 +1578,7 @@ func (p *Parser) parseOperationDefinition() {
 	case keyword.LBRACE:
 		operationDefinition.OperationType = ast.OperationTypeQuery
 		operationDefinition.SelectionSet, operationDefinition.HasSelections = p.parseSelectionSet()
+		operationDefinition.Position = p.positionFrom(start)
 		p.document.OperationDefinitions = append(p.document.OperationDefinitions, operationDefinition)
 		ref := len(p.document.OperationDefinitions) - 1
 		rootNode := ast.Node{
@@ -1472,6 +1605,7 @@ func (p *Parser) parseOperationDefinition() {
 	}
 
 	operationDefinition.SelectionSet, operationDefinition.HasSelections = p.parseSelectionSet()
+	operationDefinition.Position = p.positionFrom(start)
 
 	p.document.OperationDefinitions = append(p.document.OperationDefinitions, operationDefinition)
 	ref := len(p.document.OperationDefinitions) - 1
@@ -1516,8 +1650,10 @@ func (p *Parser) parseVariableDefinition() int {
 
 	var variableDefinition ast.VariableDefinition
 
+	start := p.peekPosition()
 	variableDefinition.VariableValue.Kind = ast.ValueKindVariable
 	variableDefinition.VariableValue.Ref = p.parseVariableValue()
+	variableDefinition.VariableValue.Position = p.positionFrom(start)
 
 	variableDefinition.Colon = p.mustRead(keyword.COLON).TextPosition
 	variableDefinition.Type = p.ParseType()
@@ -1528,6 +1664,7 @@ func (p *Parser) parseVariableDefinition() int {
 		variableDefinition.Directives = p.parseDirectiveList()
 		variableDefinition.HasDirectives = len(variableDefinition.Directives.Refs) > 0
 	}
+	variableDefinition.Position = p.positionFrom(start)
 	p.document.VariableDefinitions = append(p.document.VariableDefinitions, variableDefinition)
 	return len(p.document.VariableDefinitions) - 1
 }
@@ -1551,6 +1688,7 @@ func (p *Parser) parseFragmentDefinition() {
 		fragmentDefinition.Directives = p.parseDirectiveList()
 	}
 	fragmentDefinition.SelectionSet, fragmentDefinition.HasSelections = p.parseSelectionSet()
+	fragmentDefinition.Position = p.positionFrom(fragmentDefinition.FragmentLiteral)
 	p.document.FragmentDefinitions = append(p.document.FragmentDefinitions, fragmentDefinition)
 
 	ref := len(p.document.FragmentDefinitions) - 1
@@ -1601,6 +1739,7 @@ func (p *Parser) parseSchemaExtension(extend position.Position) {
 		schemaDefinition.HasDirectives = len(schemaDefinition.Directives.Refs) > 0
 	}
 	p.parseRootOperationTypeDefinitionList(&schemaDefinition.RootOperationTypeDefinitions)
+	schemaDefinition.Position = p.positionFrom(extend)
 
 	schemaExtension := ast.SchemaExtension{
 		ExtendLiteral:    extend,
@@ -1626,6 +1765,7 @@ func (p *Parser) parseObjectTypeExtension(extend position.Position) {
 		objectTypeDefinition.FieldsDefinition = p.parseFieldDefinitionList()
 		objectTypeDefinition.HasFieldDefinitions = len(objectTypeDefinition.FieldsDefinition.Refs) > 0
 	}
+	objectTypeDefinition.Position = p.positionFrom(extend)
 	objectTypeExtension := ast.ObjectTypeExtension{
 		ExtendLiteral:        extend,
 		ObjectTypeDefinition: objectTypeDefinition,
@@ -1655,6 +1795,7 @@ func (p *Parser) parseInterfaceTypeExtension(extend position.Position) {
 		interfaceTypeDefinition.FieldsDefinition = p.parseFieldDefinitionList()
 		interfaceTypeDefinition.HasFieldDefinitions = len(interfaceTypeDefinition.FieldsDefinition.Refs) > 0
 	}
+	interfaceTypeDefinition.Position = p.positionFrom(extend)
 	interfaceTypeExtension := ast.InterfaceTypeExtension{
 		ExtendLiteral:           extend,
 		InterfaceTypeDefinition: interfaceTypeDefinition,
@@ -1677,6 +1818,7 @@ func (p *Parser) parseScalarTypeExtension(extend position.Position) {
 		scalarTypeDefinition.Directives = p.parseDirectiveList()
 		scalarTypeDefinition.HasDirectives = len(scalarTypeDefinition.Directives.Refs) > 0
 	}
+	scalarTypeDefinition.Position = p.positionFrom(extend)
 	scalarTypeExtension := ast.ScalarTypeExtension{
 		ExtendLiteral:        extend,
 		ScalarTypeDefinition: scalarTypeDefinition,
@@ -1704,6 +1846,7 @@ func (p *Parser) parseUnionTypeExtension(extend position.Position) {
 		unionTypeDefinition.UnionMemberTypes = p.parseUnionMemberTypes()
 		unionTypeDefinition.HasUnionMemberTypes = len(unionTypeDefinition.UnionMemberTypes.Refs) > 0
 	}
+	unionTypeDefinition.Position = p.positionFrom(extend)
 	unionTypeExtension := ast.UnionTypeExtension{
 		ExtendLiteral:       extend,
 		UnionTypeDefinition: unionTypeDefinition,
@@ -1730,6 +1873,7 @@ func (p *Parser) parseEnumTypeExtension(extend position.Position) {
 		enumTypeDefinition.EnumValuesDefinition = p.parseEnumValueDefinitionList()
 		enumTypeDefinition.HasEnumValuesDefinition = len(enumTypeDefinition.EnumValuesDefinition.Refs) > 0
 	}
+	enumTypeDefinition.Position = p.positionFrom(extend)
 	enumTypeExtension := ast.EnumTypeExtension{
 		ExtendLiteral:      extend,
 		EnumTypeDefinition: enumTypeDefinition,
@@ -1756,6 +1900,7 @@ func (p *Parser) parseInputObjectTypeExtension(extend position.Position) {
 		inputObjectTypeDefinition.InputFieldsDefinition = p.parseInputValueDefinitionList(keyword.RBRACE)
 		inputObjectTypeDefinition.HasInputFieldsDefinition = len(inputObjectTypeDefinition.InputFieldsDefinition.Refs) > 0
 	}
+	inputObjectTypeDefinition.Position = p.positionFrom(extend)
 	inputObjectTypeExtension := ast.InputObjectTypeExtension{
 		ExtendLiteral:             extend,
 		InputObjectTypeDefinition: inputObjectTypeDefinition,
@@ -1,9 +1,16 @@
 package astparser
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/wundergraph/graphql-go-tools/pkg/ast"
 	"github.com/wundergraph/graphql-go-tools/pkg/lexer/keyword"
@@ -2235,6 +2242,163 @@ func TestErrorReport(t *testing.T) {
 	})
 }
 
+func TestParser_Positions(t *testing.T) {
+	input := `query Q($id: ID! = 1) {
+  user(id: $id) @include(if: true) {
+    ...F
+    ... on User { name }
+  }
+}
+"a user"
+type User implements Node {
+  name(format: [String!]): String
+}
+extend enum Role { ADMIN }`
+
+	doc, report := ParseGraphqlDocumentString(input)
+	if report.HasErrors() {
+		t.Fatal(report.Error())
+	}
+
+	assertPosition := func(t *testing.T, node ast.Node, want string) {
+		t.Helper()
+		if got := doc.NodePosition(node).String(); got != want {
+			t.Fatalf("want position of %s: %s, got: %s", node.Kind, want, got)
+		}
+	}
+
+	t.Run("executable definitions", func(t *testing.T) {
+		assertPosition(t, ast.Node{Kind: ast.NodeKindOperationDefinition, Ref: 0}, "1:1-6:2")
+		assertPosition(t, ast.Node{Kind: ast.NodeKindVariableDefinition, Ref: 0}, "1:9-1:21")
+		assertPosition(t, ast.Node{Kind: ast.NodeKindField, Ref: 0}, "4:19-4:23")
+		assertPosition(t, ast.Node{Kind: ast.NodeKindField, Ref: 1}, "2:3-5:4")
+		assertPosition(t, ast.Node{Kind: ast.NodeKindArgument, Ref: 0}, "2:8-2:15")
+		assertPosition(t, ast.Node{Kind: ast.NodeKindDirective, Ref: 0}, "2:17-2:35")
+		assertPosition(t, ast.Node{Kind: ast.NodeKindFragmentSpread, Ref: 0}, "3:5-3:9")
+		assertPosition(t, ast.Node{Kind: ast.NodeKindInlineFragment, Ref: 0}, "4:5-4:25")
+		assertPosition(t, ast.Node{Kind: ast.NodeKindSelectionSet, Ref: 2}, "1:23-6:2")
+		if got := doc.Arguments[0].Value.Position.String(); got != "2:12-2:15" {
+			t.Fatalf("unexpected position of argument value: %s", got)
+		}
+	})
+	t.Run("type system definitions", func(t *testing.T) {
+		assertPosition(t, ast.Node{Kind: ast.NodeKindObjectTypeDefinition, Ref: 0}, "8:1-10:2")
+		assertPosition(t, ast.Node{Kind: ast.NodeKindFieldDefinition, Ref: 0}, "9:3-9:34")
+		assertPosition(t, ast.Node{Kind: ast.NodeKindInputValueDefinition, Ref: 0}, "9:8-9:25")
+		assertPosition(t, ast.Node{Kind: ast.NodeKindEnumTypeExtension, Ref: 0}, "11:1-11:27")
+		assertPosition(t, ast.Node{Kind: ast.NodeKindEnumValueDefinition, Ref: 0}, "11:20-11:25")
+		if got := doc.Types[doc.InputValueDefinitions[0].Type].Position.String(); got != "9:16-9:25" {
+			t.Fatalf("unexpected position of type: %s", got)
+		}
+	})
+}
+
+func TestParser_KeepComments(t *testing.T) {
+	input := "# first\n# second\ntype Query { # trailing\n  user: User\n}"
+
+	t.Run("comments are dropped by default", func(t *testing.T) {
+		doc, report := ParseGraphqlDocumentString(input)
+		if report.HasErrors() {
+			t.Fatal(report.Error())
+		}
+		if len(doc.Comments) != 0 {
+			t.Fatalf("want no comments, got: %d", len(doc.Comments))
+		}
+	})
+	t.Run("keep comments", func(t *testing.T) {
+		doc := ast.NewDocument()
+		doc.Input.ResetInputString(input)
+		report := operationreport.Report{}
+		parser := NewParser()
+		parser.KeepComments(true)
+		parser.Parse(doc, &report)
+		if report.HasErrors() {
+			t.Fatal(report.Error())
+		}
+
+		want := []string{"# first\n# second", "# trailing"}
+		if len(doc.Comments) != len(want) {
+			t.Fatalf("want %d comments, got: %d", len(want), len(doc.Comments))
+		}
+		for i := range want {
+			if got := doc.CommentString(i); got != want[i] {
+				t.Fatalf("want comment %d:\n%s\ngot:\n%s", i, want[i], got)
+			}
+		}
+		if doc.Comments[1].Position.LineStart != 3 || doc.Comments[1].Position.CharStart != 14 {
+			t.Fatalf("unexpected position of trailing comment: %s", doc.Comments[1].Position)
+		}
+	})
+}
+
+func TestParser_ParseReader(t *testing.T) {
+	t.Run("parses the same document as parse", func(t *testing.T) {
+		schema, err := ioutil.ReadFile("./testdata/github.schema.graphql")
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, report := ParseGraphqlDocumentBytes(schema)
+		if report.HasErrors() {
+			t.Fatal(report.Error())
+		}
+
+		file, err := os.Open("./testdata/github.schema.graphql")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer file.Close()
+
+		got, report := ParseGraphqlDocumentReader(file)
+		if report.HasErrors() {
+			t.Fatal(report.Error())
+		}
+
+		assert.Equal(t, schema, got.Input.RawBytes)
+		assert.Equal(t, want.RootNodes, got.RootNodes)
+		assert.Equal(t, want.ObjectTypeDefinitions, got.ObjectTypeDefinitions)
+		assert.Equal(t, want.FieldDefinitions, got.FieldDefinitions)
+		assert.Equal(t, want.InputValueDefinitions, got.InputValueDefinitions)
+	})
+	t.Run("streamed in small chunks", func(t *testing.T) {
+		input := "# users\nquery Q($id: ID!) { user(id: $id) { name } } # trailing"
+		doc := ast.NewDocument()
+		report := operationreport.Report{}
+		parser := NewParser()
+		parser.KeepComments(true)
+		parser.ParseReader(iotest.OneByteReader(strings.NewReader(input)), doc, &report)
+		if report.HasErrors() {
+			t.Fatal(report.Error())
+		}
+
+		assert.Equal(t, "Q", doc.OperationDefinitionNameString(0))
+		assert.Equal(t, "user", doc.FieldNameString(1))
+		assert.Equal(t, "2:1-2:45", doc.OperationDefinitions[0].Position.String())
+		assert.Equal(t, 2, len(doc.Comments))
+		assert.Equal(t, "# trailing", doc.CommentString(1))
+	})
+	t.Run("replaces the previous input", func(t *testing.T) {
+		doc := ast.NewDocument()
+		doc.Input.ResetInputString("type Query { old: String }")
+		report := operationreport.Report{}
+		NewParser().ParseReader(bytes.NewBufferString("{ new }"), doc, &report)
+		if report.HasErrors() {
+			t.Fatal(report.Error())
+		}
+
+		assert.Equal(t, "{ new }", string(doc.Input.RawBytes))
+		assert.Equal(t, "new", doc.FieldNameString(0))
+	})
+	t.Run("reader error", func(t *testing.T) {
+		readErr := errors.New("connection reset")
+		reader := io.MultiReader(strings.NewReader("type Query {"), iotest.ErrReader(readErr))
+		_, report := ParseGraphqlDocumentReader(reader)
+
+		assert.Equal(t, 0, len(report.ExternalErrors))
+		assert.Equal(t, 1, len(report.InternalErrors))
+		assert.True(t, errors.Is(report.InternalErrors[0], readErr))
+	})
+}
+
 func TestParseStarwars(t *testing.T) {
 
 	starWarsSchema, err := ioutil.ReadFile("./testdata/starwars.schema.graphql")
@@ -2317,6 +2481,85 @@ func BenchmarkParseGithub(b *testing.B) {
 	}
 }
 
+// BenchmarkParseLargeDocument compares parsing a large document from a file by reading it into memory upfront
+// with streaming it into the parser. The document is parsed into a new AST for every iteration,
+// so that the allocations reflect the memory needed to parse a document once.
+func BenchmarkParseLargeDocument(b *testing.B) {
+
+	schema, err := ioutil.ReadFile("./testdata/github.schema.graphql")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	// ~12MB, duplicate type names are fine as long as the document isn't validated
+	fileName := filepath.Join(b.TempDir(), "large.graphql")
+	large := bytes.Repeat(schema, 20)
+	if err := ioutil.WriteFile(fileName, large, 0644); err != nil {
+		b.Fatal(err)
+	}
+
+	parser := NewParser()
+	report := operationreport.Report{}
+
+	b.Run("read file", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(large)))
+
+		for i := 0; i < b.N; i++ {
+			content, err := ioutil.ReadFile(fileName)
+			if err != nil {
+				b.Fatal(err)
+			}
+			doc := ast.NewDocument()
+			doc.Input.ResetInputBytes(content)
+			report.Reset()
+			parser.Parse(doc, &report)
+			if report.HasErrors() {
+				b.Fatal(report.Error())
+			}
+		}
+	})
+
+	b.Run("stream file", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(large)))
+
+		for i := 0; i < b.N; i++ {
+			file, err := os.Open(fileName)
+			if err != nil {
+				b.Fatal(err)
+			}
+			doc := ast.NewDocument()
+			report.Reset()
+			parser.ParseReader(file, doc, &report)
+			file.Close()
+			if report.HasErrors() {
+				b.Fatal(report.Error())
+			}
+		}
+	})
+
+	b.Run("stream unknown size", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(large)))
+
+		for i := 0; i < b.N; i++ {
+			file, err := os.Open(fileName)
+			if err != nil {
+				b.Fatal(err)
+			}
+			doc := ast.NewDocument()
+			report.Reset()
+			// hide the size of the file like for network streams
+			parser.ParseReader(struct{ io.Reader }{file}, doc, &report)
+			file.Close()
+			if report.HasErrors() {
+				b.Fatal(report.Error())
+			}
+		}
+	})
+}
+
 func BenchmarkSelectionSet(b *testing.B) {
 
 	doc := ast.NewDocument()
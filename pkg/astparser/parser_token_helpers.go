@@ -4,6 +4,7 @@ import (
 	"github.com/wundergraph/graphql-go-tools/pkg/ast"
 	"github.com/wundergraph/graphql-go-tools/pkg/lexer/identkeyword"
 	"github.com/wundergraph/graphql-go-tools/pkg/lexer/keyword"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/position"
 	"github.com/wundergraph/graphql-go-tools/pkg/lexer/token"
 )
 
@@ -12,6 +13,17 @@ func (p *Parser) read() token.Token {
 	return p.tokenizer.Read()
 }
 
+// positionFrom - returns the position of a node which starts at start and ends with the last read token
+func (p *Parser) positionFrom(start position.Position) position.Position {
+	start.MergeEndIntoEnd(p.tokenizer.lastPosition())
+	return start
+}
+
+// peekPosition - returns the position of the token next to currentToken
+func (p *Parser) peekPosition() position.Position {
+	return p.tokenizer.Peek().TextPosition
+}
+
 // peek - returns token next to currentToken
 // returns keyword.EOF when reached end of document
 func (p *Parser) peek() keyword.Keyword {
@@ -0,0 +1,262 @@
+package astparser
+
+import (
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/identkeyword"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/keyword"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/position"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/token"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+// Diagnostic is a syntax error found by ParseWithRecovery
+type Diagnostic struct {
+	Message string
+	// Position is the source range of the unexpected token
+	Position position.Position
+}
+
+// recovery holds the state of a parse with error recovery
+type recovery struct {
+	// report is the report passed to ParseWithRecovery, it collects the errors of all definitions
+	report *operationreport.Report
+	// definitionReport collects the errors of the definition or selection which is currently parsed
+	definitionReport operationreport.Report
+	diagnostics      []Diagnostic
+	// unterminatedSelectionSet is true when a selection set of the current definition misses its closing brace
+	unterminatedSelectionSet bool
+}
+
+// ParseWithRecovery parses all input in a Document.Input into the Document.
+// Unlike Parse it doesn't stop at the first syntax error, which makes it suitable for editor tooling and linting:
+// Definitions with syntax errors are skipped and parsing continues with the next definition.
+// Selections with syntax errors are skipped and parsing continues with the next selection of the same selection set.
+// Unterminated selection sets are closed at the end of the input or at the next definition keyword at the start of a line.
+// All syntax errors are added to the report and returned as diagnostics with their source range,
+// the document only contains the well-formed definitions and can be used by the walkers.
+func (p *Parser) ParseWithRecovery(document *ast.Document, report *operationreport.Report) []Diagnostic {
+	p.document = document
+	p.recovery = &recovery{
+		report: report,
+	}
+	p.report = &p.recovery.definitionReport
+	defer func() {
+		p.report = report
+		p.recovery = nil
+	}()
+
+	p.tokenize()
+	p.parseWithRecovery()
+
+	return p.recovery.diagnostics
+}
+
+func (p *Parser) parseWithRecovery() {
+	for {
+		key, literalReference := p.peekLiteral()
+		if key == keyword.EOF {
+			p.read()
+			return
+		}
+
+		definitionStart := p.nextTokenIndex()
+		rootNodes := len(p.document.RootNodes)
+		index := p.document.Index
+		p.recovery.unterminatedSelectionSet = false
+
+		p.parseDefinition(key, literalReference)
+
+		if !p.report.HasErrors() {
+			continue
+		}
+
+		p.moveErrorsToRecoveryReport()
+		p.removeRootNodesFrom(rootNodes)
+		p.document.Index.QueryTypeName = index.QueryTypeName
+		p.document.Index.MutationTypeName = index.MutationTypeName
+		p.document.Index.SubscriptionTypeName = index.SubscriptionTypeName
+		p.skipToNextDefinition(definitionStart)
+	}
+}
+
+func (p *Parser) addDiagnostic(message string, unexpected token.Token) {
+	diagnostic := Diagnostic{
+		Message:  message,
+		Position: unexpected.TextPosition,
+	}
+	if unexpected.Keyword == keyword.EOF && p.tokenizer.maxTokens > 0 {
+		// the EOF token has no position, it's located at the end of the last token
+		last := p.tokenizer.tokens[p.tokenizer.maxTokens-1].TextPosition
+		diagnostic.Position = position.Position{
+			LineStart: last.LineEnd,
+			LineEnd:   last.LineEnd,
+			CharStart: last.CharEnd,
+			CharEnd:   last.CharEnd,
+		}
+	}
+	p.recovery.diagnostics = append(p.recovery.diagnostics, diagnostic)
+}
+
+func (p *Parser) moveErrorsToRecoveryReport() {
+	p.recovery.report.ExternalErrors = append(p.recovery.report.ExternalErrors, p.report.ExternalErrors...)
+	p.recovery.report.InternalErrors = append(p.recovery.report.InternalErrors, p.report.InternalErrors...)
+	p.report.Reset()
+}
+
+// removeRootNodesFrom removes the root nodes of an invalid definition, including their index entries
+func (p *Parser) removeRootNodesFrom(ref int) {
+	for _, node := range p.document.RootNodes[ref:] {
+		name := p.document.NodeNameBytes(node)
+		if node.Kind == ast.NodeKindSchemaDefinition {
+			name = []byte("schema")
+		}
+		p.document.Index.RemoveNodeBytes(name, node)
+	}
+	p.document.RootNodes = p.document.RootNodes[:ref]
+}
+
+// skipToNextDefinition moves the tokenizer in front of the next definition after an invalid definition
+// The next definition is a definition keyword at the start of a line or any definition start outside of brackets.
+func (p *Parser) skipToNextDefinition(definitionStart int) {
+	tokens := p.tokenizer.tokens[:p.tokenizer.maxTokens]
+	current := p.tokenizer.currentToken
+	if current < definitionStart {
+		current = definitionStart
+	}
+
+	// a definition keyword at the start of a line could have been consumed as part of the invalid definition,
+	// e.g. when the closing brace of the previous definition is missing
+	for i := definitionStart + 1; i <= current && i < len(tokens); i++ {
+		if p.isDefinitionStartOfLine(tokens[i]) {
+			p.tokenizer.currentToken = i - 1
+			return
+		}
+	}
+
+	depth := 0
+	for i := definitionStart; i <= current && i < len(tokens); i++ {
+		depth += bracketDepth(tokens[i].Keyword)
+	}
+
+	for i := current + 1; i < len(tokens); i++ {
+		if (depth <= 0 && p.isDefinitionStart(tokens[i])) || p.isDefinitionStartOfLine(tokens[i]) {
+			p.tokenizer.currentToken = i - 1
+			return
+		}
+		depth += bracketDepth(tokens[i].Keyword)
+	}
+
+	p.tokenizer.currentToken = len(tokens) - 1
+}
+
+// recoverSelection moves the tokenizer in front of the next selection after an invalid selection
+// returns false when the closing brace of the selection set was part of the invalid selection
+func (p *Parser) recoverSelection(selectionStart int) bool {
+	p.moveErrorsToRecoveryReport()
+
+	tokens := p.tokenizer.tokens[:p.tokenizer.maxTokens]
+	current := p.tokenizer.currentToken
+
+	braces, brackets := 0, 0
+	for i := selectionStart; i <= current && i < len(tokens); i++ {
+		braces, brackets = selectionDepth(tokens[i].Keyword, braces, brackets)
+		if braces < 0 {
+			return false
+		}
+	}
+
+	for i := current + 1; i < len(tokens); i++ {
+		if p.isDefinitionStartOfLine(tokens[i]) {
+			p.tokenizer.currentToken = i - 1
+			return true
+		}
+		if braces == 0 && brackets == 0 {
+			switch tokens[i].Keyword {
+			case keyword.IDENT, keyword.SPREAD, keyword.RBRACE:
+				p.tokenizer.currentToken = i - 1
+				return true
+			}
+		}
+		braces, brackets = selectionDepth(tokens[i].Keyword, braces, brackets)
+		if braces < 0 {
+			p.tokenizer.currentToken = i
+			return false
+		}
+	}
+
+	p.tokenizer.currentToken = len(tokens) - 1
+	return true
+}
+
+// selectionSetIsUnterminated reports whether the selection set ends without closing brace,
+// i.e. at the end of the input or at a definition keyword at the start of a line
+// The missing closing brace is reported once per definition.
+func (p *Parser) selectionSetIsUnterminated() bool {
+	next := p.tokenizer.Peek()
+	if next.Keyword != keyword.EOF && !p.isDefinitionStartOfLine(next) {
+		return false
+	}
+	if !p.recovery.unterminatedSelectionSet {
+		p.recovery.unterminatedSelectionSet = true
+		p.errUnexpectedToken(next, keyword.RBRACE)
+		p.moveErrorsToRecoveryReport()
+	}
+	return true
+}
+
+// nextTokenIndex returns the index of the next token which isn't a comment
+func (p *Parser) nextTokenIndex() int {
+	next := p.tokenizer.currentToken + 1
+	for next < p.tokenizer.maxTokens && p.tokenizer.tokens[next].Keyword == keyword.COMMENT {
+		next++
+	}
+	return next
+}
+
+func (p *Parser) isDefinitionStartOfLine(tok token.Token) bool {
+	return tok.TextPosition.CharStart == 1 && p.isDefinitionStart(tok)
+}
+
+func (p *Parser) isDefinitionStart(tok token.Token) bool {
+	switch tok.Keyword {
+	case keyword.LBRACE, keyword.STRING, keyword.BLOCKSTRING:
+		return true
+	case keyword.IDENT:
+		switch p.identKeywordToken(tok) {
+		case identkeyword.QUERY, identkeyword.MUTATION, identkeyword.SUBSCRIPTION, identkeyword.FRAGMENT,
+			identkeyword.SCHEMA, identkeyword.TYPE, identkeyword.INTERFACE, identkeyword.UNION, identkeyword.ENUM,
+			identkeyword.INPUT, identkeyword.SCALAR, identkeyword.DIRECTIVE, identkeyword.EXTEND:
+			return true
+		}
+	}
+	return false
+}
+
+func bracketDepth(key keyword.Keyword) int {
+	switch key {
+	case keyword.LBRACE, keyword.LPAREN, keyword.LBRACK:
+		return 1
+	case keyword.RBRACE, keyword.RPAREN, keyword.RBRACK:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// selectionDepth tracks braces separately from parentheses and brackets,
+// so that a closing brace of the selection set is detected even if an argument list is unterminated
+func selectionDepth(key keyword.Keyword, braces, brackets int) (int, int) {
+	switch key {
+	case keyword.LBRACE:
+		braces++
+	case keyword.RBRACE:
+		braces--
+	case keyword.LPAREN, keyword.LBRACK:
+		brackets++
+	case keyword.RPAREN, keyword.RBRACK:
+		if brackets > 0 {
+			brackets--
+		}
+	}
+	return braces, brackets
+}
@@ -0,0 +1,234 @@
+package astparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astprinter"
+	"github.com/wundergraph/graphql-go-tools/pkg/graphqlerrors"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/position"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+func TestParser_ParseWithRecovery(t *testing.T) {
+	run := func(t *testing.T, input string, expectedOutput string, expectedDiagnostics []Diagnostic) {
+		t.Helper()
+
+		doc := ast.NewDocument()
+		doc.Input.ResetInputString(input)
+		report := operationreport.Report{}
+
+		diagnostics := NewParser().ParseWithRecovery(doc, &report)
+		assert.Equal(t, expectedDiagnostics, diagnostics)
+
+		require.Len(t, report.ExternalErrors, len(expectedDiagnostics))
+		for i := range expectedDiagnostics {
+			assert.Equal(t, expectedDiagnostics[i].Message, report.ExternalErrors[i].Message)
+		}
+
+		out, err := astprinter.PrintString(doc, nil)
+		require.NoError(t, err)
+		assert.Equal(t, expectedOutput, out)
+	}
+
+	t.Run("valid document", func(t *testing.T) {
+		run(t, `
+			type Query { user: User }
+			type User { name: String }
+			query { user { name } }`,
+			`type Query {user: User} type User {name: String} {user {name}}`,
+			nil)
+	})
+	t.Run("skips invalid definitions", func(t *testing.T) {
+		run(t, `type Query {
+  user: User
+  broken(: String
+}
+
+type User {
+  name: String
+}
+
+query { user { name } }`,
+			`type User {name: String} {user {name}}`,
+			[]Diagnostic{
+				{
+					Message:  "unexpected token - got: COLON want one of: []",
+					Position: position.Position{LineStart: 3, LineEnd: 3, CharStart: 10, CharEnd: 11},
+				},
+			})
+	})
+	t.Run("removes invalid definitions from the index", func(t *testing.T) {
+		doc := ast.NewDocument()
+		doc.Input.ResetInputString("type User { name: String }\ntype User { age: }\nschema { query: Query, }")
+		report := operationreport.Report{}
+
+		diagnostics := NewParser().ParseWithRecovery(doc, &report)
+		assert.Len(t, diagnostics, 1)
+
+		nodes, ok := doc.Index.NodesByNameStr("User")
+		assert.True(t, ok)
+		assert.Equal(t, []ast.Node{{Kind: ast.NodeKindObjectTypeDefinition, Ref: 0}}, nodes)
+		assert.Equal(t, "Query", string(doc.Index.QueryTypeName))
+	})
+	t.Run("resumes at a definition keyword at the start of a line", func(t *testing.T) {
+		run(t, `type A {
+  a: String
+
+type B {
+  b: Int
+}`,
+			`type B {b: Int}`,
+			[]Diagnostic{
+				{
+					Message:  "unexpected token - got: IDENT want one of: [COLON]",
+					Position: position.Position{LineStart: 4, LineEnd: 4, CharStart: 6, CharEnd: 7},
+				},
+			})
+	})
+	t.Run("skips invalid selections", func(t *testing.T) {
+		run(t, `query {
+  user(id: ) {
+    name
+  }
+  posts {
+    title
+    ...
+  }
+  other
+}`,
+			`{posts {title} other}`,
+			[]Diagnostic{
+				{
+					Message:  "unexpected token - got: RPAREN want one of: []",
+					Position: position.Position{LineStart: 2, LineEnd: 2, CharStart: 12, CharEnd: 13},
+				},
+				{
+					Message:  "unexpected token - got: RBRACE want one of: [IDENT]",
+					Position: position.Position{LineStart: 8, LineEnd: 8, CharStart: 3, CharEnd: 4},
+				},
+			})
+	})
+	t.Run("closing brace of the selection set in an invalid selection", func(t *testing.T) {
+		run(t, `query { a b( } query B { c }`,
+			`{a} query B {c}`,
+			[]Diagnostic{
+				{
+					Message:  "unexpected token - got: RBRACE want one of: [RPAREN]",
+					Position: position.Position{LineStart: 1, LineEnd: 1, CharStart: 14, CharEnd: 15},
+				},
+			})
+	})
+	t.Run("unterminated selection set before next definition", func(t *testing.T) {
+		run(t, `query A {
+  user {
+    name
+
+query B {
+  a
+}`,
+			`query A {user {name}} query B {a}`,
+			[]Diagnostic{
+				{
+					Message:  "unexpected token - got: IDENT want one of: [RBRACE]",
+					Position: position.Position{LineStart: 5, LineEnd: 5, CharStart: 1, CharEnd: 6},
+				},
+			})
+	})
+	t.Run("unterminated selection set at end of input", func(t *testing.T) {
+		run(t, `query A {
+  user {
+    name`,
+			`query A {user {name}}`,
+			[]Diagnostic{
+				{
+					Message:  "unexpected token - got: EOF want one of: [RBRACE]",
+					Position: position.Position{LineStart: 3, LineEnd: 3, CharStart: 9, CharEnd: 9},
+				},
+			})
+	})
+	t.Run("definition keyword followed by an unterminated block string", func(t *testing.T) {
+		run(t, `scalar """x`,
+			``,
+			[]Diagnostic{
+				{
+					Message:  "unexpected token - got: BLOCKSTRING want one of: [IDENT]",
+					Position: position.Position{LineStart: 1, LineEnd: 1, CharStart: 8, CharEnd: 12},
+				},
+			})
+		run(t, "enum\n\"\"\"x",
+			``,
+			[]Diagnostic{
+				{
+					Message:  "unexpected token - got: BLOCKSTRING want one of: [IDENT]",
+					Position: position.Position{LineStart: 2, LineEnd: 2, CharStart: 1, CharEnd: 5},
+				},
+				{
+					Message:  "unexpected token - got: EOF want one of: [IDENT]",
+					Position: position.Position{LineStart: 2, LineEnd: 2, CharStart: 5, CharEnd: 5},
+				},
+			})
+		run(t, "type Query { a: Int }\ntype\n\"\"\"x",
+			`type Query {a: Int}`,
+			[]Diagnostic{
+				{
+					Message:  "unexpected token - got: BLOCKSTRING want one of: [IDENT]",
+					Position: position.Position{LineStart: 3, LineEnd: 3, CharStart: 1, CharEnd: 5},
+				},
+				{
+					Message:  "unexpected token - got: EOF want one of: [IDENT]",
+					Position: position.Position{LineStart: 3, LineEnd: 3, CharStart: 5, CharEnd: 5},
+				},
+			})
+	})
+	t.Run("reports the first error like Parse", func(t *testing.T) {
+		input := "query { a b( } query B { c }"
+
+		doc := ast.NewDocument()
+		doc.Input.ResetInputString(input)
+		recoveryReport := operationreport.Report{}
+		NewParser().ParseWithRecovery(doc, &recoveryReport)
+
+		_, report := ParseGraphqlDocumentString(input)
+		assert.Equal(t, report.ExternalErrors[0], recoveryReport.ExternalErrors[0])
+		assert.Equal(t, []graphqlerrors.Location{{Line: 1, Column: 14}}, recoveryReport.ExternalErrors[0].Locations)
+	})
+	t.Run("parser can be reused for Parse", func(t *testing.T) {
+		parser := NewParser()
+
+		doc := ast.NewDocument()
+		doc.Input.ResetInputString("query { a( }\nquery B { b( }")
+		report := operationreport.Report{}
+		parser.ParseWithRecovery(doc, &report)
+		assert.Len(t, report.ExternalErrors, 2)
+
+		doc = ast.NewDocument()
+		doc.Input.ResetInputString("query { a( }\nquery B { b( }")
+		report = operationreport.Report{}
+		parser.Parse(doc, &report)
+		assert.Len(t, report.ExternalErrors, 1)
+	})
+}
+
+func FuzzParseWithRecovery(f *testing.F) {
+	for _, input := range []string{
+		`type Query { user: User } type User { name: String }`,
+		`query A { user { name } } query B { a( }`,
+		"query A {\n  user {\n    name\n\nquery B {\n  a\n}",
+		`scalar """x`,
+		"enum\n\"\"\"x",
+		"type Query { a: Int }\ntype\n\"\"\"x",
+		`"""description""" type Query { a(b: String = """x`,
+	} {
+		f.Add(input)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		doc := ast.NewDocument()
+		doc.Input.ResetInputString(input)
+		report := operationreport.Report{}
+		NewParser().ParseWithRecovery(doc, &report)
+	})
+}
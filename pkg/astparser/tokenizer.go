@@ -1,9 +1,12 @@
 package astparser
 
 import (
+	"io"
+
 	"github.com/wundergraph/graphql-go-tools/pkg/ast"
 	"github.com/wundergraph/graphql-go-tools/pkg/lexer"
 	"github.com/wundergraph/graphql-go-tools/pkg/lexer/keyword"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/position"
 	"github.com/wundergraph/graphql-go-tools/pkg/lexer/token"
 )
 
@@ -27,6 +30,18 @@ func NewTokenizer() *Tokenizer {
 
 func (t *Tokenizer) Tokenize(input *ast.Input) {
 	t.lexer.SetInput(input)
+	t.tokenize()
+}
+
+// TokenizeReader takes the input from reader and turns it into set of tokens
+// The input is streamed into input.RawBytes while lexing, the returned error is the error of the reader.
+func (t *Tokenizer) TokenizeReader(input *ast.Input, reader io.Reader) error {
+	t.lexer.SetInputReader(input, reader)
+	t.tokenize()
+	return t.lexer.Err()
+}
+
+func (t *Tokenizer) tokenize() {
 	t.tokens = t.tokens[:0]
 
 	for {
@@ -65,6 +80,16 @@ func (t *Tokenizer) Read() token.Token {
 	return tok
 }
 
+// lastPosition - returns the position of the last read token which is not a comment
+func (t *Tokenizer) lastPosition() position.Position {
+	for i := t.currentToken; i >= 0; i-- {
+		if t.tokens[i].Keyword != keyword.COMMENT {
+			return t.tokens[i].TextPosition
+		}
+	}
+	return position.Position{}
+}
+
 func (t *Tokenizer) read() token.Token {
 	if t.hasNextToken(0) {
 		return t.tokens[t.next()]
@@ -0,0 +1,1116 @@
+package astprinter
+
+import (
+	"bytes"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/position"
+)
+
+// DescriptionStyle defines how Format prints descriptions
+type DescriptionStyle int
+
+const (
+	// DescriptionStylePreserve prints block string descriptions as block strings and string descriptions as strings
+	DescriptionStylePreserve DescriptionStyle = iota
+	// DescriptionStyleBlock prints all descriptions as block strings
+	DescriptionStyleBlock
+	// DescriptionStyleSingleLine prints all descriptions which fit on a single line as strings
+	DescriptionStyleSingleLine
+)
+
+const defaultFormatIndent = "  "
+
+// FormatOptions configures Format
+type FormatOptions struct {
+	// Indent is the indentation of a single level, defaults to two spaces
+	Indent string
+	// MaxLineLength is the length after which arguments, argument definitions, variable definitions and union members
+	// get wrapped onto separate lines, 0 disables wrapping
+	MaxLineLength int
+	// Descriptions is the style of descriptions
+	Descriptions DescriptionStyle
+	// Sort orders the type system definitions alphabetically, as well as the fields of types and input types
+	// Operations, fragments and selection sets keep their order, because it determines the order of the response.
+	Sort bool
+}
+
+// Format prints a document in a stable, human readable format.
+// In contrast to Print it preserves the # comments of documents parsed with astparser.Parser.KeepComments.
+// Comments are attached to the definition, field or selection which follows them,
+// or, if they're on the same line, to the one which precedes them, so they move together with it when sorting.
+func Format(document *ast.Document, options FormatOptions, out io.Writer) error {
+	f := newFormatter(document, options)
+	f.formatDocument()
+	_, err := out.Write(f.out.Bytes())
+	return err
+}
+
+// FormatString is the same as Format but returns a string instead of writing to an io.Writer
+func FormatString(document *ast.Document, options FormatOptions) (string, error) {
+	buff := &bytes.Buffer{}
+	err := Format(document, options, buff)
+	return buff.String(), err
+}
+
+type formatter struct {
+	document *ast.Document
+	options  FormatOptions
+	out      bytes.Buffer
+	depth    int
+
+	// lineOffsets contains the input offset of the start of each line
+	lineOffsets []int
+	comments    []comment
+	// leading contains the comments in front of an anchor by the input offset of the anchor
+	leading map[int][]int
+	// trailing contains the comment at the end of the first line of an anchor
+	trailing map[int]int
+	// trailingComment is appended to the current line
+	trailingComment string
+}
+
+// comment is a single line of a comment of the document
+type comment struct {
+	text   string
+	offset int
+	line   int
+	// blankLineAfter is true if the comment is followed by a blank line in the input
+	blankLineAfter bool
+	printed        bool
+}
+
+// anchor is the start of a node which comments can be attached to
+type anchor struct {
+	offset int
+	line   int
+	// inline is true for nodes which are not necessarily printed on their own line, e.g. arguments
+	inline bool
+}
+
+func newFormatter(document *ast.Document, options FormatOptions) *formatter {
+	if options.Indent == "" {
+		options.Indent = defaultFormatIndent
+	}
+	f := &formatter{
+		document:    document,
+		options:     options,
+		lineOffsets: []int{0},
+		leading:     map[int][]int{},
+		trailing:    map[int]int{},
+	}
+	for i, b := range document.Input.RawBytes {
+		if b == '\n' {
+			f.lineOffsets = append(f.lineOffsets, i+1)
+		}
+	}
+	f.collectComments()
+	if len(f.comments) > 0 {
+		f.attachComments()
+	}
+	return f
+}
+
+// offset converts a position of the lexer into an input offset, -1 for positions which are not set
+func (f *formatter) offset(pos position.Position) int {
+	if pos.LineStart == 0 || int(pos.LineStart) > len(f.lineOffsets) {
+		return -1
+	}
+	return f.lineOffsets[pos.LineStart-1] + int(pos.CharStart) - 1
+}
+
+func (f *formatter) lineOf(offset int) int {
+	return sort.Search(len(f.lineOffsets), func(i int) bool {
+		return f.lineOffsets[i] > offset
+	})
+}
+
+// collectComments splits the comments of the document into single lines
+func (f *formatter) collectComments() {
+	for i := range f.document.Comments {
+		lineStart := int(f.document.Comments[i].Content.Start)
+		for _, text := range strings.Split(f.document.CommentString(i), "\n") {
+			offset := lineStart + strings.IndexByte(text, '#')
+			lineStart += len(text) + 1
+			text = strings.TrimSpace(text)
+			if text == "" {
+				continue
+			}
+			f.comments = append(f.comments, comment{
+				text:           text,
+				offset:         offset,
+				line:           f.lineOf(offset),
+				blankLineAfter: f.blankLineAfter(offset),
+			})
+		}
+	}
+}
+
+// blankLineAfter returns true if the line containing offset is followed by a blank line and more content
+func (f *formatter) blankLineAfter(offset int) bool {
+	input := f.document.Input.RawBytes
+	lineEnd := bytes.IndexByte(input[offset:], '\n')
+	if lineEnd == -1 {
+		return false
+	}
+	lineBreaks := 0
+	for _, b := range input[offset+lineEnd:] {
+		switch b {
+		case '\n':
+			lineBreaks++
+		case ' ', '\t', '\r', ',':
+		default:
+			return lineBreaks > 1
+		}
+	}
+	return false
+}
+
+// attachComments attaches each comment to the anchor on the same line in front of it or to the next anchor
+func (f *formatter) attachComments() {
+	anchors := f.anchors()
+	sort.Slice(anchors, func(i, j int) bool {
+		return anchors[i].offset < anchors[j].offset
+	})
+	for i := range f.comments {
+		c := &f.comments[i]
+		next := sort.Search(len(anchors), func(j int) bool {
+			return anchors[j].offset > c.offset
+		})
+
+		trailingAnchor := -1
+		for j := next - 1; j >= 0 && anchors[j].line == c.line; j-- {
+			if !anchors[j].inline {
+				trailingAnchor = anchors[j].offset
+				break
+			}
+			if trailingAnchor == -1 {
+				trailingAnchor = anchors[j].offset
+			}
+		}
+		if _, taken := f.trailing[trailingAnchor]; trailingAnchor != -1 && !taken {
+			f.trailing[trailingAnchor] = i
+			continue
+		}
+
+		if next < len(anchors) {
+			f.leading[anchors[next].offset] = append(f.leading[anchors[next].offset], i)
+		}
+	}
+}
+
+// anchors returns the start of all nodes which get printed at the start of a line
+func (f *formatter) anchors() []anchor {
+	var anchors []anchor
+	add := func(offset int, inline bool) {
+		if offset < 0 {
+			return
+		}
+		anchors = append(anchors, anchor{offset: offset, line: f.lineOf(offset), inline: inline})
+	}
+	addPosition := func(pos position.Position) {
+		add(f.offset(pos), false)
+	}
+
+	d := f.document
+	for _, node := range d.RootNodes {
+		add(f.rootNodeStart(node), false)
+	}
+	for i := range d.FieldDefinitions {
+		add(int(d.FieldDefinitions[i].Name.Start), false)
+		if d.FieldDefinitions[i].HasArgumentsDefinitions {
+			for _, ref := range d.FieldDefinitions[i].ArgumentsDefinition.Refs {
+				add(f.inputValueDefinitionStart(ref), true)
+			}
+		}
+	}
+	for i := range d.DirectiveDefinitions {
+		for _, ref := range d.DirectiveDefinitions[i].ArgumentsDefinition.Refs {
+			add(f.inputValueDefinitionStart(ref), true)
+		}
+	}
+	for _, list := range f.inputFieldsDefinitions() {
+		for _, ref := range list.Refs {
+			add(f.inputValueDefinitionStart(ref), false)
+		}
+		addPosition(list.RPAREN)
+	}
+	for i := range d.EnumValueDefinitions {
+		add(int(d.EnumValueDefinitions[i].EnumValue.Start), false)
+	}
+	for _, list := range f.fieldsDefinitions() {
+		addPosition(list.RBRACE)
+	}
+	for i := range d.EnumTypeDefinitions {
+		addPosition(d.EnumTypeDefinitions[i].EnumValuesDefinition.RBRACE)
+	}
+	for i := range d.EnumTypeExtensions {
+		addPosition(d.EnumTypeExtensions[i].EnumValuesDefinition.RBRACE)
+	}
+	for i := range d.RootOperationTypeDefinitions {
+		addPosition(d.RootOperationTypeDefinitions[i].Colon)
+	}
+	for i := range d.SchemaDefinitions {
+		addPosition(d.SchemaDefinitions[i].RootOperationTypeDefinitions.RBrace)
+	}
+	for i := range d.SchemaExtensions {
+		addPosition(d.SchemaExtensions[i].RootOperationTypeDefinitions.RBrace)
+	}
+	for i := range d.Fields {
+		add(f.fieldStart(i), false)
+		if d.Fields[i].HasArguments {
+			for _, ref := range d.Fields[i].Arguments.Refs {
+				add(int(d.Arguments[ref].Name.Start), true)
+			}
+		}
+	}
+	for i := range d.FragmentSpreads {
+		addPosition(d.FragmentSpreads[i].Spread)
+	}
+	for i := range d.InlineFragments {
+		addPosition(d.InlineFragments[i].Spread)
+	}
+	for i := range d.SelectionSets {
+		addPosition(d.SelectionSets[i].RBrace)
+	}
+	for i := range d.VariableDefinitions {
+		add(f.variableDefinitionStart(i), true)
+	}
+	return anchors
+}
+
+func (f *formatter) fieldsDefinitions() []ast.FieldDefinitionList {
+	d := f.document
+	var lists []ast.FieldDefinitionList
+	for i := range d.ObjectTypeDefinitions {
+		lists = append(lists, d.ObjectTypeDefinitions[i].FieldsDefinition)
+	}
+	for i := range d.ObjectTypeExtensions {
+		lists = append(lists, d.ObjectTypeExtensions[i].FieldsDefinition)
+	}
+	for i := range d.InterfaceTypeDefinitions {
+		lists = append(lists, d.InterfaceTypeDefinitions[i].FieldsDefinition)
+	}
+	for i := range d.InterfaceTypeExtensions {
+		lists = append(lists, d.InterfaceTypeExtensions[i].FieldsDefinition)
+	}
+	return lists
+}
+
+func (f *formatter) inputFieldsDefinitions() []ast.InputValueDefinitionList {
+	d := f.document
+	var lists []ast.InputValueDefinitionList
+	for i := range d.InputObjectTypeDefinitions {
+		lists = append(lists, d.InputObjectTypeDefinitions[i].InputFieldsDefinition)
+	}
+	for i := range d.InputObjectTypeExtensions {
+		lists = append(lists, d.InputObjectTypeExtensions[i].InputFieldsDefinition)
+	}
+	return lists
+}
+
+func (f *formatter) inputValueDefinitionStart(ref int) int {
+	return int(f.document.InputValueDefinitions[ref].Name.Start)
+}
+
+func (f *formatter) variableDefinitionStart(ref int) int {
+	value := f.document.VariableDefinitions[ref].VariableValue
+	if value.Kind != ast.ValueKindVariable {
+		return -1
+	}
+	return f.offset(f.document.VariableValues[value.Ref].Dollar)
+}
+
+func (f *formatter) fieldStart(ref int) int {
+	field := f.document.Fields[ref]
+	if field.Alias.IsDefined {
+		return int(field.Alias.Name.Start)
+	}
+	return int(field.Name.Start)
+}
+
+func (f *formatter) rootNodeStart(node ast.Node) int {
+	d := f.document
+	switch node.Kind {
+	case ast.NodeKindSchemaDefinition:
+		return f.offset(d.SchemaDefinitions[node.Ref].SchemaLiteral)
+	case ast.NodeKindSchemaExtension:
+		return f.offset(d.SchemaExtensions[node.Ref].ExtendLiteral)
+	case ast.NodeKindObjectTypeDefinition:
+		return f.offset(d.ObjectTypeDefinitions[node.Ref].TypeLiteral)
+	case ast.NodeKindObjectTypeExtension:
+		return f.offset(d.ObjectTypeExtensions[node.Ref].ExtendLiteral)
+	case ast.NodeKindInterfaceTypeDefinition:
+		return f.offset(d.InterfaceTypeDefinitions[node.Ref].InterfaceLiteral)
+	case ast.NodeKindInterfaceTypeExtension:
+		return f.offset(d.InterfaceTypeExtensions[node.Ref].ExtendLiteral)
+	case ast.NodeKindUnionTypeDefinition:
+		return f.offset(d.UnionTypeDefinitions[node.Ref].UnionLiteral)
+	case ast.NodeKindUnionTypeExtension:
+		return f.offset(d.UnionTypeExtensions[node.Ref].ExtendLiteral)
+	case ast.NodeKindEnumTypeDefinition:
+		return f.offset(d.EnumTypeDefinitions[node.Ref].EnumLiteral)
+	case ast.NodeKindEnumTypeExtension:
+		return f.offset(d.EnumTypeExtensions[node.Ref].ExtendLiteral)
+	case ast.NodeKindInputObjectTypeDefinition:
+		return f.offset(d.InputObjectTypeDefinitions[node.Ref].InputLiteral)
+	case ast.NodeKindInputObjectTypeExtension:
+		return f.offset(d.InputObjectTypeExtensions[node.Ref].ExtendLiteral)
+	case ast.NodeKindScalarTypeDefinition:
+		return f.offset(d.ScalarTypeDefinitions[node.Ref].ScalarLiteral)
+	case ast.NodeKindScalarTypeExtension:
+		return f.offset(d.ScalarTypeExtensions[node.Ref].ExtendLiteral)
+	case ast.NodeKindDirectiveDefinition:
+		return f.offset(d.DirectiveDefinitions[node.Ref].DirectiveLiteral)
+	case ast.NodeKindOperationDefinition:
+		operation := d.OperationDefinitions[node.Ref]
+		if operation.OperationTypeLiteral.LineStart != 0 {
+			return f.offset(operation.OperationTypeLiteral)
+		}
+		return f.offset(d.SelectionSets[operation.SelectionSet].LBrace)
+	case ast.NodeKindFragmentDefinition:
+		return f.offset(d.FragmentDefinitions[node.Ref].FragmentLiteral)
+	default:
+		return -1
+	}
+}
+
+// anchor prints the comments in front of the node starting at offset
+// and remembers its trailing comment for the end of the current line.
+func (f *formatter) anchor(offset int) {
+	f.formatLeadingComments(offset)
+	if ref, ok := f.trailing[offset]; ok {
+		f.trailingComment = f.comments[ref].text
+		f.comments[ref].printed = true
+		delete(f.trailing, offset)
+	}
+}
+
+func (f *formatter) formatLeadingComments(offset int) {
+	for _, ref := range f.leading[offset] {
+		f.printComment(ref)
+	}
+	delete(f.leading, offset)
+}
+
+func (f *formatter) printComment(ref int) {
+	if f.comments[ref].printed {
+		return
+	}
+	f.comments[ref].printed = true
+	f.line(f.comments[ref].text)
+	if f.comments[ref].blankLineAfter {
+		f.line("")
+	}
+}
+
+// hasComments returns true if a comment is attached to the node starting at offset
+func (f *formatter) hasComments(offset int) bool {
+	_, hasTrailing := f.trailing[offset]
+	return len(f.leading[offset]) > 0 || hasTrailing
+}
+
+// line writes an indented line
+func (f *formatter) line(text string) {
+	if text != "" {
+		for i := 0; i < f.depth; i++ {
+			f.out.WriteString(f.options.Indent)
+		}
+		f.out.WriteString(text)
+	}
+	if f.trailingComment != "" {
+		if text != "" {
+			f.out.WriteByte(' ')
+		}
+		f.out.WriteString(f.trailingComment)
+		f.trailingComment = ""
+	}
+	f.out.WriteByte('\n')
+}
+
+// fits returns true if text fits on the current line
+func (f *formatter) fits(text string) bool {
+	if f.options.MaxLineLength <= 0 {
+		return true
+	}
+	return f.depth*utf8.RuneCountInString(f.options.Indent)+utf8.RuneCountInString(text) <= f.options.MaxLineLength
+}
+
+func (f *formatter) formatDocument() {
+	for i, node := range f.rootNodes() {
+		if i > 0 {
+			f.line("")
+		}
+		f.formatRootNode(node)
+	}
+
+	remaining := false
+	for i := range f.comments {
+		if f.comments[i].printed {
+			continue
+		}
+		if !remaining && f.out.Len() > 0 {
+			f.line("")
+		}
+		remaining = true
+		f.printComment(i)
+	}
+}
+
+// rootNodes returns the root nodes in the order of printing
+// Sorted documents start with schema definitions, followed by directive definitions, types, operations and fragments.
+func (f *formatter) rootNodes() []ast.Node {
+	nodes := make([]ast.Node, 0, len(f.document.RootNodes))
+	for _, node := range f.document.RootNodes {
+		if _, ok := f.rootNodeGroup(node); ok {
+			nodes = append(nodes, node)
+		}
+	}
+	if !f.options.Sort {
+		return nodes
+	}
+
+	sort.SliceStable(nodes, func(i, j int) bool {
+		left, _ := f.rootNodeGroup(nodes[i])
+		right, _ := f.rootNodeGroup(nodes[j])
+		if left != right {
+			return left < right
+		}
+		if left != rootNodeGroupDirectives && left != rootNodeGroupTypes {
+			return false
+		}
+		leftName, rightName := f.document.NodeNameUnsafeString(nodes[i]), f.document.NodeNameUnsafeString(nodes[j])
+		if leftName != rightName {
+			return leftName < rightName
+		}
+		return !isExtension(nodes[i].Kind) && isExtension(nodes[j].Kind)
+	})
+	return nodes
+}
+
+const (
+	rootNodeGroupSchema = iota
+	rootNodeGroupDirectives
+	rootNodeGroupTypes
+	rootNodeGroupExecutable
+)
+
+func (f *formatter) rootNodeGroup(node ast.Node) (int, bool) {
+	switch node.Kind {
+	case ast.NodeKindSchemaDefinition, ast.NodeKindSchemaExtension:
+		return rootNodeGroupSchema, true
+	case ast.NodeKindDirectiveDefinition:
+		return rootNodeGroupDirectives, true
+	case ast.NodeKindObjectTypeDefinition, ast.NodeKindObjectTypeExtension,
+		ast.NodeKindInterfaceTypeDefinition, ast.NodeKindInterfaceTypeExtension,
+		ast.NodeKindUnionTypeDefinition, ast.NodeKindUnionTypeExtension,
+		ast.NodeKindEnumTypeDefinition, ast.NodeKindEnumTypeExtension,
+		ast.NodeKindInputObjectTypeDefinition, ast.NodeKindInputObjectTypeExtension,
+		ast.NodeKindScalarTypeDefinition, ast.NodeKindScalarTypeExtension:
+		return rootNodeGroupTypes, true
+	case ast.NodeKindOperationDefinition, ast.NodeKindFragmentDefinition:
+		return rootNodeGroupExecutable, true
+	default:
+		return 0, false
+	}
+}
+
+func isExtension(kind ast.NodeKind) bool {
+	switch kind {
+	case ast.NodeKindSchemaExtension, ast.NodeKindObjectTypeExtension, ast.NodeKindInterfaceTypeExtension,
+		ast.NodeKindUnionTypeExtension, ast.NodeKindEnumTypeExtension, ast.NodeKindInputObjectTypeExtension,
+		ast.NodeKindScalarTypeExtension:
+		return true
+	default:
+		return false
+	}
+}
+
+func (f *formatter) formatRootNode(node ast.Node) {
+	d := f.document
+	f.formatDescription(f.rootNodeDescription(node), f.rootNodeStart(node))
+
+	switch node.Kind {
+	case ast.NodeKindSchemaDefinition:
+		f.formatSchema("schema", d.SchemaDefinitions[node.Ref])
+	case ast.NodeKindSchemaExtension:
+		f.formatSchema("extend schema", d.SchemaExtensions[node.Ref].SchemaDefinition)
+	case ast.NodeKindObjectTypeDefinition:
+		f.formatObjectType("type ", d.ObjectTypeDefinitions[node.Ref])
+	case ast.NodeKindObjectTypeExtension:
+		f.formatObjectType("extend type ", d.ObjectTypeExtensions[node.Ref].ObjectTypeDefinition)
+	case ast.NodeKindInterfaceTypeDefinition:
+		f.formatInterfaceType("interface ", d.InterfaceTypeDefinitions[node.Ref])
+	case ast.NodeKindInterfaceTypeExtension:
+		f.formatInterfaceType("extend interface ", d.InterfaceTypeExtensions[node.Ref].InterfaceTypeDefinition)
+	case ast.NodeKindUnionTypeDefinition:
+		f.formatUnionType("union ", d.UnionTypeDefinitions[node.Ref])
+	case ast.NodeKindUnionTypeExtension:
+		f.formatUnionType("extend union ", d.UnionTypeExtensions[node.Ref].UnionTypeDefinition)
+	case ast.NodeKindEnumTypeDefinition:
+		f.formatEnumType("enum ", d.EnumTypeDefinitions[node.Ref])
+	case ast.NodeKindEnumTypeExtension:
+		f.formatEnumType("extend enum ", d.EnumTypeExtensions[node.Ref].EnumTypeDefinition)
+	case ast.NodeKindInputObjectTypeDefinition:
+		f.formatInputObjectType("input ", d.InputObjectTypeDefinitions[node.Ref])
+	case ast.NodeKindInputObjectTypeExtension:
+		f.formatInputObjectType("extend input ", d.InputObjectTypeExtensions[node.Ref].InputObjectTypeDefinition)
+	case ast.NodeKindScalarTypeDefinition:
+		f.formatScalarType("scalar ", d.ScalarTypeDefinitions[node.Ref])
+	case ast.NodeKindScalarTypeExtension:
+		f.formatScalarType("extend scalar ", d.ScalarTypeExtensions[node.Ref].ScalarTypeDefinition)
+	case ast.NodeKindDirectiveDefinition:
+		f.formatDirectiveDefinition(node.Ref)
+	case ast.NodeKindOperationDefinition:
+		f.formatOperationDefinition(node.Ref)
+	case ast.NodeKindFragmentDefinition:
+		f.formatFragmentDefinition(node.Ref)
+	}
+}
+
+func (f *formatter) rootNodeDescription(node ast.Node) ast.Description {
+	d := f.document
+	switch node.Kind {
+	case ast.NodeKindObjectTypeDefinition:
+		return d.ObjectTypeDefinitions[node.Ref].Description
+	case ast.NodeKindInterfaceTypeDefinition:
+		return d.InterfaceTypeDefinitions[node.Ref].Description
+	case ast.NodeKindUnionTypeDefinition:
+		return d.UnionTypeDefinitions[node.Ref].Description
+	case ast.NodeKindEnumTypeDefinition:
+		return d.EnumTypeDefinitions[node.Ref].Description
+	case ast.NodeKindInputObjectTypeDefinition:
+		return d.InputObjectTypeDefinitions[node.Ref].Description
+	case ast.NodeKindScalarTypeDefinition:
+		return d.ScalarTypeDefinitions[node.Ref].Description
+	case ast.NodeKindDirectiveDefinition:
+		return d.DirectiveDefinitions[node.Ref].Description
+	default:
+		return ast.Description{}
+	}
+}
+
+func (f *formatter) formatSchema(prefix string, schema ast.SchemaDefinition) {
+	header := prefix + f.directives(schema.HasDirectives, schema.Directives)
+	if len(schema.RootOperationTypeDefinitions.Refs) == 0 {
+		f.line(header)
+		return
+	}
+
+	f.line(header + " {")
+	f.depth++
+	for _, ref := range schema.RootOperationTypeDefinitions.Refs {
+		definition := f.document.RootOperationTypeDefinitions[ref]
+		f.anchor(f.offset(definition.Colon))
+		f.line(operationTypeName(definition.OperationType) + ": " + f.document.Input.ByteSliceString(definition.NamedType.Name))
+	}
+	f.depth--
+	f.closeBlock(schema.RootOperationTypeDefinitions.RBrace)
+}
+
+// closeBlock prints the closing brace of a block, comments in front of it are indented like the content of the block
+func (f *formatter) closeBlock(closing position.Position) {
+	offset := f.offset(closing)
+	f.depth++
+	f.formatLeadingComments(offset)
+	f.depth--
+	f.anchor(offset)
+	f.line("}")
+}
+
+func (f *formatter) formatObjectType(prefix string, definition ast.ObjectTypeDefinition) {
+	header := prefix + f.document.Input.ByteSliceString(definition.Name) +
+		f.implementsInterfaces(definition.ImplementsInterfaces) +
+		f.directives(definition.HasDirectives, definition.Directives)
+	f.formatFieldDefinitions(header, definition.HasFieldDefinitions, definition.FieldsDefinition)
+}
+
+func (f *formatter) formatInterfaceType(prefix string, definition ast.InterfaceTypeDefinition) {
+	header := prefix + f.document.Input.ByteSliceString(definition.Name) +
+		f.implementsInterfaces(definition.ImplementsInterfaces) +
+		f.directives(definition.HasDirectives, definition.Directives)
+	f.formatFieldDefinitions(header, definition.HasFieldDefinitions, definition.FieldsDefinition)
+}
+
+func (f *formatter) implementsInterfaces(interfaces ast.TypeList) string {
+	if len(interfaces.Refs) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(interfaces.Refs))
+	for _, ref := range interfaces.Refs {
+		names = append(names, f.document.TypeNameString(ref))
+	}
+	return " implements " + strings.Join(names, " & ")
+}
+
+func (f *formatter) formatFieldDefinitions(header string, hasFieldDefinitions bool, list ast.FieldDefinitionList) {
+	if !hasFieldDefinitions || len(list.Refs) == 0 {
+		f.line(header)
+		return
+	}
+
+	f.line(header + " {")
+	f.depth++
+	for _, ref := range f.sortedByName(list.Refs, f.document.FieldDefinitionNameString) {
+		f.formatFieldDefinition(ref)
+	}
+	f.depth--
+	f.closeBlock(list.RBRACE)
+}
+
+func (f *formatter) formatFieldDefinition(ref int) {
+	definition := f.document.FieldDefinitions[ref]
+	f.formatDescription(definition.Description, int(definition.Name.Start))
+
+	name := f.document.Input.ByteSliceString(definition.Name)
+	suffix := ": " + f.typeString(definition.Type) + f.directives(definition.HasDirectives, definition.Directives)
+	if !definition.HasArgumentsDefinitions || len(definition.ArgumentsDefinition.Refs) == 0 {
+		f.line(name + suffix)
+		return
+	}
+	f.formatInputValueDefinitions(name, suffix, definition.ArgumentsDefinition.Refs)
+}
+
+// formatInputValueDefinitions prints argument definitions in parentheses between prefix and suffix,
+// either on a single line or, if they don't fit, have descriptions or comments, one per line
+func (f *formatter) formatInputValueDefinitions(prefix, suffix string, refs []int) {
+	wrap := false
+	inline := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		if f.document.InputValueDefinitions[ref].Description.IsDefined || f.hasComments(f.inputValueDefinitionStart(ref)) {
+			wrap = true
+		}
+		inline = append(inline, f.inputValueDefinition(ref))
+	}
+	line := prefix + "(" + strings.Join(inline, ", ") + ")" + suffix
+	if !wrap && f.fits(line) {
+		f.line(line)
+		return
+	}
+
+	f.line(prefix + "(")
+	f.depth++
+	for i, ref := range refs {
+		f.formatDescription(f.document.InputValueDefinitions[ref].Description, f.inputValueDefinitionStart(ref))
+		f.line(inline[i])
+	}
+	f.depth--
+	f.line(")" + suffix)
+}
+
+func (f *formatter) inputValueDefinition(ref int) string {
+	definition := f.document.InputValueDefinitions[ref]
+	out := f.document.Input.ByteSliceString(definition.Name) + ": " + f.typeString(definition.Type)
+	if definition.DefaultValue.IsDefined {
+		out += " = " + f.value(definition.DefaultValue.Value)
+	}
+	return out + f.directives(definition.HasDirectives, definition.Directives)
+}
+
+func (f *formatter) formatUnionType(prefix string, definition ast.UnionTypeDefinition) {
+	header := prefix + f.document.Input.ByteSliceString(definition.Name) + f.directives(definition.HasDirectives, definition.Directives)
+	if !definition.HasUnionMemberTypes || len(definition.UnionMemberTypes.Refs) == 0 {
+		f.line(header)
+		return
+	}
+
+	members := make([]string, 0, len(definition.UnionMemberTypes.Refs))
+	for _, ref := range definition.UnionMemberTypes.Refs {
+		members = append(members, f.document.TypeNameString(ref))
+	}
+	line := header + " = " + strings.Join(members, " | ")
+	if f.fits(line) {
+		f.line(line)
+		return
+	}
+
+	f.line(header + " =")
+	f.depth++
+	for _, member := range members {
+		f.line("| " + member)
+	}
+	f.depth--
+}
+
+func (f *formatter) formatEnumType(prefix string, definition ast.EnumTypeDefinition) {
+	header := prefix + f.document.Input.ByteSliceString(definition.Name) + f.directives(definition.HasDirectives, definition.Directives)
+	if !definition.HasEnumValuesDefinition || len(definition.EnumValuesDefinition.Refs) == 0 {
+		f.line(header)
+		return
+	}
+
+	f.line(header + " {")
+	f.depth++
+	for _, ref := range definition.EnumValuesDefinition.Refs {
+		value := f.document.EnumValueDefinitions[ref]
+		f.formatDescription(value.Description, int(value.EnumValue.Start))
+		f.line(f.document.Input.ByteSliceString(value.EnumValue) + f.directives(value.HasDirectives, value.Directives))
+	}
+	f.depth--
+	f.closeBlock(definition.EnumValuesDefinition.RBRACE)
+}
+
+func (f *formatter) formatInputObjectType(prefix string, definition ast.InputObjectTypeDefinition) {
+	header := prefix + f.document.Input.ByteSliceString(definition.Name) + f.directives(definition.HasDirectives, definition.Directives)
+	if !definition.HasInputFieldsDefinition || len(definition.InputFieldsDefinition.Refs) == 0 {
+		f.line(header)
+		return
+	}
+
+	f.line(header + " {")
+	f.depth++
+	for _, ref := range f.sortedByName(definition.InputFieldsDefinition.Refs, f.document.InputValueDefinitionNameString) {
+		f.formatDescription(f.document.InputValueDefinitions[ref].Description, f.inputValueDefinitionStart(ref))
+		f.line(f.inputValueDefinition(ref))
+	}
+	f.depth--
+	f.closeBlock(definition.InputFieldsDefinition.RPAREN)
+}
+
+func (f *formatter) formatScalarType(prefix string, definition ast.ScalarTypeDefinition) {
+	f.line(prefix + f.document.Input.ByteSliceString(definition.Name) + f.directives(definition.HasDirectives, definition.Directives))
+}
+
+func (f *formatter) formatDirectiveDefinition(ref int) {
+	definition := f.document.DirectiveDefinitions[ref]
+
+	name := "directive @" + f.document.Input.ByteSliceString(definition.Name)
+	suffix := ""
+	if definition.Repeatable.IsRepeatable {
+		suffix += " repeatable"
+	}
+	var locations []string
+	iter := definition.DirectiveLocations.Iterable()
+	for iter.Next() {
+		locations = append(locations, iter.Value().LiteralString())
+	}
+	suffix += " on " + strings.Join(locations, " | ")
+
+	if !definition.HasArgumentsDefinitions || len(definition.ArgumentsDefinition.Refs) == 0 {
+		f.line(name + suffix)
+		return
+	}
+	f.formatInputValueDefinitions(name, suffix, definition.ArgumentsDefinition.Refs)
+}
+
+func (f *formatter) formatOperationDefinition(ref int) {
+	operation := f.document.OperationDefinitions[ref]
+	isShorthand := operation.OperationTypeLiteral.LineStart == 0 && operation.Name.Length() == 0 &&
+		!operation.HasVariableDefinitions && !operation.HasDirectives
+	if isShorthand {
+		f.line("{")
+		f.formatSelectionSet(operation.SelectionSet)
+		return
+	}
+
+	prefix := operationTypeName(operation.OperationType)
+	if operation.Name.Length() > 0 {
+		prefix += " " + f.document.Input.ByteSliceString(operation.Name)
+	}
+	suffix := f.directives(operation.HasDirectives, operation.Directives) + " {"
+
+	if !operation.HasVariableDefinitions || len(operation.VariableDefinitions.Refs) == 0 {
+		f.line(prefix + suffix)
+	} else {
+		f.formatVariableDefinitions(prefix, suffix, operation.VariableDefinitions.Refs)
+	}
+	f.formatSelectionSet(operation.SelectionSet)
+}
+
+func (f *formatter) formatVariableDefinitions(prefix, suffix string, refs []int) {
+	wrap := false
+	inline := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		if f.hasComments(f.variableDefinitionStart(ref)) {
+			wrap = true
+		}
+		inline = append(inline, f.variableDefinition(ref))
+	}
+	line := prefix + "(" + strings.Join(inline, ", ") + ")" + suffix
+	if !wrap && f.fits(line) {
+		f.line(line)
+		return
+	}
+
+	f.line(prefix + "(")
+	f.depth++
+	for i, ref := range refs {
+		f.anchor(f.variableDefinitionStart(ref))
+		f.line(inline[i])
+	}
+	f.depth--
+	f.line(")" + suffix)
+}
+
+func (f *formatter) variableDefinition(ref int) string {
+	definition := f.document.VariableDefinitions[ref]
+	out := f.value(definition.VariableValue) + ": " + f.typeString(definition.Type)
+	if definition.DefaultValue.IsDefined {
+		out += " = " + f.value(definition.DefaultValue.Value)
+	}
+	return out + f.directives(definition.HasDirectives, definition.Directives)
+}
+
+func (f *formatter) formatFragmentDefinition(ref int) {
+	fragment := f.document.FragmentDefinitions[ref]
+	f.line("fragment " + f.document.Input.ByteSliceString(fragment.Name) +
+		" on " + f.document.TypeNameString(fragment.TypeCondition.Type) +
+		f.directives(len(fragment.Directives.Refs) > 0, fragment.Directives) + " {")
+	f.formatSelectionSet(fragment.SelectionSet)
+}
+
+// formatSelectionSet prints the selections and the closing brace of a selection set
+func (f *formatter) formatSelectionSet(ref int) {
+	set := f.document.SelectionSets[ref]
+	f.depth++
+	for _, selectionRef := range set.SelectionRefs {
+		selection := f.document.Selections[selectionRef]
+		switch selection.Kind {
+		case ast.SelectionKindField:
+			f.formatField(selection.Ref)
+		case ast.SelectionKindFragmentSpread:
+			spread := f.document.FragmentSpreads[selection.Ref]
+			f.anchor(f.offset(spread.Spread))
+			f.line("..." + f.document.Input.ByteSliceString(spread.FragmentName) + f.directives(spread.HasDirectives, spread.Directives))
+		case ast.SelectionKindInlineFragment:
+			f.formatInlineFragment(selection.Ref)
+		}
+	}
+	f.depth--
+	f.closeBlock(set.RBrace)
+}
+
+func (f *formatter) formatField(ref int) {
+	field := f.document.Fields[ref]
+	f.anchor(f.fieldStart(ref))
+
+	prefix := f.document.Input.ByteSliceString(field.Name)
+	if field.Alias.IsDefined {
+		prefix = f.document.Input.ByteSliceString(field.Alias.Name) + ": " + prefix
+	}
+	suffix := f.directives(field.HasDirectives, field.Directives)
+	if field.HasSelections {
+		suffix += " {"
+	}
+
+	if !field.HasArguments || len(field.Arguments.Refs) == 0 {
+		f.line(prefix + suffix)
+	} else {
+		f.formatArguments(prefix, suffix, field.Arguments.Refs)
+	}
+	if field.HasSelections {
+		f.formatSelectionSet(field.SelectionSet)
+	}
+}
+
+func (f *formatter) formatArguments(prefix, suffix string, refs []int) {
+	wrap := false
+	inline := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		if f.hasComments(int(f.document.Arguments[ref].Name.Start)) {
+			wrap = true
+		}
+		inline = append(inline, f.argument(ref))
+	}
+	line := prefix + "(" + strings.Join(inline, ", ") + ")" + suffix
+	if !wrap && f.fits(line) {
+		f.line(line)
+		return
+	}
+
+	f.line(prefix + "(")
+	f.depth++
+	for i, ref := range refs {
+		f.anchor(int(f.document.Arguments[ref].Name.Start))
+		f.line(inline[i])
+	}
+	f.depth--
+	f.line(")" + suffix)
+}
+
+func (f *formatter) formatInlineFragment(ref int) {
+	fragment := f.document.InlineFragments[ref]
+	f.anchor(f.offset(fragment.Spread))
+
+	line := "..."
+	if f.document.InlineFragmentHasTypeCondition(ref) {
+		line += " on " + f.document.InlineFragmentTypeConditionNameString(ref)
+	}
+	line += f.directives(fragment.HasDirectives, fragment.Directives)
+	if !fragment.HasSelections {
+		f.line(line)
+		return
+	}
+	f.line(line + " {")
+	f.formatSelectionSet(fragment.SelectionSet)
+}
+
+func (f *formatter) directives(hasDirectives bool, list ast.DirectiveList) string {
+	if !hasDirectives {
+		return ""
+	}
+	var out strings.Builder
+	for _, ref := range list.Refs {
+		directive := f.document.Directives[ref]
+		out.WriteString(" @")
+		out.WriteString(f.document.Input.ByteSliceString(directive.Name))
+		if directive.HasArguments && len(directive.Arguments.Refs) > 0 {
+			arguments := make([]string, 0, len(directive.Arguments.Refs))
+			for _, argument := range directive.Arguments.Refs {
+				arguments = append(arguments, f.argument(argument))
+			}
+			out.WriteString("(" + strings.Join(arguments, ", ") + ")")
+		}
+	}
+	return out.String()
+}
+
+func (f *formatter) argument(ref int) string {
+	argument := f.document.Arguments[ref]
+	return f.document.Input.ByteSliceString(argument.Name) + ": " + f.value(argument.Value)
+}
+
+func (f *formatter) value(value ast.Value) string {
+	switch value.Kind {
+	case ast.ValueKindList:
+		items := make([]string, 0, len(f.document.ListValues[value.Ref].Refs))
+		for _, ref := range f.document.ListValues[value.Ref].Refs {
+			items = append(items, f.value(f.document.Value(ref)))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case ast.ValueKindObject:
+		fields := make([]string, 0, len(f.document.ObjectValues[value.Ref].Refs))
+		for _, ref := range f.document.ObjectValues[value.Ref].Refs {
+			fields = append(fields, f.document.ObjectFieldNameString(ref)+": "+f.value(f.document.ObjectFieldValue(ref)))
+		}
+		return "{" + strings.Join(fields, ", ") + "}"
+	default:
+		out, _ := f.document.PrintValueBytes(value, nil)
+		return string(out)
+	}
+}
+
+func (f *formatter) typeString(ref int) string {
+	out, _ := f.document.PrintTypeBytes(ref, nil)
+	return string(out)
+}
+
+// sortedByName returns the refs sorted by name if sorting is enabled
+func (f *formatter) sortedByName(refs []int, name func(ref int) string) []int {
+	if !f.options.Sort {
+		return refs
+	}
+	sorted := make([]int, len(refs))
+	copy(sorted, refs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return name(sorted[i]) < name(sorted[j])
+	})
+	return sorted
+}
+
+// formatDescription prints the description of the node starting at offset, preceded by the comments in front of the node
+// The trailing comment of the node is written at the end of the line following the description.
+func (f *formatter) formatDescription(description ast.Description, offset int) {
+	f.formatLeadingComments(offset)
+	defer f.anchor(offset)
+	if !description.IsDefined {
+		return
+	}
+
+	content := f.document.Input.ByteSliceString(description.Content)
+	isBlockString := description.IsBlockString
+	lines := []string{content}
+	if isBlockString {
+		lines = blockStringLines(f.blockStringFirstLineIndent(description.Content) + content)
+	}
+
+	switch f.options.Descriptions {
+	case DescriptionStyleBlock:
+		if !isBlockString && !strings.Contains(content, `\`) && !strings.Contains(content, `"""`) {
+			isBlockString = true
+		}
+	case DescriptionStyleSingleLine:
+		if isBlockString && len(lines) <= 1 {
+			singleLine := strings.Join(lines, "")
+			if !strings.ContainsAny(singleLine, `"\`) {
+				isBlockString = false
+				lines = []string{singleLine}
+			}
+		}
+	}
+
+	if !isBlockString {
+		f.line(`"` + lines[0] + `"`)
+		return
+	}
+	f.line(`"""`)
+	for _, line := range lines {
+		f.line(line)
+	}
+	f.line(`"""`)
+}
+
+// blockStringFirstLineIndent returns the indentation of the first line of a block string
+// The lexer excludes leading whitespace from the content, but it's part of the common indentation
+// if the content starts on a new line after the opening quotes.
+func (f *formatter) blockStringFirstLineIndent(content ast.ByteSliceReference) string {
+	input := f.document.Input.RawBytes
+	start := int(content.Start)
+	for i := start - 1; i >= 0; i-- {
+		switch input[i] {
+		case ' ', '\t':
+			continue
+		case '\n':
+			return "\n" + string(input[i+1:start])
+		default:
+			return ""
+		}
+	}
+	return ""
+}
+
+// blockStringLines returns the lines of a block string without common indentation and leading or trailing blank lines
+func blockStringLines(raw string) []string {
+	lines := strings.Split(strings.ReplaceAll(raw, "\r\n", "\n"), "\n")
+
+	commonIndent := -1
+	for _, line := range lines[1:] {
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed == "" {
+			continue
+		}
+		indent := len(line) - len(trimmed)
+		if commonIndent == -1 || indent < commonIndent {
+			commonIndent = indent
+		}
+	}
+	for i := range lines {
+		if i > 0 && commonIndent > 0 && len(lines[i]) >= commonIndent {
+			lines[i] = lines[i][commonIndent:]
+		}
+		lines[i] = strings.TrimRight(lines[i], " \t")
+	}
+
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+func operationTypeName(operationType ast.OperationType) string {
+	switch operationType {
+	case ast.OperationTypeMutation:
+		return "mutation"
+	case ast.OperationTypeSubscription:
+		return "subscription"
+	default:
+		return "query"
+	}
+}
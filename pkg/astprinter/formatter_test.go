@@ -0,0 +1,338 @@
+package astprinter
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/sebdah/goldie"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astparser"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+func parseWithComments(t *testing.T, input string) *ast.Document {
+	t.Helper()
+	doc := ast.NewDocument()
+	doc.Input.ResetInputString(input)
+	parser := astparser.NewParser()
+	parser.KeepComments(true)
+	report := operationreport.Report{}
+	parser.Parse(doc, &report)
+	require.False(t, report.HasErrors(), report.Error())
+	return doc
+}
+
+func TestFormat(t *testing.T) {
+	run := func(t *testing.T, options FormatOptions, input, expected string) {
+		t.Helper()
+
+		out, err := FormatString(parseWithComments(t, input), options)
+		require.NoError(t, err)
+		assert.Equal(t, expected, out)
+
+		// formatting must be stable
+		again, err := FormatString(parseWithComments(t, out), options)
+		require.NoError(t, err)
+		assert.Equal(t, out, again)
+	}
+
+	t.Run("type system definitions", func(t *testing.T) {
+		run(t, FormatOptions{}, `
+			schema @a { query: Query mutation: Mutation }
+			type Query implements Node&Entity @key(fields: "id") { user(id: ID!, filter: Filter = {a: ["x"], b: 2}): User @deprecated }
+			interface Node { id: ID! }
+			union SearchResult @a = User | Photo
+			enum Role { ADMIN @a USER }
+			input Filter { a: [String!] = ["x","y"] b: Int = 1 }
+			scalar Date @specifiedBy(url: "https://example.com")
+			directive @key(fields: String!) repeatable on OBJECT | INTERFACE
+			extend type Query { me: User }
+			extend schema { subscription: Subscription }`, `schema @a {
+  query: Query
+  mutation: Mutation
+}
+
+type Query implements Node & Entity @key(fields: "id") {
+  user(id: ID!, filter: Filter = {a: ["x"], b: 2}): User @deprecated
+}
+
+interface Node {
+  id: ID!
+}
+
+union SearchResult @a = User | Photo
+
+enum Role {
+  ADMIN @a
+  USER
+}
+
+input Filter {
+  a: [String!] = ["x", "y"]
+  b: Int = 1
+}
+
+scalar Date @specifiedBy(url: "https://example.com")
+
+directive @key(fields: String!) repeatable on OBJECT | INTERFACE
+
+extend type Query {
+  me: User
+}
+
+extend schema {
+  subscription: Subscription
+}
+`)
+	})
+
+	t.Run("executable definitions", func(t *testing.T) {
+		run(t, FormatOptions{}, `
+			query Q($id: ID!, $verbose: Boolean = false) @live { u: user(id: $id) { ...F @include(if: $verbose) ... on User { id } ... @skip(if: true) { name } } }
+			{ user(id: 1) { id } }
+			fragment F on User { name }`, `query Q($id: ID!, $verbose: Boolean = false) @live {
+  u: user(id: $id) {
+    ...F @include(if: $verbose)
+    ... on User {
+      id
+    }
+    ... @skip(if: true) {
+      name
+    }
+  }
+}
+
+{
+  user(id: 1) {
+    id
+  }
+}
+
+fragment F on User {
+  name
+}
+`)
+	})
+
+	t.Run("indent", func(t *testing.T) {
+		run(t, FormatOptions{Indent: "\t"}, `{ user { id } }`, "{\n\tuser {\n\t\tid\n\t}\n}\n")
+	})
+
+	t.Run("comments", func(t *testing.T) {
+		run(t, FormatOptions{}, `# header
+
+# leading type
+type User { # opening
+  # leading id
+  id: ID! # trailing id
+  name(
+    # leading argument
+    format: String
+  ): String
+} # closing
+
+query { # opening query
+  user { # opening user
+    # leading id
+    id
+    # before closing
+  }
+}
+# end`, `# header
+
+# leading type
+type User { # opening
+  # leading id
+  id: ID! # trailing id
+  name(
+    # leading argument
+    format: String
+  ): String
+} # closing
+
+query { # opening query
+  user { # opening user
+    # leading id
+    id
+    # before closing
+  }
+}
+
+# end
+`)
+	})
+
+	t.Run("comments of descriptions", func(t *testing.T) {
+		run(t, FormatOptions{}, `
+			# leading
+			"description" type User {
+				"the id" id: ID! # trailing
+			}`, `# leading
+"description"
+type User {
+  "the id"
+  id: ID! # trailing
+}
+`)
+	})
+
+	t.Run("max line length", func(t *testing.T) {
+		run(t, FormatOptions{MaxLineLength: 30}, `
+			type Query { user(id: ID!, name: String): User short(a: Int): Int }
+			union SearchResult = User | Photo | Video | Article
+			query Q($first: Int, $after: String) { users(first: $first, after: $after) { id } }`, `type Query {
+  user(
+    id: ID!
+    name: String
+  ): User
+  short(a: Int): Int
+}
+
+union SearchResult =
+  | User
+  | Photo
+  | Video
+  | Article
+
+query Q(
+  $first: Int
+  $after: String
+) {
+  users(
+    first: $first
+    after: $after
+  ) {
+    id
+  }
+}
+`)
+	})
+
+	t.Run("argument descriptions wrap arguments", func(t *testing.T) {
+		run(t, FormatOptions{}, `type Query { user("the id" id: ID!): User }`, `type Query {
+  user(
+    "the id"
+    id: ID!
+  ): User
+}
+`)
+	})
+
+	t.Run("descriptions", func(t *testing.T) {
+		input := `
+			"""
+			  A user
+			    indented
+			"""
+			type User {
+			  """the id"""
+			  id: ID!
+			  "the name"
+			  name: String
+			}`
+
+		t.Run("preserve", func(t *testing.T) {
+			run(t, FormatOptions{Descriptions: DescriptionStylePreserve}, input, `"""
+A user
+  indented
+"""
+type User {
+  """
+  the id
+  """
+  id: ID!
+  "the name"
+  name: String
+}
+`)
+		})
+		t.Run("block", func(t *testing.T) {
+			run(t, FormatOptions{Descriptions: DescriptionStyleBlock}, input, `"""
+A user
+  indented
+"""
+type User {
+  """
+  the id
+  """
+  id: ID!
+  """
+  the name
+  """
+  name: String
+}
+`)
+		})
+		t.Run("single line", func(t *testing.T) {
+			run(t, FormatOptions{Descriptions: DescriptionStyleSingleLine}, input, `"""
+A user
+  indented
+"""
+type User {
+  "the id"
+  id: ID!
+  "the name"
+  name: String
+}
+`)
+		})
+	})
+
+	t.Run("sort", func(t *testing.T) {
+		run(t, FormatOptions{Sort: true}, `
+			query Q { b a }
+			extend type B { d: Int }
+			# leading B
+			type B { c: Int b: Int # trailing b
+			}
+			input A { z: Int y: Int }
+			directive @z on FIELD
+			schema { query: B }
+			fragment F on B { c }`, `schema {
+  query: B
+}
+
+directive @z on FIELD
+
+input A {
+  y: Int
+  z: Int
+}
+
+# leading B
+type B {
+  b: Int # trailing b
+  c: Int
+}
+
+extend type B {
+  d: Int
+}
+
+query Q {
+  b
+  a
+}
+
+fragment F on B {
+  c
+}
+`)
+	})
+}
+
+func TestFormatSchemaDefinition(t *testing.T) {
+	schema, err := ioutil.ReadFile("./testdata/starwars.schema.graphql")
+	require.NoError(t, err)
+
+	out, err := FormatString(parseWithComments(t, string(schema)), FormatOptions{MaxLineLength: 80})
+	require.NoError(t, err)
+	goldie.Assert(t, "starwars_schema_definition_formatted", []byte(out))
+
+	again, err := FormatString(parseWithComments(t, out), FormatOptions{MaxLineLength: 80})
+	require.NoError(t, err)
+	assert.Equal(t, out, again)
+}
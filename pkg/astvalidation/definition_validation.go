@@ -1,24 +1,81 @@
 package astvalidation
 
 import (
+	"fmt"
+
 	"github.com/wundergraph/graphql-go-tools/pkg/ast"
 	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
 	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
 )
 
-func DefaultDefinitionValidator() *DefinitionValidator {
-	return NewDefinitionValidator(
-		PopulatedTypeBodies(),
-		UniqueOperationTypes(),
-		UniqueTypeNames(),
-		UniqueFieldDefinitionNames(),
-		UniqueEnumValueNames(),
-		UniqueUnionMemberTypes(),
-		KnownTypeNames(),
-		RequireDefinedTypesForExtensions(),
-		ImplementTransitiveInterfaces(),
-		ImplementingTypesAreSupersets(),
-	)
+type definitionValidatorOptions struct {
+	shards int
+}
+
+type DefinitionValidatorOption func(options *definitionValidatorOptions)
+
+// WithShards walks the rules validating each definition on its own concurrently using the given amount of shards.
+// Rules which need to see all definitions, e.g. UniqueTypeNames, still run in a single walk.
+// It is meant for large schemas, the errors of the sharded rules get reported after the errors of all other rules.
+func WithShards(shards int) DefinitionValidatorOption {
+	return func(options *definitionValidatorOptions) {
+		options.shards = shards
+	}
+}
+
+func DefaultDefinitionValidator(opts ...DefinitionValidatorOption) *DefinitionValidator {
+	var options definitionValidatorOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	// perDefinition marks the rules which validate each definition on its own, all other rules collect state across definitions
+	rules := []struct {
+		rule          Rule
+		perDefinition bool
+	}{
+		{rule: PopulatedTypeBodies(), perDefinition: true},
+		{rule: UniqueOperationTypes()},
+		{rule: RootOperationTypesAreObjectTypes(), perDefinition: true},
+		{rule: UniqueTypeNames()},
+		{rule: UniqueDirectiveNames()},
+		{rule: UniqueFieldDefinitionNames()},
+		{rule: UniqueArgumentDefinitionNames(), perDefinition: true},
+		{rule: UniqueEnumValueNames()},
+		{rule: UniqueUnionMemberTypes()},
+		{rule: KnownTypeNames()},
+		{rule: RequireDefinedTypesForExtensions(), perDefinition: true},
+		{rule: ImplementTransitiveInterfaces()},
+		{rule: ImplementingTypesAreSupersets()},
+		{rule: ReservedNames(), perDefinition: true},
+		{rule: FieldsAreOutputTypes(), perDefinition: true},
+		{rule: InputValuesAreInputTypes(), perDefinition: true},
+		{rule: UnionMembersAreObjectTypes(), perDefinition: true},
+		{rule: NoCircularInputObjectReferences()},
+		{rule: ValidDefaultValues(), perDefinition: true},
+		{rule: ValidDefinitionDirectives(), perDefinition: true},
+		{rule: UniqueDefinitionDirectivesPerLocation()},
+		{rule: NoCircularDirectiveReferences(), perDefinition: true},
+	}
+
+	validator := NewDefinitionValidator()
+	var sharded []Rule
+	for _, i := range rules {
+		if i.perDefinition && options.shards > 1 {
+			sharded = append(sharded, i.rule)
+			continue
+		}
+		validator.RegisterRule(i.rule)
+	}
+
+	if len(sharded) != 0 {
+		validator.parallel = astvisitor.NewParallelWalker(options.shards, func(walker *astvisitor.Walker) {
+			for _, rule := range sharded {
+				rule(walker)
+			}
+		})
+	}
+	return validator
 }
 
 func NewDefinitionValidator(rules ...Rule) *DefinitionValidator {
@@ -34,7 +91,8 @@ func NewDefinitionValidator(rules ...Rule) *DefinitionValidator {
 }
 
 type DefinitionValidator struct {
-	walker astvisitor.Walker
+	walker   astvisitor.Walker
+	parallel *astvisitor.ParallelWalker
 }
 
 func (d *DefinitionValidator) RegisterRule(rule Rule) {
@@ -47,9 +105,57 @@ func (d *DefinitionValidator) Validate(definition *ast.Document, report *operati
 	}
 
 	d.walker.Walk(definition, nil, report)
+	if d.parallel != nil {
+		d.parallel.Walk(definition, nil, report)
+	}
 
 	if report.HasErrors() {
 		return Invalid
 	}
 	return Valid
 }
+
+// isInputTypeKind reports whether a named type of the given kind can be used as type of arguments and input fields
+func isInputTypeKind(kind ast.NodeKind) bool {
+	switch kind {
+	case ast.NodeKindScalarTypeDefinition, ast.NodeKindScalarTypeExtension,
+		ast.NodeKindEnumTypeDefinition, ast.NodeKindEnumTypeExtension,
+		ast.NodeKindInputObjectTypeDefinition, ast.NodeKindInputObjectTypeExtension:
+		return true
+	default:
+		return false
+	}
+}
+
+// isOutputTypeKind reports whether a named type of the given kind can be used as type of fields
+func isOutputTypeKind(kind ast.NodeKind) bool {
+	switch kind {
+	case ast.NodeKindInputObjectTypeDefinition, ast.NodeKindInputObjectTypeExtension:
+		return false
+	default:
+		return true
+	}
+}
+
+// inputValueDefinitionCoordinate returns the schema coordinate of the input value definition the walker is currently on,
+// e.g. "Query.user(id:)", "@include(if:)" or "UserInput.name"
+func inputValueDefinitionCoordinate(walker *astvisitor.Walker, definition *ast.Document, ref int) string {
+	name := definition.InputValueDefinitionNameString(ref)
+	if len(walker.Ancestors) == 0 {
+		return name
+	}
+
+	parent := walker.Ancestors[len(walker.Ancestors)-1]
+	switch parent.Kind {
+	case ast.NodeKindFieldDefinition:
+		typeName := "?"
+		if len(walker.Ancestors) > 1 {
+			typeName = definition.NodeNameString(walker.Ancestors[len(walker.Ancestors)-2])
+		}
+		return fmt.Sprintf("%s.%s(%s:)", typeName, definition.FieldDefinitionNameString(parent.Ref), name)
+	case ast.NodeKindDirectiveDefinition:
+		return fmt.Sprintf("@%s(%s:)", definition.DirectiveDefinitionNameString(parent.Ref), name)
+	default:
+		return fmt.Sprintf("%s.%s", definition.NodeNameString(parent), name)
+	}
+}
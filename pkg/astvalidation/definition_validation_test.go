@@ -8,6 +8,7 @@ import (
 
 	"github.com/wundergraph/graphql-go-tools/pkg/astparser"
 	"github.com/wundergraph/graphql-go-tools/pkg/asttransform"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
 )
 
 func runDefinitionValidation(t *testing.T, definitionInput string, expectation ValidationState, rules ...Rule) {
@@ -25,3 +26,45 @@ func runDefinitionValidation(t *testing.T, definitionInput string, expectation V
 	result := validator.Validate(&definition, &report)
 	assert.Equal(t, expectation, result)
 }
+
+func runDefinitionValidationWithErrors(t *testing.T, definitionInput string, expectedErrors []operationreport.ExternalError, rules ...Rule) {
+	definition, report := astparser.ParseGraphqlDocumentString(definitionInput)
+	require.False(t, report.HasErrors())
+
+	err := asttransform.MergeDefinitionWithBaseSchema(&definition)
+	require.NoError(t, err)
+
+	validator := NewDefinitionValidator(rules...)
+	validator.Validate(&definition, &report)
+	assert.Equal(t, expectedErrors, report.ExternalErrors)
+}
+
+func TestDefaultDefinitionValidator_WithShards(t *testing.T) {
+	definitionInput := `
+		type Query { user: User __reserved: String }
+		type User { name: String input: UserInput }
+		type User { id: ID }
+		input UserInput { user: User }
+		union Result = String | User
+		enum Empty
+		type Fine { field(arg: Int = "wrong"): String }
+		type AlsoFine { field: String @unknown }
+		extend type Missing { field: String }
+	`
+
+	validate := func(opts ...DefinitionValidatorOption) []operationreport.ExternalError {
+		definition, report := astparser.ParseGraphqlDocumentString(definitionInput)
+		require.False(t, report.HasErrors())
+		require.NoError(t, asttransform.MergeDefinitionWithBaseSchema(&definition))
+
+		DefaultDefinitionValidator(opts...).Validate(&definition, &report)
+		return report.ExternalErrors
+	}
+
+	expected := validate()
+	assert.True(t, len(expected) > 5)
+
+	for _, shards := range []int{2, 3, 16} {
+		assert.ElementsMatch(t, expected, validate(WithShards(shards)))
+	}
+}
@@ -52,5 +52,5 @@ func (a *allVariableUsesDefinedVisitor) EnterArgument(ref int) {
 
 	// at this point we're safe to say this variable was not defined on the root operation of this argument
 	argumentName := a.operation.ArgumentNameBytes(ref)
-	a.StopWithExternalErr(operationreport.ErrVariableNotDefinedOnArgument(variableName, argumentName))
+	a.StopVisitorWithExternalErr(operationreport.ErrVariableNotDefinedOnArgument(variableName, argumentName, a.operation.Arguments[ref].Value.Position))
 }
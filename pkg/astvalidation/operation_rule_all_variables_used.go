@@ -42,9 +42,9 @@ func (a *allVariablesUsedVisitor) LeaveOperationDefinition(ref int) {
 		operationName := a.operation.Input.ByteSlice(a.operation.OperationDefinitions[ref].Name)
 		for _, i := range a.variableDefinitions {
 			variableName := a.operation.VariableDefinitionNameBytes(i)
-			a.Report.AddExternalError(operationreport.ErrVariableDefinedButNeverUsed(variableName, operationName))
+			a.Report.AddExternalError(operationreport.ErrVariableDefinedButNeverUsed(variableName, operationName, a.operation.VariableDefinitions[i].Position))
 		}
-		a.Stop()
+		a.StopVisitor()
 	}
 }
 
@@ -35,7 +35,7 @@ func (a *argumentUniquenessVisitor) EnterArgument(ref int) {
 
 	for _, i := range argumentsAfter {
 		if bytes.Equal(argumentName, a.operation.ArgumentNameBytes(i)) {
-			a.StopWithExternalErr(operationreport.ErrArgumentMustBeUnique(argumentName))
+			a.StopVisitorWithExternalErr(operationreport.ErrArgumentMustBeUnique(argumentName, a.operation.Arguments[i].Position))
 			return
 		}
 	}
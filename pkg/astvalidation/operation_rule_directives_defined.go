@@ -33,7 +33,7 @@ func (d *directivesAreDefinedVisitor) EnterDirective(ref int) {
 	definition, exists := d.definition.Index.FirstNodeByNameBytes(directiveName)
 
 	if !exists || definition.Kind != ast.NodeKindDirectiveDefinition {
-		d.StopWithExternalErr(operationreport.ErrDirectiveUndefined(directiveName))
+		d.StopVisitorWithExternalErr(operationreport.ErrDirectiveUndefined(directiveName, d.operation.Directives[ref].Position))
 		return
 	}
 }
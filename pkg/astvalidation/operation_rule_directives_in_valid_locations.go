@@ -40,7 +40,7 @@ func (d *directivesAreInValidLocationsVisitor) EnterDirective(ref int) {
 
 	if !d.directiveDefinitionContainsNodeLocation(definition.Ref, ancestor) {
 		ancestorKindName := d.operation.NodeKindNameBytes(ancestor)
-		d.StopWithExternalErr(operationreport.ErrDirectiveNotAllowedOnNode(directiveName, ancestorKindName))
+		d.StopVisitorWithExternalErr(operationreport.ErrDirectiveNotAllowedOnNode(directiveName, ancestorKindName, d.operation.Directives[ref].Position))
 		return
 	}
 }
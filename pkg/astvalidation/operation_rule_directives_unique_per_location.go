@@ -39,7 +39,7 @@ func (d *directivesAreUniquePerLocationVisitor) EnterDirective(ref int) {
 			continue
 		}
 		if bytes.Equal(directiveName, d.operation.DirectiveNameBytes(j)) {
-			d.StopWithExternalErr(operationreport.ErrDirectiveMustBeUniquePerLocation(directiveName))
+			d.StopVisitorWithExternalErr(operationreport.ErrDirectiveMustBeUniquePerLocation(directiveName, d.operation.Directives[ref].Position))
 			return
 		}
 	}
@@ -3,6 +3,7 @@ package astvalidation
 import (
 	"github.com/wundergraph/graphql-go-tools/pkg/ast"
 	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/position"
 	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
 )
 
@@ -22,7 +23,7 @@ type documentContainsExecutableOperation struct {
 
 func (d *documentContainsExecutableOperation) EnterDocument(operation, definition *ast.Document) {
 	if len(operation.RootNodes) == 0 {
-		d.StopWithExternalErr(operationreport.ErrDocumentDoesntContainExecutableOperation())
+		d.StopVisitorWithExternalErr(operationreport.ErrDocumentDoesntContainExecutableOperation(position.Position{}))
 		return
 	}
 	for i := range operation.RootNodes {
@@ -30,5 +31,5 @@ func (d *documentContainsExecutableOperation) EnterDocument(operation, definitio
 			return
 		}
 	}
-	d.StopWithExternalErr(operationreport.ErrDocumentDoesntContainExecutableOperation())
+	d.StopVisitorWithExternalErr(operationreport.ErrDocumentDoesntContainExecutableOperation(operation.NodePosition(operation.RootNodes[0])))
 }
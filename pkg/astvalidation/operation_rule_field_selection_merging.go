@@ -32,6 +32,7 @@ type fieldSelectionMergingVisitor struct {
 type nonScalarRequirement struct {
 	path                    ast.Path
 	objectName              ast.ByteSlice
+	fieldRef                int
 	fieldTypeRef            int
 	fieldTypeDefinitionNode ast.Node
 }
@@ -96,8 +97,8 @@ func (f *fieldSelectionMergingVisitor) EnterField(ref int) {
 	objectName := f.operation.FieldAliasOrNameBytes(ref)
 	definition, ok := f.definition.NodeFieldDefinitionByName(f.EnclosingTypeDefinition, fieldName)
 	if !ok {
-		enclosingTypeName := f.definition.NodeNameBytes(f.EnclosingTypeDefinition)
-		f.StopWithExternalErr(operationreport.ErrFieldUndefinedOnType(fieldName, enclosingTypeName))
+		// undefined fields are reported by FieldSelections
+		f.SkipNodeForVisitor()
 		return
 	}
 
@@ -111,7 +112,7 @@ func (f *fieldSelectionMergingVisitor) EnterField(ref int) {
 
 			if !f.potentiallySameObject(fieldDefinitionTypeNode, f.nonScalarRequirements[i].fieldTypeDefinitionNode) {
 				if !objectName.Equals(f.nonScalarRequirements[i].objectName) {
-					f.StopWithExternalErr(operationreport.ErrResponseOfDifferingTypesMustBeOfSameShape(objectName, f.nonScalarRequirements[i].objectName))
+					f.StopVisitorWithExternalErr(operationreport.ErrResponseOfDifferingTypesMustBeOfSameShape(objectName, f.nonScalarRequirements[i].objectName, f.operation.Fields[f.nonScalarRequirements[i].fieldRef].Position, f.operation.Fields[ref].Position))
 					return
 				}
 			} else if !f.definition.TypesAreCompatibleDeep(f.nonScalarRequirements[i].fieldTypeRef, fieldType) {
@@ -125,7 +126,7 @@ func (f *fieldSelectionMergingVisitor) EnterField(ref int) {
 					f.StopWithInternalErr(err)
 					return
 				}
-				f.StopWithExternalErr(operationreport.ErrTypesForFieldMismatch(objectName, left, right))
+				f.StopVisitorWithExternalErr(operationreport.ErrTypesForFieldMismatch(objectName, left, right, f.operation.Fields[f.nonScalarRequirements[i].fieldRef].Position, f.operation.Fields[ref].Position))
 				return
 			}
 
@@ -153,6 +154,7 @@ func (f *fieldSelectionMergingVisitor) EnterField(ref int) {
 		f.nonScalarRequirements = append(f.nonScalarRequirements, nonScalarRequirement{
 			path:                    path,
 			objectName:              objectName,
+			fieldRef:                ref,
 			fieldTypeRef:            fieldType,
 			fieldTypeDefinitionNode: fieldDefinitionTypeNode,
 		})
@@ -165,7 +167,7 @@ func (f *fieldSelectionMergingVisitor) EnterField(ref int) {
 	for _, i := range matchedRequirements {
 		if f.potentiallySameObject(f.scalarRequirements[i].enclosingTypeDefinition, f.EnclosingTypeDefinition) {
 			if !f.operation.FieldsAreEqualFlat(f.scalarRequirements[i].fieldRef, ref) {
-				f.StopWithExternalErr(operationreport.ErrDifferingFieldsOnPotentiallySameType(objectName))
+				f.StopVisitorWithExternalErr(operationreport.ErrDifferingFieldsOnPotentiallySameType(objectName, f.operation.Fields[f.scalarRequirements[i].fieldRef].Position, f.operation.Fields[ref].Position))
 				return
 			}
 		}
@@ -180,7 +182,7 @@ func (f *fieldSelectionMergingVisitor) EnterField(ref int) {
 				f.StopWithInternalErr(err)
 				return
 			}
-			f.StopWithExternalErr(operationreport.ErrFieldsConflict(objectName, left, right))
+			f.StopVisitorWithExternalErr(operationreport.ErrFieldsConflict(objectName, left, right, f.operation.Fields[f.scalarRequirements[i].fieldRef].Position, f.operation.Fields[ref].Position))
 			return
 		}
 
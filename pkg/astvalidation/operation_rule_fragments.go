@@ -5,6 +5,7 @@ import (
 
 	"github.com/wundergraph/graphql-go-tools/pkg/ast"
 	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/position"
 	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
 )
 
@@ -32,7 +33,7 @@ type fragmentsVisitor struct {
 func (f *fragmentsVisitor) EnterFragmentSpread(ref int) {
 	if f.Ancestors[0].Kind == ast.NodeKindOperationDefinition {
 		spreadName := f.operation.FragmentSpreadNameBytes(ref)
-		f.StopWithExternalErr(operationreport.ErrFragmentSpreadFormsCycle(spreadName))
+		f.StopVisitorWithExternalErr(operationreport.ErrFragmentSpreadFormsCycle(spreadName, f.operation.FragmentSpreads[ref].Position))
 	}
 }
 
@@ -40,7 +41,11 @@ func (f *fragmentsVisitor) LeaveDocument(operation, definition *ast.Document) {
 	for i := range f.fragmentDefinitionsVisited {
 		if !f.operation.FragmentDefinitionIsUsed(f.fragmentDefinitionsVisited[i]) {
 			fragmentName := f.fragmentDefinitionsVisited[i]
-			f.StopWithExternalErr(operationreport.ErrFragmentDefinedButNotUsed(fragmentName))
+			var position position.Position
+			if fragmentDefinition, exists := f.operation.FragmentDefinitionRef(fragmentName); exists {
+				position = f.operation.FragmentDefinitions[fragmentDefinition].Position
+			}
+			f.StopVisitorWithExternalErr(operationreport.ErrFragmentDefinedButNotUsed(fragmentName, position))
 			return
 		}
 	}
@@ -65,18 +70,18 @@ func (f *fragmentsVisitor) EnterInlineFragment(ref int) {
 
 	node, exists := f.definition.Index.FirstNonExtensionNodeByNameBytes(typeName)
 	if !exists {
-		f.StopWithExternalErr(operationreport.ErrTypeUndefined(typeName))
+		f.StopVisitorWithExternalErr(operationreport.ErrTypeUndefined(typeName, f.operation.Types[f.operation.InlineFragments[ref].TypeCondition.Type].Position))
 		return
 	}
 
 	if !f.fragmentOnNodeIsAllowed(node) {
-		f.StopWithExternalErr(operationreport.ErrInlineFragmentOnTypeDisallowed(typeName))
+		f.StopVisitorWithExternalErr(operationreport.ErrInlineFragmentOnTypeDisallowed(typeName, f.operation.InlineFragments[ref].Position))
 		return
 	}
 
 	if !f.definition.NodeFragmentIsAllowedOnNode(node, f.EnclosingTypeDefinition) {
 		enclosingTypeName := f.definition.NodeNameBytes(f.EnclosingTypeDefinition)
-		f.StopWithExternalErr(operationreport.ErrInlineFragmentOnTypeMismatchEnclosingType(typeName, enclosingTypeName))
+		f.StopVisitorWithExternalErr(operationreport.ErrInlineFragmentOnTypeMismatchEnclosingType(typeName, enclosingTypeName, f.operation.InlineFragments[ref].Position))
 		return
 	}
 }
@@ -94,18 +99,18 @@ func (f *fragmentsVisitor) EnterFragmentDefinition(ref int) {
 
 	node, exists := f.definition.Index.FirstNodeByNameBytes(typeName)
 	if !exists {
-		f.StopWithExternalErr(operationreport.ErrTypeUndefined(typeName))
+		f.StopVisitorWithExternalErr(operationreport.ErrTypeUndefined(typeName, f.operation.Types[f.operation.FragmentDefinitions[ref].TypeCondition.Type].Position))
 		return
 	}
 
 	if !f.fragmentOnNodeIsAllowed(node) {
-		f.StopWithExternalErr(operationreport.ErrFragmentDefinitionOnTypeDisallowed(fragmentDefinitionName, typeName))
+		f.StopVisitorWithExternalErr(operationreport.ErrFragmentDefinitionOnTypeDisallowed(fragmentDefinitionName, typeName, f.operation.FragmentDefinitions[ref].Position))
 		return
 	}
 
 	for i := range f.fragmentDefinitionsVisited {
 		if bytes.Equal(fragmentDefinitionName, f.fragmentDefinitionsVisited[i]) {
-			f.StopWithExternalErr(operationreport.ErrFragmentDefinitionMustBeUnique(fragmentDefinitionName))
+			f.StopVisitorWithExternalErr(operationreport.ErrFragmentDefinitionMustBeUnique(fragmentDefinitionName, f.operation.FragmentDefinitions[ref].Position))
 			return
 		}
 	}
@@ -24,7 +24,7 @@ func (l *loneAnonymousOperationVisitor) EnterDocument(operation, definition *ast
 
 	for i := range operation.OperationDefinitions {
 		if operation.OperationDefinitions[i].Name.Length() == 0 {
-			l.StopWithExternalErr(operationreport.ErrAnonymousOperationMustBeTheOnlyOperationInDocument())
+			l.StopVisitorWithExternalErr(operationreport.ErrAnonymousOperationMustBeTheOnlyOperationInDocument(operation.OperationDefinitions[i].Position))
 			return
 		}
 	}
@@ -33,7 +33,7 @@ func (o *operationNameUniquenessVisitor) EnterDocument(operation, definition *as
 
 			if ast.ByteSliceEquals(left, operation.Input, right, operation.Input) {
 				operationName := operation.Input.ByteSlice(operation.OperationDefinitions[i].Name)
-				o.StopWithExternalErr(operationreport.ErrOperationNameMustBeUnique(operationName))
+				o.StopVisitorWithExternalErr(operationreport.ErrOperationNameMustBeUnique(operationName, operation.OperationDefinitions[k].Position))
 				return
 			}
 		}
@@ -41,12 +41,12 @@ func (r *requiredArgumentsVisitor) EnterField(ref int) {
 
 		argument, exists := r.operation.FieldArgument(ref, name)
 		if !exists {
-			r.StopWithExternalErr(operationreport.ErrArgumentRequiredOnField(name, fieldName))
+			r.StopVisitorWithExternalErr(operationreport.ErrArgumentRequiredOnField(name, fieldName, r.operation.Fields[ref].Position))
 			return
 		}
 
 		if r.operation.ArgumentValue(argument).Kind == ast.ValueKindNull {
-			r.StopWithExternalErr(operationreport.ErrArgumentOnFieldMustNotBeNull(name, fieldName))
+			r.StopVisitorWithExternalErr(operationreport.ErrArgumentOnFieldMustNotBeNull(name, fieldName, r.operation.Arguments[argument].Position))
 			return
 		}
 	}
@@ -24,7 +24,7 @@ func (s *subscriptionSingleRootFieldVisitor) EnterDocument(operation, definition
 			selections := len(operation.SelectionSets[operation.OperationDefinitions[i].SelectionSet].SelectionRefs)
 			if selections > 1 {
 				subscriptionName := operation.Input.ByteSlice(operation.OperationDefinitions[i].Name)
-				s.StopWithExternalErr(operationreport.ErrSubscriptionMustOnlyHaveOneRootSelection(subscriptionName))
+				s.StopVisitorWithExternalErr(operationreport.ErrSubscriptionMustOnlyHaveOneRootSelection(subscriptionName, operation.OperationDefinitions[i].Position))
 				return
 			} else if selections == 1 {
 				ref := operation.SelectionSets[operation.OperationDefinitions[i].SelectionSet].SelectionRefs[0]
@@ -37,7 +37,7 @@ func (v *validArgumentsVisitor) EnterArgument(ref int) {
 	if !exists {
 		argumentName := v.operation.ArgumentNameBytes(ref)
 		ancestorName := v.AncestorNameBytes()
-		v.StopWithExternalErr(operationreport.ErrArgumentNotDefinedOnNode(argumentName, ancestorName))
+		v.StopVisitorWithExternalErr(operationreport.ErrArgumentNotDefinedOnNode(argumentName, ancestorName, v.operation.Arguments[ref].Position))
 		return
 	}
 
@@ -87,7 +87,7 @@ func (v *validArgumentsVisitor) validateIfValueSatisfiesInputFieldDefinition(val
 		return
 	}
 
-	v.StopWithExternalErr(operationreport.ErrValueDoesntSatisfyInputValueDefinition(printedValue, printedType))
+	v.StopVisitorWithExternalErr(operationreport.ErrValueDoesntSatisfyInputValueDefinition(printedValue, printedType, value.Position))
 }
 
 func (v *validArgumentsVisitor) floatValueSatisfiesInputValueDefinition(value ast.Value, inputValueDefinition int) bool {
@@ -38,7 +38,7 @@ func (f *fieldDefined) ValidateUnionField(ref int, enclosingTypeDefinition ast.N
 	}
 	fieldName := f.operation.FieldNameBytes(ref)
 	unionName := f.definition.NodeNameBytes(enclosingTypeDefinition)
-	f.StopWithExternalErr(operationreport.ErrFieldSelectionOnUnion(fieldName, unionName))
+	f.StopVisitorWithExternalErr(operationreport.ErrFieldSelectionOnUnion(fieldName, unionName, f.operation.Fields[ref].Position))
 }
 
 func (f *fieldDefined) ValidateInterfaceObjectTypeField(ref int, enclosingTypeDefinition ast.Node) {
@@ -56,21 +56,22 @@ func (f *fieldDefined) ValidateInterfaceObjectTypeField(ref int, enclosingTypeDe
 			fieldDefinitionTypeKind := f.definition.FieldDefinitionTypeNode(i).Kind
 			switch {
 			case hasSelections && fieldDefinitionTypeKind == ast.NodeKindScalarTypeDefinition:
-				f.StopWithExternalErr(operationreport.ErrFieldSelectionOnScalar(fieldName, definitionName))
+				f.StopVisitorWithExternalErr(operationreport.ErrFieldSelectionOnScalar(fieldName, definitionName, f.operation.Fields[ref].Position))
 			case !hasSelections && (fieldDefinitionTypeKind != ast.NodeKindScalarTypeDefinition && fieldDefinitionTypeKind != ast.NodeKindEnumTypeDefinition):
-				f.StopWithExternalErr(operationreport.ErrMissingFieldSelectionOnNonScalar(fieldName, typeName))
+				f.StopVisitorWithExternalErr(operationreport.ErrMissingFieldSelectionOnNonScalar(fieldName, typeName, f.operation.Fields[ref].Position))
 			}
 			return
 		}
 	}
 
-	f.StopWithExternalErr(operationreport.ErrFieldUndefinedOnType(fieldName, typeName))
+	// the walker can't resolve the types below an undefined field, so the walk stops for all rules
+	f.StopWithExternalErr(operationreport.ErrFieldUndefinedOnType(fieldName, typeName, f.operation.Fields[ref].Position))
 }
 
 func (f *fieldDefined) ValidateScalarField(ref int, enclosingTypeDefinition ast.Node) {
 	fieldName := f.operation.FieldNameBytes(ref)
 	scalarTypeName := f.operation.NodeNameBytes(enclosingTypeDefinition)
-	f.StopWithExternalErr(operationreport.ErrFieldSelectionOnScalar(fieldName, scalarTypeName))
+	f.StopVisitorWithExternalErr(operationreport.ErrFieldSelectionOnScalar(fieldName, scalarTypeName, f.operation.Fields[ref].Position))
 }
 
 func (f *fieldDefined) EnterField(ref int) {
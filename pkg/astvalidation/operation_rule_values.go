@@ -7,13 +7,21 @@ import (
 	"github.com/wundergraph/graphql-go-tools/pkg/astimport"
 	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
 	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+	"github.com/wundergraph/graphql-go-tools/pkg/scalars"
 )
 
 // Values validates if values are used properly
 func Values() Rule {
+	return ValuesWithScalarRegistry(nil)
+}
+
+// ValuesWithScalarRegistry validates if values are used properly
+// Literal values of custom scalars contained in the registry are validated by the scalar.
+func ValuesWithScalarRegistry(registry *scalars.Registry) Rule {
 	return func(walker *astvisitor.Walker) {
 		visitor := valuesVisitor{
-			Walker: walker,
+			Walker:  walker,
+			scalars: registry,
 		}
 		walker.RegisterEnterDocumentVisitor(&visitor)
 		walker.RegisterEnterArgumentVisitor(&visitor)
@@ -24,6 +32,7 @@ type valuesVisitor struct {
 	*astvisitor.Walker
 	operation, definition *ast.Document
 	importer              astimport.Importer
+	scalars               *scalars.Registry
 }
 
 func (v *valuesVisitor) EnterDocument(operation, definition *ast.Document) {
@@ -38,7 +47,7 @@ func (v *valuesVisitor) EnterArgument(ref int) {
 	if !exists {
 		argName := v.operation.ArgumentNameBytes(ref)
 		nodeName := v.operation.NodeNameBytes(v.Ancestors[len(v.Ancestors)-1])
-		v.StopWithExternalErr(operationreport.ErrArgumentNotDefinedOnNode(argName, nodeName))
+		v.StopVisitorWithExternalErr(operationreport.ErrArgumentNotDefinedOnNode(argName, nodeName, v.operation.Arguments[ref].Position))
 		return
 	}
 
@@ -48,7 +57,7 @@ func (v *valuesVisitor) EnterArgument(ref int) {
 		variableDefinition, exists := v.operation.VariableDefinitionByNameAndOperation(v.Ancestors[0].Ref, variableName)
 		if !exists {
 			operationName := v.operation.NodeNameBytes(v.Ancestors[0])
-			v.StopWithExternalErr(operationreport.ErrVariableNotDefinedOnOperation(variableName, operationName))
+			v.StopVisitorWithExternalErr(operationreport.ErrVariableNotDefinedOnOperation(variableName, operationName, v.operation.Arguments[ref].Value.Position))
 			return
 		}
 		if !v.operation.VariableDefinitions[variableDefinition].DefaultValue.IsDefined {
@@ -69,7 +78,7 @@ func (v *valuesVisitor) EnterArgument(ref int) {
 			return
 		}
 
-		v.StopWithExternalErr(operationreport.ErrValueDoesntSatisfyInputValueDefinition(printedValue, printedType))
+		v.StopVisitorWithExternalErr(operationreport.ErrValueDoesntSatisfyInputValueDefinition(printedValue, printedType, v.operation.Arguments[ref].Value.Position))
 		return
 	}
 }
@@ -274,6 +283,9 @@ func (v *valuesVisitor) valueSatisfiesScalar(value ast.Value, scalar int) bool {
 		typeName := v.operation.ResolveTypeNameString(variableTypeRef)
 		return scalarName == typeName
 	}
+	if scalar, ok := v.scalars.Scalar(scalarName); ok {
+		return scalar.ParseLiteral(v.operation, value) == nil
+	}
 	switch scalarName {
 	case "Boolean":
 		return value.Kind == ast.ValueKindBoolean
@@ -50,7 +50,7 @@ func (v *variableUniquenessVisitor) EnterVariableDefinition(ref int) {
 				return
 			}
 			operationName := v.operation.Input.ByteSlice(v.operation.OperationDefinitions[v.Ancestors[0].Ref].Name)
-			v.StopWithExternalErr(operationreport.ErrVariableMustBeUnique(name, operationName))
+			v.StopVisitorWithExternalErr(operationreport.ErrVariableMustBeUnique(name, operationName, v.operation.VariableDefinitions[i].Position))
 			return
 		}
 	}
@@ -36,7 +36,7 @@ func (v *variablesAreInputTypesVisitor) EnterVariableDefinition(ref int) {
 		return
 	default:
 		variableName := v.operation.VariableDefinitionNameBytes(ref)
-		v.StopWithExternalErr(operationreport.ErrVariableOfTypeIsNoValidInputValue(variableName, typeName))
+		v.StopVisitorWithExternalErr(operationreport.ErrVariableOfTypeIsNoValidInputValue(variableName, typeName, v.operation.Types[v.operation.VariableDefinitions[ref].Type].Position))
 		return
 	}
 }
@@ -5,10 +5,28 @@ import (
 	"github.com/wundergraph/graphql-go-tools/pkg/ast"
 	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
 	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+	"github.com/wundergraph/graphql-go-tools/pkg/scalars"
 )
 
+type operationValidatorOptions struct {
+	scalars *scalars.Registry
+}
+
+type OperationValidatorOption func(options *operationValidatorOptions)
+
+// WithScalarRegistry validates literal values of custom scalars using the scalars of the registry
+func WithScalarRegistry(registry *scalars.Registry) OperationValidatorOption {
+	return func(options *operationValidatorOptions) {
+		options.scalars = registry
+	}
+}
+
 // DefaultOperationValidator returns a fully initialized OperationValidator with all default rules registered
-func DefaultOperationValidator() *OperationValidator {
+func DefaultOperationValidator(opts ...OperationValidatorOption) *OperationValidator {
+	var options operationValidatorOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
 
 	validator := OperationValidator{
 		walker: astvisitor.NewWalker(48),
@@ -21,7 +39,7 @@ func DefaultOperationValidator() *OperationValidator {
 	validator.RegisterRule(FieldSelections())
 	validator.RegisterRule(FieldSelectionMerging())
 	validator.RegisterRule(ValidArguments())
-	validator.RegisterRule(Values())
+	validator.RegisterRule(ValuesWithScalarRegistry(options.scalars))
 	validator.RegisterRule(ArgumentUniqueness())
 	validator.RegisterRule(RequiredArguments())
 	validator.RegisterRule(Fragments())
@@ -59,6 +77,8 @@ func (o *OperationValidator) RegisterRule(rule Rule) {
 }
 
 // Validate validates the operation against the definition using the registered ruleset.
+// All rules run in a single walk, a rule stops at its first error while all other rules continue to validate the operation.
+// Undefined fields stop the whole walk as the types below them can't be resolved.
 func (o *OperationValidator) Validate(operation, definition *ast.Document, report *operationreport.Report) ValidationState {
 
 	if report == nil {
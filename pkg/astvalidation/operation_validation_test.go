@@ -4,12 +4,15 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/wundergraph/graphql-go-tools/internal/pkg/unsafeparser"
 	"github.com/wundergraph/graphql-go-tools/pkg/ast"
 	"github.com/wundergraph/graphql-go-tools/pkg/astnormalization"
 	"github.com/wundergraph/graphql-go-tools/pkg/astparser"
 	"github.com/wundergraph/graphql-go-tools/pkg/astprinter"
 	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+	"github.com/wundergraph/graphql-go-tools/pkg/scalars"
 )
 
 type options struct {
@@ -3799,6 +3802,37 @@ func TestValidationEdgeCases(t *testing.T) {
 	))
 }
 
+func TestValuesWithScalarRegistry(t *testing.T) {
+	definition := `
+		schema { query: Query }
+		type Query {
+			event(id: UUID!, at: DateTime, payload: JSON): String
+		}
+		scalar UUID
+		scalar DateTime
+		scalar JSON
+		scalar String`
+
+	run := func(operation string, registry *scalars.Registry, expectation ValidationState) func(t *testing.T) {
+		return func(t *testing.T) {
+			op := unsafeparser.ParseGraphqlDocumentString(operation)
+			def := unsafeparser.ParseGraphqlDocumentString(definition)
+
+			validator := &OperationValidator{}
+			validator.RegisterRule(ValuesWithScalarRegistry(registry))
+			var report operationreport.Report
+			assert.Equal(t, expectation, validator.Validate(&op, &def, &report), report.Error())
+		}
+	}
+
+	t.Run("valid custom scalar literals", run(`{ event(id: "0b5b8ff5-4c5a-4a3c-9f05-08b3d5e5ac66", at: "2022-10-19T10:00:00Z", payload: {a: [1, 2]}) }`, scalars.DefaultRegistry(), Valid))
+	t.Run("invalid UUID literal", run(`{ event(id: "123") }`, scalars.DefaultRegistry(), Invalid))
+	t.Run("invalid DateTime literal", run(`{ event(id: "0b5b8ff5-4c5a-4a3c-9f05-08b3d5e5ac66", at: "yesterday") }`, scalars.DefaultRegistry(), Invalid))
+	t.Run("invalid variable default value", run(`query ($at: DateTime = 1) { event(id: "0b5b8ff5-4c5a-4a3c-9f05-08b3d5e5ac66", at: $at) }`, scalars.DefaultRegistry(), Invalid))
+	t.Run("object literal for JSON", run(`{ event(id: "0b5b8ff5-4c5a-4a3c-9f05-08b3d5e5ac66", payload: {a: true}) }`, scalars.DefaultRegistry(), Valid))
+	t.Run("object literal without registry", run(`{ event(id: "0b5b8ff5-4c5a-4a3c-9f05-08b3d5e5ac66", payload: {a: true}) }`, nil, Invalid))
+}
+
 func BenchmarkValidation(b *testing.B) {
 	must := func(err error) {
 		if err != nil {
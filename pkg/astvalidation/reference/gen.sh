@@ -6,3 +6,7 @@ cd ..
 
 go run main.go
 gofmt -w testsgo
+
+# record which of the reference tests pass, review the diff of testsgo/matrix.yml for regressions
+cd testsgo
+go test -count=1 . -update-matrix
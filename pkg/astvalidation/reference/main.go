@@ -93,9 +93,9 @@ var (
 		"ValuesOfCorrectTypeRule",
 		"VariablesAreInputTypesRule",
 		"VariablesInAllowedPositionRule",
-		// "validation", // should be rewritten manually
-		// "NoDeprecatedCustomRule", // should be ignored we have no custom rules
-		// "NoSchemaIntrospectionCustomRule", // should be ignored we have no custom rules
+		"NoDeprecatedCustomRule",
+		"NoSchemaIntrospectionCustomRule",
+		// "validation", // tests the graphql-js validate function, e.g. custom rules and maxErrors, not a rule
 	}
 )
 
@@ -5,7 +5,6 @@ import (
 )
 
 func TestFragmentsOnCompositeTypesRule(t *testing.T) {
-	t.Skip()
 
 	ExpectErrors := func(t *testing.T, queryStr string) ResultCompare {
 		return ExpectValidationErrors(t, FragmentsOnCompositeTypesRule, queryStr)
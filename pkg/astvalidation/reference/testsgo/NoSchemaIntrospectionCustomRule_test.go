@@ -0,0 +1,136 @@
+package testsgo
+
+import (
+	"testing"
+)
+
+func TestNoSchemaIntrospectionCustomRule(t *testing.T) {
+
+	var schema string
+	ExpectErrors := func(t *testing.T, queryStr string) ResultCompare {
+		return ExpectValidationErrorsWithSchema(t,
+			schema,
+			NoSchemaIntrospectionCustomRule,
+			queryStr,
+		)
+	}
+
+	ExpectValid := func(t *testing.T, queryStr string) {
+		ExpectErrors(t, queryStr)([]Err{})
+	}
+
+	schema = BuildSchema(`
+  type Query {
+    someQuery: SomeType
+  }
+
+  type SomeType {
+    someField: String
+    introspectionField: __EnumValue
+  }
+`)
+
+	t.Run("Validate: Prohibit introspection queries", func(t *testing.T) {
+		t.Run("ignores valid fields including __typename", func(t *testing.T) {
+			ExpectValid(t, `
+      {
+        someQuery {
+          __typename
+          someField
+        }
+      }
+    `)
+		})
+
+		t.Run("ignores fields not in the schema", func(t *testing.T) {
+			ExpectValid(t, `
+      {
+        __introspect
+      }
+    `)
+		})
+
+		t.Run("reports error when a field with an introspection type is requested", func(t *testing.T) {
+			ExpectErrors(t, `
+      {
+        __schema {
+          queryType {
+            name
+          }
+        }
+      }
+    `)([]Err{
+				{
+					message:   `GraphQL introspection has been disabled, but the requested query contained the field "__schema".`,
+					locations: []Loc{{line: 3, column: 9}},
+				},
+				{
+					message:   `GraphQL introspection has been disabled, but the requested query contained the field "queryType".`,
+					locations: []Loc{{line: 4, column: 11}},
+				},
+			})
+		})
+
+		t.Run("reports error when a field with an introspection type is requested and aliased", func(t *testing.T) {
+			ExpectErrors(t, `
+      {
+        s: __schema {
+          queryType {
+            name
+          }
+        }
+      }
+      `)([]Err{
+				{
+					message:   `GraphQL introspection has been disabled, but the requested query contained the field "__schema".`,
+					locations: []Loc{{line: 3, column: 9}},
+				},
+				{
+					message:   `GraphQL introspection has been disabled, but the requested query contained the field "queryType".`,
+					locations: []Loc{{line: 4, column: 11}},
+				},
+			})
+		})
+
+		t.Run("reports error when using a fragment with a field with an introspection type", func(t *testing.T) {
+			ExpectErrors(t, `
+      {
+        ...QueryFragment
+      }
+
+      fragment QueryFragment on Query {
+        __schema {
+          queryType {
+            name
+          }
+        }
+      }
+    `)([]Err{
+				{
+					message:   `GraphQL introspection has been disabled, but the requested query contained the field "__schema".`,
+					locations: []Loc{{line: 7, column: 9}},
+				},
+				{
+					message:   `GraphQL introspection has been disabled, but the requested query contained the field "queryType".`,
+					locations: []Loc{{line: 8, column: 11}},
+				},
+			})
+		})
+
+		t.Run("reports error for non-standard introspection fields", func(t *testing.T) {
+			ExpectErrors(t, `
+      {
+        someQuery {
+          introspectionField
+        }
+      }
+    `)([]Err{
+				{
+					message:   `GraphQL introspection has been disabled, but the requested query contained the field "introspectionField".`,
+					locations: []Loc{{line: 4, column: 11}},
+				},
+			})
+		})
+	})
+
+}
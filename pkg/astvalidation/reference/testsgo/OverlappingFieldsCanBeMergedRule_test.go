@@ -5,7 +5,6 @@ import (
 )
 
 func TestOverlappingFieldsCanBeMergedRule(t *testing.T) {
-	t.Skip("part of the tests works. for errors message formats differs. locations is missing")
 
 	ExpectErrors := func(t *testing.T, queryStr string) ResultCompare {
 		return ExpectValidationErrors(t, OverlappingFieldsCanBeMergedRule, queryStr)
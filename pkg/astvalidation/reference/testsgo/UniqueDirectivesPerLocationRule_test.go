@@ -5,7 +5,6 @@ import (
 )
 
 func TestUniqueDirectivesPerLocationRule(t *testing.T) {
-	t.Skip()
 
 	extensionSDL := `
   directive @directive on FIELD | FRAGMENT_DEFINITION
@@ -245,8 +244,6 @@ func TestUniqueDirectivesPerLocationRule(t *testing.T) {
 		})
 
 		t.Run("duplicate directives on SDL extensions", func(t *testing.T) {
-			t.Skip("Parser do not support repeatable directives")
-
 			ExpectSDLErrors(t, `
       directive @nonRepeatable on
         SCHEMA | SCALAR | OBJECT | INTERFACE | UNION | INPUT_OBJECT
@@ -305,8 +302,6 @@ func TestUniqueDirectivesPerLocationRule(t *testing.T) {
 		})
 
 		t.Run("duplicate directives between SDL definitions and extensions", func(t *testing.T) {
-			t.Skip("Parser do not support repeatable directives")
-
 			ExpectSDLErrors(t, `
       directive @nonRepeatable on SCHEMA
 
@@ -58,13 +58,16 @@ const (
 	LoneSchemaDefinitionRule   = "LoneSchemaDefinitionRule"
 	ScalarLeafsRule            = "ScalarLeafsRule"
 	PossibleTypeExtensionsRule = "PossibleTypeExtensionsRule"
+
+	NoDeprecatedCustomRule          = "NoDeprecatedCustomRule"
+	NoSchemaIntrospectionCustomRule = "NoSchemaIntrospectionCustomRule"
 )
 
 var rulesMap = map[string][]astvalidation.Rule{
 	ExecutableDefinitionsRule:                 {astvalidation.DocumentContainsExecutableOperation()},
 	FieldsOnCorrectTypeRule:                   {astvalidation.FieldSelections()},
 	KnownArgumentNamesRule:                    {}, // {astvalidation.KnownArguments()},
-	KnownArgumentNamesOnDirectivesRule:        {},
+	KnownArgumentNamesOnDirectivesRule:        {astvalidation.ValidDefinitionDirectives()},
 	KnownDirectivesRule:                       {astvalidation.DirectivesAreDefined()},
 	KnownTypeNamesRule:                        {astvalidation.KnownTypeNames()},
 	LoneAnonymousOperationRule:                {astvalidation.LoneAnonymousOperation()},
@@ -72,15 +75,16 @@ var rulesMap = map[string][]astvalidation.Rule{
 	NoUnusedVariablesRule:                     {astvalidation.AllVariablesUsed()},
 	OverlappingFieldsCanBeMergedRule:          {astvalidation.FieldSelectionMerging()},
 	ProvidedRequiredArgumentsRule:             {astvalidation.RequiredArguments()},
-	ProvidedRequiredArgumentsOnDirectivesRule: {},
+	ProvidedRequiredArgumentsOnDirectivesRule: {astvalidation.ValidDefinitionDirectives()},
 	SingleFieldSubscriptionsRule:              {astvalidation.SubscriptionSingleRootField()},
 	UniqueArgumentNamesRule:                   {astvalidation.ArgumentUniqueness()},
-	UniqueDirectivesPerLocationRule:           {astvalidation.DirectivesAreUniquePerLocation()},
+	UniqueDirectivesPerLocationRule:           {astvalidation.DirectivesAreUniquePerLocation(), astvalidation.UniqueDefinitionDirectivesPerLocation()},
 	UniqueEnumValueNamesRule:                  {astvalidation.UniqueEnumValueNames()},
 	UniqueFieldDefinitionNamesRule:            {astvalidation.UniqueFieldDefinitionNames()},
 	UniqueOperationNamesRule:                  {astvalidation.OperationNameUniqueness()},
 	UniqueOperationTypesRule:                  {astvalidation.UniqueOperationTypes()},
 	UniqueTypeNamesRule:                       {astvalidation.UniqueTypeNames()},
+	UniqueDirectiveNamesRule:                  {astvalidation.UniqueDirectiveNames()},
 	UniqueVariableNamesRule:                   {astvalidation.VariableUniqueness()},
 	ValuesOfCorrectTypeRule:                   {astvalidation.Values()},
 	VariablesAreInputTypesRule:                {astvalidation.VariablesAreInputTypes()},
@@ -97,10 +101,13 @@ var rulesMap = map[string][]astvalidation.Rule{
 	// not mapped rules
 
 	UniqueInputFieldNamesRule:  {astvalidation.Values()},
-	UniqueDirectiveNamesRule:   {},
 	LoneSchemaDefinitionRule:   {},
 	ScalarLeafsRule:            {},
 	PossibleTypeExtensionsRule: {},
+
+	// optional rules of graphql-js, we have no counterparts
+	NoDeprecatedCustomRule:          {},
+	NoSchemaIntrospectionCustomRule: {},
 }
 
 func operationValidatorFor(rule string) (*astvalidation.OperationValidator, bool) {
@@ -138,9 +145,15 @@ type ResultCompare func(expectedErrors []Err)
 
 // ExpectValidationErrorsWithSchema - is a helper to run operation validation
 // returns ResultCompare function
-func ExpectValidationErrorsWithSchema(t *testing.T, schema string, rule string, queryStr string) ResultCompare {
+func ExpectValidationErrorsWithSchema(t *testing.T, schema string, rule string, queryStr string) (compare ResultCompare) {
 	t.Helper()
 
+	defer func() {
+		if err := recover(); err != nil {
+			compare = failedCompare(t, err)
+		}
+	}()
+
 	op, opReport := astparser.ParseGraphqlDocumentString(queryStr)
 	def := prepareSchema(schema)
 
@@ -174,9 +187,15 @@ func ExpectValidationErrors(t *testing.T, rule string, queryStr string) ResultCo
 // ExpectSDLValidationErrors - is a helper to run schema definition validation
 // returns ResultCompare function
 // in reference tests schema is optional but leaves on a first param
-func ExpectSDLValidationErrors(t *testing.T, schema string, rule string, sdlStr string) ResultCompare {
+func ExpectSDLValidationErrors(t *testing.T, schema string, rule string, sdlStr string) (compare ResultCompare) {
 	t.Helper()
 
+	defer func() {
+		if err := recover(); err != nil {
+			compare = failedCompare(t, err)
+		}
+	}()
+
 	def := prepareSchema(sdlStr)
 
 	if schema != "" {
@@ -217,7 +236,13 @@ func BuildSchema(sdl string) string {
 
 // ExpectValidationErrorMessage - is a helper to run operation validation and check single error message
 // returns MessageCompare
-func ExpectValidationErrorMessage(t *testing.T, schema string, queryStr string) MessageCompare {
+func ExpectValidationErrorMessage(t *testing.T, schema string, queryStr string) (compare MessageCompare) {
+	defer func() {
+		if err := recover(); err != nil {
+			compare = failedMessageCompare(t, err)
+		}
+	}()
+
 	op, opReport := astparser.ParseGraphqlDocumentString(queryStr)
 	def := prepareSchema(schema)
 
@@ -234,6 +259,7 @@ func ExpectValidationErrorMessage(t *testing.T, schema string, queryStr string)
 }
 
 // ExtendSchema - helper to extend schema with provided sdl
+//
 //nolint:unused
 func ExtendSchema(schema string, sdlStr string) string {
 	definition := prepareSchema(schema)
@@ -287,7 +313,9 @@ func externalErrors(report operationreport.Report) (out []Err) {
 func compareReportErrors(t *testing.T, report operationreport.Report) ResultCompare {
 	return func(expectedErrors []Err) {
 		actualErrors := externalErrors(report)
-		assert.Equal(t, expectedErrors, actualErrors)
+		checkCase(t, assert.ObjectsAreEqual(expectedErrors, actualErrors), func() {
+			assert.Equal(t, expectedErrors, actualErrors)
+		})
 	}
 }
 
@@ -301,7 +329,16 @@ func hasReportError(t *testing.T, report operationreport.Report) MessageCompare
 			messages = append(messages, actualError.message)
 		}
 
-		assert.Contains(t, messages, msg)
+		found := false
+		for _, message := range messages {
+			if message == msg {
+				found = true
+				break
+			}
+		}
+		checkCase(t, found, func() {
+			assert.Contains(t, messages, msg)
+		})
 	}
 }
 
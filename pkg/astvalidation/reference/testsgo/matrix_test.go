@@ -0,0 +1,219 @@
+package testsgo
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	matrixFile = "matrix.yml"
+
+	matrixHeader = "# pass/fail matrix of the graphql-js reference validation tests\n" +
+		"# generated with: go test ./pkg/astvalidation/reference/testsgo -update-matrix\n" +
+		"# not converted: validation-test.js, it tests the validate function of graphql-js (custom rules, maxErrors) instead of a rule\n"
+
+	KnownFailureSkipMsg  = "known failure, tracked in " + matrixFile
+	UnexpectedPassMsg    = "%s is tracked as failing in " + matrixFile + " but passes, run the tests with -update-matrix"
+	OutdatedMatrixMsg    = matrixFile + " is outdated, run the tests with -update-matrix"
+	UnexpectedFailureMsg = "validation failed: %v"
+)
+
+var updateMatrix = flag.Bool("update-matrix", false, "update the pass/fail matrix of the reference tests")
+
+// RuleResults - summary of the reference test cases of a single rule
+type RuleResults struct {
+	Passed  int      `yaml:"passed"`
+	Failed  int      `yaml:"failed"`
+	Failing []string `yaml:"failing,omitempty"`
+}
+
+// Matrix - pass/fail matrix of the reference test cases by rule name
+// The matrix makes gaps of the validation visible: cases listed as failing are skipped,
+// every other failing case is a regression.
+type Matrix map[string]*RuleResults
+
+// knownFailure - returns true when the test case is listed as failing
+func (m Matrix) knownFailure(rule, testCase string) bool {
+	results, ok := m[rule]
+	if !ok {
+		return false
+	}
+	for _, failing := range results.Failing {
+		if failing == testCase {
+			return true
+		}
+	}
+	return false
+}
+
+// caseResults - records the outcome of the test cases during a test run
+type caseResults struct {
+	mu     sync.Mutex
+	byName map[string]bool
+}
+
+func (c *caseResults) record(name string, passed bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// a test case could contain multiple assertions, it passes only when all of them pass
+	if previous, ok := c.byName[name]; ok {
+		passed = passed && previous
+	}
+	c.byName[name] = passed
+}
+
+func (c *caseResults) matrix() Matrix {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	out := Matrix{}
+	for name, passed := range c.byName {
+		rule, testCase := splitCaseName(name)
+		results, ok := out[rule]
+		if !ok {
+			results = &RuleResults{}
+			out[rule] = results
+		}
+		if passed {
+			results.Passed++
+			continue
+		}
+		results.Failed++
+		results.Failing = append(results.Failing, testCase)
+	}
+
+	for _, results := range out {
+		sort.Strings(results.Failing)
+	}
+
+	return out
+}
+
+var (
+	expectedMatrix = Matrix{}
+	actualResults  = &caseResults{byName: map[string]bool{}}
+)
+
+// splitCaseName - splits the name of a test into the rule name and the name of the test case
+// e.g. TestKnownDirectivesRule/Validate:_Known_directives/with_no_directives
+func splitCaseName(name string) (rule, testCase string) {
+	parts := strings.SplitN(name, "/", 2)
+	rule = strings.TrimPrefix(parts[0], "Test")
+	if len(parts) == 2 {
+		testCase = parts[1]
+	}
+	return
+}
+
+// checkCase - checks the outcome of a test case against the matrix
+// reportFailure is called for failing test cases which are not known to fail
+func checkCase(t *testing.T, passed bool, reportFailure func()) {
+	t.Helper()
+
+	actualResults.record(t.Name(), passed)
+	if *updateMatrix {
+		if !passed {
+			t.Skip(KnownFailureSkipMsg)
+		}
+		return
+	}
+
+	knownFailure := expectedMatrix.knownFailure(splitCaseName(t.Name()))
+	switch {
+	case passed && knownFailure:
+		t.Errorf(UnexpectedPassMsg, t.Name())
+	case !passed && knownFailure:
+		t.Skip(KnownFailureSkipMsg)
+	case !passed:
+		reportFailure()
+	}
+}
+
+// failedCompare - returns ResultCompare for a validation which couldn't be run, e.g. it panicked
+func failedCompare(t *testing.T, err interface{}) ResultCompare {
+	return func(_ []Err) {
+		checkCase(t, false, func() {
+			t.Fatalf(UnexpectedFailureMsg, err)
+		})
+	}
+}
+
+// failedMessageCompare - returns MessageCompare for a validation which couldn't be run
+func failedMessageCompare(t *testing.T, err interface{}) MessageCompare {
+	return func(_ string) {
+		checkCase(t, false, func() {
+			t.Fatalf(UnexpectedFailureMsg, err)
+		})
+	}
+}
+
+func readMatrix() (Matrix, error) {
+	content, err := ioutil.ReadFile(matrixFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Matrix{}, nil
+		}
+		return nil, err
+	}
+
+	matrix := Matrix{}
+	err = yaml.Unmarshal(content, &matrix)
+	return matrix, err
+}
+
+func writeMatrix(matrix Matrix) error {
+	content, err := yaml.Marshal(matrix)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(matrixFile, append([]byte(matrixHeader), content...), 0644)
+}
+
+// runsAllTests - returns true when the test run isn't narrowed down with -run
+func runsAllTests() bool {
+	run := flag.Lookup("test.run")
+	return run == nil || run.Value.String() == ""
+}
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+
+	matrix, err := readMatrix()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	expectedMatrix = matrix
+
+	code := m.Run()
+	if code != 0 || !runsAllTests() {
+		os.Exit(code)
+	}
+
+	actualMatrix := actualResults.matrix()
+	if *updateMatrix {
+		if err := writeMatrix(actualMatrix); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		os.Exit(code)
+	}
+
+	expected, _ := yaml.Marshal(expectedMatrix)
+	actual, _ := yaml.Marshal(actualMatrix)
+	if string(expected) != string(actual) {
+		fmt.Println(OutdatedMatrixMsg)
+		os.Exit(1)
+	}
+
+	os.Exit(code)
+}
@@ -0,0 +1,43 @@
+package astvalidation
+
+import (
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+// FieldsAreOutputTypes validates that fields of object types and interfaces don't return input object types
+func FieldsAreOutputTypes() Rule {
+	return func(walker *astvisitor.Walker) {
+		visitor := &fieldsAreOutputTypesVisitor{
+			Walker: walker,
+		}
+
+		walker.RegisterEnterDocumentVisitor(visitor)
+		walker.RegisterEnterFieldDefinitionVisitor(visitor)
+	}
+}
+
+type fieldsAreOutputTypesVisitor struct {
+	*astvisitor.Walker
+	definition *ast.Document
+}
+
+func (f *fieldsAreOutputTypesVisitor) EnterDocument(operation, _ *ast.Document) {
+	f.definition = operation
+}
+
+func (f *fieldsAreOutputTypesVisitor) EnterFieldDefinition(ref int) {
+	typeName := f.definition.ResolveTypeNameBytes(f.definition.FieldDefinitionType(ref))
+	node, exists := f.definition.Index.FirstNodeByNameBytes(typeName)
+	if !exists || isOutputTypeKind(node.Kind) {
+		return // unknown types are reported by KnownTypeNames
+	}
+
+	coordinate := f.definition.FieldDefinitionNameString(ref)
+	if len(f.Ancestors) != 0 {
+		coordinate = f.definition.NodeNameString(f.Ancestors[len(f.Ancestors)-1]) + "." + coordinate
+	}
+	position := f.definition.Input.ByteSliceReferencePosition(f.definition.FieldDefinitions[ref].Name)
+	f.Report.AddExternalError(operationreport.ErrFieldMustBeOutputType(coordinate, typeName, position))
+}
@@ -0,0 +1,55 @@
+package astvalidation
+
+import (
+	"testing"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/graphqlerrors"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+func TestFieldsAreOutputTypes(t *testing.T) {
+	t.Run("Definition", func(t *testing.T) {
+		t.Run("Fields returning output types are valid", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					type Query {
+						user: User
+						pets: [Pet!]!
+						species: Species
+						name: String
+					}
+					type User { name: String }
+					interface Pet { name: String }
+					enum Species { CAT }
+				`, Valid, FieldsAreOutputTypes(),
+			)
+		})
+
+		t.Run("Fields of unknown types are skipped", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					type Query { user: User }
+				`, Valid, FieldsAreOutputTypes(),
+			)
+		})
+
+		t.Run("Fields returning input object types are invalid", func(t *testing.T) {
+			runDefinitionValidationWithErrors(t, `type Query {
+  user: UserInput
+}
+interface Node {
+  input: [UserInput!]
+}
+input UserInput { name: String }`,
+				[]operationreport.ExternalError{
+					{
+						Message:   "the type of Query.user must be an output type but got: UserInput",
+						Locations: []graphqlerrors.Location{{Line: 2, Column: 3}},
+					},
+					{
+						Message:   "the type of Node.input must be an output type but got: UserInput",
+						Locations: []graphqlerrors.Location{{Line: 5, Column: 3}},
+					},
+				}, FieldsAreOutputTypes(),
+			)
+		})
+	})
+}
@@ -3,6 +3,7 @@ package astvalidation
 import (
 	"github.com/wundergraph/graphql-go-tools/pkg/ast"
 	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/position"
 	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
 )
 
@@ -24,11 +25,13 @@ type implementTransitiveInterfacesVisitor struct {
 	*astvisitor.Walker
 	definition                  *ast.Document
 	typesImplementingInterfaces map[string][]string
+	typePositions               map[string]position.Position
 }
 
 func (v *implementTransitiveInterfacesVisitor) EnterDocument(operation, definition *ast.Document) {
 	v.definition = operation
 	v.typesImplementingInterfaces = map[string][]string{}
+	v.typePositions = map[string]position.Position{}
 }
 
 // LeaveDocument will iterate over the types implementing interfaces lookup map
@@ -56,7 +59,7 @@ func (v *implementTransitiveInterfacesVisitor) LeaveDocument(operation, definiti
 			for j := 0; j < len(v.typesImplementingInterfaces[implementedInterfaceName]); j++ {
 				transitiveInterfaceName := v.typesImplementingInterfaces[implementedInterfaceName][j]
 				if _, ok := interfaceNamesLookupList[transitiveInterfaceName]; !ok {
-					v.Report.AddExternalError(operationreport.ErrTransitiveInterfaceNotImplemented([]byte(typeName), []byte(transitiveInterfaceName)))
+					v.Report.AddExternalError(operationreport.ErrTransitiveInterfaceNotImplemented([]byte(typeName), []byte(transitiveInterfaceName), v.typePositions[typeName]))
 				}
 			}
 		}
@@ -70,7 +73,7 @@ func (v *implementTransitiveInterfacesVisitor) EnterInterfaceTypeDefinition(ref
 	}
 
 	interfaceName := v.definition.InterfaceTypeDefinitionNameString(ref)
-	v.collectImplementedInterfaces(interfaceName, v.definition.InterfaceTypeDefinitions[ref].ImplementsInterfaces.Refs)
+	v.collectImplementedInterfaces(interfaceName, v.definition.InterfaceTypeDefinitions[ref].ImplementsInterfaces.Refs, v.definition.InterfaceTypeDefinitions[ref].Position)
 }
 
 func (v *implementTransitiveInterfacesVisitor) EnterInterfaceTypeExtension(ref int) {
@@ -82,9 +85,9 @@ func (v *implementTransitiveInterfacesVisitor) EnterInterfaceTypeExtension(ref i
 	interfaceName := v.definition.InterfaceTypeExtensionNameString(ref)
 	fieldDefinitionRefs := v.definition.InterfaceTypeExtensions[ref].FieldsDefinition.Refs
 	if len(fieldDefinitionRefs) == 0 {
-		v.Report.AddExternalError(operationreport.ErrTransitiveInterfaceExtensionImplementingWithoutBody([]byte(interfaceName)))
+		v.Report.AddExternalError(operationreport.ErrTransitiveInterfaceExtensionImplementingWithoutBody([]byte(interfaceName), v.definition.InterfaceTypeExtensions[ref].Position))
 	}
-	v.collectImplementedInterfaces(interfaceName, v.definition.InterfaceTypeExtensions[ref].ImplementsInterfaces.Refs)
+	v.collectImplementedInterfaces(interfaceName, v.definition.InterfaceTypeExtensions[ref].ImplementsInterfaces.Refs, v.definition.InterfaceTypeExtensions[ref].Position)
 }
 
 func (v *implementTransitiveInterfacesVisitor) EnterObjectTypeDefinition(ref int) {
@@ -94,7 +97,7 @@ func (v *implementTransitiveInterfacesVisitor) EnterObjectTypeDefinition(ref int
 	}
 
 	objectTypeName := v.definition.ObjectTypeDefinitionNameString(ref)
-	v.collectImplementedInterfaces(objectTypeName, v.definition.ObjectTypeDefinitions[ref].ImplementsInterfaces.Refs)
+	v.collectImplementedInterfaces(objectTypeName, v.definition.ObjectTypeDefinitions[ref].ImplementsInterfaces.Refs, v.definition.ObjectTypeDefinitions[ref].Position)
 }
 
 func (v *implementTransitiveInterfacesVisitor) EnterObjectTypeExtension(ref int) {
@@ -104,7 +107,7 @@ func (v *implementTransitiveInterfacesVisitor) EnterObjectTypeExtension(ref int)
 	}
 
 	objectTypeName := v.definition.ObjectTypeExtensionNameString(ref)
-	v.collectImplementedInterfaces(objectTypeName, v.definition.ObjectTypeExtensions[ref].ImplementsInterfaces.Refs)
+	v.collectImplementedInterfaces(objectTypeName, v.definition.ObjectTypeExtensions[ref].ImplementsInterfaces.Refs, v.definition.ObjectTypeExtensions[ref].Position)
 }
 
 // collectImplementedInterfaces iterates over all implemented interfaces over a given type so that the
@@ -113,7 +116,11 @@ func (v *implementTransitiveInterfacesVisitor) EnterObjectTypeExtension(ref int)
 // Result:
 //      typeName -> [interfaceOne, interfaceBase]
 //      interfaceOne -> [interfaceBase]
-func (v *implementTransitiveInterfacesVisitor) collectImplementedInterfaces(typeName string, implementedInterfacesRefs []int) {
+func (v *implementTransitiveInterfacesVisitor) collectImplementedInterfaces(typeName string, implementedInterfacesRefs []int, typePosition position.Position) {
+	if _, ok := v.typePositions[typeName]; !ok {
+		v.typePositions[typeName] = typePosition
+	}
+
 	for i := 0; i < len(implementedInterfacesRefs); i++ {
 		implementedInterfaceRef := implementedInterfacesRefs[i]
 		implementedInterfaceName := v.definition.TypeNameString(implementedInterfaceRef)
@@ -3,6 +3,7 @@ package astvalidation
 import (
 	"github.com/wundergraph/graphql-go-tools/pkg/ast"
 	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/position"
 	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
 )
 
@@ -25,12 +26,14 @@ type implementingTypesAreSupersetsVisitor struct {
 	definition                           *ast.Document
 	implementingTypesWithFields          map[string][]string
 	implementingTypesWithInterfacesNames map[string][]string
+	implementingTypePositions            map[string]position.Position
 }
 
 func (v *implementingTypesAreSupersetsVisitor) EnterDocument(operation, definition *ast.Document) {
 	v.definition = operation
 	v.implementingTypesWithFields = make(map[string][]string)
 	v.implementingTypesWithInterfacesNames = make(map[string][]string)
+	v.implementingTypePositions = make(map[string]position.Position)
 }
 
 // LeaveDocument will iterate over all types which implement an interface by using the interface name. If a
@@ -78,7 +81,7 @@ func (v *implementingTypesAreSupersetsVisitor) LeaveDocument(operation, definiti
 			}
 
 			if !typeNameHasFields && len(interfaceFieldRefs) > 0 {
-				v.Report.AddExternalError(operationreport.ErrImplementingTypeDoesNotHaveFields([]byte(typeName)))
+				v.Report.AddExternalError(operationreport.ErrImplementingTypeDoesNotHaveFields([]byte(typeName), v.implementingTypePositions[typeName]))
 				continue
 			}
 
@@ -89,6 +92,7 @@ func (v *implementingTypesAreSupersetsVisitor) LeaveDocument(operation, definiti
 						[]byte(typeName),
 						[]byte(interfacesNames[i]),
 						[]byte(interfaceFieldName),
+						v.implementingTypePositions[typeName],
 					))
 				}
 			}
@@ -105,7 +109,7 @@ func (v *implementingTypesAreSupersetsVisitor) EnterInterfaceTypeDefinition(ref
 	typeName := v.definition.InterfaceTypeDefinitionNameString(ref)
 	fieldDefinitionRefs := v.definition.InterfaceTypeDefinitions[ref].FieldsDefinition.Refs
 	v.collectFieldsForTypeName(typeName, fieldDefinitionRefs)
-	v.collectInterfaceNamesForImplementedInterfacesByTypeName(typeName, interfacesRefs)
+	v.collectInterfaceNamesForImplementedInterfacesByTypeName(typeName, interfacesRefs, v.definition.InterfaceTypeDefinitions[ref].Position)
 }
 
 func (v *implementingTypesAreSupersetsVisitor) EnterInterfaceTypeExtension(ref int) {
@@ -136,7 +140,7 @@ func (v *implementingTypesAreSupersetsVisitor) EnterInterfaceTypeExtension(ref i
 	}
 
 	v.collectFieldsForTypeName(typeName, fieldDefinitionRefs)
-	v.collectInterfaceNamesForImplementedInterfacesByTypeName(typeName, interfacesRefs)
+	v.collectInterfaceNamesForImplementedInterfacesByTypeName(typeName, interfacesRefs, v.definition.InterfaceTypeExtensions[ref].Position)
 }
 
 func (v *implementingTypesAreSupersetsVisitor) EnterObjectTypeDefinition(ref int) {
@@ -148,7 +152,7 @@ func (v *implementingTypesAreSupersetsVisitor) EnterObjectTypeDefinition(ref int
 	typeName := v.definition.ObjectTypeDefinitionNameString(ref)
 	fieldDefinitionRefs := v.definition.ObjectTypeDefinitions[ref].FieldsDefinition.Refs
 	v.collectFieldsForTypeName(typeName, fieldDefinitionRefs)
-	v.collectInterfaceNamesForImplementedInterfacesByTypeName(typeName, interfacesRefs)
+	v.collectInterfaceNamesForImplementedInterfacesByTypeName(typeName, interfacesRefs, v.definition.ObjectTypeDefinitions[ref].Position)
 }
 
 func (v *implementingTypesAreSupersetsVisitor) EnterObjectTypeExtension(ref int) {
@@ -179,7 +183,7 @@ func (v *implementingTypesAreSupersetsVisitor) EnterObjectTypeExtension(ref int)
 	}
 
 	v.collectFieldsForTypeName(typeName, fieldDefinitionRefs)
-	v.collectInterfaceNamesForImplementedInterfacesByTypeName(typeName, interfacesRefs)
+	v.collectInterfaceNamesForImplementedInterfacesByTypeName(typeName, interfacesRefs, v.definition.ObjectTypeExtensions[ref].Position)
 }
 
 // collectFieldsForTypeName will add all field names of a type which implements an interface to a slice in a
@@ -218,7 +222,11 @@ func (v *implementingTypesAreSupersetsVisitor) collectFieldsForTypeName(typeName
 // Example:
 // 		interfaceOne -> [interfaceBase]
 //		objectType -> [interfaceOne, interfaceBase]
-func (v *implementingTypesAreSupersetsVisitor) collectInterfaceNamesForImplementedInterfacesByTypeName(typeName string, typeRefs []int) {
+func (v *implementingTypesAreSupersetsVisitor) collectInterfaceNamesForImplementedInterfacesByTypeName(typeName string, typeRefs []int, typePosition position.Position) {
+	if _, ok := v.implementingTypePositions[typeName]; !ok {
+		v.implementingTypePositions[typeName] = typePosition
+	}
+
 	if _, ok := v.implementingTypesWithInterfacesNames[typeName]; !ok {
 		v.implementingTypesWithInterfacesNames[typeName] = []string{}
 	}
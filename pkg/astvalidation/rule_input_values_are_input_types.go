@@ -0,0 +1,41 @@
+package astvalidation
+
+import (
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+// InputValuesAreInputTypes validates that arguments of fields and directives and fields of input objects
+// only use scalars, enums and input objects as type
+func InputValuesAreInputTypes() Rule {
+	return func(walker *astvisitor.Walker) {
+		visitor := &inputValuesAreInputTypesVisitor{
+			Walker: walker,
+		}
+
+		walker.RegisterEnterDocumentVisitor(visitor)
+		walker.RegisterEnterInputValueDefinitionVisitor(visitor)
+	}
+}
+
+type inputValuesAreInputTypesVisitor struct {
+	*astvisitor.Walker
+	definition *ast.Document
+}
+
+func (i *inputValuesAreInputTypesVisitor) EnterDocument(operation, _ *ast.Document) {
+	i.definition = operation
+}
+
+func (i *inputValuesAreInputTypesVisitor) EnterInputValueDefinition(ref int) {
+	typeName := i.definition.ResolveTypeNameBytes(i.definition.InputValueDefinitionType(ref))
+	node, exists := i.definition.Index.FirstNodeByNameBytes(typeName)
+	if !exists || isInputTypeKind(node.Kind) {
+		return // unknown types are reported by KnownTypeNames
+	}
+
+	coordinate := inputValueDefinitionCoordinate(i.Walker, i.definition, ref)
+	position := i.definition.Input.ByteSliceReferencePosition(i.definition.InputValueDefinitions[ref].Name)
+	i.Report.AddExternalError(operationreport.ErrInputValueMustBeInputType(coordinate, typeName, position))
+}
@@ -0,0 +1,50 @@
+package astvalidation
+
+import (
+	"testing"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/graphqlerrors"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+func TestInputValuesAreInputTypes(t *testing.T) {
+	t.Run("Definition", func(t *testing.T) {
+		t.Run("Input values of input types are valid", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					type Query {
+						users(filter: UserFilter, species: [Species!], limit: Int): String
+					}
+					input UserFilter { name: String species: Species nested: UserFilter }
+					enum Species { CAT }
+					directive @limit(max: Int!) on FIELD_DEFINITION
+				`, Valid, InputValuesAreInputTypes(),
+			)
+		})
+
+		t.Run("Arguments, input fields and directive arguments of output types are invalid", func(t *testing.T) {
+			runDefinitionValidationWithErrors(t, `type Query {
+  users(filter: User): String
+}
+type User { name: String }
+input UserFilter {
+  user: [User!]
+}
+directive @limit(user: User) on FIELD_DEFINITION`,
+				[]operationreport.ExternalError{
+					{
+						Message:   "the type of Query.users(filter:) must be an input type but got: User",
+						Locations: []graphqlerrors.Location{{Line: 2, Column: 9}},
+					},
+					{
+						Message:   "the type of UserFilter.user must be an input type but got: User",
+						Locations: []graphqlerrors.Location{{Line: 6, Column: 3}},
+					},
+					{
+						Message:   "the type of @limit(user:) must be an input type but got: User",
+						Locations: []graphqlerrors.Location{{Line: 8, Column: 18}},
+					},
+				}, InputValuesAreInputTypes(),
+			)
+		})
+	})
+}
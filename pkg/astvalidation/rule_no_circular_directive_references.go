@@ -0,0 +1,127 @@
+package astvalidation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+// NoCircularDirectiveReferences validates that directive definitions don't reference themselves,
+// either directly by a directive on an argument or indirectly through other directives or the types of the arguments
+// Each directive definition reports the first cycle which is found.
+func NoCircularDirectiveReferences() Rule {
+	return func(walker *astvisitor.Walker) {
+		visitor := &noCircularDirectiveReferencesVisitor{
+			Walker: walker,
+		}
+
+		walker.RegisterEnterDocumentVisitor(visitor)
+		walker.RegisterEnterDirectiveDefinitionVisitor(visitor)
+	}
+}
+
+type noCircularDirectiveReferencesVisitor struct {
+	*astvisitor.Walker
+	definition    *ast.Document
+	directiveName string
+	visited       map[string]bool
+	// path contains the references from the directive definition where the search started
+	path []string
+}
+
+func (n *noCircularDirectiveReferencesVisitor) EnterDocument(operation, _ *ast.Document) {
+	n.definition = operation
+}
+
+func (n *noCircularDirectiveReferencesVisitor) EnterDirectiveDefinition(ref int) {
+	n.directiveName = n.definition.DirectiveDefinitionNameString(ref)
+	n.visited = map[string]bool{"@" + n.directiveName: true}
+	n.path = n.path[:0]
+
+	if n.referencesItself(n.definition.DirectiveDefinitions[ref].ArgumentsDefinition.Refs, "@"+n.directiveName) {
+		position := n.definition.Input.ByteSliceReferencePosition(n.definition.DirectiveDefinitions[ref].Name)
+		n.Report.AddExternalError(operationreport.ErrDirectiveMustNotReferenceItself(n.definition.DirectiveDefinitionNameBytes(ref), strings.Join(n.path, " -> "), position))
+	}
+}
+
+// referencesItself searches the directives and types of the input values for the directive where the search started
+// parentName is the name of the directive, e.g. "@example", or of the input object the input values belong to.
+func (n *noCircularDirectiveReferencesVisitor) referencesItself(inputValueDefinitions []int, parentName string) bool {
+	for _, inputValueDefinition := range inputValueDefinitions {
+		name := n.definition.InputValueDefinitionNameString(inputValueDefinition)
+		if strings.HasPrefix(parentName, "@") {
+			n.path = append(n.path, fmt.Sprintf("%s(%s:)", parentName, name))
+		} else {
+			n.path = append(n.path, fmt.Sprintf("%s.%s", parentName, name))
+		}
+		if n.directivesReferenceItself(n.definition.InputValueDefinitions[inputValueDefinition].Directives.Refs) {
+			return true
+		}
+		typeName := n.definition.ResolveTypeNameString(n.definition.InputValueDefinitionType(inputValueDefinition))
+		if n.typeReferencesItself(typeName) {
+			return true
+		}
+		n.path = n.path[:len(n.path)-1]
+	}
+	return false
+}
+
+func (n *noCircularDirectiveReferencesVisitor) directivesReferenceItself(directives []int) bool {
+	for _, directive := range directives {
+		directiveName := n.definition.DirectiveNameString(directive)
+		if directiveName == n.directiveName {
+			n.path = append(n.path, "@"+directiveName)
+			return true
+		}
+		if n.visited["@"+directiveName] {
+			continue
+		}
+		n.visited["@"+directiveName] = true
+		directiveDefinition, exists := n.definition.DirectiveDefinitionByName(directiveName)
+		if !exists {
+			continue
+		}
+		if n.referencesItself(n.definition.DirectiveDefinitions[directiveDefinition].ArgumentsDefinition.Refs, "@"+directiveName) {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *noCircularDirectiveReferencesVisitor) typeReferencesItself(typeName string) bool {
+	if n.visited[typeName] {
+		return false
+	}
+	n.visited[typeName] = true
+	node, exists := n.definition.Index.FirstNonExtensionNodeByNameBytes([]byte(typeName))
+	if !exists {
+		return false
+	}
+
+	switch node.Kind {
+	case ast.NodeKindScalarTypeDefinition, ast.NodeKindEnumTypeDefinition, ast.NodeKindInputObjectTypeDefinition:
+		n.path = append(n.path, typeName)
+		if n.directivesReferenceItself(n.definition.NodeDirectives(node)) {
+			return true
+		}
+		n.path = n.path[:len(n.path)-1]
+	}
+	switch node.Kind {
+	case ast.NodeKindEnumTypeDefinition:
+		for _, enumValue := range n.definition.EnumTypeDefinitions[node.Ref].EnumValuesDefinition.Refs {
+			n.path = append(n.path, fmt.Sprintf("%s.%s", typeName, n.definition.EnumValueDefinitionNameString(enumValue)))
+			if n.directivesReferenceItself(n.definition.EnumValueDefinitions[enumValue].Directives.Refs) {
+				return true
+			}
+			n.path = n.path[:len(n.path)-1]
+		}
+	case ast.NodeKindInputObjectTypeDefinition:
+		if n.referencesItself(n.definition.InputObjectTypeDefinitions[node.Ref].InputFieldsDefinition.Refs, typeName) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package astvalidation
+
+import (
+	"testing"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/graphqlerrors"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+func TestNoCircularDirectiveReferences(t *testing.T) {
+	t.Run("Definition", func(t *testing.T) {
+		t.Run("Directives referencing other directives and types are valid", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					type Query { a: String @cached(scope: PUBLIC) }
+					enum Scope { PUBLIC PRIVATE @deprecated }
+					input Options { ttl: Int @length }
+					directive @cached(scope: Scope, options: Options @length) on FIELD_DEFINITION
+					directive @length(max: Int) on ARGUMENT_DEFINITION | INPUT_FIELD_DEFINITION
+				`, Valid, NoCircularDirectiveReferences(),
+			)
+		})
+
+		t.Run("Directives referencing themselves directly or indirectly are invalid", func(t *testing.T) {
+			runDefinitionValidationWithErrors(t, `type Query { a: String }
+directive @self(a: Int @self) on ARGUMENT_DEFINITION
+directive @first(a: Int @second) on ARGUMENT_DEFINITION
+directive @second(b: Options) on ARGUMENT_DEFINITION
+input Options { c: Int @first }`,
+				[]operationreport.ExternalError{
+					{
+						Message:   "directive '@self' cannot reference itself: '@self(a:) -> @self'",
+						Locations: []graphqlerrors.Location{{Line: 2, Column: 12}},
+					},
+					{
+						Message:   "directive '@first' cannot reference itself: '@first(a:) -> @second(b:) -> Options.c -> @first'",
+						Locations: []graphqlerrors.Location{{Line: 3, Column: 12}},
+					},
+					{
+						Message:   "directive '@second' cannot reference itself: '@second(b:) -> Options.c -> @first(a:) -> @second'",
+						Locations: []graphqlerrors.Location{{Line: 4, Column: 12}},
+					},
+				}, NoCircularDirectiveReferences(),
+			)
+		})
+	})
+}
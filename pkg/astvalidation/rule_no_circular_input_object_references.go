@@ -0,0 +1,89 @@
+package astvalidation
+
+import (
+	"strings"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+// NoCircularInputObjectReferences validates that input objects don't reference themselves through non-null fields,
+// such input objects would be impossible to provide as value
+// Each cycle is reported once, lists and nullable fields break cycles.
+func NoCircularInputObjectReferences() Rule {
+	return func(walker *astvisitor.Walker) {
+		visitor := &noCircularInputObjectReferencesVisitor{
+			Walker: walker,
+		}
+
+		walker.RegisterEnterDocumentVisitor(visitor)
+		walker.RegisterEnterInputObjectTypeDefinitionVisitor(visitor)
+	}
+}
+
+type noCircularInputObjectReferencesVisitor struct {
+	*astvisitor.Walker
+	definition *ast.Document
+	visited    map[string]bool
+	// fieldPath contains the input value definitions from the input object where the search started
+	fieldPath []int
+	// fieldPathIndexByTypeName contains the index of the field path at which an input object got entered
+	fieldPathIndexByTypeName map[string]int
+}
+
+func (n *noCircularInputObjectReferencesVisitor) EnterDocument(operation, _ *ast.Document) {
+	n.definition = operation
+	n.visited = map[string]bool{}
+	n.fieldPath = n.fieldPath[:0]
+	n.fieldPathIndexByTypeName = map[string]int{}
+}
+
+func (n *noCircularInputObjectReferencesVisitor) EnterInputObjectTypeDefinition(ref int) {
+	n.detectCycle(ref)
+}
+
+func (n *noCircularInputObjectReferencesVisitor) detectCycle(inputObjectTypeDefinition int) {
+	typeName := n.definition.InputObjectTypeDefinitionNameString(inputObjectTypeDefinition)
+	if n.visited[typeName] {
+		return
+	}
+	n.visited[typeName] = true
+	n.fieldPathIndexByTypeName[typeName] = len(n.fieldPath)
+
+	for _, field := range n.definition.InputObjectTypeDefinitions[inputObjectTypeDefinition].InputFieldsDefinition.Refs {
+		fieldType := n.definition.InputValueDefinitionType(field)
+		if n.definition.Types[fieldType].TypeKind != ast.TypeKindNonNull {
+			continue
+		}
+		namedType := n.definition.Types[fieldType].OfType
+		if n.definition.Types[namedType].TypeKind != ast.TypeKindNamed {
+			continue
+		}
+		node, exists := n.definition.Index.FirstNonExtensionNodeByNameBytes(n.definition.TypeNameBytes(namedType))
+		if !exists || node.Kind != ast.NodeKindInputObjectTypeDefinition {
+			continue
+		}
+
+		n.fieldPath = append(n.fieldPath, field)
+		cycleIndex, isCycle := n.fieldPathIndexByTypeName[n.definition.InputObjectTypeDefinitionNameString(node.Ref)]
+		if isCycle {
+			n.reportCycle(n.fieldPath[cycleIndex:])
+		} else {
+			n.detectCycle(node.Ref)
+		}
+		n.fieldPath = n.fieldPath[:len(n.fieldPath)-1]
+	}
+
+	delete(n.fieldPathIndexByTypeName, typeName)
+}
+
+func (n *noCircularInputObjectReferencesVisitor) reportCycle(cycle []int) {
+	fieldNames := make([]string, 0, len(cycle))
+	for _, field := range cycle {
+		fieldNames = append(fieldNames, n.definition.InputValueDefinitionNameString(field))
+	}
+	typeName := n.definition.ResolveTypeNameBytes(n.definition.InputValueDefinitionType(cycle[len(cycle)-1]))
+	position := n.definition.Input.ByteSliceReferencePosition(n.definition.InputValueDefinitions[cycle[0]].Name)
+	n.Report.AddExternalError(operationreport.ErrInputObjectMustNotReferenceItself(typeName, strings.Join(fieldNames, "."), position))
+}
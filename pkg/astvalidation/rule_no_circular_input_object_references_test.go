@@ -0,0 +1,59 @@
+package astvalidation
+
+import (
+	"testing"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/graphqlerrors"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+func TestNoCircularInputObjectReferences(t *testing.T) {
+	t.Run("Definition", func(t *testing.T) {
+		t.Run("References through nullable fields and lists are valid", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					input Filter {
+						and: Filter
+						or: [Filter!]!
+						not: Negation!
+					}
+					input Negation {
+						filter: Filter
+					}
+				`, Valid, NoCircularInputObjectReferences(),
+			)
+		})
+
+		t.Run("Input object referencing itself through a non-null field is invalid", func(t *testing.T) {
+			runDefinitionValidationWithErrors(t, `input Filter {
+  self: Filter!
+}`,
+				[]operationreport.ExternalError{
+					{
+						Message:   "input object 'Filter' cannot reference itself through a series of non-null fields: 'self'",
+						Locations: []graphqlerrors.Location{{Line: 2, Column: 3}},
+					},
+				}, NoCircularInputObjectReferences(),
+			)
+		})
+
+		t.Run("Cycle through multiple input objects is reported once", func(t *testing.T) {
+			runDefinitionValidationWithErrors(t, `input A {
+  b: B!
+}
+input B {
+  name: String
+  c: C!
+}
+input C {
+  a: A!
+}`,
+				[]operationreport.ExternalError{
+					{
+						Message:   "input object 'A' cannot reference itself through a series of non-null fields: 'b.c.a'",
+						Locations: []graphqlerrors.Location{{Line: 2, Column: 3}},
+					},
+				}, NoCircularInputObjectReferences(),
+			)
+		})
+	})
+}
@@ -37,28 +37,28 @@ func (p *populatedTypeBodiesVisitor) EnterDocument(operation, _ *ast.Document) {
 
 func (p populatedTypeBodiesVisitor) EnterEnumTypeDefinition(ref int) {
 	if !p.definition.EnumTypeDefinitions[ref].HasEnumValuesDefinition {
-		p.Report.AddExternalError(operationreport.ErrTypeBodyMustNotBeEmpty("enum", p.definition.EnumTypeDefinitionNameString(ref)))
+		p.Report.AddExternalError(operationreport.ErrTypeBodyMustNotBeEmpty("enum", p.definition.EnumTypeDefinitionNameString(ref), p.definition.EnumTypeDefinitions[ref].Position))
 		return
 	}
 }
 
 func (p *populatedTypeBodiesVisitor) EnterEnumTypeExtension(ref int) {
 	if !p.definition.EnumTypeExtensions[ref].HasEnumValuesDefinition {
-		p.Report.AddExternalError(operationreport.ErrTypeBodyMustNotBeEmpty("enum extension", p.definition.EnumTypeExtensionNameString(ref)))
+		p.Report.AddExternalError(operationreport.ErrTypeBodyMustNotBeEmpty("enum extension", p.definition.EnumTypeExtensionNameString(ref), p.definition.EnumTypeExtensions[ref].Position))
 		return
 	}
 }
 
 func (p populatedTypeBodiesVisitor) EnterInputObjectTypeDefinition(ref int) {
 	if !p.definition.InputObjectTypeDefinitions[ref].HasInputFieldsDefinition {
-		p.Report.AddExternalError(operationreport.ErrTypeBodyMustNotBeEmpty("input", p.definition.InputObjectTypeDefinitionNameString(ref)))
+		p.Report.AddExternalError(operationreport.ErrTypeBodyMustNotBeEmpty("input", p.definition.InputObjectTypeDefinitionNameString(ref), p.definition.InputObjectTypeDefinitions[ref].Position))
 		return
 	}
 }
 
 func (p *populatedTypeBodiesVisitor) EnterInputObjectTypeExtension(ref int) {
 	if !p.definition.InputObjectTypeExtensions[ref].HasInputFieldsDefinition {
-		p.Report.AddExternalError(operationreport.ErrTypeBodyMustNotBeEmpty("input extension", p.definition.InputObjectTypeExtensionNameString(ref)))
+		p.Report.AddExternalError(operationreport.ErrTypeBodyMustNotBeEmpty("input extension", p.definition.InputObjectTypeExtensionNameString(ref), p.definition.InputObjectTypeExtensions[ref].Position))
 		return
 	}
 }
@@ -71,14 +71,14 @@ func (p populatedTypeBodiesVisitor) EnterInterfaceTypeDefinition(ref int) {
 		}
 		fallthrough
 	case false:
-		p.Report.AddExternalError(operationreport.ErrTypeBodyMustNotBeEmpty("interface", p.definition.InterfaceTypeDefinitionNameString(ref)))
+		p.Report.AddExternalError(operationreport.ErrTypeBodyMustNotBeEmpty("interface", p.definition.InterfaceTypeDefinitionNameString(ref), p.definition.InterfaceTypeDefinitions[ref].Position))
 		return
 	}
 }
 
 func (p *populatedTypeBodiesVisitor) EnterInterfaceTypeExtension(ref int) {
 	if !p.definition.InterfaceTypeExtensions[ref].HasFieldDefinitions {
-		p.Report.AddExternalError(operationreport.ErrTypeBodyMustNotBeEmpty("interface extension", p.definition.InterfaceTypeExtensionNameString(ref)))
+		p.Report.AddExternalError(operationreport.ErrTypeBodyMustNotBeEmpty("interface extension", p.definition.InterfaceTypeExtensionNameString(ref), p.definition.InterfaceTypeExtensions[ref].Position))
 		return
 	}
 }
@@ -93,14 +93,14 @@ func (p populatedTypeBodiesVisitor) EnterObjectTypeDefinition(ref int) {
 		}
 		fallthrough
 	case false:
-		p.Report.AddExternalError(operationreport.ErrTypeBodyMustNotBeEmpty("object", string(nameBytes)))
+		p.Report.AddExternalError(operationreport.ErrTypeBodyMustNotBeEmpty("object", string(nameBytes), object.Position))
 		return
 	}
 }
 
 func (p *populatedTypeBodiesVisitor) EnterObjectTypeExtension(ref int) {
 	if !p.definition.ObjectTypeExtensions[ref].HasFieldDefinitions {
-		p.Report.AddExternalError(operationreport.ErrTypeBodyMustNotBeEmpty("object extension", p.definition.ObjectTypeExtensionNameString(ref)))
+		p.Report.AddExternalError(operationreport.ErrTypeBodyMustNotBeEmpty("object extension", p.definition.ObjectTypeExtensionNameString(ref), p.definition.ObjectTypeExtensions[ref].Position))
 		return
 	}
 }
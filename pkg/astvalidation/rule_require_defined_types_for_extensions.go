@@ -34,42 +34,42 @@ func (r *requireDefinedTypesForExtensionsVisitor) EnterDocument(operation, defin
 func (r *requireDefinedTypesForExtensionsVisitor) EnterScalarTypeExtension(ref int) {
 	name := r.definition.ScalarTypeExtensionNameBytes(ref)
 	if !r.extensionIsValidForNodeKind(name, ast.NodeKindScalarTypeDefinition) {
-		r.Report.AddExternalError(operationreport.ErrScalarTypeUndefined(name))
+		r.Report.AddExternalError(operationreport.ErrScalarTypeUndefined(name, r.definition.ScalarTypeExtensions[ref].Position))
 	}
 }
 
 func (r *requireDefinedTypesForExtensionsVisitor) EnterObjectTypeExtension(ref int) {
 	name := r.definition.ObjectTypeExtensionNameBytes(ref)
 	if !r.extensionIsValidForNodeKind(name, ast.NodeKindObjectTypeDefinition) {
-		r.Report.AddExternalError(operationreport.ErrTypeUndefined(name))
+		r.Report.AddExternalError(operationreport.ErrTypeUndefined(name, r.definition.ObjectTypeExtensions[ref].Position))
 	}
 }
 
 func (r *requireDefinedTypesForExtensionsVisitor) EnterInterfaceTypeExtension(ref int) {
 	name := r.definition.InterfaceTypeExtensionNameBytes(ref)
 	if !r.extensionIsValidForNodeKind(name, ast.NodeKindInterfaceTypeDefinition) {
-		r.Report.AddExternalError(operationreport.ErrInterfaceTypeUndefined(name))
+		r.Report.AddExternalError(operationreport.ErrInterfaceTypeUndefined(name, r.definition.InterfaceTypeExtensions[ref].Position))
 	}
 }
 
 func (r *requireDefinedTypesForExtensionsVisitor) EnterUnionTypeExtension(ref int) {
 	name := r.definition.UnionTypeExtensionNameBytes(ref)
 	if !r.extensionIsValidForNodeKind(name, ast.NodeKindUnionTypeDefinition) {
-		r.Report.AddExternalError(operationreport.ErrUnionTypeUndefined(name))
+		r.Report.AddExternalError(operationreport.ErrUnionTypeUndefined(name, r.definition.UnionTypeExtensions[ref].Position))
 	}
 }
 
 func (r *requireDefinedTypesForExtensionsVisitor) EnterEnumTypeExtension(ref int) {
 	name := r.definition.EnumTypeExtensionNameBytes(ref)
 	if !r.extensionIsValidForNodeKind(name, ast.NodeKindEnumTypeDefinition) {
-		r.Report.AddExternalError(operationreport.ErrEnumTypeUndefined(name))
+		r.Report.AddExternalError(operationreport.ErrEnumTypeUndefined(name, r.definition.EnumTypeExtensions[ref].Position))
 	}
 }
 
 func (r *requireDefinedTypesForExtensionsVisitor) EnterInputObjectTypeExtension(ref int) {
 	name := r.definition.InputObjectTypeExtensionNameBytes(ref)
 	if !r.extensionIsValidForNodeKind(name, ast.NodeKindInputObjectTypeDefinition) {
-		r.Report.AddExternalError(operationreport.ErrInputObjectTypeUndefined(name))
+		r.Report.AddExternalError(operationreport.ErrInputObjectTypeUndefined(name, r.definition.InputObjectTypeExtensions[ref].Position))
 	}
 }
 
@@ -0,0 +1,97 @@
+package astvalidation
+
+import (
+	"bytes"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+// introspectionNames are the types and fields of the introspection system, which are added by the base schema
+var introspectionNames = map[string]struct{}{
+	"__Schema": {}, "__Type": {}, "__TypeKind": {}, "__Field": {}, "__InputValue": {}, "__EnumValue": {},
+	"__Directive": {}, "__DirectiveLocation": {}, "__schema": {}, "__type": {}, "__typename": {},
+}
+
+// ReservedNames validates that types, fields, arguments, enum values and directives don't use names with the reserved prefix "__"
+// The types and fields of the introspection system are allowed.
+func ReservedNames() Rule {
+	return func(walker *astvisitor.Walker) {
+		visitor := &reservedNamesVisitor{
+			Walker: walker,
+		}
+
+		walker.RegisterEnterDocumentVisitor(visitor)
+		walker.RegisterEnterObjectTypeDefinitionVisitor(visitor)
+		walker.RegisterEnterInterfaceTypeDefinitionVisitor(visitor)
+		walker.RegisterEnterUnionTypeDefinitionVisitor(visitor)
+		walker.RegisterEnterEnumTypeDefinitionVisitor(visitor)
+		walker.RegisterEnterInputObjectTypeDefinitionVisitor(visitor)
+		walker.RegisterEnterScalarTypeDefinitionVisitor(visitor)
+		walker.RegisterEnterDirectiveDefinitionVisitor(visitor)
+		walker.RegisterEnterFieldDefinitionVisitor(visitor)
+		walker.RegisterEnterInputValueDefinitionVisitor(visitor)
+		walker.RegisterEnterEnumValueDefinitionVisitor(visitor)
+	}
+}
+
+type reservedNamesVisitor struct {
+	*astvisitor.Walker
+	definition *ast.Document
+}
+
+func (r *reservedNamesVisitor) EnterDocument(operation, _ *ast.Document) {
+	r.definition = operation
+}
+
+func (r *reservedNamesVisitor) EnterObjectTypeDefinition(ref int) {
+	r.checkName(r.definition.ObjectTypeDefinitions[ref].Name)
+}
+
+func (r *reservedNamesVisitor) EnterInterfaceTypeDefinition(ref int) {
+	r.checkName(r.definition.InterfaceTypeDefinitions[ref].Name)
+}
+
+func (r *reservedNamesVisitor) EnterUnionTypeDefinition(ref int) {
+	r.checkName(r.definition.UnionTypeDefinitions[ref].Name)
+}
+
+func (r *reservedNamesVisitor) EnterEnumTypeDefinition(ref int) {
+	r.checkName(r.definition.EnumTypeDefinitions[ref].Name)
+}
+
+func (r *reservedNamesVisitor) EnterInputObjectTypeDefinition(ref int) {
+	r.checkName(r.definition.InputObjectTypeDefinitions[ref].Name)
+}
+
+func (r *reservedNamesVisitor) EnterScalarTypeDefinition(ref int) {
+	r.checkName(r.definition.ScalarTypeDefinitions[ref].Name)
+}
+
+func (r *reservedNamesVisitor) EnterDirectiveDefinition(ref int) {
+	r.checkName(r.definition.DirectiveDefinitions[ref].Name)
+}
+
+func (r *reservedNamesVisitor) EnterFieldDefinition(ref int) {
+	r.checkName(r.definition.FieldDefinitions[ref].Name)
+}
+
+func (r *reservedNamesVisitor) EnterInputValueDefinition(ref int) {
+	r.checkName(r.definition.InputValueDefinitions[ref].Name)
+}
+
+func (r *reservedNamesVisitor) EnterEnumValueDefinition(ref int) {
+	r.checkName(r.definition.EnumValueDefinitions[ref].EnumValue)
+}
+
+func (r *reservedNamesVisitor) checkName(nameRef ast.ByteSliceReference) {
+	name := r.definition.Input.ByteSlice(nameRef)
+	if !bytes.HasPrefix(name, reservedFieldPrefix) {
+		return
+	}
+	if _, ok := introspectionNames[string(name)]; ok {
+		return
+	}
+	r.Report.AddExternalError(operationreport.ErrNameIsReserved(name, r.definition.Input.ByteSliceReferencePosition(nameRef)))
+}
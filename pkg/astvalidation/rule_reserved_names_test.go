@@ -0,0 +1,60 @@
+package astvalidation
+
+import (
+	"testing"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/graphqlerrors"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+func TestReservedNames(t *testing.T) {
+	t.Run("Definition", func(t *testing.T) {
+		t.Run("Introspection types and fields of the base schema are valid", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					type Query { user(id: ID): User }
+					type User { name: String }
+				`, Valid, ReservedNames(),
+			)
+		})
+
+		t.Run("Names with reserved prefix are invalid", func(t *testing.T) {
+			runDefinitionValidationWithErrors(t, `type __Query {
+  __field(__arg: String): String
+}
+enum Species { __CAT }
+input __Filter { __name: String }
+directive @__cached on FIELD_DEFINITION`,
+				[]operationreport.ExternalError{
+					{
+						Message:   "name '__Query' must not begin with '__', which is reserved by GraphQL introspection",
+						Locations: []graphqlerrors.Location{{Line: 1, Column: 6}},
+					},
+					{
+						Message:   "name '__field' must not begin with '__', which is reserved by GraphQL introspection",
+						Locations: []graphqlerrors.Location{{Line: 2, Column: 3}},
+					},
+					{
+						Message:   "name '__arg' must not begin with '__', which is reserved by GraphQL introspection",
+						Locations: []graphqlerrors.Location{{Line: 2, Column: 11}},
+					},
+					{
+						Message:   "name '__CAT' must not begin with '__', which is reserved by GraphQL introspection",
+						Locations: []graphqlerrors.Location{{Line: 4, Column: 16}},
+					},
+					{
+						Message:   "name '__Filter' must not begin with '__', which is reserved by GraphQL introspection",
+						Locations: []graphqlerrors.Location{{Line: 5, Column: 7}},
+					},
+					{
+						Message:   "name '__name' must not begin with '__', which is reserved by GraphQL introspection",
+						Locations: []graphqlerrors.Location{{Line: 5, Column: 18}},
+					},
+					{
+						Message:   "name '__cached' must not begin with '__', which is reserved by GraphQL introspection",
+						Locations: []graphqlerrors.Location{{Line: 6, Column: 12}},
+					},
+				}, ReservedNames(),
+			)
+		})
+	})
+}
@@ -0,0 +1,54 @@
+package astvalidation
+
+import (
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/literal"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+// RootOperationTypesAreObjectTypes validates that the query, mutation and subscription root types of the schema are object types
+func RootOperationTypesAreObjectTypes() Rule {
+	return func(walker *astvisitor.Walker) {
+		visitor := &rootOperationTypesAreObjectTypesVisitor{
+			Walker: walker,
+		}
+
+		walker.RegisterEnterDocumentVisitor(visitor)
+		walker.RegisterEnterRootOperationTypeDefinitionVisitor(visitor)
+	}
+}
+
+type rootOperationTypesAreObjectTypesVisitor struct {
+	*astvisitor.Walker
+	definition *ast.Document
+}
+
+func (r *rootOperationTypesAreObjectTypesVisitor) EnterDocument(operation, _ *ast.Document) {
+	r.definition = operation
+}
+
+func (r *rootOperationTypesAreObjectTypesVisitor) EnterRootOperationTypeDefinition(ref int) {
+	namedType := r.definition.RootOperationTypeDefinitions[ref].NamedType
+	typeName := r.definition.Input.ByteSlice(namedType.Name)
+	node, exists := r.definition.Index.FirstNodeByNameBytes(typeName)
+	if !exists {
+		return // unknown types are reported by KnownTypeNames
+	}
+	switch node.Kind {
+	case ast.NodeKindObjectTypeDefinition, ast.NodeKindObjectTypeExtension:
+		return
+	}
+
+	var operationType ast.ByteSlice
+	switch r.definition.RootOperationTypeDefinitions[ref].OperationType {
+	case ast.OperationTypeQuery:
+		operationType = literal.QUERY
+	case ast.OperationTypeMutation:
+		operationType = literal.MUTATION
+	case ast.OperationTypeSubscription:
+		operationType = literal.SUBSCRIPTION
+	}
+	position := r.definition.Input.ByteSliceReferencePosition(namedType.Name)
+	r.Report.AddExternalError(operationreport.ErrRootOperationTypeMustBeObjectType(operationType, typeName, position))
+}
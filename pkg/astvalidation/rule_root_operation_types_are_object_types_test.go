@@ -0,0 +1,42 @@
+package astvalidation
+
+import (
+	"testing"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/graphqlerrors"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+func TestRootOperationTypesAreObjectTypes(t *testing.T) {
+	t.Run("Definition", func(t *testing.T) {
+		t.Run("Object root types are valid", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					schema { query: Root mutation: Mutation }
+					type Root { a: String }
+					type Mutation { b: String }
+				`, Valid, RootOperationTypesAreObjectTypes(),
+			)
+		})
+
+		t.Run("Root types which are not object types are invalid", func(t *testing.T) {
+			runDefinitionValidationWithErrors(t, `schema {
+  query: Query
+  mutation: Mutation
+  subscription: String
+}
+type Query { a: String }
+input Mutation { b: String }`,
+				[]operationreport.ExternalError{
+					{
+						Message:   "the mutation root type must be an object type but got: Mutation",
+						Locations: []graphqlerrors.Location{{Line: 3, Column: 13}},
+					},
+					{
+						Message:   "the subscription root type must be an object type but got: String",
+						Locations: []graphqlerrors.Location{{Line: 4, Column: 17}},
+					},
+				}, RootOperationTypesAreObjectTypes(),
+			)
+		})
+	})
+}
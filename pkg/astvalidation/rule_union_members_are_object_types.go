@@ -0,0 +1,44 @@
+package astvalidation
+
+import (
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+// UnionMembersAreObjectTypes validates that unions only include object types
+func UnionMembersAreObjectTypes() Rule {
+	return func(walker *astvisitor.Walker) {
+		visitor := &unionMembersAreObjectTypesVisitor{
+			Walker: walker,
+		}
+
+		walker.RegisterEnterDocumentVisitor(visitor)
+		walker.RegisterEnterUnionMemberTypeVisitor(visitor)
+	}
+}
+
+type unionMembersAreObjectTypesVisitor struct {
+	*astvisitor.Walker
+	definition *ast.Document
+}
+
+func (u *unionMembersAreObjectTypesVisitor) EnterDocument(operation, _ *ast.Document) {
+	u.definition = operation
+}
+
+func (u *unionMembersAreObjectTypesVisitor) EnterUnionMemberType(ref int) {
+	memberName := u.definition.TypeNameBytes(ref)
+	node, exists := u.definition.Index.FirstNodeByNameBytes(memberName)
+	if !exists {
+		return // unknown types are reported by KnownTypeNames
+	}
+	switch node.Kind {
+	case ast.NodeKindObjectTypeDefinition, ast.NodeKindObjectTypeExtension:
+		return
+	}
+
+	unionName := u.definition.NodeNameBytes(u.Ancestors[len(u.Ancestors)-1])
+	position := u.definition.Input.ByteSliceReferencePosition(u.definition.Types[ref].Name)
+	u.Report.AddExternalError(operationreport.ErrUnionMemberMustBeObjectType(unionName, memberName, position))
+}
@@ -0,0 +1,46 @@
+package astvalidation
+
+import (
+	"testing"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/graphqlerrors"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+func TestUnionMembersAreObjectTypes(t *testing.T) {
+	t.Run("Definition", func(t *testing.T) {
+		t.Run("Union of object types is valid", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					union Pet = Cat | Dog
+					extend union Pet = Bird
+					type Cat { name: String }
+					type Dog { name: String }
+					type Bird { name: String }
+				`, Valid, UnionMembersAreObjectTypes(),
+			)
+		})
+
+		t.Run("Union with interfaces, scalars or other unions is invalid", func(t *testing.T) {
+			runDefinitionValidationWithErrors(t, `union Pet = Cat | Named
+extend union Pet = String | Animal
+type Cat { name: String }
+interface Named { name: String }
+union Animal = Cat`,
+				[]operationreport.ExternalError{
+					{
+						Message:   "union 'Pet' can only include object types, it cannot include 'Named'",
+						Locations: []graphqlerrors.Location{{Line: 1, Column: 19}},
+					},
+					{
+						Message:   "union 'Pet' can only include object types, it cannot include 'String'",
+						Locations: []graphqlerrors.Location{{Line: 2, Column: 20}},
+					},
+					{
+						Message:   "union 'Pet' can only include object types, it cannot include 'Animal'",
+						Locations: []graphqlerrors.Location{{Line: 2, Column: 29}},
+					},
+				}, UnionMembersAreObjectTypes(),
+			)
+		})
+	})
+}
@@ -0,0 +1,60 @@
+package astvalidation
+
+import (
+	"bytes"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+// UniqueArgumentDefinitionNames validates that the arguments of a field or directive definition have unique names
+func UniqueArgumentDefinitionNames() Rule {
+	return func(walker *astvisitor.Walker) {
+		visitor := &uniqueArgumentDefinitionNamesVisitor{
+			Walker: walker,
+		}
+
+		walker.RegisterEnterDocumentVisitor(visitor)
+		walker.RegisterEnterInputValueDefinitionVisitor(visitor)
+	}
+}
+
+type uniqueArgumentDefinitionNamesVisitor struct {
+	*astvisitor.Walker
+	definition *ast.Document
+}
+
+func (u *uniqueArgumentDefinitionNamesVisitor) EnterDocument(operation, _ *ast.Document) {
+	u.definition = operation
+}
+
+func (u *uniqueArgumentDefinitionNamesVisitor) EnterInputValueDefinition(ref int) {
+	if len(u.Ancestors) == 0 {
+		return
+	}
+
+	var arguments []int
+	parent := u.Ancestors[len(u.Ancestors)-1]
+	switch parent.Kind {
+	case ast.NodeKindFieldDefinition:
+		arguments = u.definition.FieldDefinitions[parent.Ref].ArgumentsDefinition.Refs
+	case ast.NodeKindDirectiveDefinition:
+		arguments = u.definition.DirectiveDefinitions[parent.Ref].ArgumentsDefinition.Refs
+	default:
+		return // input fields are validated by UniqueFieldDefinitionNames
+	}
+
+	argumentName := u.definition.InputValueDefinitionNameBytes(ref)
+	for _, argument := range arguments {
+		if argument == ref {
+			return
+		}
+		if !bytes.Equal(argumentName, u.definition.InputValueDefinitionNameBytes(argument)) {
+			continue
+		}
+		position := u.definition.Input.ByteSliceReferencePosition(u.definition.InputValueDefinitions[ref].Name)
+		u.Report.AddExternalError(operationreport.ErrArgumentDefinitionMustBeUnique(inputValueDefinitionCoordinate(u.Walker, u.definition, ref), position))
+		return
+	}
+}
@@ -0,0 +1,39 @@
+package astvalidation
+
+import (
+	"testing"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/graphqlerrors"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+func TestUniqueArgumentDefinitionNames(t *testing.T) {
+	t.Run("Definition", func(t *testing.T) {
+		t.Run("Arguments with different names are valid", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					type Query { users(first: Int, after: String): [String] }
+					extend type Query { user(id: ID): String }
+					directive @cached(ttl: Int, scope: String) on FIELD_DEFINITION
+				`, Valid, UniqueArgumentDefinitionNames(),
+			)
+		})
+
+		t.Run("Arguments defined twice on a field or directive are invalid", func(t *testing.T) {
+			runDefinitionValidationWithErrors(t, `type Query {
+  users(first: Int, first: String): [String]
+}
+directive @cached(ttl: Int, ttl: Int) on FIELD_DEFINITION`,
+				[]operationreport.ExternalError{
+					{
+						Message:   "argument 'Query.users(first:)' can only be defined once",
+						Locations: []graphqlerrors.Location{{Line: 2, Column: 21}},
+					},
+					{
+						Message:   "argument '@cached(ttl:)' can only be defined once",
+						Locations: []graphqlerrors.Location{{Line: 4, Column: 29}},
+					},
+				}, UniqueArgumentDefinitionNames(),
+			)
+		})
+	})
+}
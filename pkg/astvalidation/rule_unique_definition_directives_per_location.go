@@ -0,0 +1,80 @@
+package astvalidation
+
+import (
+	"bytes"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/literal"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+// UniqueDefinitionDirectivesPerLocation validates that directives which are not repeatable are used at most once per schema location
+// A type and its extensions are the same location, e.g. a directive used on a type mustn't be used again on an extension of the type.
+// Directives without definition are skipped, e.g. federation directives.
+func UniqueDefinitionDirectivesPerLocation() Rule {
+	return func(walker *astvisitor.Walker) {
+		visitor := &uniqueDefinitionDirectivesPerLocationVisitor{
+			Walker: walker,
+		}
+
+		walker.RegisterEnterDocumentVisitor(visitor)
+		walker.RegisterEnterDirectiveVisitor(visitor)
+	}
+}
+
+type uniqueDefinitionDirectivesPerLocationVisitor struct {
+	*astvisitor.Walker
+	definition *ast.Document
+	// typeDirectives are the directives used on each type, including its extensions, by type name
+	typeDirectives map[string][]ast.ByteSlice
+}
+
+func (u *uniqueDefinitionDirectivesPerLocationVisitor) EnterDocument(operation, _ *ast.Document) {
+	u.definition = operation
+	u.typeDirectives = map[string][]ast.ByteSlice{}
+}
+
+func (u *uniqueDefinitionDirectivesPerLocationVisitor) EnterDirective(ref int) {
+	if len(u.Ancestors) == 0 {
+		return
+	}
+	directiveName := u.definition.DirectiveNameBytes(ref)
+	directiveDefinition, exists := u.definition.DirectiveDefinitionByName(string(directiveName))
+	if !exists || u.definition.DirectiveDefinitions[directiveDefinition].Repeatable.IsRepeatable {
+		return
+	}
+
+	ancestor := u.Ancestors[len(u.Ancestors)-1]
+	switch ancestor.Kind {
+	case ast.NodeKindSchemaDefinition, ast.NodeKindSchemaExtension:
+		u.checkTypeDirective(string(literal.SCHEMA), directiveName, ref)
+	case ast.NodeKindObjectTypeDefinition, ast.NodeKindObjectTypeExtension,
+		ast.NodeKindInterfaceTypeDefinition, ast.NodeKindInterfaceTypeExtension,
+		ast.NodeKindInputObjectTypeDefinition, ast.NodeKindInputObjectTypeExtension,
+		ast.NodeKindScalarTypeDefinition, ast.NodeKindScalarTypeExtension,
+		ast.NodeKindUnionTypeDefinition, ast.NodeKindUnionTypeExtension,
+		ast.NodeKindEnumTypeDefinition, ast.NodeKindEnumTypeExtension:
+		u.checkTypeDirective(u.definition.NodeNameString(ancestor), directiveName, ref)
+	case ast.NodeKindFieldDefinition, ast.NodeKindInputValueDefinition, ast.NodeKindEnumValueDefinition:
+		for _, directive := range u.definition.NodeDirectives(ancestor) {
+			if directive == ref {
+				return
+			}
+			if bytes.Equal(directiveName, u.definition.DirectiveNameBytes(directive)) {
+				u.Report.AddExternalError(operationreport.ErrDirectiveMustBeUniquePerLocation(directiveName, u.definition.Directives[ref].At))
+				return
+			}
+		}
+	}
+}
+
+func (u *uniqueDefinitionDirectivesPerLocationVisitor) checkTypeDirective(typeName string, directiveName ast.ByteSlice, ref int) {
+	for _, used := range u.typeDirectives[typeName] {
+		if bytes.Equal(used, directiveName) {
+			u.Report.AddExternalError(operationreport.ErrDirectiveMustBeUniquePerLocation(directiveName, u.definition.Directives[ref].At))
+			return
+		}
+	}
+	u.typeDirectives[typeName] = append(u.typeDirectives[typeName], directiveName)
+}
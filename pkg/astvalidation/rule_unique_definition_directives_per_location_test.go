@@ -0,0 +1,58 @@
+package astvalidation
+
+import (
+	"testing"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/graphqlerrors"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+func TestUniqueDefinitionDirectivesPerLocation(t *testing.T) {
+	t.Run("Definition", func(t *testing.T) {
+		t.Run("Directives used once per location or repeatable directives are valid", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					type Query @cached @tag(name: "a") @tag(name: "b") {
+						a(id: ID @length): String @cached @deprecated
+						b: String @cached
+					}
+					extend type Query @auth { c: String }
+					enum Scope { PUBLIC @deprecated PRIVATE @deprecated }
+					type User @key(fields: "id") @key(fields: "name") { id: ID name: String }
+					directive @cached on OBJECT | FIELD_DEFINITION
+					directive @auth on OBJECT
+					directive @length on ARGUMENT_DEFINITION
+					directive @tag(name: String!) repeatable on OBJECT
+				`, Valid, UniqueDefinitionDirectivesPerLocation(),
+			)
+		})
+
+		t.Run("Directives which are not repeatable used twice per location are invalid", func(t *testing.T) {
+			runDefinitionValidationWithErrors(t, `type Query @cached {
+  a(id: ID @length @length): String @cached @deprecated @cached
+}
+extend type Query @cached
+enum Scope { PUBLIC @deprecated @deprecated }
+directive @cached on OBJECT | FIELD_DEFINITION
+directive @length on ARGUMENT_DEFINITION`,
+				[]operationreport.ExternalError{
+					{
+						Message:   "directive: length must be unique per location",
+						Locations: []graphqlerrors.Location{{Line: 2, Column: 20}},
+					},
+					{
+						Message:   "directive: cached must be unique per location",
+						Locations: []graphqlerrors.Location{{Line: 2, Column: 57}},
+					},
+					{
+						Message:   "directive: cached must be unique per location",
+						Locations: []graphqlerrors.Location{{Line: 4, Column: 19}},
+					},
+					{
+						Message:   "directive: deprecated must be unique per location",
+						Locations: []graphqlerrors.Location{{Line: 5, Column: 33}},
+					},
+				}, UniqueDefinitionDirectivesPerLocation(),
+			)
+		})
+	})
+}
@@ -0,0 +1,43 @@
+package astvalidation
+
+import (
+	"github.com/cespare/xxhash/v2"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+// UniqueDirectiveNames validates that each directive is defined only once
+func UniqueDirectiveNames() Rule {
+	return func(walker *astvisitor.Walker) {
+		visitor := &uniqueDirectiveNamesVisitor{
+			Walker: walker,
+		}
+
+		walker.RegisterEnterDocumentVisitor(visitor)
+		walker.RegisterEnterDirectiveDefinitionVisitor(visitor)
+	}
+}
+
+type uniqueDirectiveNamesVisitor struct {
+	*astvisitor.Walker
+	definition     *ast.Document
+	usedDirectives map[uint64]bool
+}
+
+func (u *uniqueDirectiveNamesVisitor) EnterDocument(operation, _ *ast.Document) {
+	u.definition = operation
+	u.usedDirectives = make(map[uint64]bool)
+}
+
+func (u *uniqueDirectiveNamesVisitor) EnterDirectiveDefinition(ref int) {
+	directiveName := u.definition.DirectiveDefinitionNameBytes(ref)
+	hash := xxhash.Sum64(directiveName)
+	if u.usedDirectives[hash] {
+		position := u.definition.Input.ByteSliceReferencePosition(u.definition.DirectiveDefinitions[ref].Name)
+		u.Report.AddExternalError(operationreport.ErrDirectiveNameMustBeUnique(directiveName, position))
+		return
+	}
+	u.usedDirectives[hash] = true
+}
@@ -0,0 +1,34 @@
+package astvalidation
+
+import (
+	"testing"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/graphqlerrors"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+func TestUniqueDirectiveNames(t *testing.T) {
+	t.Run("Definition", func(t *testing.T) {
+		t.Run("Directives with different names are valid", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					type Query { a: String @cached @auth }
+					directive @cached on FIELD_DEFINITION
+					directive @auth on FIELD_DEFINITION
+				`, Valid, UniqueDirectiveNames(),
+			)
+		})
+
+		t.Run("Directive defined twice is invalid", func(t *testing.T) {
+			runDefinitionValidationWithErrors(t, `type Query { a: String }
+directive @cached on FIELD_DEFINITION
+directive @cached(ttl: Int) on OBJECT`,
+				[]operationreport.ExternalError{
+					{
+						Message:   "there can be only one directive named '@cached'",
+						Locations: []graphqlerrors.Location{{Line: 3, Column: 12}},
+					},
+				}, UniqueDirectiveNames(),
+			)
+		})
+	})
+}
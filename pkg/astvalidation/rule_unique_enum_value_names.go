@@ -5,6 +5,7 @@ import (
 
 	"github.com/wundergraph/graphql-go-tools/pkg/ast"
 	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/position"
 	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
 )
 
@@ -40,7 +41,7 @@ func (u *uniqueEnumValueNamesVisitor) EnterDocument(operation, definition *ast.D
 
 func (u *uniqueEnumValueNamesVisitor) EnterEnumValueDefinition(ref int) {
 	enumValueName := u.definition.EnumValueDefinitionNameBytes(ref)
-	u.checkEnumValueName(enumValueName)
+	u.checkEnumValueName(enumValueName, u.definition.EnumValueDefinitions[ref].Position)
 }
 
 func (u *uniqueEnumValueNamesVisitor) EnterEnumTypeDefinition(ref int) {
@@ -71,7 +72,7 @@ func (u *uniqueEnumValueNamesVisitor) unsetCurrentEnum() {
 	u.currentEnumHash = 0
 }
 
-func (u *uniqueEnumValueNamesVisitor) checkEnumValueName(enumValueName ast.ByteSlice) {
+func (u *uniqueEnumValueNamesVisitor) checkEnumValueName(enumValueName ast.ByteSlice, position position.Position) {
 	if len(u.currentEnumName) == 0 || u.currentEnumHash == 0 {
 		return
 	}
@@ -83,7 +84,7 @@ func (u *uniqueEnumValueNamesVisitor) checkEnumValueName(enumValueName ast.ByteS
 	}
 
 	if enumValueNames[enumValueNameHash] {
-		u.Report.AddExternalError(operationreport.ErrEnumValueNameMustBeUnique(u.currentEnumName, enumValueName))
+		u.Report.AddExternalError(operationreport.ErrEnumValueNameMustBeUnique(u.currentEnumName, enumValueName, position))
 		return
 	}
 
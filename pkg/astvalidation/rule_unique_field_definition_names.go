@@ -7,6 +7,7 @@ import (
 
 	"github.com/wundergraph/graphql-go-tools/pkg/ast"
 	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/position"
 	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
 )
 
@@ -49,7 +50,7 @@ func (u *uniqueFieldDefinitionNamesVisitor) EnterDocument(operation, _ *ast.Docu
 
 func (u *uniqueFieldDefinitionNamesVisitor) EnterFieldDefinition(ref int) {
 	fieldName := u.definition.FieldDefinitionNameBytes(ref)
-	u.checkField(fieldName)
+	u.checkField(fieldName, u.definition.FieldDefinitions[ref].Position)
 }
 
 func (u *uniqueFieldDefinitionNamesVisitor) EnterInputValueDefinition(ref int) {
@@ -58,7 +59,7 @@ func (u *uniqueFieldDefinitionNamesVisitor) EnterInputValueDefinition(ref int) {
 	}
 
 	name := u.definition.InputValueDefinitionNameBytes(ref)
-	u.checkField(name)
+	u.checkField(name, u.definition.InputValueDefinitions[ref].Position)
 }
 
 func (u *uniqueFieldDefinitionNamesVisitor) EnterObjectTypeDefinition(ref int) {
@@ -131,7 +132,7 @@ func (u *uniqueFieldDefinitionNamesVisitor) unsetCurrentTypeName() {
 	u.currentTypeKind = ast.NodeKindUnknown
 }
 
-func (u *uniqueFieldDefinitionNamesVisitor) checkField(fieldName ast.ByteSlice) {
+func (u *uniqueFieldDefinitionNamesVisitor) checkField(fieldName ast.ByteSlice, position position.Position) {
 	if bytes.HasPrefix(fieldName, reservedFieldPrefix) { // don't validate graphql reserved fields
 		return
 	}
@@ -146,7 +147,7 @@ func (u *uniqueFieldDefinitionNamesVisitor) checkField(fieldName ast.ByteSlice)
 	}
 
 	if fieldNames[xxhash.Sum64(fieldName)] {
-		u.Report.AddExternalError(operationreport.ErrFieldNameMustBeUniqueOnType(fieldName, u.currentTypeName))
+		u.Report.AddExternalError(operationreport.ErrFieldNameMustBeUniqueOnType(fieldName, u.currentTypeName, position))
 		return
 	}
 
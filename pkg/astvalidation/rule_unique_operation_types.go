@@ -37,17 +37,17 @@ func (u *uniqueOperationTypesVisitor) EnterRootOperationTypeDefinition(ref int)
 	switch operationType {
 	case ast.OperationTypeQuery:
 		if u.queryIsDefined {
-			u.Report.AddExternalError(operationreport.ErrOnlyOneQueryTypeAllowed())
+			u.Report.AddExternalError(operationreport.ErrOnlyOneQueryTypeAllowed(u.definition.RootOperationTypeDefinitions[ref].Position))
 		}
 		u.queryIsDefined = true
 	case ast.OperationTypeMutation:
 		if u.mutationIsDefined {
-			u.Report.AddExternalError(operationreport.ErrOnlyOneMutationTypeAllowed())
+			u.Report.AddExternalError(operationreport.ErrOnlyOneMutationTypeAllowed(u.definition.RootOperationTypeDefinitions[ref].Position))
 		}
 		u.mutationIsDefined = true
 	case ast.OperationTypeSubscription:
 		if u.subscriptionIsDefined {
-			u.Report.AddExternalError(operationreport.ErrOnlyOneSubscriptionTypeAllowed())
+			u.Report.AddExternalError(operationreport.ErrOnlyOneSubscriptionTypeAllowed(u.definition.RootOperationTypeDefinitions[ref].Position))
 		}
 		u.subscriptionIsDefined = true
 	}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/wundergraph/graphql-go-tools/pkg/ast"
 	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/position"
 	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
 )
 
@@ -37,38 +38,38 @@ func (u *uniqueTypeNamesVisitor) EnterDocument(operation, definition *ast.Docume
 
 func (u *uniqueTypeNamesVisitor) EnterObjectTypeDefinition(ref int) {
 	typeName := u.definition.ObjectTypeDefinitionNameBytes(ref)
-	u.checkTypeName(typeName)
+	u.checkTypeName(typeName, u.definition.ObjectTypeDefinitions[ref].Position)
 }
 
 func (u *uniqueTypeNamesVisitor) EnterScalarTypeDefinition(ref int) {
 	typeName := u.definition.ScalarTypeDefinitionNameBytes(ref)
-	u.checkTypeName(typeName)
+	u.checkTypeName(typeName, u.definition.ScalarTypeDefinitions[ref].Position)
 }
 
 func (u *uniqueTypeNamesVisitor) EnterInterfaceTypeDefinition(ref int) {
 	typeName := u.definition.InterfaceTypeDefinitionNameBytes(ref)
-	u.checkTypeName(typeName)
+	u.checkTypeName(typeName, u.definition.InterfaceTypeDefinitions[ref].Position)
 }
 
 func (u *uniqueTypeNamesVisitor) EnterUnionTypeDefinition(ref int) {
 	typeName := u.definition.UnionTypeDefinitionNameBytes(ref)
-	u.checkTypeName(typeName)
+	u.checkTypeName(typeName, u.definition.UnionTypeDefinitions[ref].Position)
 }
 
 func (u *uniqueTypeNamesVisitor) EnterEnumTypeDefinition(ref int) {
 	typeName := u.definition.EnumTypeDefinitionNameBytes(ref)
-	u.checkTypeName(typeName)
+	u.checkTypeName(typeName, u.definition.EnumTypeDefinitions[ref].Position)
 }
 
 func (u *uniqueTypeNamesVisitor) EnterInputObjectTypeDefinition(ref int) {
 	typeName := u.definition.InputObjectTypeDefinitionNameBytes(ref)
-	u.checkTypeName(typeName)
+	u.checkTypeName(typeName, u.definition.InputObjectTypeDefinitions[ref].Position)
 }
 
-func (u *uniqueTypeNamesVisitor) checkTypeName(typeName ast.ByteSlice) {
+func (u *uniqueTypeNamesVisitor) checkTypeName(typeName ast.ByteSlice, position position.Position) {
 	hashedTypeName := xxhash.Sum64(typeName)
 	if u.usedTypeNamesAsHash[hashedTypeName] {
-		u.Report.AddExternalError(operationreport.ErrTypeNameMustBeUnique(typeName))
+		u.Report.AddExternalError(operationreport.ErrTypeNameMustBeUnique(typeName, position))
 		return
 	}
 	u.usedTypeNamesAsHash[hashedTypeName] = true
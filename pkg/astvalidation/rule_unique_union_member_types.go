@@ -5,6 +5,7 @@ import (
 
 	"github.com/wundergraph/graphql-go-tools/pkg/ast"
 	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/position"
 	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
 )
 
@@ -50,7 +51,7 @@ func (u *uniqueUnionMemberTypesVisitor) LeaveUnionTypeDefinition(_ int) {
 
 func (u *uniqueUnionMemberTypesVisitor) EnterUnionMemberType(ref int) {
 	memberName := u.definition.TypeNameBytes(ref)
-	u.checkMemberName(memberName)
+	u.checkMemberName(memberName, u.definition.Types[ref].Position)
 }
 
 func (u *uniqueUnionMemberTypesVisitor) EnterUnionTypeExtension(ref int) {
@@ -72,7 +73,7 @@ func (u *uniqueUnionMemberTypesVisitor) unsetCurrentUnion() {
 	u.currentUnionHash = 0
 }
 
-func (u *uniqueUnionMemberTypesVisitor) checkMemberName(memberName ast.ByteSlice) {
+func (u *uniqueUnionMemberTypesVisitor) checkMemberName(memberName ast.ByteSlice, position position.Position) {
 	if len(u.currentUnionName) == 0 || u.currentUnionHash == 0 {
 		return
 	}
@@ -84,7 +85,7 @@ func (u *uniqueUnionMemberTypesVisitor) checkMemberName(memberName ast.ByteSlice
 	}
 
 	if memberNames[memberNameHash] {
-		u.Report.AddExternalError(operationreport.ErrUnionMembersMustBeUnique(u.currentUnionName, memberName))
+		u.Report.AddExternalError(operationreport.ErrUnionMembersMustBeUnique(u.currentUnionName, memberName, position))
 		return
 	}
 
@@ -0,0 +1,188 @@
+package astvalidation
+
+import (
+	"bytes"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+// ValidDefaultValues validates that default values of arguments and input fields satisfy their type
+func ValidDefaultValues() Rule {
+	return func(walker *astvisitor.Walker) {
+		visitor := &validDefaultValuesVisitor{
+			Walker: walker,
+		}
+
+		walker.RegisterEnterDocumentVisitor(visitor)
+		walker.RegisterEnterInputValueDefinitionVisitor(visitor)
+	}
+}
+
+type validDefaultValuesVisitor struct {
+	*astvisitor.Walker
+	definition *ast.Document
+}
+
+func (v *validDefaultValuesVisitor) EnterDocument(operation, _ *ast.Document) {
+	v.definition = operation
+}
+
+func (v *validDefaultValuesVisitor) EnterInputValueDefinition(ref int) {
+	if !v.definition.InputValueDefinitionHasDefaultValue(ref) {
+		return
+	}
+
+	value := v.definition.InputValueDefinitionDefaultValue(ref)
+	typeRef := v.definition.InputValueDefinitionType(ref)
+	if constValueSatisfiesType(v.definition, value, typeRef) {
+		return
+	}
+
+	printedValue, err := v.definition.PrintValueBytes(value, nil)
+	if v.HandleInternalErr(err) {
+		return
+	}
+	printedType, err := v.definition.PrintTypeBytes(typeRef, nil)
+	if v.HandleInternalErr(err) {
+		return
+	}
+
+	coordinate := inputValueDefinitionCoordinate(v.Walker, v.definition, ref)
+	position := v.definition.Input.ByteSliceReferencePosition(v.definition.InputValueDefinitions[ref].Name)
+	v.Report.AddExternalError(operationreport.ErrDefaultValueDoesntSatisfyType(coordinate, printedValue, printedType, position))
+}
+
+// constValueSatisfiesType reports whether a value of the schema, e.g. a default value or a directive argument, satisfies a type of the same schema
+// Values of the schema must not contain variables. Values of custom scalars aren't validated, unknown types are reported by KnownTypeNames.
+func constValueSatisfiesType(definition *ast.Document, value ast.Value, typeRef int) bool {
+	if value.Kind == ast.ValueKindVariable {
+		return false
+	}
+
+	switch definition.Types[typeRef].TypeKind {
+	case ast.TypeKindNonNull:
+		if value.Kind == ast.ValueKindNull {
+			return false
+		}
+		return constValueSatisfiesType(definition, value, definition.Types[typeRef].OfType)
+	case ast.TypeKindList:
+		switch value.Kind {
+		case ast.ValueKindNull:
+			return true
+		case ast.ValueKindList:
+			for _, item := range definition.ListValues[value.Ref].Refs {
+				if !constValueSatisfiesType(definition, definition.Value(item), definition.Types[typeRef].OfType) {
+					return false
+				}
+			}
+			return true
+		default:
+			// input coercion allows a single item for a list
+			return constValueSatisfiesType(definition, value, definition.Types[typeRef].OfType)
+		}
+	case ast.TypeKindNamed:
+		if value.Kind == ast.ValueKindNull {
+			return true
+		}
+		node, exists := definition.Index.FirstNonExtensionNodeByNameBytes(definition.TypeNameBytes(typeRef))
+		if !exists {
+			return true
+		}
+		return constValueSatisfiesNamedType(definition, value, node)
+	default:
+		return false
+	}
+}
+
+func constValueSatisfiesNamedType(definition *ast.Document, value ast.Value, node ast.Node) bool {
+	switch node.Kind {
+	case ast.NodeKindScalarTypeDefinition:
+		switch definition.ScalarTypeDefinitionNameString(node.Ref) {
+		case "Int":
+			return value.Kind == ast.ValueKindInteger
+		case "Float":
+			return value.Kind == ast.ValueKindFloat || value.Kind == ast.ValueKindInteger
+		case "String":
+			return value.Kind == ast.ValueKindString
+		case "Boolean":
+			return value.Kind == ast.ValueKindBoolean
+		case "ID":
+			return value.Kind == ast.ValueKindString || value.Kind == ast.ValueKindInteger
+		default:
+			return !constValueContainsVariable(definition, value)
+		}
+	case ast.NodeKindEnumTypeDefinition:
+		return value.Kind == ast.ValueKindEnum &&
+			definition.EnumTypeDefinitionContainsEnumValue(node.Ref, definition.EnumValueNameBytes(value.Ref))
+	case ast.NodeKindInputObjectTypeDefinition:
+		return constObjectValueSatisfiesInputObject(definition, value, node.Ref)
+	default:
+		return false
+	}
+}
+
+func constObjectValueSatisfiesInputObject(definition *ast.Document, value ast.Value, inputObjectTypeDefinition int) bool {
+	if value.Kind != ast.ValueKindObject {
+		return false
+	}
+
+	inputFields := definition.InputObjectTypeDefinitions[inputObjectTypeDefinition].InputFieldsDefinition.Refs
+	objectFields := definition.ObjectValues[value.Ref].Refs
+
+	for _, objectField := range objectFields {
+		name := definition.ObjectFieldNameBytes(objectField)
+		defined := false
+		for _, inputField := range inputFields {
+			if !bytes.Equal(name, definition.InputValueDefinitionNameBytes(inputField)) {
+				continue
+			}
+			defined = true
+			if !constValueSatisfiesType(definition, definition.ObjectFieldValue(objectField), definition.InputValueDefinitionType(inputField)) {
+				return false
+			}
+		}
+		if !defined {
+			return false
+		}
+	}
+
+	for _, inputField := range inputFields {
+		if definition.InputValueDefinitionArgumentIsOptional(inputField) {
+			continue
+		}
+		provided := false
+		for _, objectField := range objectFields {
+			if bytes.Equal(definition.ObjectFieldNameBytes(objectField), definition.InputValueDefinitionNameBytes(inputField)) {
+				provided = true
+				break
+			}
+		}
+		if !provided {
+			return false
+		}
+	}
+
+	return true
+}
+
+func constValueContainsVariable(definition *ast.Document, value ast.Value) bool {
+	switch value.Kind {
+	case ast.ValueKindVariable:
+		return true
+	case ast.ValueKindList:
+		for _, item := range definition.ListValues[value.Ref].Refs {
+			if constValueContainsVariable(definition, definition.Value(item)) {
+				return true
+			}
+		}
+	case ast.ValueKindObject:
+		for _, objectField := range definition.ObjectValues[value.Ref].Refs {
+			if constValueContainsVariable(definition, definition.ObjectFieldValue(objectField)) {
+				return true
+			}
+		}
+	}
+	return false
+}
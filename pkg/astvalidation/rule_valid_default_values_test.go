@@ -0,0 +1,78 @@
+package astvalidation
+
+import (
+	"testing"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/graphqlerrors"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+func TestValidDefaultValues(t *testing.T) {
+	t.Run("Definition", func(t *testing.T) {
+		t.Run("Default values satisfying their type are valid", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					type Query {
+						users(
+							limit: Int = 10
+							ratio: Float = 1
+							id: ID = 1
+							species: [Species!] = CAT
+							filter: Filter = { name: "Jens", species: [CAT, DOG] }
+							optional: String = null
+							json: JSON = { any: [1, "value"] }
+						): String
+					}
+					input Filter {
+						name: String!
+						species: [Species!]! = []
+						age: Int
+					}
+					enum Species { CAT DOG }
+					scalar JSON
+					directive @cached(ttl: Int = 60) on FIELD_DEFINITION
+				`, Valid, ValidDefaultValues(),
+			)
+		})
+
+		t.Run("Default values not satisfying their type are invalid", func(t *testing.T) {
+			runDefinitionValidationWithErrors(t, `type Query {
+  users(limit: Int = "10", required: String! = null, species: Species = BIRD): String
+}
+input Filter {
+  name: String!
+  nested: Nested = { unknown: 1 }
+  missing: Nested = {}
+}
+input Nested { name: String! }
+enum Species { CAT }
+directive @cached(ttl: [Int] = [1.5]) on FIELD_DEFINITION`,
+				[]operationreport.ExternalError{
+					{
+						Message:   `default value "10" of Query.users(limit:) doesn't satisfy type: Int`,
+						Locations: []graphqlerrors.Location{{Line: 2, Column: 9}},
+					},
+					{
+						Message:   `default value null of Query.users(required:) doesn't satisfy type: String!`,
+						Locations: []graphqlerrors.Location{{Line: 2, Column: 28}},
+					},
+					{
+						Message:   `default value BIRD of Query.users(species:) doesn't satisfy type: Species`,
+						Locations: []graphqlerrors.Location{{Line: 2, Column: 54}},
+					},
+					{
+						Message:   `default value {unknown: 1} of Filter.nested doesn't satisfy type: Nested`,
+						Locations: []graphqlerrors.Location{{Line: 6, Column: 3}},
+					},
+					{
+						Message:   `default value {} of Filter.missing doesn't satisfy type: Nested`,
+						Locations: []graphqlerrors.Location{{Line: 7, Column: 3}},
+					},
+					{
+						Message:   `default value [1.5] of @cached(ttl:) doesn't satisfy type: [Int]`,
+						Locations: []graphqlerrors.Location{{Line: 11, Column: 19}},
+					},
+				}, ValidDefaultValues(),
+			)
+		})
+	})
+}
@@ -0,0 +1,132 @@
+package astvalidation
+
+import (
+	"bytes"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+// ValidDefinitionDirectives validates directives which are used in the schema against their definition:
+// the location has to be allowed, arguments have to be defined and satisfy their type, and required arguments have to be provided
+// Directives without definition are skipped, e.g. federation directives.
+func ValidDefinitionDirectives() Rule {
+	return func(walker *astvisitor.Walker) {
+		visitor := &validDefinitionDirectivesVisitor{
+			Walker: walker,
+		}
+
+		walker.RegisterEnterDocumentVisitor(visitor)
+		walker.RegisterEnterDirectiveVisitor(visitor)
+	}
+}
+
+type validDefinitionDirectivesVisitor struct {
+	*astvisitor.Walker
+	definition *ast.Document
+}
+
+func (v *validDefinitionDirectivesVisitor) EnterDocument(operation, _ *ast.Document) {
+	v.definition = operation
+}
+
+func (v *validDefinitionDirectivesVisitor) EnterDirective(ref int) {
+	directiveName := v.definition.DirectiveNameBytes(ref)
+	directiveDefinition, exists := v.definition.DirectiveDefinitionByName(string(directiveName))
+	if !exists {
+		return
+	}
+	position := v.definition.Directives[ref].At
+
+	location, ok := v.directiveLocation()
+	if !ok {
+		return // executable directive locations are validated by the operation rules
+	}
+	if !v.definition.DirectiveDefinitions[directiveDefinition].DirectiveLocations.Get(location) {
+		v.Report.AddExternalError(operationreport.ErrDirectiveNotAllowedOnLocation(directiveName, location.LiteralBytes(), position))
+	}
+
+	argumentDefinitions := v.definition.DirectiveDefinitions[directiveDefinition].ArgumentsDefinition.Refs
+	arguments := v.definition.DirectiveArgumentSet(ref)
+
+	for _, argument := range arguments {
+		argumentName := v.definition.ArgumentNameBytes(argument)
+		argumentPosition := v.definition.Input.ByteSliceReferencePosition(v.definition.Arguments[argument].Name)
+		argumentDefinition, defined := v.argumentDefinition(argumentDefinitions, argumentName)
+		if !defined {
+			v.Report.AddExternalError(operationreport.ErrDirectiveArgumentNotDefined(directiveName, argumentName, argumentPosition))
+			continue
+		}
+
+		value := v.definition.ArgumentValue(argument)
+		argumentType := v.definition.InputValueDefinitionType(argumentDefinition)
+		if constValueSatisfiesType(v.definition, value, argumentType) {
+			continue
+		}
+		printedValue, err := v.definition.PrintValueBytes(value, nil)
+		if v.HandleInternalErr(err) {
+			return
+		}
+		printedType, err := v.definition.PrintTypeBytes(argumentType, nil)
+		if v.HandleInternalErr(err) {
+			return
+		}
+		v.Report.AddExternalError(operationreport.ErrDirectiveArgumentValueDoesntSatisfyType(directiveName, argumentName, printedValue, printedType, argumentPosition))
+	}
+
+	for _, argumentDefinition := range argumentDefinitions {
+		if v.definition.InputValueDefinitionArgumentIsOptional(argumentDefinition) {
+			continue
+		}
+		argumentName := v.definition.InputValueDefinitionNameBytes(argumentDefinition)
+		if _, provided := v.definition.DirectiveArgumentValueByName(ref, argumentName); provided {
+			continue
+		}
+		printedType, err := v.definition.PrintTypeBytes(v.definition.InputValueDefinitionType(argumentDefinition), nil)
+		if v.HandleInternalErr(err) {
+			return
+		}
+		v.Report.AddExternalError(operationreport.ErrDirectiveArgumentRequired(directiveName, argumentName, printedType, position))
+	}
+}
+
+// directiveLocation returns the type system directive location of the node the directive is used on
+func (v *validDefinitionDirectivesVisitor) directiveLocation() (ast.DirectiveLocation, bool) {
+	if len(v.Ancestors) == 0 {
+		return ast.DirectiveLocationUnknown, false
+	}
+	ancestor := v.Ancestors[len(v.Ancestors)-1]
+
+	switch ancestor.Kind {
+	case ast.NodeKindFieldDefinition:
+		return ast.TypeSystemDirectiveLocationFieldDefinition, true
+	case ast.NodeKindEnumValueDefinition:
+		return ast.TypeSystemDirectiveLocationEnumValue, true
+	case ast.NodeKindScalarTypeExtension:
+		return ast.TypeSystemDirectiveLocationScalar, true
+	case ast.NodeKindInputValueDefinition:
+		if len(v.Ancestors) > 1 {
+			switch v.Ancestors[len(v.Ancestors)-2].Kind {
+			case ast.NodeKindInputObjectTypeDefinition, ast.NodeKindInputObjectTypeExtension:
+				return ast.TypeSystemDirectiveLocationInputFieldDefinition, true
+			}
+		}
+		return ast.TypeSystemDirectiveLocationArgumentDefinition, true
+	}
+
+	location, err := v.definition.NodeDirectiveLocation(ancestor)
+	if err != nil || location < ast.TypeSystemDirectiveLocationSchema {
+		return ast.DirectiveLocationUnknown, false
+	}
+	return location, true
+}
+
+func (v *validDefinitionDirectivesVisitor) argumentDefinition(argumentDefinitions []int, argumentName ast.ByteSlice) (int, bool) {
+	for _, argumentDefinition := range argumentDefinitions {
+		if bytes.Equal(argumentName, v.definition.InputValueDefinitionNameBytes(argumentDefinition)) {
+			return argumentDefinition, true
+		}
+	}
+	return -1, false
+}
@@ -0,0 +1,74 @@
+package astvalidation
+
+import (
+	"testing"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/graphqlerrors"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+func TestValidDefinitionDirectives(t *testing.T) {
+	t.Run("Definition", func(t *testing.T) {
+		t.Run("Directives used with valid arguments in allowed locations are valid", func(t *testing.T) {
+			runDefinitionValidation(t, `
+					type Query @cached(ttl: 60, scopes: [PUBLIC]) {
+						user(id: ID! @length(max: 10)): User @deprecated(reason: "use users") @cached(ttl: 10)
+					}
+					type User @key(fields: "id") { id: ID! }
+					input Filter { name: String @length(max: 10) }
+					enum Scope { PUBLIC PRIVATE @deprecated }
+					directive @cached(ttl: Int!, scopes: [Scope!] = [PUBLIC]) on OBJECT | FIELD_DEFINITION
+					directive @length(max: Int!) on ARGUMENT_DEFINITION | INPUT_FIELD_DEFINITION
+				`, Valid, ValidDefinitionDirectives(),
+			)
+		})
+
+		t.Run("Directives in wrong locations are invalid", func(t *testing.T) {
+			runDefinitionValidationWithErrors(t, `type Query @length(max: 1) {
+  user(id: ID! @cached): String
+}
+input Filter { name: String @cached }
+directive @cached on FIELD_DEFINITION
+directive @length(max: Int!) on ARGUMENT_DEFINITION`,
+				[]operationreport.ExternalError{
+					{
+						Message:   "directive '@length' is not allowed on location: OBJECT",
+						Locations: []graphqlerrors.Location{{Line: 1, Column: 12}},
+					},
+					{
+						Message:   "directive '@cached' is not allowed on location: ARGUMENT_DEFINITION",
+						Locations: []graphqlerrors.Location{{Line: 2, Column: 16}},
+					},
+					{
+						Message:   "directive '@cached' is not allowed on location: INPUT_FIELD_DEFINITION",
+						Locations: []graphqlerrors.Location{{Line: 4, Column: 29}},
+					},
+				}, ValidDefinitionDirectives(),
+			)
+		})
+
+		t.Run("Directives with unknown, invalid or missing arguments are invalid", func(t *testing.T) {
+			runDefinitionValidationWithErrors(t, `type Query {
+  a: String @cached
+  b: String @cached(ttl: "60")
+  c: String @cached(ttl: 60, scope: PUBLIC)
+}
+directive @cached(ttl: Int!) on FIELD_DEFINITION`,
+				[]operationreport.ExternalError{
+					{
+						Message:   "argument 'ttl' of type 'Int!' is required on directive '@cached' but missing",
+						Locations: []graphqlerrors.Location{{Line: 2, Column: 13}},
+					},
+					{
+						Message:   `value "60" of argument 'ttl' on directive '@cached' doesn't satisfy type: Int!`,
+						Locations: []graphqlerrors.Location{{Line: 3, Column: 21}},
+					},
+					{
+						Message:   "argument 'scope' is not defined on directive '@cached'",
+						Locations: []graphqlerrors.Location{{Line: 4, Column: 30}},
+					},
+				}, ValidDefinitionDirectives(),
+			)
+		})
+	})
+}
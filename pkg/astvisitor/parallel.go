@@ -0,0 +1,84 @@
+package astvisitor
+
+import (
+	"sync"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+// ParallelWalker walks the root nodes of a document sharded across multiple goroutines.
+// Each shard is walked by its own Walker with its own set of visitors, registered by the setup func.
+//
+// It is meant for large SDL documents and visitors which look at one root node at a time,
+// e.g. validation rules for independent definitions.
+// Visitors must not modify the document and must not rely on seeing all root nodes:
+// EnterDocument and LeaveDocument get called once per shard with a document only containing the root nodes of the shard.
+// Always use NewParallelWalker to instantiate a new ParallelWalker
+type ParallelWalker struct {
+	walkers []*Walker
+	reports []operationreport.Report
+}
+
+// NewParallelWalker returns a ParallelWalker with the given amount of shards
+// setup gets called once per shard to register the visitors on the Walker of the shard
+func NewParallelWalker(shards int, setup func(walker *Walker)) *ParallelWalker {
+	if shards < 1 {
+		shards = 1
+	}
+	parallel := &ParallelWalker{
+		walkers: make([]*Walker, shards),
+		reports: make([]operationreport.Report, shards),
+	}
+	for i := range parallel.walkers {
+		walker := NewWalker(48)
+		parallel.walkers[i] = &walker
+		setup(parallel.walkers[i])
+	}
+	return parallel
+}
+
+// Walk splits the root nodes of the document into consecutive shards and walks them concurrently
+// The errors of all shards are added to the report in the order of the root nodes
+func (p *ParallelWalker) Walk(document, definition *ast.Document, report *operationreport.Report) {
+	if report == nil {
+		report = &operationreport.Report{}
+	}
+
+	shards := len(p.walkers)
+	if len(document.RootNodes) < shards {
+		shards = len(document.RootNodes)
+	}
+	if shards <= 1 {
+		p.walkers[0].Walk(document, definition, report)
+		return
+	}
+
+	// the shard size is rounded up, so fewer shards than walkers might be needed to cover all root nodes
+	size := (len(document.RootNodes) + shards - 1) / shards
+	shards = (len(document.RootNodes) + size - 1) / size
+	documents := make([]ast.Document, shards)
+
+	wg := &sync.WaitGroup{}
+	wg.Add(shards)
+	for i := 0; i < shards; i++ {
+		start, end := i*size, (i+1)*size
+		if end > len(document.RootNodes) {
+			end = len(document.RootNodes)
+		}
+		documents[i] = *document
+		documents[i].RootNodes = document.RootNodes[start:end:end]
+		p.reports[i].Reset()
+
+		go func(i int) {
+			defer wg.Done()
+			p.walkers[i].Walk(&documents[i], definition, &p.reports[i])
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < shards; i++ {
+		report.InternalErrors = append(report.InternalErrors, p.reports[i].InternalErrors...)
+		report.ExternalErrors = append(report.ExternalErrors, p.reports[i].ExternalErrors...)
+	}
+}
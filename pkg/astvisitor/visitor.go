@@ -3,6 +3,7 @@ package astvisitor
 import (
 	"bytes"
 	"fmt"
+	"unsafe"
 
 	"github.com/wundergraph/graphql-go-tools/pkg/ast"
 	"github.com/wundergraph/graphql-go-tools/pkg/lexer/literal"
@@ -46,6 +47,25 @@ type Walker struct {
 	revisit         bool
 	filter          VisitorFilter
 	deferred        []func()
+	// currentVisitor is the visitor which is currently called, used for per visitor skip and stop
+	currentVisitor  visitorIdentity
+	skippedVisitors []skippedVisitor
+	stoppedVisitors []visitorIdentity
+}
+
+// skippedVisitor is a visitor which doesn't get called until the walker leaves the node at depth
+type skippedVisitor struct {
+	visitor visitorIdentity
+	depth   int
+}
+
+// visitorIdentity is the type and data word of a visitor interface
+// Contrary to comparing visitors with == it doesn't panic for visitors of non comparable types, e.g. funcs or structs containing maps.
+// Pointer visitors are identified by the pointer, all other visitors by the value the interface was created from on registration.
+type visitorIdentity [2]unsafe.Pointer
+
+func identityOf(visitor interface{}) visitorIdentity {
+	return *(*visitorIdentity)(unsafe.Pointer(&visitor))
 }
 
 // NewWalker returns a fully initialized Walker
@@ -1298,6 +1318,9 @@ func (w *Walker) Walk(document, definition *ast.Document, report *operationrepor
 	w.definition = definition
 	w.Depth = 0
 	w.stop = false
+	w.currentVisitor = visitorIdentity{}
+	w.skippedVisitors = w.skippedVisitors[:0]
+	w.stoppedVisitors = w.stoppedVisitors[:0]
 	w.walk()
 }
 
@@ -1365,9 +1388,10 @@ func (w *Walker) appendAncestor(ref int, kind ast.NodeKind) {
 	case ast.NodeKindField:
 		fieldName := w.document.FieldNameBytes(ref)
 		w.Path = append(w.Path, ast.PathItem{
-			Kind:       ast.FieldName,
-			ArrayIndex: 0,
-			FieldName:  w.document.FieldAliasOrNameBytes(ref),
+			Kind:           ast.FieldName,
+			ArrayIndex:     0,
+			FieldName:      w.document.FieldAliasOrNameBytes(ref),
+			ParentTypeName: w.definition.NodeNameBytes(w.typeDefinitions[len(w.typeDefinitions)-1]),
 		})
 		if bytes.Equal(fieldName, literal.TYPENAME) {
 			typeName = literal.STRING
@@ -1381,7 +1405,7 @@ func (w *Walker) appendAncestor(ref int, kind ast.NodeKind) {
 		}
 		if typeName == nil {
 			typeName := w.definition.NodeNameBytes(w.typeDefinitions[len(w.typeDefinitions)-1])
-			w.StopWithExternalErr(operationreport.ErrFieldUndefinedOnType(fieldName, typeName))
+			w.StopWithExternalErr(operationreport.ErrFieldUndefinedOnType(fieldName, typeName, w.document.Fields[ref].Position))
 			return
 		}
 	case ast.NodeKindObjectTypeDefinition, ast.NodeKindInterfaceTypeDefinition, ast.NodeKindUnionTypeDefinition:
@@ -1397,7 +1421,7 @@ func (w *Walker) appendAncestor(ref int, kind ast.NodeKind) {
 	var exists bool
 	w.EnclosingTypeDefinition, exists = w.definition.Index.FirstNonExtensionNodeByNameBytes(typeName)
 	if !exists {
-		w.StopWithExternalErr(operationreport.ErrTypeUndefined(typeName))
+		w.StopWithExternalErr(operationreport.ErrTypeUndefined(typeName, w.document.NodePosition(ast.Node{Kind: kind, Ref: ref})))
 		return
 	}
 
@@ -1438,6 +1462,47 @@ func (w *Walker) increaseDepth() {
 
 func (w *Walker) decreaseDepth() {
 	w.Depth--
+	if len(w.skippedVisitors) != 0 {
+		w.unskipVisitors()
+	}
+}
+
+// unskipVisitors removes the skips of all visitors for nodes the walker has left
+func (w *Walker) unskipVisitors() {
+	skipped := w.skippedVisitors[:0]
+	for i := range w.skippedVisitors {
+		if w.skippedVisitors[i].depth <= w.Depth {
+			skipped = append(skipped, w.skippedVisitors[i])
+		}
+	}
+	w.skippedVisitors = skipped
+}
+
+// allowVisitor reports whether the visitor should be called
+// It is false if the visitor skipped the current node or stopped, otherwise the VisitorFilter decides
+func (w *Walker) allowVisitor(kind VisitorKind, ref int, visitor interface{}) bool {
+	identity := identityOf(visitor)
+	if len(w.skippedVisitors) != 0 || len(w.stoppedVisitors) != 0 {
+		if w.visitorIsSkippedOrStopped(identity) {
+			return false
+		}
+	}
+	w.currentVisitor = identity
+	return w.filter == nil || w.filter.AllowVisitor(kind, ref, visitor)
+}
+
+func (w *Walker) visitorIsSkippedOrStopped(visitor visitorIdentity) bool {
+	for i := range w.stoppedVisitors {
+		if w.stoppedVisitors[i] == visitor {
+			return true
+		}
+	}
+	for i := range w.skippedVisitors {
+		if w.skippedVisitors[i].visitor == visitor && w.Depth >= w.skippedVisitors[i].depth {
+			return true
+		}
+	}
+	return false
 }
 
 func (w *Walker) walk() {
@@ -1448,7 +1513,7 @@ func (w *Walker) walk() {
 	}
 
 	for i := 0; i < len(w.visitors.enterDocument); {
-		if w.filter == nil || w.filter.AllowVisitor(EnterDocument, 0, w.visitors.enterDocument[i]) {
+		if w.allowVisitor(EnterDocument, 0, w.visitors.enterDocument[i]) {
 			w.visitors.enterDocument[i].EnterDocument(w.document, w.definition)
 		}
 		if w.revisit {
@@ -1521,7 +1586,7 @@ func (w *Walker) walk() {
 	}
 
 	for i := 0; i < len(w.visitors.leaveDocument); {
-		if w.filter == nil || w.filter.AllowVisitor(LeaveDocument, 0, w.visitors.leaveDocument[i]) {
+		if w.allowVisitor(LeaveDocument, 0, w.visitors.leaveDocument[i]) {
 			w.visitors.leaveDocument[i].LeaveDocument(w.document, w.definition)
 		}
 		if w.revisit {
@@ -1543,7 +1608,7 @@ func (w *Walker) walkOperationDefinition(ref int) {
 	w.increaseDepth()
 
 	for i := 0; i < len(w.visitors.enterOperation); {
-		if w.filter == nil || w.filter.AllowVisitor(EnterOperation, ref, w.visitors.enterOperation[i]) {
+		if w.allowVisitor(EnterOperation, ref, w.visitors.enterOperation[i]) {
 			w.visitors.enterOperation[i].EnterOperationDefinition(ref)
 		}
 		if w.revisit {
@@ -1594,7 +1659,7 @@ func (w *Walker) walkOperationDefinition(ref int) {
 	w.removeLastAncestor()
 
 	for i := 0; i < len(w.visitors.leaveOperation); {
-		if w.filter == nil || w.filter.AllowVisitor(LeaveOperation, ref, w.visitors.leaveOperation[i]) {
+		if w.allowVisitor(LeaveOperation, ref, w.visitors.leaveOperation[i]) {
 			w.visitors.leaveOperation[i].LeaveOperationDefinition(ref)
 		}
 		if w.revisit {
@@ -1619,7 +1684,7 @@ func (w *Walker) walkVariableDefinition(ref int) {
 	w.increaseDepth()
 
 	for i := 0; i < len(w.visitors.enterVariableDefinition); {
-		if w.filter == nil || w.filter.AllowVisitor(EnterVariableDefinition, ref, w.visitors.enterVariableDefinition[i]) {
+		if w.allowVisitor(EnterVariableDefinition, ref, w.visitors.enterVariableDefinition[i]) {
 			w.visitors.enterVariableDefinition[i].EnterVariableDefinition(ref)
 		}
 		if w.revisit {
@@ -1654,7 +1719,7 @@ func (w *Walker) walkVariableDefinition(ref int) {
 	w.removeLastAncestor()
 
 	for i := 0; i < len(w.visitors.leaveVariableDefinition); {
-		if w.filter == nil || w.filter.AllowVisitor(LeaveVariableDefinition, ref, w.visitors.leaveVariableDefinition[i]) {
+		if w.allowVisitor(LeaveVariableDefinition, ref, w.visitors.leaveVariableDefinition[i]) {
 			w.visitors.leaveVariableDefinition[i].LeaveVariableDefinition(ref)
 		}
 		if w.revisit {
@@ -1679,7 +1744,7 @@ func (w *Walker) walkSelectionSet(ref int) {
 	w.increaseDepth()
 
 	for i := 0; i < len(w.visitors.enterSelectionSet); {
-		if w.filter == nil || w.filter.AllowVisitor(EnterSelectionSet, ref, w.visitors.enterSelectionSet[i]) {
+		if w.allowVisitor(EnterSelectionSet, ref, w.visitors.enterSelectionSet[i]) {
 			w.visitors.enterSelectionSet[i].EnterSelectionSet(ref)
 		}
 		if w.revisit {
@@ -1732,7 +1797,7 @@ RefsChanged:
 	w.removeLastAncestor()
 
 	for i := 0; i < len(w.visitors.leaveSelectionSet); {
-		if w.filter == nil || w.filter.AllowVisitor(LeaveSelectionSet, ref, w.visitors.leaveSelectionSet[i]) {
+		if w.allowVisitor(LeaveSelectionSet, ref, w.visitors.leaveSelectionSet[i]) {
 			w.visitors.leaveSelectionSet[i].LeaveSelectionSet(ref)
 		}
 		if w.revisit {
@@ -1762,7 +1827,7 @@ func (w *Walker) walkField(ref int) {
 	w.setCurrent(ast.NodeKindField, ref)
 
 	for i := 0; i < len(w.visitors.enterField); {
-		if w.filter == nil || w.filter.AllowVisitor(EnterField, ref, w.visitors.enterField[i]) {
+		if w.allowVisitor(EnterField, ref, w.visitors.enterField[i]) {
 			w.visitors.enterField[i].EnterField(ref)
 		}
 		if w.revisit {
@@ -1817,7 +1882,7 @@ func (w *Walker) walkField(ref int) {
 	w.setCurrent(ast.NodeKindField, ref)
 
 	for i := 0; i < len(w.visitors.leaveField); {
-		if w.filter == nil || w.filter.AllowVisitor(LeaveField, ref, w.visitors.leaveField[i]) {
+		if w.allowVisitor(LeaveField, ref, w.visitors.leaveField[i]) {
 			w.visitors.leaveField[i].LeaveField(ref)
 		}
 		if w.revisit {
@@ -1844,7 +1909,7 @@ func (w *Walker) walkDirective(ref int) {
 	w.setCurrent(ast.NodeKindDirective, ref)
 
 	for i := 0; i < len(w.visitors.enterDirective); {
-		if w.filter == nil || w.filter.AllowVisitor(EnterDirective, ref, w.visitors.enterDirective[i]) {
+		if w.allowVisitor(EnterDirective, ref, w.visitors.enterDirective[i]) {
 			w.visitors.enterDirective[i].EnterDirective(ref)
 		}
 		if w.revisit {
@@ -1878,7 +1943,7 @@ func (w *Walker) walkDirective(ref int) {
 	w.setCurrent(ast.NodeKindDirective, ref)
 
 	for i := 0; i < len(w.visitors.leaveDirective); {
-		if w.filter == nil || w.filter.AllowVisitor(LeaveDirective, ref, w.visitors.leaveDirective[i]) {
+		if w.allowVisitor(LeaveDirective, ref, w.visitors.leaveDirective[i]) {
 			w.visitors.leaveDirective[i].LeaveDirective(ref)
 		}
 		if w.revisit {
@@ -1905,7 +1970,7 @@ func (w *Walker) walkArgument(ref int) {
 	w.setCurrent(ast.NodeKindArgument, ref)
 
 	for i := 0; i < len(w.visitors.enterArgument); {
-		if w.filter == nil || w.filter.AllowVisitor(EnterArgument, ref, w.visitors.enterArgument[i]) {
+		if w.allowVisitor(EnterArgument, ref, w.visitors.enterArgument[i]) {
 			w.visitors.enterArgument[i].EnterArgument(ref)
 		}
 		if w.revisit {
@@ -1924,7 +1989,7 @@ func (w *Walker) walkArgument(ref int) {
 	}
 
 	for i := 0; i < len(w.visitors.leaveArgument); {
-		if w.filter == nil || w.filter.AllowVisitor(LeaveArgument, ref, w.visitors.leaveArgument[i]) {
+		if w.allowVisitor(LeaveArgument, ref, w.visitors.leaveArgument[i]) {
 			w.visitors.leaveArgument[i].LeaveArgument(ref)
 		}
 		if w.revisit {
@@ -1951,7 +2016,7 @@ func (w *Walker) walkFragmentSpread(ref int) {
 	w.setCurrent(ast.NodeKindFragmentSpread, ref)
 
 	for i := 0; i < len(w.visitors.enterFragmentSpread); {
-		if w.filter == nil || w.filter.AllowVisitor(EnterFragmentSpread, ref, w.visitors.enterFragmentSpread[i]) {
+		if w.allowVisitor(EnterFragmentSpread, ref, w.visitors.enterFragmentSpread[i]) {
 			w.visitors.enterFragmentSpread[i].EnterFragmentSpread(ref)
 		}
 		if w.revisit {
@@ -1970,7 +2035,7 @@ func (w *Walker) walkFragmentSpread(ref int) {
 	}
 
 	for i := 0; i < len(w.visitors.leaveFragmentSpread); {
-		if w.filter == nil || w.filter.AllowVisitor(LeaveFragmentSpread, ref, w.visitors.leaveFragmentSpread[i]) {
+		if w.allowVisitor(LeaveFragmentSpread, ref, w.visitors.leaveFragmentSpread[i]) {
 			w.visitors.leaveFragmentSpread[i].LeaveFragmentSpread(ref)
 		}
 		if w.revisit {
@@ -2000,7 +2065,7 @@ func (w *Walker) walkInlineFragment(ref int) {
 	w.setCurrent(ast.NodeKindInlineFragment, ref)
 
 	for i := 0; i < len(w.visitors.enterInlineFragment); {
-		if w.filter == nil || w.filter.AllowVisitor(EnterInlineFragment, ref, w.visitors.enterInlineFragment[i]) {
+		if w.allowVisitor(EnterInlineFragment, ref, w.visitors.enterInlineFragment[i]) {
 			w.visitors.enterInlineFragment[i].EnterInlineFragment(ref)
 		}
 		if w.revisit {
@@ -2044,7 +2109,7 @@ func (w *Walker) walkInlineFragment(ref int) {
 	w.setCurrent(ast.NodeKindInlineFragment, ref)
 
 	for i := 0; i < len(w.visitors.leaveInlineFragment); {
-		if w.filter == nil || w.filter.AllowVisitor(LeaveInlineFragment, ref, w.visitors.leaveInlineFragment[i]) {
+		if w.allowVisitor(LeaveInlineFragment, ref, w.visitors.leaveInlineFragment[i]) {
 			w.visitors.leaveInlineFragment[i].LeaveInlineFragment(ref)
 		}
 		if w.revisit {
@@ -2071,7 +2136,7 @@ func (w *Walker) walkFragmentDefinition(ref int) {
 	w.setCurrent(ast.NodeKindFragmentDefinition, ref)
 
 	for i := 0; i < len(w.visitors.enterFragmentDefinition); {
-		if w.filter == nil || w.filter.AllowVisitor(EnterFragmentDefinition, ref, w.visitors.enterFragmentDefinition[i]) {
+		if w.allowVisitor(EnterFragmentDefinition, ref, w.visitors.enterFragmentDefinition[i]) {
 			w.visitors.enterFragmentDefinition[i].EnterFragmentDefinition(ref)
 		}
 		if w.revisit {
@@ -2106,7 +2171,7 @@ func (w *Walker) walkFragmentDefinition(ref int) {
 	w.setCurrent(ast.NodeKindFragmentDefinition, ref)
 
 	for i := 0; i < len(w.visitors.leaveFragmentDefinition); {
-		if w.filter == nil || w.filter.AllowVisitor(LeaveFragmentDefinition, ref, w.visitors.leaveFragmentDefinition[i]) {
+		if w.allowVisitor(LeaveFragmentDefinition, ref, w.visitors.leaveFragmentDefinition[i]) {
 			w.visitors.leaveFragmentDefinition[i].LeaveFragmentDefinition(ref)
 		}
 		if w.revisit {
@@ -2133,7 +2198,7 @@ func (w *Walker) walkObjectTypeDefinition(ref int) {
 	w.setCurrent(ast.NodeKindObjectTypeDefinition, ref)
 
 	for i := 0; i < len(w.visitors.enterObjectTypeDefinition); {
-		if w.filter == nil || w.filter.AllowVisitor(EnterObjectTypeDefinition, ref, w.visitors.enterObjectTypeDefinition[i]) {
+		if w.allowVisitor(EnterObjectTypeDefinition, ref, w.visitors.enterObjectTypeDefinition[i]) {
 			w.visitors.enterObjectTypeDefinition[i].EnterObjectTypeDefinition(ref)
 		}
 		if w.revisit {
@@ -2179,7 +2244,7 @@ func (w *Walker) walkObjectTypeDefinition(ref int) {
 	w.setCurrent(ast.NodeKindObjectTypeDefinition, ref)
 
 	for i := 0; i < len(w.visitors.leaveObjectTypeDefinition); {
-		if w.filter == nil || w.filter.AllowVisitor(LeaveObjectTypeDefinition, ref, w.visitors.leaveObjectTypeDefinition[i]) {
+		if w.allowVisitor(LeaveObjectTypeDefinition, ref, w.visitors.leaveObjectTypeDefinition[i]) {
 			w.visitors.leaveObjectTypeDefinition[i].LeaveObjectTypeDefinition(ref)
 		}
 		if w.revisit {
@@ -2206,7 +2271,7 @@ func (w *Walker) walkObjectTypeExtension(ref int) {
 	w.setCurrent(ast.NodeKindObjectTypeExtension, ref)
 
 	for i := 0; i < len(w.visitors.enterObjectTypeExtension); {
-		if w.filter == nil || w.filter.AllowVisitor(EnterObjectTypeExtension, ref, w.visitors.enterObjectTypeExtension[i]) {
+		if w.allowVisitor(EnterObjectTypeExtension, ref, w.visitors.enterObjectTypeExtension[i]) {
 			w.visitors.enterObjectTypeExtension[i].EnterObjectTypeExtension(ref)
 		}
 		if w.revisit {
@@ -2252,7 +2317,7 @@ func (w *Walker) walkObjectTypeExtension(ref int) {
 	w.setCurrent(ast.NodeKindObjectTypeExtension, ref)
 
 	for i := 0; i < len(w.visitors.leaveObjectTypeExtension); {
-		if w.filter == nil || w.filter.AllowVisitor(LeaveObjectTypeExtension, ref, w.visitors.leaveObjectTypeExtension[i]) {
+		if w.allowVisitor(LeaveObjectTypeExtension, ref, w.visitors.leaveObjectTypeExtension[i]) {
 			w.visitors.leaveObjectTypeExtension[i].LeaveObjectTypeExtension(ref)
 		}
 		if w.revisit {
@@ -2279,7 +2344,7 @@ func (w *Walker) walkFieldDefinition(ref int) {
 	w.setCurrent(ast.NodeKindFieldDefinition, ref)
 
 	for i := 0; i < len(w.visitors.enterFieldDefinition); {
-		if w.filter == nil || w.filter.AllowVisitor(EnterFieldDefinition, ref, w.visitors.enterFieldDefinition[i]) {
+		if w.allowVisitor(EnterFieldDefinition, ref, w.visitors.enterFieldDefinition[i]) {
 			w.visitors.enterFieldDefinition[i].EnterFieldDefinition(ref)
 		}
 		if w.revisit {
@@ -2325,7 +2390,7 @@ func (w *Walker) walkFieldDefinition(ref int) {
 	w.setCurrent(ast.NodeKindFieldDefinition, ref)
 
 	for i := 0; i < len(w.visitors.leaveFieldDefinition); {
-		if w.filter == nil || w.filter.AllowVisitor(LeaveFieldDefinition, ref, w.visitors.leaveFieldDefinition[i]) {
+		if w.allowVisitor(LeaveFieldDefinition, ref, w.visitors.leaveFieldDefinition[i]) {
 			w.visitors.leaveFieldDefinition[i].LeaveFieldDefinition(ref)
 		}
 		if w.revisit {
@@ -2352,7 +2417,7 @@ func (w *Walker) walkInputValueDefinition(ref int) {
 	w.setCurrent(ast.NodeKindInputValueDefinition, ref)
 
 	for i := 0; i < len(w.visitors.enterInputValueDefinition); {
-		if w.filter == nil || w.filter.AllowVisitor(EnterInputValueDefinition, ref, w.visitors.enterInputValueDefinition[i]) {
+		if w.allowVisitor(EnterInputValueDefinition, ref, w.visitors.enterInputValueDefinition[i]) {
 			w.visitors.enterInputValueDefinition[i].EnterInputValueDefinition(ref)
 		}
 		if w.revisit {
@@ -2389,7 +2454,7 @@ func (w *Walker) walkInputValueDefinition(ref int) {
 	w.setCurrent(ast.NodeKindInputValueDefinition, ref)
 
 	for i := 0; i < len(w.visitors.leaveInputValueDefinition); {
-		if w.filter == nil || w.filter.AllowVisitor(LeaveInputValueDefinition, ref, w.visitors.leaveInputValueDefinition[i]) {
+		if w.allowVisitor(LeaveInputValueDefinition, ref, w.visitors.leaveInputValueDefinition[i]) {
 			w.visitors.leaveInputValueDefinition[i].LeaveInputValueDefinition(ref)
 		}
 		if w.revisit {
@@ -2416,7 +2481,7 @@ func (w *Walker) walkInterfaceTypeDefinition(ref int) {
 	w.setCurrent(ast.NodeKindInterfaceTypeDefinition, ref)
 
 	for i := 0; i < len(w.visitors.enterInterfaceTypeDefinition); {
-		if w.filter == nil || w.filter.AllowVisitor(EnterInterfaceTypeDefinition, ref, w.visitors.enterInterfaceTypeDefinition[i]) {
+		if w.allowVisitor(EnterInterfaceTypeDefinition, ref, w.visitors.enterInterfaceTypeDefinition[i]) {
 			w.visitors.enterInterfaceTypeDefinition[i].EnterInterfaceTypeDefinition(ref)
 		}
 		if w.revisit {
@@ -2462,7 +2527,7 @@ func (w *Walker) walkInterfaceTypeDefinition(ref int) {
 	w.setCurrent(ast.NodeKindInterfaceTypeDefinition, ref)
 
 	for i := 0; i < len(w.visitors.leaveInterfaceTypeDefinition); {
-		if w.filter == nil || w.filter.AllowVisitor(LeaveInterfaceTypeDefinition, ref, w.visitors.leaveInterfaceTypeDefinition[i]) {
+		if w.allowVisitor(LeaveInterfaceTypeDefinition, ref, w.visitors.leaveInterfaceTypeDefinition[i]) {
 			w.visitors.leaveInterfaceTypeDefinition[i].LeaveInterfaceTypeDefinition(ref)
 		}
 		if w.revisit {
@@ -2489,7 +2554,7 @@ func (w *Walker) walkInterfaceTypeExtension(ref int) {
 	w.setCurrent(ast.NodeKindInterfaceTypeExtension, ref)
 
 	for i := 0; i < len(w.visitors.enterInterfaceTypeExtension); {
-		if w.filter == nil || w.filter.AllowVisitor(EnterInterfaceTypeExtension, ref, w.visitors.enterInterfaceTypeExtension[i]) {
+		if w.allowVisitor(EnterInterfaceTypeExtension, ref, w.visitors.enterInterfaceTypeExtension[i]) {
 			w.visitors.enterInterfaceTypeExtension[i].EnterInterfaceTypeExtension(ref)
 		}
 		if w.revisit {
@@ -2535,7 +2600,7 @@ func (w *Walker) walkInterfaceTypeExtension(ref int) {
 	w.setCurrent(ast.NodeKindInterfaceTypeExtension, ref)
 
 	for i := 0; i < len(w.visitors.leaveInterfaceTypeExtension); {
-		if w.filter == nil || w.filter.AllowVisitor(LeaveInterfaceTypeExtension, ref, w.visitors.leaveInterfaceTypeExtension[i]) {
+		if w.allowVisitor(LeaveInterfaceTypeExtension, ref, w.visitors.leaveInterfaceTypeExtension[i]) {
 			w.visitors.leaveInterfaceTypeExtension[i].LeaveInterfaceTypeExtension(ref)
 		}
 		if w.revisit {
@@ -2562,7 +2627,7 @@ func (w *Walker) walkScalarTypeDefinition(ref int) {
 	w.setCurrent(ast.NodeKindScalarTypeDefinition, ref)
 
 	for i := 0; i < len(w.visitors.enterScalarTypeDefinition); {
-		if w.filter == nil || w.filter.AllowVisitor(EnterScalarTypeDefinition, ref, w.visitors.enterScalarTypeDefinition[i]) {
+		if w.allowVisitor(EnterScalarTypeDefinition, ref, w.visitors.enterScalarTypeDefinition[i]) {
 			w.visitors.enterScalarTypeDefinition[i].EnterScalarTypeDefinition(ref)
 		}
 		if w.revisit {
@@ -2599,7 +2664,7 @@ func (w *Walker) walkScalarTypeDefinition(ref int) {
 	w.setCurrent(ast.NodeKindScalarTypeDefinition, ref)
 
 	for i := 0; i < len(w.visitors.leaveScalarTypeDefinition); {
-		if w.filter == nil || w.filter.AllowVisitor(LeaveScalarTypeDefinition, ref, w.visitors.leaveScalarTypeDefinition[i]) {
+		if w.allowVisitor(LeaveScalarTypeDefinition, ref, w.visitors.leaveScalarTypeDefinition[i]) {
 			w.visitors.leaveScalarTypeDefinition[i].LeaveScalarTypeDefinition(ref)
 		}
 		if w.revisit {
@@ -2626,7 +2691,7 @@ func (w *Walker) walkScalarTypeExtension(ref int) {
 	w.setCurrent(ast.NodeKindScalarTypeExtension, ref)
 
 	for i := 0; i < len(w.visitors.enterScalarTypeExtension); {
-		if w.filter == nil || w.filter.AllowVisitor(EnterScalarTypeExtension, ref, w.visitors.enterScalarTypeExtension[i]) {
+		if w.allowVisitor(EnterScalarTypeExtension, ref, w.visitors.enterScalarTypeExtension[i]) {
 			w.visitors.enterScalarTypeExtension[i].EnterScalarTypeExtension(ref)
 		}
 		if w.revisit {
@@ -2663,7 +2728,7 @@ func (w *Walker) walkScalarTypeExtension(ref int) {
 	w.setCurrent(ast.NodeKindScalarTypeExtension, ref)
 
 	for i := 0; i < len(w.visitors.leaveScalarTypeExtension); {
-		if w.filter == nil || w.filter.AllowVisitor(LeaveScalarTypeExtension, ref, w.visitors.leaveScalarTypeExtension[i]) {
+		if w.allowVisitor(LeaveScalarTypeExtension, ref, w.visitors.leaveScalarTypeExtension[i]) {
 			w.visitors.leaveScalarTypeExtension[i].LeaveScalarTypeExtension(ref)
 		}
 		if w.revisit {
@@ -2690,7 +2755,7 @@ func (w *Walker) walkUnionTypeDefinition(ref int) {
 	w.setCurrent(ast.NodeKindUnionTypeDefinition, ref)
 
 	for i := 0; i < len(w.visitors.enterUnionTypeDefinition); {
-		if w.filter == nil || w.filter.AllowVisitor(EnterUnionTypeDefinition, ref, w.visitors.enterUnionTypeDefinition[i]) {
+		if w.allowVisitor(EnterUnionTypeDefinition, ref, w.visitors.enterUnionTypeDefinition[i]) {
 			w.visitors.enterUnionTypeDefinition[i].EnterUnionTypeDefinition(ref)
 		}
 		if w.revisit {
@@ -2736,7 +2801,7 @@ func (w *Walker) walkUnionTypeDefinition(ref int) {
 	w.setCurrent(ast.NodeKindUnionTypeDefinition, ref)
 
 	for i := 0; i < len(w.visitors.leaveUnionTypeDefinition); {
-		if w.filter == nil || w.filter.AllowVisitor(LeaveUnionTypeDefinition, ref, w.visitors.leaveUnionTypeDefinition[i]) {
+		if w.allowVisitor(LeaveUnionTypeDefinition, ref, w.visitors.leaveUnionTypeDefinition[i]) {
 			w.visitors.leaveUnionTypeDefinition[i].LeaveUnionTypeDefinition(ref)
 		}
 		if w.revisit {
@@ -2763,7 +2828,7 @@ func (w *Walker) walkUnionTypeExtension(ref int) {
 	w.setCurrent(ast.NodeKindUnionTypeExtension, ref)
 
 	for i := 0; i < len(w.visitors.enterUnionTypeExtension); {
-		if w.filter == nil || w.filter.AllowVisitor(EnterUnionTypeExtension, ref, w.visitors.enterUnionTypeExtension[i]) {
+		if w.allowVisitor(EnterUnionTypeExtension, ref, w.visitors.enterUnionTypeExtension[i]) {
 			w.visitors.enterUnionTypeExtension[i].EnterUnionTypeExtension(ref)
 		}
 		if w.revisit {
@@ -2809,7 +2874,7 @@ func (w *Walker) walkUnionTypeExtension(ref int) {
 	w.setCurrent(ast.NodeKindUnionTypeExtension, ref)
 
 	for i := 0; i < len(w.visitors.leaveUnionTypeExtension); {
-		if w.filter == nil || w.filter.AllowVisitor(LeaveUnionTypeExtension, ref, w.visitors.leaveUnionTypeExtension[i]) {
+		if w.allowVisitor(LeaveUnionTypeExtension, ref, w.visitors.leaveUnionTypeExtension[i]) {
 			w.visitors.leaveUnionTypeExtension[i].LeaveUnionTypeExtension(ref)
 		}
 		if w.revisit {
@@ -2836,7 +2901,7 @@ func (w *Walker) walkUnionMemberType(ref int) {
 	w.setCurrent(ast.NodeKindUnionMemberType, ref)
 
 	for i := 0; i < len(w.visitors.enterUnionMemberType); {
-		if w.filter == nil || w.filter.AllowVisitor(EnterUnionMemberType, ref, w.visitors.enterUnionMemberType[i]) {
+		if w.allowVisitor(EnterUnionMemberType, ref, w.visitors.enterUnionMemberType[i]) {
 			w.visitors.enterUnionMemberType[i].EnterUnionMemberType(ref)
 		}
 		if w.revisit {
@@ -2855,7 +2920,7 @@ func (w *Walker) walkUnionMemberType(ref int) {
 	}
 
 	for i := 0; i < len(w.visitors.leaveUnionMemberType); {
-		if w.filter == nil || w.filter.AllowVisitor(LeaveUnionMemberType, ref, w.visitors.leaveUnionMemberType[i]) {
+		if w.allowVisitor(LeaveUnionMemberType, ref, w.visitors.leaveUnionMemberType[i]) {
 			w.visitors.leaveUnionMemberType[i].LeaveUnionMemberType(ref)
 		}
 		if w.revisit {
@@ -2882,7 +2947,7 @@ func (w *Walker) walkEnumTypeDefinition(ref int) {
 	w.setCurrent(ast.NodeKindEnumTypeDefinition, ref)
 
 	for i := 0; i < len(w.visitors.enterEnumTypeDefinition); {
-		if w.filter == nil || w.filter.AllowVisitor(EnterEnumTypeDefinition, ref, w.visitors.enterEnumTypeDefinition[i]) {
+		if w.allowVisitor(EnterEnumTypeDefinition, ref, w.visitors.enterEnumTypeDefinition[i]) {
 			w.visitors.enterEnumTypeDefinition[i].EnterEnumTypeDefinition(ref)
 		}
 		if w.revisit {
@@ -2928,7 +2993,7 @@ func (w *Walker) walkEnumTypeDefinition(ref int) {
 	w.setCurrent(ast.NodeKindEnumTypeDefinition, ref)
 
 	for i := 0; i < len(w.visitors.leaveEnumTypeDefinition); {
-		if w.filter == nil || w.filter.AllowVisitor(LeaveEnumTypeDefinition, ref, w.visitors.leaveEnumTypeDefinition[i]) {
+		if w.allowVisitor(LeaveEnumTypeDefinition, ref, w.visitors.leaveEnumTypeDefinition[i]) {
 			w.visitors.leaveEnumTypeDefinition[i].LeaveEnumTypeDefinition(ref)
 		}
 		if w.revisit {
@@ -2955,7 +3020,7 @@ func (w *Walker) walkEnumTypeExtension(ref int) {
 	w.setCurrent(ast.NodeKindEnumTypeExtension, ref)
 
 	for i := 0; i < len(w.visitors.enterEnumTypeExtension); {
-		if w.filter == nil || w.filter.AllowVisitor(EnterEnumTypeExtension, ref, w.visitors.enterEnumTypeExtension[i]) {
+		if w.allowVisitor(EnterEnumTypeExtension, ref, w.visitors.enterEnumTypeExtension[i]) {
 			w.visitors.enterEnumTypeExtension[i].EnterEnumTypeExtension(ref)
 		}
 		if w.revisit {
@@ -3001,7 +3066,7 @@ func (w *Walker) walkEnumTypeExtension(ref int) {
 	w.setCurrent(ast.NodeKindEnumTypeExtension, ref)
 
 	for i := 0; i < len(w.visitors.leaveEnumTypeExtension); {
-		if w.filter == nil || w.filter.AllowVisitor(LeaveEnumTypeExtension, ref, w.visitors.leaveEnumTypeExtension[i]) {
+		if w.allowVisitor(LeaveEnumTypeExtension, ref, w.visitors.leaveEnumTypeExtension[i]) {
 			w.visitors.leaveEnumTypeExtension[i].LeaveEnumTypeExtension(ref)
 		}
 		if w.revisit {
@@ -3028,7 +3093,7 @@ func (w *Walker) walkEnumValueDefinition(ref int) {
 	w.setCurrent(ast.NodeKindEnumValueDefinition, ref)
 
 	for i := 0; i < len(w.visitors.enterEnumValueDefinition); {
-		if w.filter == nil || w.filter.AllowVisitor(EnterEnumValueDefinition, ref, w.visitors.enterEnumValueDefinition[i]) {
+		if w.allowVisitor(EnterEnumValueDefinition, ref, w.visitors.enterEnumValueDefinition[i]) {
 			w.visitors.enterEnumValueDefinition[i].EnterEnumValueDefinition(ref)
 		}
 		if w.revisit {
@@ -3065,7 +3130,7 @@ func (w *Walker) walkEnumValueDefinition(ref int) {
 	w.setCurrent(ast.NodeKindEnumValueDefinition, ref)
 
 	for i := 0; i < len(w.visitors.leaveEnumValueDefinition); {
-		if w.filter == nil || w.filter.AllowVisitor(LeaveEnumValueDefinition, ref, w.visitors.leaveEnumValueDefinition[i]) {
+		if w.allowVisitor(LeaveEnumValueDefinition, ref, w.visitors.leaveEnumValueDefinition[i]) {
 			w.visitors.leaveEnumValueDefinition[i].LeaveEnumValueDefinition(ref)
 		}
 		if w.revisit {
@@ -3092,7 +3157,7 @@ func (w *Walker) walkInputObjectTypeDefinition(ref int) {
 	w.setCurrent(ast.NodeKindInputObjectTypeDefinition, ref)
 
 	for i := 0; i < len(w.visitors.enterInputObjectTypeDefinition); {
-		if w.filter == nil || w.filter.AllowVisitor(EnterInputObjectTypeDefinition, ref, w.visitors.enterInputObjectTypeDefinition[i]) {
+		if w.allowVisitor(EnterInputObjectTypeDefinition, ref, w.visitors.enterInputObjectTypeDefinition[i]) {
 			w.visitors.enterInputObjectTypeDefinition[i].EnterInputObjectTypeDefinition(ref)
 		}
 		if w.revisit {
@@ -3138,7 +3203,7 @@ func (w *Walker) walkInputObjectTypeDefinition(ref int) {
 	w.setCurrent(ast.NodeKindInputObjectTypeDefinition, ref)
 
 	for i := 0; i < len(w.visitors.leaveInputObjectTypeDefinition); {
-		if w.filter == nil || w.filter.AllowVisitor(LeaveInputObjectTypeDefinition, ref, w.visitors.leaveInputObjectTypeDefinition[i]) {
+		if w.allowVisitor(LeaveInputObjectTypeDefinition, ref, w.visitors.leaveInputObjectTypeDefinition[i]) {
 			w.visitors.leaveInputObjectTypeDefinition[i].LeaveInputObjectTypeDefinition(ref)
 		}
 		if w.revisit {
@@ -3165,7 +3230,7 @@ func (w *Walker) walkInputObjectTypeExtension(ref int) {
 	w.setCurrent(ast.NodeKindInputObjectTypeExtension, ref)
 
 	for i := 0; i < len(w.visitors.enterInputObjectTypeExtension); {
-		if w.filter == nil || w.filter.AllowVisitor(EnterInputObjectTypeExtension, ref, w.visitors.enterInputObjectTypeExtension[i]) {
+		if w.allowVisitor(EnterInputObjectTypeExtension, ref, w.visitors.enterInputObjectTypeExtension[i]) {
 			w.visitors.enterInputObjectTypeExtension[i].EnterInputObjectTypeExtension(ref)
 		}
 		if w.revisit {
@@ -3211,7 +3276,7 @@ func (w *Walker) walkInputObjectTypeExtension(ref int) {
 	w.setCurrent(ast.NodeKindInputObjectTypeExtension, ref)
 
 	for i := 0; i < len(w.visitors.leaveInputObjectTypeExtension); {
-		if w.filter == nil || w.filter.AllowVisitor(LeaveInputObjectTypeExtension, ref, w.visitors.leaveInputObjectTypeExtension[i]) {
+		if w.allowVisitor(LeaveInputObjectTypeExtension, ref, w.visitors.leaveInputObjectTypeExtension[i]) {
 			w.visitors.leaveInputObjectTypeExtension[i].LeaveInputObjectTypeExtension(ref)
 		}
 		if w.revisit {
@@ -3238,7 +3303,7 @@ func (w *Walker) walkDirectiveDefinition(ref int) {
 	w.setCurrent(ast.NodeKindDirectiveDefinition, ref)
 
 	for i := 0; i < len(w.visitors.enterDirectiveDefinition); {
-		if w.filter == nil || w.filter.AllowVisitor(EnterDirectiveDefinition, ref, w.visitors.enterDirectiveDefinition[i]) {
+		if w.allowVisitor(EnterDirectiveDefinition, ref, w.visitors.enterDirectiveDefinition[i]) {
 			w.visitors.enterDirectiveDefinition[i].EnterDirectiveDefinition(ref)
 		}
 		if w.revisit {
@@ -3280,7 +3345,7 @@ func (w *Walker) walkDirectiveDefinition(ref int) {
 	w.setCurrent(ast.NodeKindDirectiveDefinition, ref)
 
 	for i := 0; i < len(w.visitors.leaveDirectiveDefinition); {
-		if w.filter == nil || w.filter.AllowVisitor(LeaveDirectiveDefinition, ref, w.visitors.leaveDirectiveDefinition[i]) {
+		if w.allowVisitor(LeaveDirectiveDefinition, ref, w.visitors.leaveDirectiveDefinition[i]) {
 			w.visitors.leaveDirectiveDefinition[i].LeaveDirectiveDefinition(ref)
 		}
 		if w.revisit {
@@ -3305,7 +3370,7 @@ func (w *Walker) walkDirectiveLocation(location ast.DirectiveLocation) {
 	w.increaseDepth()
 
 	for i := 0; i < len(w.visitors.enterDirectiveLocation); {
-		if w.filter == nil || w.filter.AllowVisitor(EnterDirectiveLocation, 0, w.visitors.enterDirectiveLocation[i]) {
+		if w.allowVisitor(EnterDirectiveLocation, 0, w.visitors.enterDirectiveLocation[i]) {
 			w.visitors.enterDirectiveLocation[i].EnterDirectiveLocation(location)
 		}
 		if w.revisit {
@@ -3324,7 +3389,7 @@ func (w *Walker) walkDirectiveLocation(location ast.DirectiveLocation) {
 	}
 
 	for i := 0; i < len(w.visitors.leaveDirectiveLocation); {
-		if w.filter == nil || w.filter.AllowVisitor(LeaveDirectiveLocation, 0, w.visitors.leaveDirectiveLocation[i]) {
+		if w.allowVisitor(LeaveDirectiveLocation, 0, w.visitors.leaveDirectiveLocation[i]) {
 			w.visitors.leaveDirectiveLocation[i].LeaveDirectiveLocation(location)
 		}
 		if w.revisit {
@@ -3351,7 +3416,7 @@ func (w *Walker) walkSchemaDefinition(ref int) {
 	w.setCurrent(ast.NodeKindSchemaDefinition, ref)
 
 	for i := 0; i < len(w.visitors.enterSchemaDefinition); {
-		if w.filter == nil || w.filter.AllowVisitor(EnterSchemaDefinition, ref, w.visitors.enterSchemaDefinition[i]) {
+		if w.allowVisitor(EnterSchemaDefinition, ref, w.visitors.enterSchemaDefinition[i]) {
 			w.visitors.enterSchemaDefinition[i].EnterSchemaDefinition(ref)
 		}
 		if w.revisit {
@@ -3395,7 +3460,7 @@ func (w *Walker) walkSchemaDefinition(ref int) {
 	w.setCurrent(ast.NodeKindSchemaDefinition, ref)
 
 	for i := 0; i < len(w.visitors.leaveSchemaDefinition); {
-		if w.filter == nil || w.filter.AllowVisitor(LeaveSchemaDefinition, ref, w.visitors.leaveSchemaDefinition[i]) {
+		if w.allowVisitor(LeaveSchemaDefinition, ref, w.visitors.leaveSchemaDefinition[i]) {
 			w.visitors.leaveSchemaDefinition[i].LeaveSchemaDefinition(ref)
 		}
 		if w.revisit {
@@ -3422,7 +3487,7 @@ func (w *Walker) walkSchemaExtension(ref int) {
 	w.setCurrent(ast.NodeKindSchemaExtension, ref)
 
 	for i := 0; i < len(w.visitors.enterSchemaExtension); {
-		if w.filter == nil || w.filter.AllowVisitor(EnterSchemaExtension, ref, w.visitors.enterSchemaExtension[i]) {
+		if w.allowVisitor(EnterSchemaExtension, ref, w.visitors.enterSchemaExtension[i]) {
 			w.visitors.enterSchemaExtension[i].EnterSchemaExtension(ref)
 		}
 		if w.revisit {
@@ -3466,7 +3531,7 @@ func (w *Walker) walkSchemaExtension(ref int) {
 	w.setCurrent(ast.NodeKindSchemaExtension, ref)
 
 	for i := 0; i < len(w.visitors.leaveSchemaExtension); {
-		if w.filter == nil || w.filter.AllowVisitor(LeaveSchemaExtension, ref, w.visitors.leaveSchemaExtension[i]) {
+		if w.allowVisitor(LeaveSchemaExtension, ref, w.visitors.leaveSchemaExtension[i]) {
 			w.visitors.leaveSchemaExtension[i].LeaveSchemaExtension(ref)
 		}
 		if w.revisit {
@@ -3491,7 +3556,7 @@ func (w *Walker) walkRootOperationTypeDefinition(ref int) {
 	w.increaseDepth()
 
 	for i := 0; i < len(w.visitors.enterRootOperationTypeDefinition); {
-		if w.filter == nil || w.filter.AllowVisitor(EnterRootOperationTypeDefinition, ref, w.visitors.enterRootOperationTypeDefinition[i]) {
+		if w.allowVisitor(EnterRootOperationTypeDefinition, ref, w.visitors.enterRootOperationTypeDefinition[i]) {
 			w.visitors.enterRootOperationTypeDefinition[i].EnterRootOperationTypeDefinition(ref)
 		}
 		if w.revisit {
@@ -3510,7 +3575,7 @@ func (w *Walker) walkRootOperationTypeDefinition(ref int) {
 	}
 
 	for i := 0; i < len(w.visitors.leaveRootOperationTypeDefinition); {
-		if w.filter == nil || w.filter.AllowVisitor(LeaveRootOperationTypeDefinition, ref, w.visitors.leaveRootOperationTypeDefinition[i]) {
+		if w.allowVisitor(LeaveRootOperationTypeDefinition, ref, w.visitors.leaveRootOperationTypeDefinition[i]) {
 			w.visitors.leaveRootOperationTypeDefinition[i].LeaveRootOperationTypeDefinition(ref)
 		}
 		if w.revisit {
@@ -3551,6 +3616,30 @@ func (w *Walker) Stop() {
 	w.stop = true
 }
 
+// SkipNodeForVisitor skips the children and the leave callback of the current node for the visitor which is currently called.
+// Contrary to SkipNode all other registered visitors continue to walk the node.
+// Visitors which aren't pointers must be registered for all callbacks at once, e.g. using RegisterFieldVisitor,
+// as every conversion of such a visitor to an interface is a distinct visitor.
+func (w *Walker) SkipNodeForVisitor() {
+	if w.currentVisitor == (visitorIdentity{}) {
+		return
+	}
+	w.skippedVisitors = append(w.skippedVisitors, skippedVisitor{
+		visitor: w.currentVisitor,
+		depth:   w.Depth,
+	})
+}
+
+// StopVisitor stops calling the visitor which is currently called for the rest of the walk.
+// Contrary to Stop all other registered visitors continue to walk the document.
+// The same as for SkipNodeForVisitor applies to visitors which aren't pointers.
+func (w *Walker) StopVisitor() {
+	if w.currentVisitor == (visitorIdentity{}) {
+		return
+	}
+	w.stoppedVisitors = append(w.stoppedVisitors, w.currentVisitor)
+}
+
 func (w *Walker) RevisitNode() {
 	w.revisit = true
 }
@@ -3574,6 +3663,15 @@ func (w *Walker) StopWithExternalErr(err operationreport.ExternalError) {
 	w.Report.AddExternalError(err)
 }
 
+// StopVisitorWithExternalErr adds the error to the report and stops the visitor which is currently called.
+// Contrary to StopWithExternalErr all other registered visitors continue to walk the document,
+// e.g. to collect the errors of all validation rules in a single pass.
+func (w *Walker) StopVisitorWithExternalErr(err operationreport.ExternalError) {
+	w.StopVisitor()
+	err.Path = w.Path
+	w.Report.AddExternalError(err)
+}
+
 func (w *Walker) StopWithErr(internal error, external operationreport.ExternalError) {
 	w.stop = true
 	external.Path = w.Path
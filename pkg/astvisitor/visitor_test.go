@@ -9,6 +9,7 @@ import (
 
 	"github.com/jensneuse/diffview"
 	"github.com/sebdah/goldie"
+	"github.com/stretchr/testify/assert"
 
 	"github.com/wundergraph/graphql-go-tools/internal/pkg/unsafeparser"
 	"github.com/wundergraph/graphql-go-tools/pkg/ast"
@@ -272,6 +273,260 @@ func (s *skipUserVisitor) EnterField(ref int) {
 	}
 }
 
+func TestWalker_SkipNodeForVisitor(t *testing.T) {
+	definition := unsafeparser.ParseGraphqlDocumentString(testDefinition)
+	operation := unsafeparser.ParseGraphqlDocumentString(`
+		query PostsUserQuery {
+			posts {
+				id
+				user {
+					id
+					name
+				}
+				description
+			}
+		}`)
+
+	walker := NewWalker(48)
+	skipping := &fieldNamesVisitor{Walker: &walker, skip: "user"}
+	stopping := &fieldNamesVisitor{Walker: &walker, stop: "user"}
+	all := &fieldNamesVisitor{Walker: &walker}
+	for _, visitor := range []*fieldNamesVisitor{skipping, stopping, all} {
+		walker.RegisterEnterDocumentVisitor(visitor)
+		walker.RegisterFieldVisitor(visitor)
+	}
+
+	report := operationreport.Report{}
+	walker.Walk(&operation, &definition, &report)
+	if report.HasErrors() {
+		t.Fatal(report.Error())
+	}
+
+	assert.Equal(t, []string{
+		"enter posts", "enter id", "leave id", "enter user", "enter description", "leave description", "leave posts",
+	}, skipping.calls)
+	assert.Equal(t, []string{
+		"enter posts", "enter id", "leave id", "enter user",
+	}, stopping.calls)
+	assert.Equal(t, []string{
+		"enter posts", "enter id", "leave id", "enter user", "enter id", "leave id", "enter name", "leave name", "leave user",
+		"enter description", "leave description", "leave posts",
+	}, all.calls)
+
+	skipping.calls = skipping.calls[:0]
+	stopping.calls = stopping.calls[:0]
+	walker.Walk(&operation, &definition, &report)
+	assert.Len(t, skipping.calls, 7)
+	assert.Len(t, stopping.calls, 4, "stopped visitors must be reset for every walk")
+}
+
+func TestWalker_SkipNodeForVisitor_NonComparableVisitor(t *testing.T) {
+	definition := unsafeparser.ParseGraphqlDocumentString(testDefinition)
+	operation := unsafeparser.ParseGraphqlDocumentString(`
+		query PostsUserQuery {
+			posts {
+				id
+				user {
+					id
+					name
+				}
+			}
+		}`)
+
+	walker := NewWalker(48)
+	skipping := fieldCallsVisitor{walker: &walker, operation: &operation, skip: "user", calls: map[string]int{}}
+	stopping := fieldCallsVisitor{walker: &walker, operation: &operation, stop: "posts", calls: map[string]int{}}
+	all := fieldCallsVisitor{walker: &walker, operation: &operation, calls: map[string]int{}}
+	walker.RegisterFieldVisitor(skipping)
+	walker.RegisterFieldVisitor(stopping)
+	walker.RegisterFieldVisitor(all)
+
+	report := operationreport.Report{}
+	walker.Walk(&operation, &definition, &report)
+	if report.HasErrors() {
+		t.Fatal(report.Error())
+	}
+
+	assert.Equal(t, map[string]int{"enter posts": 1, "enter id": 1, "leave id": 1, "enter user": 1, "leave posts": 1}, skipping.calls)
+	assert.Equal(t, map[string]int{"enter posts": 1}, stopping.calls)
+	assert.Equal(t, map[string]int{
+		"enter posts": 1, "enter id": 2, "leave id": 2, "enter user": 1, "enter name": 1, "leave name": 1, "leave user": 1, "leave posts": 1,
+	}, all.calls)
+}
+
+// fieldCallsVisitor is a visitor value which isn't comparable because of the map
+type fieldCallsVisitor struct {
+	walker     *Walker
+	operation  *ast.Document
+	skip, stop string
+	calls      map[string]int
+}
+
+func (f fieldCallsVisitor) EnterField(ref int) {
+	name := f.operation.FieldNameString(ref)
+	f.calls["enter "+name]++
+	switch name {
+	case f.skip:
+		f.walker.SkipNodeForVisitor()
+	case f.stop:
+		f.walker.StopVisitor()
+	}
+}
+
+func (f fieldCallsVisitor) LeaveField(ref int) {
+	f.calls["leave "+f.operation.FieldNameString(ref)]++
+}
+
+type fieldNamesVisitor struct {
+	*Walker
+	operation  *ast.Document
+	skip, stop string
+	calls      []string
+}
+
+func (f *fieldNamesVisitor) EnterDocument(operation, definition *ast.Document) {
+	f.operation = operation
+}
+
+func (f *fieldNamesVisitor) EnterField(ref int) {
+	name := f.operation.FieldNameString(ref)
+	f.calls = append(f.calls, "enter "+name)
+	switch name {
+	case f.skip:
+		f.SkipNodeForVisitor()
+	case f.stop:
+		f.StopVisitor()
+	}
+}
+
+func (f *fieldNamesVisitor) LeaveField(ref int) {
+	f.calls = append(f.calls, "leave "+f.operation.FieldNameString(ref))
+}
+
+func TestWalker_PathParentTypeName(t *testing.T) {
+	definition := unsafeparser.ParseGraphqlDocumentString(`
+		schema { query: Query }
+		type Query { node: Node }
+		interface Node { id: ID }
+		type User implements Node { id: ID friends: [User] }
+		scalar ID`)
+	operation := unsafeparser.ParseGraphqlDocumentString(`
+		query {
+			node {
+				... on User {
+					buddies: friends {
+						id
+					}
+				}
+			}
+		}`)
+
+	walker := NewWalker(48)
+	visitor := &parentTypeNameVisitor{Walker: &walker}
+	walker.RegisterEnterFieldVisitor(visitor)
+
+	report := operationreport.Report{}
+	walker.Walk(&operation, &definition, &report)
+	if report.HasErrors() {
+		t.Fatal(report.Error())
+	}
+
+	assert.Equal(t, []string{
+		"query:",
+		"query: node:Query",
+		"query: node:Query buddies:User",
+	}, visitor.paths)
+}
+
+type parentTypeNameVisitor struct {
+	*Walker
+	paths []string
+}
+
+func (p *parentTypeNameVisitor) EnterField(ref int) {
+	path := ""
+	for _, item := range p.Path {
+		if path != "" {
+			path += " "
+		}
+		path += fmt.Sprintf("%s:%s", item.FieldName, item.ParentTypeName)
+	}
+	p.paths = append(p.paths, path)
+}
+
+func TestParallelWalker(t *testing.T) {
+	definition := unsafeparser.ParseGraphqlDocumentString(testDefinition)
+
+	walk := func(shards int) (operationreport.Report, []*typeNamesVisitor) {
+		var visitors []*typeNamesVisitor
+		walker := NewParallelWalker(shards, func(walker *Walker) {
+			visitor := &typeNamesVisitor{Walker: walker}
+			walker.RegisterEnterDocumentVisitor(visitor)
+			walker.RegisterEnterObjectTypeDefinitionVisitor(visitor)
+			visitors = append(visitors, visitor)
+		})
+		report := operationreport.Report{}
+		walker.Walk(&definition, nil, &report)
+		return report, visitors
+	}
+
+	expected, visitors := walk(1)
+	assert.Len(t, visitors, 1)
+	assert.True(t, len(expected.ExternalErrors) > 3)
+
+	for _, shards := range []int{2, 3, 64} {
+		report, visitors := walk(shards)
+		assert.Equal(t, expected, report)
+
+		walked := 0
+		for _, visitor := range visitors {
+			walked += visitor.walked
+		}
+		assert.Equal(t, len(expected.ExternalErrors), walked)
+	}
+
+	t.Run("root nodes not dividing evenly into shards", func(t *testing.T) {
+		definition := unsafeparser.ParseGraphqlDocumentString(`
+			type A { a: String }
+			type B { b: String }
+			type C { c: String }
+			type D { d: String }
+			type E { e: String }
+		`)
+
+		walker := NewParallelWalker(4, func(walker *Walker) {
+			visitor := &typeNamesVisitor{Walker: walker}
+			walker.RegisterEnterDocumentVisitor(visitor)
+			walker.RegisterEnterObjectTypeDefinitionVisitor(visitor)
+		})
+		report := operationreport.Report{}
+		walker.Walk(&definition, nil, &report)
+
+		var typeNames []string
+		for _, err := range report.ExternalErrors {
+			typeNames = append(typeNames, err.Message)
+		}
+		assert.Equal(t, []string{"A", "B", "C", "D", "E"}, typeNames)
+	})
+}
+
+type typeNamesVisitor struct {
+	*Walker
+	definition *ast.Document
+	walked     int
+}
+
+func (v *typeNamesVisitor) EnterDocument(operation, definition *ast.Document) {
+	v.definition = operation
+}
+
+func (v *typeNamesVisitor) EnterObjectTypeDefinition(ref int) {
+	v.walked++
+	v.Report.AddExternalError(operationreport.ExternalError{
+		Message: v.definition.ObjectTypeDefinitionNameString(ref),
+	})
+}
+
 func BenchmarkVisitor(b *testing.B) {
 
 	definition := unsafeparser.ParseGraphqlDocumentString(testDefinition)
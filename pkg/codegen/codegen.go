@@ -1,6 +1,7 @@
 // Package codegen generates code to make using this library easier
-// You can currently use the code generator to generate go structs and Unmarshal methods for Directives and Input Objects type definitions
+// You can use the code generator to generate go structs and Unmarshal methods for Directives and Input Objects type definitions
 // This helps you interact very easily with configuration supplied by Directives which you can easily unmarshal into go structs
+// Additionally OperationsCodeGen generates a typed go client for the operations of an executable document
 package codegen
 
 import (
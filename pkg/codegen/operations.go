@@ -0,0 +1,804 @@
+package codegen
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/iancoleman/strcase"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astimport"
+	"github.com/wundergraph/graphql-go-tools/pkg/astnormalization"
+	"github.com/wundergraph/graphql-go-tools/pkg/astparser"
+	"github.com/wundergraph/graphql-go-tools/pkg/astprinter"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvalidation"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+const graphqlPackagePath = "github.com/wundergraph/graphql-go-tools/pkg/graphql"
+
+type OperationsConfig struct {
+	PackageName string
+	// ScalarTypes maps custom scalars to go types, e.g. "DateTime": "time.Time" or "Decimal": "github.com/shopspring/decimal.Decimal"
+	// Custom scalars without a mapping are generated as json.RawMessage
+	ScalarTypes map[string]string
+	// ExecutionEngine additionally generates a func per operation which executes it using a graphql.ExecutionEngineV2
+	ExecutionEngine bool
+}
+
+// OperationsCodeGen generates a typed go client for the named operations of an executable document
+// For each operation it generates a struct for the variables, structs for the response shape
+// and a func to execute the operation using a http.Client
+type OperationsCodeGen struct {
+	schema     *ast.Document
+	operations *ast.Document
+	config     OperationsConfig
+	file       *jen.File
+	enums      []string
+	inputs     []string
+	generated  map[string]bool
+	names      map[string]bool // identifiers declared at the package level of the generated file
+}
+
+// selectedField is a field of a response struct, merged from all selections with the same response key
+type selectedField struct {
+	responseKey   string
+	fieldName     string
+	typeRef       int   // type of the field definition in the schema, -1 for __typename
+	selectionSets []int // selection sets of the field in the operations document
+	goName        string
+}
+
+// NewOperations returns a generator for the operations
+// The schema must be merged with the base schema, e.g. using asttransform.MergeDefinitionWithBaseSchema
+func NewOperations(schema, operations *ast.Document, config OperationsConfig) *OperationsCodeGen {
+	return &OperationsCodeGen{
+		schema:     schema,
+		operations: operations,
+		config:     config,
+	}
+}
+
+// Generate validates the operations against the schema and writes the generated code to w
+// Selection sets of interfaces and unions with fragments on their possible types get a __typename field added
+// to the operations document if it's not selected, it's used to unmarshal the response into the matching type.
+func (c *OperationsCodeGen) Generate(w io.Writer) (int, error) {
+	if err := c.validate(); err != nil {
+		return 0, err
+	}
+
+	c.file = jen.NewFile(c.config.PackageName)
+	c.file.PackageComment("Code generated by graphql-go-tools gen, DO NOT EDIT.")
+	c.enums = c.enums[:0]
+	c.inputs = c.inputs[:0]
+	c.generated = map[string]bool{}
+	c.names = map[string]bool{}
+	if err := c.reserveNames(); err != nil {
+		return 0, err
+	}
+
+	executable := false
+	for _, node := range c.operations.RootNodes {
+		if node.Kind != ast.NodeKindOperationDefinition {
+			continue
+		}
+		if err := c.renderOperation(node.Ref); err != nil {
+			return 0, err
+		}
+		if c.operations.OperationDefinitions[node.Ref].OperationType != ast.OperationTypeSubscription {
+			executable = true
+		}
+	}
+
+	for i := 0; i < len(c.enums); i++ {
+		c.renderEnum(c.enums[i])
+	}
+	for i := 0; i < len(c.inputs); i++ {
+		c.renderInputObject(c.inputs[i])
+	}
+	if executable {
+		c.renderExecutionHelpers()
+	}
+
+	return fmt.Fprintf(w, "%#v", c.file)
+}
+
+// validate validates a normalized copy of the operations, the fragments of the operations are needed for generating the structs
+func (c *OperationsCodeGen) validate() error {
+	printed, err := astprinter.PrintString(c.operations, nil)
+	if err != nil {
+		return err
+	}
+
+	operations := ast.NewDocument()
+	operations.Input.ResetInputString(printed)
+	report := operationreport.Report{}
+	astparser.NewParser().Parse(operations, &report)
+	if report.HasErrors() {
+		return report
+	}
+
+	normalizer := astnormalization.NewWithOpts(astnormalization.WithRemoveFragmentDefinitions())
+	normalizer.NormalizeOperation(operations, c.schema, &report)
+	if report.HasErrors() {
+		return report
+	}
+
+	astvalidation.DefaultOperationValidator().Validate(operations, c.schema, &report)
+	if report.HasErrors() {
+		return report
+	}
+	return nil
+}
+
+// reserveNames reserves the package level identifiers which aren't derived from selections
+// The structs of the selections get a numeric suffix in case their name is already used.
+func (c *OperationsCodeGen) reserveNames() error {
+	for _, name := range []string{"GraphQLError", "GraphQLErrors", "graphqlRequest", "executeHTTP", "executeEngine", "decodeResponse"} {
+		c.names[name] = true
+	}
+
+	for _, node := range c.schema.RootNodes {
+		switch node.Kind {
+		case ast.NodeKindEnumTypeDefinition:
+			name := c.schema.EnumTypeDefinitionNameString(node.Ref)
+			c.names[name] = true
+			for _, i := range c.schema.EnumTypeDefinitions[node.Ref].EnumValuesDefinition.Refs {
+				c.names[name+"_"+c.schema.EnumValueDefinitionNameString(i)] = true
+			}
+		case ast.NodeKindInputObjectTypeDefinition:
+			c.names[c.schema.InputObjectTypeDefinitionNameString(node.Ref)] = true
+		}
+	}
+
+	for _, node := range c.operations.RootNodes {
+		if node.Kind != ast.NodeKindOperationDefinition {
+			continue
+		}
+		operationName := c.operations.OperationDefinitionNameString(node.Ref)
+		if operationName == "" {
+			continue
+		}
+		name := strcase.ToCamel(operationName)
+		for _, identifier := range []string{name, name + "WithEngine", name + "Variables", name + "Response", name + "Operation"} {
+			if c.names[identifier] {
+				return fmt.Errorf("codegen: identifier %s of operation %s is already declared", identifier, operationName)
+			}
+			c.names[identifier] = true
+		}
+	}
+	return nil
+}
+
+// uniqueName returns name or, if name is already used, name with the lowest numeric suffix which is unused
+// The returned name is marked as used.
+func uniqueName(used map[string]bool, name string) string {
+	unique := name
+	for i := 2; used[unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	used[unique] = true
+	return unique
+}
+
+func (c *OperationsCodeGen) renderOperation(ref int) error {
+	operationName := c.operations.OperationDefinitionNameString(ref)
+	if operationName == "" {
+		return fmt.Errorf("codegen: operations must be named to generate code")
+	}
+	name := strcase.ToCamel(operationName)
+
+	var rootTypeName ast.ByteSlice
+	operationType := c.operations.OperationDefinitions[ref].OperationType
+	switch operationType {
+	case ast.OperationTypeQuery:
+		rootTypeName = c.schema.Index.QueryTypeName
+	case ast.OperationTypeMutation:
+		rootTypeName = c.schema.Index.MutationTypeName
+	case ast.OperationTypeSubscription:
+		rootTypeName = c.schema.Index.SubscriptionTypeName
+	}
+	if len(rootTypeName) == 0 {
+		return fmt.Errorf("codegen: schema has no root type for operation %s", operationName)
+	}
+
+	variables := c.operations.OperationDefinitions[ref].VariableDefinitions.Refs
+	if len(variables) != 0 {
+		c.file.Type().Id(name + "Variables").StructFunc(func(group *jen.Group) {
+			fieldNames := map[string]bool{}
+			for _, i := range variables {
+				variableName := c.operations.VariableDefinitionNameString(i)
+				typeRef := c.operations.VariableDefinitions[i].Type
+				tag := variableName
+				if c.operations.Types[typeRef].TypeKind != ast.TypeKindNonNull {
+					tag += ",omitempty"
+				}
+				group.Id(uniqueName(fieldNames, strcase.ToCamel(variableName))).Add(c.renderInputType(c.operations, typeRef, true)).Tag(map[string]string{"json": tag})
+			}
+		})
+	}
+
+	// __typename fields get added while rendering the response, so the operation gets printed afterwards
+	c.renderSelectionSetStruct(name+"Response", []int{c.operations.OperationDefinitions[ref].SelectionSet}, string(rootTypeName))
+
+	query, err := c.printOperation(ref)
+	if err != nil {
+		return err
+	}
+	c.file.Const().Id(name + "Operation").Op("=").Lit(query)
+
+	if operationType == ast.OperationTypeSubscription {
+		return nil
+	}
+
+	c.renderExecuteFunc(name, operationName, "", operationType, variables, []jen.Code{
+		jen.Id("client").Op("*").Qual("net/http", "Client"),
+		jen.Id("url").String(),
+	}, jen.Id("executeHTTP").Call(jen.Id("ctx"), jen.Id("client"), jen.Id("url"), jen.Id("request"), jen.Id("response")))
+
+	if c.config.ExecutionEngine {
+		c.renderExecuteFunc(name, operationName, "WithEngine", operationType, variables, []jen.Code{
+			jen.Id("engine").Op("*").Qual(graphqlPackagePath, "ExecutionEngineV2"),
+		}, jen.Id("executeEngine").Call(jen.Id("ctx"), jen.Id("engine"), jen.Id("request"), jen.Id("response")))
+	}
+
+	return nil
+}
+
+func (c *OperationsCodeGen) renderExecuteFunc(name, operationName, suffix string, operationType ast.OperationType, variables []int, params []jen.Code, execute *jen.Statement) {
+	params = append([]jen.Code{jen.Id("ctx").Qual("context", "Context")}, params...)
+	request := jen.Dict{
+		jen.Id("Query"):         jen.Id(name + "Operation"),
+		jen.Id("OperationName"): jen.Lit(operationName),
+	}
+	if len(variables) != 0 {
+		params = append(params, jen.Id("variables").Id(name+"Variables"))
+		request[jen.Id("Variables")] = jen.Id("variables")
+	}
+
+	via := "a http.Client"
+	if suffix == "WithEngine" {
+		via = "the execution engine"
+	}
+	c.file.Comment(fmt.Sprintf("%s executes the %s %s using %s", name+suffix, operationName, operationTypeName(operationType), via))
+	c.file.Comment("The response is returned together with GraphQL errors as it might contain partial data")
+	c.file.Func().Id(name+suffix).Params(params...).Params(jen.Op("*").Id(name+"Response"), jen.Error()).Block(
+		jen.Id("request").Op(":=").Id("graphqlRequest").Values(request),
+		jen.Id("response").Op(":=").Op("&").Id(name+"Response").Values(),
+		jen.Id("err").Op(":=").Add(execute),
+		jen.Return(jen.Id("response"), jen.Id("err")),
+	)
+}
+
+func operationTypeName(operationType ast.OperationType) string {
+	switch operationType {
+	case ast.OperationTypeMutation:
+		return "mutation"
+	case ast.OperationTypeSubscription:
+		return "subscription"
+	default:
+		return "query"
+	}
+}
+
+// printOperation prints the operation together with all fragments it uses
+func (c *OperationsCodeGen) printOperation(ref int) (string, error) {
+	doc := ast.NewDocument()
+	importer := &astimport.Importer{}
+	importer.ImportOperationDefinition(ref, c.operations, doc)
+
+	fragments := map[string]bool{}
+	c.usedFragments(c.operations.OperationDefinitions[ref].SelectionSet, fragments)
+	for _, node := range c.operations.RootNodes {
+		if node.Kind != ast.NodeKindFragmentDefinition {
+			continue
+		}
+		if fragments[c.operations.FragmentDefinitionNameString(node.Ref)] {
+			importer.ImportFragmentDefinition(node.Ref, c.operations, doc)
+		}
+	}
+
+	return astprinter.PrintString(doc, nil)
+}
+
+func (c *OperationsCodeGen) usedFragments(set int, fragments map[string]bool) {
+	for _, selection := range c.operations.SelectionSets[set].SelectionRefs {
+		ref := c.operations.Selections[selection].Ref
+		switch c.operations.Selections[selection].Kind {
+		case ast.SelectionKindField:
+			if c.operations.Fields[ref].HasSelections {
+				c.usedFragments(c.operations.Fields[ref].SelectionSet, fragments)
+			}
+		case ast.SelectionKindInlineFragment:
+			c.usedFragments(c.operations.InlineFragments[ref].SelectionSet, fragments)
+		case ast.SelectionKindFragmentSpread:
+			name := c.operations.FragmentSpreadNameString(ref)
+			if fragments[name] {
+				continue
+			}
+			fragments[name] = true
+			if fragment, exists := c.operations.FragmentDefinitionRef(c.operations.FragmentSpreadNameBytes(ref)); exists {
+				c.usedFragments(c.operations.FragmentDefinitions[fragment].SelectionSet, fragments)
+			}
+		}
+	}
+}
+
+// renderSelectionSetStruct renders the struct for the merged selection sets on typeName followed by the structs of its fields
+// Interfaces and unions get a pointer field per possible type selected using fragments and an UnmarshalJSON method to fill it based on __typename.
+func (c *OperationsCodeGen) renderSelectionSetStruct(name string, selectionSets []int, typeName string) {
+	var possibleTypes []string
+	node, _ := c.schema.NodeByNameStr(typeName)
+	if node.Kind == ast.NodeKindInterfaceTypeDefinition || node.Kind == ast.NodeKindUnionTypeDefinition {
+		for _, objectTypeName := range c.possibleTypes(node) {
+			if c.hasFragmentOnPossibleType(selectionSets, typeName, objectTypeName) {
+				possibleTypes = append(possibleTypes, objectTypeName)
+			}
+		}
+	}
+
+	fields := c.collectFields(selectionSets, typeName, nil)
+	if len(possibleTypes) != 0 && !hasTypenameField(fields) {
+		c.operations.AddFieldToSelectionSet(selectionSets[0], "__typename")
+		fields = c.collectFields(selectionSets, typeName, nil)
+	}
+
+	fieldNames := setGoNames(fields)
+	possibleTypeFields := make([]string, len(possibleTypes))
+	possibleTypeStructs := make([]string, len(possibleTypes))
+	for i, objectTypeName := range possibleTypes {
+		possibleTypeFields[i] = uniqueName(fieldNames, "As"+strcase.ToCamel(objectTypeName))
+		possibleTypeStructs[i] = uniqueName(c.names, name+strcase.ToCamel(objectTypeName))
+	}
+
+	c.renderStruct(name, fields, func(group *jen.Group) {
+		for i := range possibleTypes {
+			group.Id(possibleTypeFields[i]).Op("*").Id(possibleTypeStructs[i]).Tag(map[string]string{"json": "-"})
+		}
+	})
+
+	if len(possibleTypes) == 0 {
+		return
+	}
+
+	for i, objectTypeName := range possibleTypes {
+		possibleTypeFields := c.collectFields(selectionSets, objectTypeName, nil)
+		setGoNames(possibleTypeFields)
+		c.renderStruct(possibleTypeStructs[i], possibleTypeFields, nil)
+	}
+
+	typenameField := ""
+	for _, field := range fields {
+		if field.responseKey == "__typename" && field.fieldName == "__typename" {
+			typenameField = field.goName
+		}
+	}
+
+	shortHandle := strings.ToLower(name)[0:1]
+	c.file.Func().Params(jen.Id(shortHandle).Op("*").Id(name)).Id("UnmarshalJSON").Params(jen.Id("data").Index().Byte()).Error().Block(
+		jen.Type().Id("alias").Id(name),
+		jen.If(
+			jen.Err().Op(":=").Qual("encoding/json", "Unmarshal").Call(jen.Id("data"), jen.Parens(jen.Op("*").Id("alias")).Parens(jen.Id(shortHandle))),
+			jen.Err().Op("!=").Nil(),
+		).Block(jen.Return(jen.Err())),
+		jen.Switch(jen.Id(shortHandle).Dot(typenameField)).BlockFunc(func(group *jen.Group) {
+			for i, objectTypeName := range possibleTypes {
+				group.Case(jen.Lit(objectTypeName)).Block(
+					jen.Id(shortHandle).Dot(possibleTypeFields[i]).Op("=").Op("&").Id(possibleTypeStructs[i]).Values(),
+					jen.Return(jen.Qual("encoding/json", "Unmarshal").Call(jen.Id("data"), jen.Id(shortHandle).Dot(possibleTypeFields[i]))),
+				)
+			}
+		}),
+		jen.Return(jen.Nil()),
+	)
+}
+
+// setGoNames sets a unique go name for each field of a struct and returns the used names
+// Different response keys might result in the same go name, e.g. a_b and aB or __typename and typename.
+func setGoNames(fields []*selectedField) map[string]bool {
+	used := map[string]bool{}
+	for _, field := range fields {
+		field.goName = uniqueName(used, strcase.ToCamel(field.responseKey))
+	}
+	return used
+}
+
+// renderStruct renders a struct with a field per selected field, the structs of fields with selections get rendered afterwards
+// The go names of the fields must be set using setGoNames.
+func (c *OperationsCodeGen) renderStruct(name string, fields []*selectedField, extraFields func(group *jen.Group)) {
+	type nestedStruct struct {
+		name          string
+		selectionSets []int
+		typeName      string
+	}
+	var nested []nestedStruct
+
+	c.file.Type().Id(name).StructFunc(func(group *jen.Group) {
+		for _, field := range fields {
+			stmt := group.Id(field.goName)
+			if field.typeRef == -1 {
+				stmt.String().Tag(map[string]string{"json": field.responseKey})
+				continue
+			}
+			typeName := c.schema.ResolveTypeNameString(field.typeRef)
+			node, _ := c.schema.NodeByNameStr(typeName)
+			switch node.Kind {
+			case ast.NodeKindObjectTypeDefinition, ast.NodeKindInterfaceTypeDefinition, ast.NodeKindUnionTypeDefinition:
+				structName := uniqueName(c.names, name+field.goName)
+				nested = append(nested, nestedStruct{name: structName, selectionSets: field.selectionSets, typeName: typeName})
+				c.renderOutputType(stmt, field.typeRef, true, structName)
+			default:
+				c.renderOutputType(stmt, field.typeRef, true, "")
+			}
+			stmt.Tag(map[string]string{"json": field.responseKey})
+		}
+		if extraFields != nil {
+			extraFields(group)
+		}
+	})
+
+	for _, child := range nested {
+		c.renderSelectionSetStruct(child.name, child.selectionSets, child.typeName)
+	}
+}
+
+// renderOutputType renders the go type of a schema type, structName is used for types with selections
+func (c *OperationsCodeGen) renderOutputType(stmt *jen.Statement, ref int, nullable bool, structName string) {
+	switch c.schema.Types[ref].TypeKind {
+	case ast.TypeKindNonNull:
+		c.renderOutputType(stmt, c.schema.Types[ref].OfType, false, structName)
+	case ast.TypeKindList:
+		if nullable {
+			stmt.Op("*")
+		}
+		c.renderOutputType(stmt.Index(), c.schema.Types[ref].OfType, true, structName)
+	case ast.TypeKindNamed:
+		if nullable {
+			stmt.Op("*")
+		}
+		if structName != "" {
+			stmt.Id(structName)
+			return
+		}
+		stmt.Add(c.namedType(c.schema.TypeNameString(ref)))
+	}
+}
+
+// renderInputType renders the go type of a variable or input field type of the doc
+func (c *OperationsCodeGen) renderInputType(doc *ast.Document, ref int, nullable bool) *jen.Statement {
+	stmt := &jen.Statement{}
+	switch doc.Types[ref].TypeKind {
+	case ast.TypeKindNonNull:
+		return c.renderInputType(doc, doc.Types[ref].OfType, false)
+	case ast.TypeKindList:
+		if nullable {
+			stmt.Op("*")
+		}
+		return stmt.Index().Add(c.renderInputType(doc, doc.Types[ref].OfType, true))
+	}
+	if nullable {
+		stmt.Op("*")
+	}
+	return stmt.Add(c.namedType(doc.TypeNameString(ref)))
+}
+
+// namedType returns the go type of a scalar, enum or input object, enums and input objects get queued for rendering
+func (c *OperationsCodeGen) namedType(typeName string) *jen.Statement {
+	switch typeName {
+	case "String", "ID":
+		return jen.String()
+	case "Int":
+		return jen.Int64()
+	case "Float":
+		return jen.Float64()
+	case "Boolean":
+		return jen.Bool()
+	}
+
+	node, _ := c.schema.NodeByNameStr(typeName)
+	switch node.Kind {
+	case ast.NodeKindEnumTypeDefinition:
+		if !c.generated[typeName] {
+			c.generated[typeName] = true
+			c.enums = append(c.enums, typeName)
+		}
+		return jen.Id(typeName)
+	case ast.NodeKindInputObjectTypeDefinition:
+		if !c.generated[typeName] {
+			c.generated[typeName] = true
+			c.inputs = append(c.inputs, typeName)
+		}
+		return jen.Id(typeName)
+	}
+
+	goType, ok := c.config.ScalarTypes[typeName]
+	if !ok {
+		return jen.Qual("encoding/json", "RawMessage")
+	}
+	if i := strings.LastIndex(goType, "."); i != -1 {
+		return jen.Qual(goType[:i], goType[i+1:])
+	}
+	return jen.Id(goType)
+}
+
+func (c *OperationsCodeGen) renderEnum(name string) {
+	node, _ := c.schema.NodeByNameStr(name)
+	c.file.Type().Id(name).String()
+	refs := c.schema.EnumTypeDefinitions[node.Ref].EnumValuesDefinition.Refs
+	if len(refs) == 0 {
+		return
+	}
+	c.file.Const().DefsFunc(func(group *jen.Group) {
+		for _, i := range refs {
+			valueName := c.schema.EnumValueDefinitionNameString(i)
+			group.Id(name + "_" + valueName).Id(name).Op("=").Lit(valueName)
+		}
+	})
+}
+
+func (c *OperationsCodeGen) renderInputObject(name string) {
+	node, _ := c.schema.NodeByNameStr(name)
+	c.file.Type().Id(name).StructFunc(func(group *jen.Group) {
+		fieldNames := map[string]bool{}
+		for _, i := range c.schema.InputObjectTypeDefinitions[node.Ref].InputFieldsDefinition.Refs {
+			fieldName := c.schema.InputValueDefinitionNameString(i)
+			typeRef := c.schema.InputValueDefinitionType(i)
+			tag := fieldName
+			if c.schema.Types[typeRef].TypeKind != ast.TypeKindNonNull {
+				tag += ",omitempty"
+			}
+			group.Id(uniqueName(fieldNames, strcase.ToCamel(fieldName))).Add(c.renderInputType(c.schema, typeRef, true)).Tag(map[string]string{"json": tag})
+		}
+	})
+}
+
+// collectFields collects the fields selected on typeName, including the fields of all fragments which apply to typeName
+// Fields with the same response key are merged into a single field.
+func (c *OperationsCodeGen) collectFields(selectionSets []int, typeName string, fields []*selectedField) []*selectedField {
+	for _, set := range selectionSets {
+		for _, selection := range c.operations.SelectionSets[set].SelectionRefs {
+			ref := c.operations.Selections[selection].Ref
+			switch c.operations.Selections[selection].Kind {
+			case ast.SelectionKindField:
+				fields = c.addField(ref, typeName, fields)
+			case ast.SelectionKindInlineFragment:
+				if c.fragmentApplies(c.inlineFragmentTypeCondition(ref, typeName), typeName) {
+					fields = c.collectFields([]int{c.operations.InlineFragments[ref].SelectionSet}, typeName, fields)
+				}
+			case ast.SelectionKindFragmentSpread:
+				fragment, exists := c.operations.FragmentDefinitionRef(c.operations.FragmentSpreadNameBytes(ref))
+				if exists && c.fragmentApplies(string(c.operations.FragmentDefinitionTypeName(fragment)), typeName) {
+					fields = c.collectFields([]int{c.operations.FragmentDefinitions[fragment].SelectionSet}, typeName, fields)
+				}
+			}
+		}
+	}
+	return fields
+}
+
+func (c *OperationsCodeGen) addField(ref int, typeName string, fields []*selectedField) []*selectedField {
+	responseKey := c.operations.FieldAliasOrNameString(ref)
+	for _, field := range fields {
+		if field.responseKey == responseKey {
+			if c.operations.Fields[ref].HasSelections {
+				field.selectionSets = append(field.selectionSets, c.operations.Fields[ref].SelectionSet)
+			}
+			return fields
+		}
+	}
+
+	field := &selectedField{
+		responseKey: responseKey,
+		fieldName:   c.operations.FieldNameString(ref),
+		typeRef:     -1,
+	}
+	if field.fieldName != "__typename" {
+		node, _ := c.schema.NodeByNameStr(typeName)
+		definition, exists := c.schema.NodeFieldDefinitionByName(node, c.operations.FieldNameBytes(ref))
+		if !exists {
+			// e.g. __schema or __type, which are already covered by validation
+			return fields
+		}
+		field.typeRef = c.schema.FieldDefinitionType(definition)
+	}
+	if c.operations.Fields[ref].HasSelections {
+		field.selectionSets = append(field.selectionSets, c.operations.Fields[ref].SelectionSet)
+	}
+	return append(fields, field)
+}
+
+func hasTypenameField(fields []*selectedField) bool {
+	for _, field := range fields {
+		if field.responseKey == "__typename" && field.fieldName == "__typename" {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *OperationsCodeGen) inlineFragmentTypeCondition(ref int, enclosingTypeName string) string {
+	if !c.operations.InlineFragmentHasTypeCondition(ref) {
+		return enclosingTypeName
+	}
+	return c.operations.InlineFragmentTypeConditionNameString(ref)
+}
+
+// fragmentApplies returns true if a fragment with the type condition always applies to typeName
+func (c *OperationsCodeGen) fragmentApplies(typeCondition, typeName string) bool {
+	if typeCondition == typeName {
+		return true
+	}
+	node, exists := c.schema.NodeByNameStr(typeName)
+	if !exists || node.Kind != ast.NodeKindObjectTypeDefinition {
+		return false
+	}
+	conditionNode, exists := c.schema.NodeByNameStr(typeCondition)
+	if !exists {
+		return false
+	}
+	switch conditionNode.Kind {
+	case ast.NodeKindInterfaceTypeDefinition:
+		return c.schema.ObjectTypeDefinitionImplementsInterface(node.Ref, []byte(typeCondition))
+	case ast.NodeKindUnionTypeDefinition:
+		return c.schema.NodeIsUnionMember(node, conditionNode)
+	}
+	return false
+}
+
+// hasFragmentOnPossibleType returns true if the selection sets on the abstract type contain a fragment
+// which only applies to some possible types, including objectTypeName
+func (c *OperationsCodeGen) hasFragmentOnPossibleType(selectionSets []int, typeName, objectTypeName string) bool {
+	for _, set := range selectionSets {
+		for _, selection := range c.operations.SelectionSets[set].SelectionRefs {
+			ref := c.operations.Selections[selection].Ref
+			var typeCondition string
+			var fragmentSelectionSet int
+			switch c.operations.Selections[selection].Kind {
+			case ast.SelectionKindInlineFragment:
+				typeCondition = c.inlineFragmentTypeCondition(ref, typeName)
+				fragmentSelectionSet = c.operations.InlineFragments[ref].SelectionSet
+			case ast.SelectionKindFragmentSpread:
+				fragment, exists := c.operations.FragmentDefinitionRef(c.operations.FragmentSpreadNameBytes(ref))
+				if !exists {
+					continue
+				}
+				typeCondition = string(c.operations.FragmentDefinitionTypeName(fragment))
+				fragmentSelectionSet = c.operations.FragmentDefinitions[fragment].SelectionSet
+			default:
+				continue
+			}
+			if typeCondition == typeName {
+				if c.hasFragmentOnPossibleType([]int{fragmentSelectionSet}, typeName, objectTypeName) {
+					return true
+				}
+				continue
+			}
+			if c.fragmentApplies(typeCondition, objectTypeName) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// possibleTypes returns the names of the object types of an interface or union in the order of the schema
+func (c *OperationsCodeGen) possibleTypes(node ast.Node) (typeNames []string) {
+	switch node.Kind {
+	case ast.NodeKindUnionTypeDefinition:
+		for _, i := range c.schema.UnionTypeDefinitions[node.Ref].UnionMemberTypes.Refs {
+			typeNames = append(typeNames, c.schema.TypeNameString(i))
+		}
+	case ast.NodeKindInterfaceTypeDefinition:
+		interfaceName := c.schema.InterfaceTypeDefinitionNameBytes(node.Ref)
+		for _, rootNode := range c.schema.RootNodes {
+			if rootNode.Kind == ast.NodeKindObjectTypeDefinition && c.schema.ObjectTypeDefinitionImplementsInterface(rootNode.Ref, interfaceName) {
+				typeNames = append(typeNames, c.schema.ObjectTypeDefinitionNameString(rootNode.Ref))
+			}
+		}
+	}
+	return typeNames
+}
+
+// renderExecutionHelpers renders the types and funcs shared by the funcs executing the operations
+func (c *OperationsCodeGen) renderExecutionHelpers() {
+	c.file.Comment("GraphQLError is an error of a GraphQL response")
+	c.file.Type().Id("GraphQLError").Struct(
+		jen.Id("Message").String().Tag(map[string]string{"json": "message"}),
+		jen.Id("Path").Index().Interface().Tag(map[string]string{"json": "path,omitempty"}),
+	)
+
+	c.file.Comment("GraphQLErrors is returned if the response of an operation contains errors")
+	c.file.Type().Id("GraphQLErrors").Index().Id("GraphQLError")
+
+	c.file.Func().Params(jen.Id("e").Id("GraphQLErrors")).Id("Error").Params().String().Block(
+		jen.Id("messages").Op(":=").Make(jen.Index().String(), jen.Len(jen.Id("e"))),
+		jen.For(jen.Id("i").Op(":=").Range().Id("e")).Block(
+			jen.Id("messages").Index(jen.Id("i")).Op("=").Id("e").Index(jen.Id("i")).Dot("Message"),
+		),
+		jen.Return(jen.Qual("strings", "Join").Call(jen.Id("messages"), jen.Lit(", "))),
+	)
+
+	c.file.Type().Id("graphqlRequest").Struct(
+		jen.Id("Query").String().Tag(map[string]string{"json": "query"}),
+		jen.Id("OperationName").String().Tag(map[string]string{"json": "operationName"}),
+		jen.Id("Variables").Interface().Tag(map[string]string{"json": "variables,omitempty"}),
+	)
+
+	c.file.Line()
+	c.file.Func().Id("executeHTTP").Params(
+		jen.Id("ctx").Qual("context", "Context"),
+		jen.Id("client").Op("*").Qual("net/http", "Client"),
+		jen.Id("url").String(),
+		jen.Id("request").Id("graphqlRequest"),
+		jen.Id("data").Interface(),
+	).Error().Block(
+		jen.List(jen.Id("body"), jen.Err()).Op(":=").Qual("encoding/json", "Marshal").Call(jen.Id("request")),
+		jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Err())),
+		jen.List(jen.Id("httpRequest"), jen.Err()).Op(":=").Qual("net/http", "NewRequestWithContext").Call(
+			jen.Id("ctx"), jen.Qual("net/http", "MethodPost"), jen.Id("url"), jen.Qual("bytes", "NewReader").Call(jen.Id("body")),
+		),
+		jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Err())),
+		jen.Id("httpRequest").Dot("Header").Dot("Set").Call(jen.Lit("Content-Type"), jen.Lit("application/json")),
+		jen.Id("httpRequest").Dot("Header").Dot("Set").Call(jen.Lit("Accept"), jen.Lit("application/json")),
+		jen.List(jen.Id("httpResponse"), jen.Err()).Op(":=").Id("client").Dot("Do").Call(jen.Id("httpRequest")),
+		jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Err())),
+		jen.Defer().Id("httpResponse").Dot("Body").Dot("Close").Call(),
+		jen.List(jen.Id("responseBody"), jen.Err()).Op(":=").Qual("io", "ReadAll").Call(jen.Id("httpResponse").Dot("Body")),
+		jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Err())),
+		jen.If(jen.Id("httpResponse").Dot("StatusCode").Op("<").Qual("net/http", "StatusOK").Op("||").Id("httpResponse").Dot("StatusCode").Op(">=").Qual("net/http", "StatusMultipleChoices")).Block(
+			jen.Return(jen.Qual("fmt", "Errorf").Call(jen.Lit("unexpected status code %d: %s"), jen.Id("httpResponse").Dot("StatusCode"), jen.Id("responseBody"))),
+		),
+		jen.Return(jen.Id("decodeResponse").Call(jen.Id("responseBody"), jen.Id("data"))),
+	)
+
+	if c.config.ExecutionEngine {
+		c.file.Line()
+		c.file.Func().Id("executeEngine").Params(
+			jen.Id("ctx").Qual("context", "Context"),
+			jen.Id("engine").Op("*").Qual(graphqlPackagePath, "ExecutionEngineV2"),
+			jen.Id("request").Id("graphqlRequest"),
+			jen.Id("data").Interface(),
+		).Error().Block(
+			jen.Id("operation").Op(":=").Qual(graphqlPackagePath, "Request").Values(jen.Dict{
+				jen.Id("OperationName"): jen.Id("request").Dot("OperationName"),
+				jen.Id("Query"):         jen.Id("request").Dot("Query"),
+			}),
+			jen.If(jen.Id("request").Dot("Variables").Op("!=").Nil()).Block(
+				jen.List(jen.Id("variables"), jen.Err()).Op(":=").Qual("encoding/json", "Marshal").Call(jen.Id("request").Dot("Variables")),
+				jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Err())),
+				jen.Id("operation").Dot("Variables").Op("=").Id("variables"),
+			),
+			jen.Id("writer").Op(":=").Qual(graphqlPackagePath, "NewEngineResultWriter").Call(),
+			jen.If(
+				jen.Err().Op(":=").Id("engine").Dot("Execute").Call(jen.Id("ctx"), jen.Op("&").Id("operation"), jen.Op("&").Id("writer")),
+				jen.Err().Op("!=").Nil(),
+			).Block(jen.Return(jen.Err())),
+			jen.Return(jen.Id("decodeResponse").Call(jen.Id("writer").Dot("Bytes").Call(), jen.Id("data"))),
+		)
+	}
+
+	c.file.Line()
+	c.file.Func().Id("decodeResponse").Params(jen.Id("body").Index().Byte(), jen.Id("data").Interface()).Error().Block(
+		jen.Var().Id("response").Struct(
+			jen.Id("Data").Qual("encoding/json", "RawMessage").Tag(map[string]string{"json": "data"}),
+			jen.Id("Errors").Id("GraphQLErrors").Tag(map[string]string{"json": "errors"}),
+		),
+		jen.If(
+			jen.Err().Op(":=").Qual("encoding/json", "Unmarshal").Call(jen.Id("body"), jen.Op("&").Id("response")),
+			jen.Err().Op("!=").Nil(),
+		).Block(jen.Return(jen.Err())),
+		jen.If(jen.Len(jen.Id("response").Dot("Data")).Op("!=").Lit(0).Op("&&").String().Call(jen.Id("response").Dot("Data")).Op("!=").Lit("null")).Block(
+			jen.If(
+				jen.Err().Op(":=").Qual("encoding/json", "Unmarshal").Call(jen.Id("response").Dot("Data"), jen.Id("data")),
+				jen.Err().Op("!=").Nil(),
+			).Block(jen.Return(jen.Err())),
+		),
+		jen.If(jen.Len(jen.Id("response").Dot("Errors")).Op("!=").Lit(0)).Block(
+			jen.Return(jen.Id("response").Dot("Errors")),
+		),
+		jen.Return(jen.Nil()),
+	)
+}
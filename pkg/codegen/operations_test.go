@@ -0,0 +1,346 @@
+package codegen
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/sebdah/goldie"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wundergraph/graphql-go-tools/internal/pkg/unsafeparser"
+	"github.com/wundergraph/graphql-go-tools/pkg/asttransform"
+)
+
+const operationsTestSchema = `
+	scalar DateTime
+
+	schema {
+		query: Query
+		mutation: Mutation
+		subscription: Subscription
+	}
+
+	type Query {
+		hero(episode: Episode): Character
+		search(text: String!): [SearchResult!]!
+		reviews(episode: Episode!): [Review]
+	}
+
+	type Mutation {
+		createReview(episode: Episode!, review: ReviewInput!): Review
+	}
+
+	type Subscription {
+		reviewAdded(episode: Episode): Review
+	}
+
+	enum Episode {
+		NEWHOPE
+		EMPIRE
+		JEDI
+	}
+
+	interface Character {
+		id: ID!
+		name: String!
+		friends: [Character]
+	}
+
+	type Human implements Character {
+		id: ID!
+		name: String!
+		friends: [Character]
+		height: Float
+	}
+
+	type Droid implements Character {
+		id: ID!
+		name: String!
+		friends: [Character]
+		primaryFunction: String
+	}
+
+	type Starship {
+		id: ID!
+		name: String!
+		length: Float!
+	}
+
+	union SearchResult = Human | Droid | Starship
+
+	type Review {
+		stars: Int!
+		commentary: String
+		createdAt: DateTime!
+	}
+
+	input ReviewInput {
+		stars: Int!
+		commentary: String
+		favoriteColor: ColorInput
+	}
+
+	input ColorInput {
+		red: Int!
+		green: Int!
+		blue: Int!
+	}
+`
+
+const operationsTestOperations = `
+	query Hero($episode: Episode) {
+		hero(episode: $episode) {
+			...CharacterFields
+			... on Droid {
+				primaryFunction
+			}
+			friends {
+				name
+			}
+		}
+	}
+
+	fragment CharacterFields on Character {
+		id
+		heroName: name
+		... on Human {
+			height
+		}
+	}
+
+	query Search($text: String!) {
+		search(text: $text) {
+			__typename
+			... on Character {
+				name
+			}
+			... on Starship {
+				name
+				length
+			}
+		}
+	}
+
+	mutation CreateReview($episode: Episode!, $review: ReviewInput!) {
+		createReview(episode: $episode, review: $review) {
+			stars
+			commentary
+			createdAt
+		}
+	}
+
+	query Reviews {
+		reviews(episode: JEDI) {
+			stars
+		}
+	}
+
+	subscription ReviewAdded {
+		reviewAdded {
+			stars
+		}
+	}
+`
+
+const nameCollisionsTestSchema = `
+	type Query {
+		node: Node
+		a_b: Item
+		aB: Item
+	}
+
+	interface Node {
+		user: User
+	}
+
+	type User implements Node {
+		id: ID!
+		user: User
+		typename: String
+	}
+
+	type Item {
+		x: String
+	}
+`
+
+const nameCollisionsTestOperations = `
+	query Q {
+		node {
+			user {
+				id
+			}
+			... on User {
+				id
+				typename
+			}
+		}
+		a_b {
+			x
+		}
+		aB {
+			x
+		}
+	}
+`
+
+// operationsTestGeneratedTest executes the generated code of operationsTestOperations against a test server
+const operationsTestGeneratedTest = `package codegen
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHero(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			OperationName string            ` + "`json:\"operationName\"`" + `
+			Variables     map[string]string ` + "`json:\"variables\"`" + `
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.OperationName != "Hero" || request.Variables["episode"] != "JEDI" {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(` + "`" + `{"data":{"hero":{"__typename":"Droid","id":"2001","heroName":"R2-D2","primaryFunction":"Astromech","friends":[{"name":"Luke"}]}}}` + "`" + `))
+	}))
+	defer server.Close()
+
+	episode := Episode_JEDI
+	response, err := Hero(context.Background(), server.Client(), server.URL, HeroVariables{Episode: &episode})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hero := response.Hero
+	if hero.HeroName != "R2-D2" || hero.AsHuman != nil || hero.AsDroid == nil || *hero.AsDroid.PrimaryFunction != "Astromech" || (*hero.Friends)[0].Name != "Luke" {
+		t.Fatalf("unexpected response: %+v", hero)
+	}
+}
+`
+
+// nameCollisionsTestGeneratedTest executes the generated code of nameCollisionsTestOperations against a test server
+const nameCollisionsTestGeneratedTest = `package codegen
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestQ(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(` + "`" + `{"data":{"node":{"__typename":"User","user":{"id":"1"},"id":"2","typename":"custom"},"a_b":{"x":"a_b"},"aB":{"x":"aB"}}}` + "`" + `))
+	}))
+	defer server.Close()
+
+	response, err := Q(context.Background(), server.Client(), server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var user *QResponseNodeUser = response.Node.AsUser
+	var nestedUser *QResponseNodeUser2 = response.Node.User
+	if user == nil || user.Id != "2" || *user.Typename != "custom" || user.Typename2 != "User" || nestedUser.Id != "1" {
+		t.Fatalf("unexpected node: %+v", response.Node)
+	}
+	if *response.AB.X != "a_b" || *response.AB2.X != "aB" {
+		t.Fatalf("unexpected response: %+v", response)
+	}
+}
+`
+
+// runGenerated compiles the generated code in a temporary package of this module and runs the tests of testFile against it
+func runGenerated(t *testing.T, generated []byte, testFile string) {
+	t.Helper()
+	goBinary, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go binary not found")
+	}
+
+	dir, err := ioutil.TempDir("testdata", "generated")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "generated.go"), generated, 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "generated_test.go"), []byte(testFile), 0644))
+
+	out, err := exec.Command(goBinary, "test", "./"+filepath.ToSlash(dir)).CombinedOutput()
+	assert.NoError(t, err, string(out))
+}
+
+func TestOperationsCodeGen_Generate(t *testing.T) {
+	schema := unsafeparser.ParseGraphqlDocumentString(operationsTestSchema)
+	require.NoError(t, asttransform.MergeDefinitionWithBaseSchema(&schema))
+	operations := unsafeparser.ParseGraphqlDocumentString(operationsTestOperations)
+
+	config := OperationsConfig{
+		PackageName: "codegen",
+		ScalarTypes: map[string]string{
+			"DateTime": "time.Time",
+		},
+		ExecutionEngine: true,
+	}
+
+	gen := NewOperations(&schema, &operations, config)
+	out := bytes.Buffer{}
+	_, err := gen.Generate(&out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := out.Bytes()
+
+	goldie.Assert(t, "Operations", data)
+	if t.Failed() {
+
+		fixture, err := ioutil.ReadFile("./fixtures/Operations.golden")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, string(data), string(fixture))
+	}
+
+	runGenerated(t, data, operationsTestGeneratedTest)
+}
+
+func TestOperationsCodeGen_Generate_Errors(t *testing.T) {
+	run := func(operation, expectedErr string) func(t *testing.T) {
+		return func(t *testing.T) {
+			schema := unsafeparser.ParseGraphqlDocumentString(operationsTestSchema)
+			require.NoError(t, asttransform.MergeDefinitionWithBaseSchema(&schema))
+			operations := unsafeparser.ParseGraphqlDocumentString(operation)
+
+			_, err := NewOperations(&schema, &operations, OperationsConfig{PackageName: "codegen"}).Generate(&bytes.Buffer{})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), expectedErr)
+		}
+	}
+
+	t.Run("anonymous operation", run(`{ hero { id } }`, "operations must be named"))
+	t.Run("invalid operation", run(`query Hero { hero { unknown } }`, "unknown"))
+	t.Run("operations with the same go name", run(`query hero { hero { id } } query Hero { hero { name } }`, "identifier Hero of operation Hero is already declared"))
+}
+
+func TestOperationsCodeGen_Generate_NameCollisions(t *testing.T) {
+	schema := unsafeparser.ParseGraphqlDocumentString(nameCollisionsTestSchema)
+	require.NoError(t, asttransform.MergeDefinitionWithBaseSchema(&schema))
+	operations := unsafeparser.ParseGraphqlDocumentString(nameCollisionsTestOperations)
+
+	gen := NewOperations(&schema, &operations, OperationsConfig{PackageName: "codegen"})
+	out := bytes.Buffer{}
+	_, err := gen.Generate(&out)
+	require.NoError(t, err)
+
+	goldie.Assert(t, "OperationsNameCollisions", out.Bytes())
+
+	runGenerated(t, out.Bytes(), nameCollisionsTestGeneratedTest)
+}
@@ -3,10 +3,14 @@ package graphql_datasource
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"sync"
 
 	"github.com/buger/jsonparser"
 	"github.com/tidwall/sjson"
@@ -20,6 +24,7 @@ import (
 	"github.com/wundergraph/graphql-go-tools/pkg/engine/plan"
 	"github.com/wundergraph/graphql-go-tools/pkg/engine/resolve"
 	"github.com/wundergraph/graphql-go-tools/pkg/federation"
+	"github.com/wundergraph/graphql-go-tools/pkg/graphqljsonschema"
 	"github.com/wundergraph/graphql-go-tools/pkg/lexer/literal"
 	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
 )
@@ -37,6 +42,7 @@ type Planner struct {
 	disallowSingleFlight               bool
 	hasFederationRoot                  bool
 	extractEntities                    bool
+	lookupFieldName                    string // lookupFieldName - holds name of the lookup field if the planner loads fields of a merged type
 	fetchClient                        *http.Client
 	subscriptionClient                 GraphQLSubscriptionClient
 	isNested                           bool   // isNested - flags that datasource is nested e.g. field with datasource is not on a query type
@@ -69,6 +75,10 @@ func (p *Planner) EnterDirective(ref int) {
 
 func (p *Planner) addDirectiveToNode(directiveRef int, node ast.Node) {
 	directiveName := p.visitor.Operation.DirectiveNameString(directiveRef)
+	if directiveName == "defer" || directiveName == "stream" {
+		// incremental delivery is handled by the engine, upstreams always respond with the complete result
+		return
+	}
 	operationType := ast.OperationTypeQuery
 	if !p.isNested {
 		operationType = p.visitor.Operation.OperationDefinitions[p.visitor.Walker.Ancestors[0].Ref].OperationType
@@ -119,7 +129,7 @@ func (p *Planner) addDirectiveToNode(directiveRef int, node ast.Node) {
 			variableType := p.visitor.Operation.VariableDefinitions[i].Type
 			typeName := p.visitor.Operation.ResolveTypeNameString(variableType)
 
-			renderer, err := resolve.NewJSONVariableRendererWithValidationFromTypeRef(p.visitor.Operation, p.visitor.Definition, variableType)
+			renderer, err := resolve.NewJSONVariableRendererWithValidationFromTypeRef(p.visitor.Operation, p.visitor.Definition, variableType, graphqljsonschema.WithScalarRegistry(p.visitor.Config.Scalars))
 			if err != nil {
 				continue
 			}
@@ -198,6 +208,8 @@ type Configuration struct {
 	Subscription   SubscriptionConfiguration
 	Federation     FederationConfiguration
 	UpstreamSchema string
+	// Lookups are root fields of the upstream to load objects of types which are merged with other upstreams
+	Lookups []LookupConfiguration
 }
 
 func ConfigJson(config Configuration) json.RawMessage {
@@ -210,14 +222,55 @@ type FederationConfiguration struct {
 	ServiceSDL string
 }
 
+// LookupConfiguration is a root field of the upstream which loads an object of a merged type by its key fields
+// A field of the type which is planned as nested fetch on this upstream is loaded through the lookup field,
+// e.g. User.name with the lookup field user(id: ID!) is fetched as query($a: ID!){user(id: $a){name}}.
+// The key fields have to be configured as RequiresFields of the field, so that they're part of the parent object.
+type LookupConfiguration struct {
+	// TypeName is the name of the type in the downstream schema
+	TypeName string
+	// FieldName is the name of the lookup field on the Query type of the upstream
+	FieldName string
+	Arguments []LookupArgument
+}
+
+// LookupArgument sets the argument of a lookup field to the value of a key field of the parent object
+type LookupArgument struct {
+	Name     string
+	KeyField string
+}
+
+func (c *Configuration) lookup(typeName string) (LookupConfiguration, bool) {
+	for i := range c.Lookups {
+		if c.Lookups[i].TypeName == typeName {
+			return c.Lookups[i], true
+		}
+	}
+	return LookupConfiguration{}, false
+}
+
 type SubscriptionConfiguration struct {
 	URL string
 }
 
 type FetchConfiguration struct {
-	URL    string
+	URL string
+	// Method is the HTTP method used to send operations to the upstream, defaults to POST
+	// With GET, the query, variables and extensions are url encoded as query parameters,
+	// e.g. to allow CDNs to cache upstream responses. Mutations can't be sent using GET.
 	Method string
 	Header http.Header
+	// UseAutomaticPersistedQueries sends the sha256 hash of the query instead of the query (Automatic Persisted Queries).
+	// Only if the upstream responds with PersistedQueryNotFound, the request is repeated including the query.
+	UseAutomaticPersistedQueries bool
+	// RequestCompression compresses the request body, httpclient.CompressionGzip or httpclient.CompressionBrotli
+	RequestCompression string
+	// DecompressResponse requests compressed responses from the upstream, independent of the client request headers
+	DecompressResponse bool
+}
+
+func (c *FetchConfiguration) isGET() bool {
+	return strings.EqualFold(c.Method, http.MethodGet)
 }
 
 func (c *Configuration) ApplyDefaults() {
@@ -251,8 +304,13 @@ func (p *Planner) Register(visitor *plan.Visitor, configuration plan.DataSourceC
 
 func (p *Planner) ConfigureFetch() plan.FetchConfiguration {
 	var input []byte
+	operation := p.printOperation()
 	input = httpclient.SetInputBodyWithPath(input, p.upstreamVariables, "variables")
-	input = httpclient.SetInputBodyWithPath(input, p.printOperation(), "query")
+	input = httpclient.SetInputBodyWithPath(input, operation, "query")
+
+	if p.config.Fetch.UseAutomaticPersistedQueries && len(operation) != 0 {
+		input = httpclient.SetInputBodyWithPath(input, persistedQueryExtension(operation), "extensions")
+	}
 
 	header, err := json.Marshal(p.config.Fetch.Header)
 	if err == nil && len(header) != 0 && !bytes.Equal(header, literal.NULL) {
@@ -261,6 +319,9 @@ func (p *Planner) ConfigureFetch() plan.FetchConfiguration {
 
 	input = httpclient.SetInputURL(input, []byte(p.config.Fetch.URL))
 	input = httpclient.SetInputMethod(input, []byte(p.config.Fetch.Method))
+	input = httpclient.SetInputURLEncodeBody(input, p.config.Fetch.isGET())
+	input = httpclient.SetInputRequestCompression(input, p.config.Fetch.RequestCompression)
+	input = httpclient.SetInputDecompressResponse(input, p.config.Fetch.DecompressResponse)
 
 	var batchConfig plan.BatchConfig
 	// Allow batch query for fetching entities.
@@ -271,6 +332,16 @@ func (p *Planner) ConfigureFetch() plan.FetchConfiguration {
 		}
 	}
 
+	var transformation *resolve.Transformation
+	// The fields of a merged type are nested in the response of the lookup field.
+	if p.lookupFieldName != "" {
+		transformation = &resolve.Transformation{
+			Steps: []resolve.TransformationStep{
+				{Kind: resolve.TransformationStepKindExtract, Path: "$." + p.lookupFieldName},
+			},
+		}
+	}
+
 	return plan.FetchConfiguration{
 		Input: string(input),
 		DataSource: &Source{
@@ -282,7 +353,8 @@ func (p *Planner) ConfigureFetch() plan.FetchConfiguration {
 			ExtractGraphqlResponse:    true,
 			ExtractFederationEntities: p.extractEntities,
 		},
-		BatchConfig: batchConfig,
+		BatchConfig:    batchConfig,
+		Transformation: transformation,
 	}
 }
 
@@ -315,6 +387,9 @@ func (p *Planner) EnterOperationDefinition(ref int) {
 	})
 	p.disallowSingleFlight = operationType == ast.OperationTypeMutation
 	p.nodes = append(p.nodes, definition)
+	if operationType == ast.OperationTypeMutation && p.config.Fetch.isGET() {
+		p.stopWithError("GraphQL Planner: mutations must not be sent to the upstream '%s' using GET", p.config.Fetch.URL)
+	}
 }
 
 func (p *Planner) LeaveOperationDefinition(ref int) {
@@ -418,6 +493,8 @@ func (p *Planner) EnterField(ref int) {
 
 	typeName := p.lastFieldEnclosingTypeName
 
+	p.handleLookup()
+
 	fieldConfiguration := p.visitor.Config.Fields.ForTypeField(typeName, fieldName)
 	if fieldConfiguration == nil {
 		p.addField(ref)
@@ -459,6 +536,7 @@ func (p *Planner) EnterDocument(operation, definition *ast.Document) {
 	p.disallowSingleFlight = false
 	p.hasFederationRoot = false
 	p.extractEntities = false
+	p.lookupFieldName = ""
 
 	// reset information about root type
 	p.rootTypeName = ""
@@ -522,6 +600,96 @@ func (p *Planner) handleFederation(fieldConfig *plan.FieldConfiguration) {
 	p.updateRepresentationsVariable(fieldConfig) // "variables\":{\"representations\":[{\"upc\":\"$$0$$\",\"__typename\":\"Product\"}]}}
 }
 
+// handleLookup wraps the fields of a merged type into its lookup field, if the planner is nested and the upstream has a lookup for the type
+// query($a: ID!){user(id: $a){name}} loads User.name of a User which has been fetched from another upstream.
+func (p *Planner) handleLookup() {
+	if p.config.Federation.Enabled || len(p.config.Lookups) == 0 {
+		return
+	}
+	// fields which follow the first field of the merged type are already wrapped into the lookup field
+	if p.lookupFieldName != "" || !p.isNestedRequest() {
+		return
+	}
+	lookup, ok := p.config.lookup(p.lastFieldEnclosingTypeName)
+	if !ok {
+		return
+	}
+
+	argumentRefs := make([]int, 0, len(lookup.Arguments))
+	for i := range lookup.Arguments {
+		argumentRef, ok := p.addLookupArgument(lookup, lookup.Arguments[i])
+		if !ok {
+			return
+		}
+		argumentRefs = append(argumentRefs, argumentRef)
+	}
+
+	selectionSet := p.upstreamOperation.AddSelectionSet()
+	lookupField := p.upstreamOperation.AddField(ast.Field{
+		Name:          p.upstreamOperation.Input.AppendInputString(lookup.FieldName),
+		HasSelections: true,
+		HasArguments:  len(argumentRefs) != 0,
+		Arguments: ast.ArgumentList{
+			Refs: argumentRefs,
+		},
+		SelectionSet: selectionSet.Ref,
+	})
+	p.upstreamOperation.AddSelection(p.nodes[len(p.nodes)-1].Ref, ast.Selection{
+		Kind: ast.SelectionKindField,
+		Ref:  lookupField.Ref,
+	})
+	p.nodes = append(p.nodes, lookupField, selectionSet)
+
+	// the lookup field is the root field of the upstream operation, so the query type of the upstream schema must not be replaced
+	p.rootFieldName = lookup.FieldName
+	p.lookupFieldName = lookup.FieldName
+}
+
+// addLookupArgument adds the variable of a lookup argument to the upstream operation, its value is the key field of the parent object
+func (p *Planner) addLookupArgument(lookup LookupConfiguration, argument LookupArgument) (argumentRef int, ok bool) {
+	argumentDefinition, definition := p.lookupArgumentDefinition(lookup.FieldName, argument.Name)
+	if argumentDefinition == -1 {
+		p.stopWithError("GraphQL Planner: lookup field Query.%s has no argument '%s'", lookup.FieldName, argument.Name)
+		return -1, false
+	}
+	keyField := p.fieldDefinition(argument.KeyField, p.lastFieldEnclosingTypeName)
+	if keyField == nil {
+		p.stopWithError("GraphQL Planner: key field %s.%s of lookup field Query.%s doesn't exist", p.lastFieldEnclosingTypeName, argument.KeyField, lookup.FieldName)
+		return -1, false
+	}
+	renderer, err := resolve.NewJSONVariableRendererWithValidationFromTypeRef(p.visitor.Definition, p.visitor.Definition, keyField.Type, graphqljsonschema.WithScalarRegistry(p.visitor.Config.Scalars))
+	if err != nil {
+		p.visitor.Walker.StopWithInternalErr(err)
+		return -1, false
+	}
+	variable, _ := p.variables.AddVariable(&resolve.ObjectVariable{
+		Path:     []string{argument.KeyField},
+		Renderer: renderer,
+	})
+
+	argumentType := definition.InputValueDefinitionType(argumentDefinition)
+	variableName := p.upstreamOperation.GenerateUnusedVariableDefinitionName(p.nodes[0].Ref)
+	variableValue, argumentRef := p.upstreamOperation.AddVariableValueArgument([]byte(argument.Name), variableName)
+	importedType := p.visitor.Importer.ImportType(argumentType, definition, p.upstreamOperation)
+	p.upstreamOperation.AddVariableDefinitionToOperationDefinition(p.nodes[0].Ref, variableValue, importedType)
+	p.upstreamVariables, _ = sjson.SetRawBytes(p.upstreamVariables, string(variableName), []byte(variable))
+	return argumentRef, true
+}
+
+// lookupArgumentDefinition returns the argument definition of a lookup field
+// The upstream schema is preferred, because the types of the downstream schema might be renamed.
+func (p *Planner) lookupArgumentDefinition(fieldName, argumentName string) (ref int, definition *ast.Document) {
+	definition = p.visitor.Definition
+	if p.upstreamDefinition != nil {
+		definition = p.upstreamDefinition
+	}
+	queryTypeDefinition, exists := definition.Index.FirstNodeByNameBytes(definition.Index.QueryTypeName)
+	if !exists {
+		return -1, definition
+	}
+	return definition.NodeFieldDefinitionArgumentDefinitionByName(queryTypeDefinition, []byte(fieldName), []byte(argumentName)), definition
+}
+
 func (p *Planner) updateRepresentationsVariable(fieldConfig *plan.FieldConfiguration) {
 	// "variables\":{\"representations\":[{\"upc\":\$$0$$\,\"__typename\":\"Product\"}]}}
 	parser := astparser.NewParser()
@@ -555,7 +723,7 @@ func (p *Planner) updateRepresentationsVariable(fieldConfig *plan.FieldConfigura
 		if fieldDef == nil {
 			continue
 		}
-		renderer, err := resolve.NewJSONVariableRendererWithValidationFromTypeRef(p.visitor.Definition, p.visitor.Definition, fieldDef.Type)
+		renderer, err := resolve.NewJSONVariableRendererWithValidationFromTypeRef(p.visitor.Definition, p.visitor.Definition, fieldDef.Type, graphqljsonschema.WithScalarRegistry(p.visitor.Config.Scalars))
 		if err != nil {
 			continue
 		}
@@ -711,7 +879,7 @@ func (p *Planner) configureFieldArgumentSource(upstreamFieldRef, downstreamField
 	}
 
 	argumentType := p.visitor.Definition.InputValueDefinitionType(argumentDefinition)
-	renderer, err := resolve.NewJSONVariableRendererWithValidationFromTypeRef(p.visitor.Definition, p.visitor.Definition, argumentType)
+	renderer, err := resolve.NewJSONVariableRendererWithValidationFromTypeRef(p.visitor.Definition, p.visitor.Definition, argumentType, graphqljsonschema.WithScalarRegistry(p.visitor.Config.Scalars))
 	if err != nil {
 		return
 	}
@@ -821,7 +989,7 @@ func (p *Planner) addVariableDefinitionsRecursively(value ast.Value, sourcePath
 	contextVariable := &resolve.ContextVariable{
 		Path: append(sourcePath, variableNameStr),
 	}
-	renderer, err := resolve.NewJSONVariableRendererWithValidationFromTypeRef(p.visitor.Operation, p.visitor.Definition, variableDefinitionTypeRef)
+	renderer, err := resolve.NewJSONVariableRendererWithValidationFromTypeRef(p.visitor.Operation, p.visitor.Definition, variableDefinitionTypeRef, graphqljsonschema.WithScalarRegistry(p.visitor.Config.Scalars))
 	if err != nil {
 		return
 	}
@@ -869,7 +1037,7 @@ func (p *Planner) configureObjectFieldSource(upstreamFieldRef, downstreamFieldRe
 	importedType := p.visitor.Importer.ImportTypeWithRename(argumentType, p.visitor.Definition, p.upstreamOperation, typeName)
 	p.upstreamOperation.AddVariableDefinitionToOperationDefinition(p.nodes[0].Ref, variableValue, importedType)
 
-	renderer, err := resolve.NewJSONVariableRendererWithValidationFromTypeRef(p.visitor.Definition, p.visitor.Definition, argumentType)
+	renderer, err := resolve.NewJSONVariableRendererWithValidationFromTypeRef(p.visitor.Definition, p.visitor.Definition, argumentType, graphqljsonschema.WithScalarRegistry(p.visitor.Config.Scalars))
 	if err != nil {
 		return
 	}
@@ -1139,12 +1307,16 @@ type Factory struct {
 	BatchFactory resolve.DataSourceBatchFactory
 	HTTPClient   *http.Client
 	wsClient     *WebSocketGraphQLSubscriptionClient
+	wsClientOnce sync.Once
 }
 
+// Planner is safe for concurrent use, the WebSocket subscription client gets shared by all planners
 func (f *Factory) Planner(ctx context.Context) plan.DataSourcePlanner {
-	if f.wsClient == nil {
-		f.wsClient = NewWebSocketGraphQLSubscriptionClient(f.HTTPClient, ctx)
-	}
+	f.wsClientOnce.Do(func() {
+		if f.wsClient == nil {
+			f.wsClient = NewWebSocketGraphQLSubscriptionClient(f.HTTPClient, ctx)
+		}
+	})
 	return &Planner{
 		batchFactory:       f.BatchFactory,
 		fetchClient:        f.HTTPClient,
@@ -1215,9 +1387,45 @@ func (s *Source) unNullVariables(input []byte) ([]byte, bool) {
 
 func (s *Source) Load(ctx context.Context, input []byte, writer io.Writer) (err error) {
 	input = s.compactAndUnNullVariables(input)
+	if _, _, _, err := jsonparser.Get(input, "body", "extensions", "persistedQuery"); err == nil {
+		return s.loadPersistedQuery(ctx, input, writer)
+	}
+	return httpclient.Do(s.httpClient, ctx, input, writer)
+}
+
+// loadPersistedQuery first sends the hash of the query only
+// If the upstream doesn't know the hash yet, the request is repeated including the query to register it
+func (s *Source) loadPersistedQuery(ctx context.Context, input []byte, writer io.Writer) error {
+	hashOnly := jsonparser.Delete(append([]byte(nil), input...), "body", "query")
+	buf := &bytes.Buffer{}
+	if err := httpclient.Do(s.httpClient, ctx, hashOnly, buf); err != nil {
+		return err
+	}
+	if !isPersistedQueryNotFound(buf.Bytes()) {
+		_, err := writer.Write(buf.Bytes())
+		return err
+	}
 	return httpclient.Do(s.httpClient, ctx, input, writer)
 }
 
+func persistedQueryExtension(operation []byte) []byte {
+	hash := sha256.Sum256(operation)
+	return []byte(fmt.Sprintf(`{"persistedQuery":{"version":1,"sha256Hash":"%s"}}`, hex.EncodeToString(hash[:])))
+}
+
+func isPersistedQueryNotFound(response []byte) bool {
+	notFound := false
+	_, _ = jsonparser.ArrayEach(response, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		if message, _ := jsonparser.GetString(value, "message"); message == "PersistedQueryNotFound" {
+			notFound = true
+		}
+		if code, _ := jsonparser.GetString(value, "extensions", "code"); code == "PERSISTED_QUERY_NOT_FOUND" {
+			notFound = true
+		}
+	}, "errors")
+	return notFound
+}
+
 type GraphQLSubscriptionClient interface {
 	Subscribe(ctx context.Context, options GraphQLSubscriptionOptions, next chan<- []byte) error
 }
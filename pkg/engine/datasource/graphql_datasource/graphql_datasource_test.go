@@ -16,9 +16,12 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/wundergraph/graphql-go-tools/internal/pkg/unsafeparser"
+	"github.com/wundergraph/graphql-go-tools/pkg/asttransform"
 	. "github.com/wundergraph/graphql-go-tools/pkg/engine/datasourcetesting"
 	"github.com/wundergraph/graphql-go-tools/pkg/engine/plan"
 	"github.com/wundergraph/graphql-go-tools/pkg/engine/resolve"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
 	"github.com/wundergraph/graphql-go-tools/pkg/testing/subscriptiontesting"
 )
 
@@ -2158,6 +2161,141 @@ func TestGraphQLDataSource(t *testing.T) {
 		},
 	))
 
+	t.Run("query using GET with automatic persisted queries", RunTest(`
+		type Query {
+			friend(id: ID!): Friend
+		}
+		type Friend {
+			id: ID!
+			name: String!
+		}
+	`,
+		`query Friend($id: ID!){ friend(id: $id){ id name } }`,
+		"Friend",
+		&plan.SynchronousResponsePlan{
+			Response: &resolve.GraphQLResponse{
+				Data: &resolve.Object{
+					Fetch: &resolve.SingleFetch{
+						BufferId:   0,
+						Input:      `{"url_encode_body":true,"method":"GET","url":"https://service.one","body":{"extensions":{"persistedQuery":{"version":1,"sha256Hash":"38de17031573d5762580cb0a597f47ffd570ea690c75cfd0e1df35a21ac55403"}},"query":"query($id: ID!){friend(id: $id){id name}}","variables":{"id":$$0$$}}}`,
+						DataSource: &Source{},
+						Variables: resolve.NewVariables(
+							&resolve.ContextVariable{
+								Path:     []string{"id"},
+								Renderer: resolve.NewJSONVariableRendererWithValidation(`{"type":["string","integer"]}`),
+							},
+						),
+						DataSourceIdentifier:  []byte("graphql_datasource.Source"),
+						ProcessResponseConfig: resolve.ProcessResponseConfig{ExtractGraphqlResponse: true},
+					},
+					Fields: []*resolve.Field{
+						{
+							BufferID:  0,
+							HasBuffer: true,
+							Name:      []byte("friend"),
+							Value: &resolve.Object{
+								Nullable: true,
+								Fields: []*resolve.Field{
+									{
+										Name: []byte("id"),
+										Value: &resolve.String{
+											Path: []string{"id"},
+										},
+									},
+									{
+										Name: []byte("name"),
+										Value: &resolve.String{
+											Path: []string{"name"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		plan.Configuration{
+			DataSources: []plan.DataSourceConfiguration{
+				{
+					RootNodes: []plan.TypeField{
+						{
+							TypeName:   "Query",
+							FieldNames: []string{"friend"},
+						},
+					},
+					ChildNodes: []plan.TypeField{
+						{
+							TypeName:   "Friend",
+							FieldNames: []string{"id", "name"},
+						},
+					},
+					Custom: ConfigJson(Configuration{
+						Fetch: FetchConfiguration{
+							URL:                          "https://service.one",
+							Method:                       "GET",
+							UseAutomaticPersistedQueries: true,
+						},
+					}),
+					Factory: &Factory{},
+				},
+			},
+			Fields: []plan.FieldConfiguration{
+				{
+					TypeName:              "Query",
+					FieldName:             "friend",
+					DisableDefaultMapping: true,
+					Arguments: []plan.ArgumentConfiguration{
+						{
+							Name:       "id",
+							SourceType: plan.FieldArgumentSource,
+						},
+					},
+				},
+			},
+			DisableResolveFieldPositions: true,
+		},
+	))
+
+	t.Run("mutation using GET", func(t *testing.T) {
+		def := unsafeparser.ParseGraphqlDocumentString(`
+			schema { mutation: Mutation }
+			type Mutation {
+				addFriend(name: String!): String!
+			}
+		`)
+		require.NoError(t, asttransform.MergeDefinitionWithBaseSchema(&def))
+		op := unsafeparser.ParseGraphqlDocumentString(`mutation AddFriend { addFriend(name: "jens") }`)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		planner := plan.NewPlanner(ctx, plan.Configuration{
+			DataSources: []plan.DataSourceConfiguration{
+				{
+					RootNodes: []plan.TypeField{
+						{
+							TypeName:   "Mutation",
+							FieldNames: []string{"addFriend"},
+						},
+					},
+					Custom: ConfigJson(Configuration{
+						Fetch: FetchConfiguration{
+							URL:    "https://service.one",
+							Method: "GET",
+						},
+					}),
+					Factory: &Factory{},
+				},
+			},
+			DisableResolveFieldPositions: true,
+		})
+
+		report := &operationreport.Report{}
+		planner.Plan(&op, &def, "AddFriend", report)
+		require.True(t, report.HasErrors())
+		assert.Contains(t, report.Error(), "mutations must not be sent to the upstream 'https://service.one' using GET")
+	})
+
 	t.Run("nested resolvers of same upstream", RunTest(`
 		type Query {
 			foo(bar: String):Baz
@@ -2899,6 +3037,152 @@ func TestGraphQLDataSource(t *testing.T) {
 		},
 	))
 
+	t.Run("nested field of merged type with lookup", RunTest(`
+		type Query {
+			me: User
+			user(id: ID!): User
+			topReviews: [Review]
+		}
+		type User {
+			id: ID!
+			name: String!
+		}
+		type Review {
+			body: String!
+			author: User!
+		}
+	`, `
+		query TopReviews {
+			topReviews {
+				body
+				author {
+					name
+				}
+			}
+		}
+	`, "TopReviews",
+		&plan.SynchronousResponsePlan{
+			Response: &resolve.GraphQLResponse{
+				Data: &resolve.Object{
+					Fetch: &resolve.SingleFetch{
+						BufferId:              0,
+						Input:                 `{"method":"POST","url":"http://reviews.service","body":{"query":"{topReviews {body author {id}}}"}}`,
+						DataSource:            &Source{},
+						DataSourceIdentifier:  []byte("graphql_datasource.Source"),
+						ProcessResponseConfig: resolve.ProcessResponseConfig{ExtractGraphqlResponse: true},
+					},
+					Fields: []*resolve.Field{
+						{
+							HasBuffer: true,
+							BufferID:  0,
+							Name:      []byte("topReviews"),
+							Value: &resolve.Array{
+								Path:     []string{"topReviews"},
+								Nullable: true,
+								Item: &resolve.Object{
+									Nullable: true,
+									Fields: []*resolve.Field{
+										{
+											Name: []byte("body"),
+											Value: &resolve.String{
+												Path: []string{"body"},
+											},
+										},
+										{
+											Name: []byte("author"),
+											Value: &resolve.Object{
+												Path: []string{"author"},
+												Fetch: &resolve.SingleFetch{
+													BufferId:   1,
+													Input:      `{"method":"POST","url":"http://accounts.service","body":{"query":"query($a: ID!){user(id: $a){name}}","variables":{"a":$$0$$}}}`,
+													DataSource: &Source{},
+													Variables: resolve.NewVariables(
+														&resolve.ObjectVariable{
+															Path:     []string{"id"},
+															Renderer: resolve.NewJSONVariableRendererWithValidation(`{"type":["string","integer"]}`),
+														},
+													),
+													DataSourceIdentifier:  []byte("graphql_datasource.Source"),
+													ProcessResponseConfig: resolve.ProcessResponseConfig{ExtractGraphqlResponse: true},
+													Transformation: &resolve.Transformation{
+														Steps: []resolve.TransformationStep{
+															{Kind: resolve.TransformationStepKindExtract, Path: "$.user"},
+														},
+													},
+												},
+												Fields: []*resolve.Field{
+													{
+														HasBuffer: true,
+														BufferID:  1,
+														Name:      []byte("name"),
+														Value: &resolve.String{
+															Path: []string{"name"},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		plan.Configuration{
+			DataSources: []plan.DataSourceConfiguration{
+				{
+					RootNodes: []plan.TypeField{
+						{TypeName: "Query", FieldNames: []string{"me", "user"}},
+						{TypeName: "User", FieldNames: []string{"name"}},
+					},
+					ChildNodes: []plan.TypeField{
+						{TypeName: "User", FieldNames: []string{"id", "name"}},
+					},
+					Factory: &Factory{},
+					Custom: ConfigJson(Configuration{
+						Fetch: FetchConfiguration{
+							URL: "http://accounts.service",
+						},
+						UpstreamSchema: `
+							type Query { me: User user(id: ID!): User }
+							type User { id: ID! name: String! }
+						`,
+						Lookups: []LookupConfiguration{
+							{
+								TypeName:  "User",
+								FieldName: "user",
+								Arguments: []LookupArgument{{Name: "id", KeyField: "id"}},
+							},
+						},
+					}),
+				},
+				{
+					RootNodes: []plan.TypeField{
+						{TypeName: "Query", FieldNames: []string{"topReviews"}},
+					},
+					ChildNodes: []plan.TypeField{
+						{TypeName: "Review", FieldNames: []string{"body", "author"}},
+						{TypeName: "User", FieldNames: []string{"id"}},
+					},
+					Factory: &Factory{},
+					Custom: ConfigJson(Configuration{
+						Fetch: FetchConfiguration{
+							URL: "http://reviews.service",
+						},
+					}),
+				},
+			},
+			Fields: []plan.FieldConfiguration{
+				{
+					TypeName:       "User",
+					FieldName:      "name",
+					RequiresFields: []string{"id"},
+				},
+			},
+			DisableResolveFieldPositions: true,
+		}))
 	t.Run("mutation with variables in array object argument", RunTest(
 		todoSchema,
 		`mutation AddTask($title: String!, $completed: Boolean!, $name: String! @fromClaim(name: "sub")) {
@@ -5583,6 +5867,59 @@ func runTestOnTestDefinition(operation, operationName string, expectedPlan plan.
 	return RunTest(testDefinition, operation, operationName, expectedPlan, config, extraChecks...)
 }
 
+func TestSource_Load(t *testing.T) {
+	t.Run("GET", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "query($id: ID!){friend(id: $id){name}}", r.URL.Query().Get("query"))
+			assert.Equal(t, `{"id":"1"}`, r.URL.Query().Get("variables"))
+			_, _ = w.Write([]byte(`{"data":{"friend":{"name":"jens"}}}`))
+		}))
+		defer server.Close()
+
+		input := []byte(fmt.Sprintf(`{"method":"GET","url":"%s","body":{"query":"query($id: ID!){friend(id: $id){name}}","variables":{"id":"1","name":null}},"url_encode_body":true}`, server.URL))
+		source := &Source{httpClient: http.DefaultClient}
+		buf := &bytes.Buffer{}
+		require.NoError(t, source.Load(context.Background(), input, buf))
+		assert.Equal(t, `{"data":{"friend":{"name":"jens"}}}`, buf.String())
+	})
+
+	t.Run("automatic persisted query", func(t *testing.T) {
+		const (
+			query      = "{friend{name}}"
+			extensions = `{"persistedQuery":{"version":1,"sha256Hash":"hash"}}`
+		)
+		var (
+			mu      sync.Mutex
+			known   bool
+			queries []string
+		)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			assert.Equal(t, extensions, r.URL.Query().Get("extensions"))
+			sentQuery := r.URL.Query().Get("query")
+			queries = append(queries, sentQuery)
+			if !known && sentQuery == "" {
+				_, _ = w.Write([]byte(`{"errors":[{"message":"PersistedQueryNotFound","extensions":{"code":"PERSISTED_QUERY_NOT_FOUND"}}]}`))
+				return
+			}
+			known = true
+			_, _ = w.Write([]byte(`{"data":{"friend":{"name":"jens"}}}`))
+		}))
+		defer server.Close()
+
+		input := []byte(fmt.Sprintf(`{"method":"GET","url":"%s","body":{"query":"%s","extensions":%s},"url_encode_body":true}`, server.URL, query, extensions))
+		source := &Source{httpClient: http.DefaultClient}
+		for i := 0; i < 2; i++ {
+			buf := &bytes.Buffer{}
+			require.NoError(t, source.Load(context.Background(), input, buf))
+			assert.Equal(t, `{"data":{"friend":{"name":"jens"}}}`, buf.String())
+		}
+		assert.Equal(t, []string{"", query, ""}, queries)
+	})
+}
+
 func TestUnNullVariables(t *testing.T) {
 
 	t.Run("variables with whitespace", func(t *testing.T) {
@@ -0,0 +1,80 @@
+package httpclient
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+)
+
+const (
+	CompressionGzip   = "gzip"
+	CompressionBrotli = "br"
+
+	supportedEncodings = "gzip, deflate, br"
+)
+
+var (
+	gzipWriterPool = sync.Pool{
+		New: func() interface{} {
+			return gzip.NewWriter(nil)
+		},
+	}
+	brotliWriterPool = sync.Pool{
+		New: func() interface{} {
+			return brotli.NewWriterLevel(nil, brotli.DefaultCompression)
+		},
+	}
+)
+
+// compressBody compresses the body using the algorithm, which is also the value of the Content-Encoding header
+func compressBody(algorithm string, body []byte) ([]byte, error) {
+	out := bytes.NewBuffer(make([]byte, 0, len(body)/4))
+	switch algorithm {
+	case CompressionGzip:
+		writer := gzipWriterPool.Get().(*gzip.Writer)
+		defer gzipWriterPool.Put(writer)
+		writer.Reset(out)
+		if _, err := writer.Write(body); err != nil {
+			return nil, err
+		}
+		if err := writer.Close(); err != nil {
+			return nil, err
+		}
+	case CompressionBrotli:
+		writer := brotliWriterPool.Get().(*brotli.Writer)
+		defer brotliWriterPool.Put(writer)
+		writer.Reset(out)
+		if _, err := writer.Write(body); err != nil {
+			return nil, err
+		}
+		if err := writer.Close(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported request compression: %s", algorithm)
+	}
+	return out.Bytes(), nil
+}
+
+func respBodyReader(req *http.Request, resp *http.Response) (io.ReadCloser, error) {
+	if req.Header.Get(AcceptEncodingHeader) == "" {
+		return resp.Body, nil
+	}
+
+	switch resp.Header.Get(ContentEncodingHeader) {
+	case "gzip":
+		return gzip.NewReader(resp.Body)
+	case "deflate":
+		return flate.NewReader(resp.Body), nil
+	case "br":
+		return io.NopCloser(brotli.NewReader(resp.Body)), nil
+	}
+
+	return resp.Body, nil
+}
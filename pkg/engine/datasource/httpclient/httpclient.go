@@ -24,6 +24,9 @@ const (
 	HEADER        = "header"
 	QUERYPARAMS   = "query_params"
 
+	REQUESTCOMPRESSION = "request_compression"
+	DECOMPRESSRESPONSE = "decompress_response"
+
 	SCHEME = "scheme"
 	HOST   = "host"
 )
@@ -35,6 +38,11 @@ var (
 		{BODY},
 		{HEADER},
 		{QUERYPARAMS},
+		{URLENCODEBODY},
+	}
+	encodingInputPaths = [][]string{
+		{REQUESTCOMPRESSION},
+		{DECOMPRESSRESPONSE},
 	}
 	subscriptionInputPaths = [][]string{
 		{URL},
@@ -105,6 +113,24 @@ func SetInputURLEncodeBody(input []byte, urlEncodeBody bool) []byte {
 	return out
 }
 
+// SetInputRequestCompression compresses the request body using the algorithm, see CompressionGzip and CompressionBrotli
+func SetInputRequestCompression(input []byte, algorithm string) []byte {
+	if algorithm == "" {
+		return input
+	}
+	out, _ := sjson.SetBytes(input, REQUESTCOMPRESSION, algorithm)
+	return out
+}
+
+// SetInputDecompressResponse advertises all supported encodings to the upstream and decompresses the response accordingly
+func SetInputDecompressResponse(input []byte, decompressResponse bool) []byte {
+	if !decompressResponse {
+		return input
+	}
+	out, _ := sjson.SetRawBytes(input, DECOMPRESSRESPONSE, []byte("true"))
+	return out
+}
+
 func SetInputMethod(input, method []byte) []byte {
 	if len(method) == 0 {
 		return input
@@ -170,7 +196,7 @@ func SetInputPath(input, path []byte) []byte {
 	return out
 }
 
-func requestInputParams(input []byte) (url, method, body, headers, queryParams []byte) {
+func requestInputParams(input []byte) (url, method, body, headers, queryParams []byte, urlEncodeBody bool) {
 	jsonparser.EachKey(input, func(i int, bytes []byte, valueType jsonparser.ValueType, err error) {
 		switch i {
 		case 0:
@@ -183,11 +209,25 @@ func requestInputParams(input []byte) (url, method, body, headers, queryParams [
 			headers = bytes
 		case 4:
 			queryParams = bytes
+		case 5:
+			urlEncodeBody = valueType == jsonparser.Boolean && bytes[0] == 't'
 		}
 	}, inputPaths...)
 	return
 }
 
+func requestEncodingParams(input []byte) (requestCompression []byte, decompressResponse bool) {
+	jsonparser.EachKey(input, func(i int, bytes []byte, valueType jsonparser.ValueType, err error) {
+		switch i {
+		case 0:
+			requestCompression = bytes
+		case 1:
+			decompressResponse = valueType == jsonparser.Boolean && bytes[0] == 't'
+		}
+	}, encodingInputPaths...)
+	return
+}
+
 func GetSubscriptionInput(input []byte) (url, header, body []byte) {
 	jsonparser.EachKey(input, func(i int, bytes []byte, valueType jsonparser.ValueType, err error) {
 		switch i {
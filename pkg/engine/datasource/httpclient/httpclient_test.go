@@ -4,13 +4,19 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
+	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/http/httputil"
 	"testing"
 
+	"github.com/andybalholm/brotli"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 
 	"github.com/wundergraph/graphql-go-tools/internal/pkg/quotes"
 	"github.com/wundergraph/graphql-go-tools/pkg/lexer/literal"
@@ -157,6 +163,31 @@ func TestHttpClientDo(t *testing.T) {
 		t.Run("net", runTest(background, input, `ok`))
 	})
 
+	t.Run("url encoded body", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			query := r.URL.Query()
+			assert.Equal(t, `{ hello(name: "world") }`, query.Get("query"))
+			assert.Equal(t, `{"a":1}`, query.Get("variables"))
+			assert.Equal(t, "bar", query.Get("foo"))
+			_, hasNull := query["extensions"]
+			assert.False(t, hasNull)
+			actualBody, err := ioutil.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Len(t, actualBody, 0)
+			_, err = w.Write([]byte("ok"))
+			assert.NoError(t, err)
+		}))
+		defer server.Close()
+		var input []byte
+		input = SetInputMethod(input, []byte("GET"))
+		input = SetInputURL(input, []byte(server.URL))
+		input = SetInputBody(input, []byte(`{"query":"{ hello(name: \"world\") }","variables":{"a":1},"extensions":null}`))
+		input = SetInputURLEncodeBody(input, true)
+		input = SetInputQueryParams(input, []byte(`[{"name":"foo","value":"bar"}]`))
+		t.Run("net", runTest(background, input, `ok`))
+	})
+
 	t.Run("post", func(t *testing.T) {
 		body := []byte(`{"foo":"bar"}`)
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -195,4 +226,126 @@ func TestHttpClientDo(t *testing.T) {
 		input = SetInputURL(input, []byte(server.URL))
 		t.Run("net", runTest(background, input, `ok`))
 	})
+
+	t.Run("request compression", func(t *testing.T) {
+		body := []byte(`{"foo":"bar"}`)
+		for _, algorithm := range []string{CompressionGzip, CompressionBrotli} {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, algorithm, r.Header.Get("Content-Encoding"))
+				actualBody, err := ioutil.ReadAll(decompress(t, algorithm, r.Body))
+				assert.NoError(t, err)
+				assert.Equal(t, string(body), string(actualBody))
+				_, err = w.Write([]byte("ok"))
+				assert.NoError(t, err)
+			}))
+			var input []byte
+			input = SetInputMethod(input, []byte("POST"))
+			input = SetInputBody(input, body)
+			input = SetInputURL(input, []byte(server.URL))
+			input = SetInputRequestCompression(input, algorithm)
+			t.Run(algorithm, runTest(background, input, `ok`))
+			server.Close()
+		}
+	})
+
+	t.Run("decompress response", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "gzip, deflate, br", r.Header.Get("Accept-Encoding"))
+			w.Header().Set("Content-Encoding", "br")
+			brotliWriter := brotli.NewWriter(w)
+			defer brotliWriter.Close()
+			_, err := brotliWriter.Write([]byte("ok"))
+			assert.NoError(t, err)
+		}))
+		defer server.Close()
+		var input []byte
+		input = SetInputMethod(input, []byte("GET"))
+		input = SetInputURL(input, []byte(server.URL))
+		input = SetInputDecompressResponse(input, true)
+		t.Run("net", runTest(background, input, `ok`))
+	})
+
+	t.Run("h2c", func(t *testing.T) {
+		server := httptest.NewServer(h2c.NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, 2, r.ProtoMajor)
+			_, err := w.Write([]byte("ok"))
+			assert.NoError(t, err)
+		}), &http2.Server{}))
+		defer server.Close()
+		var input []byte
+		input = SetInputMethod(input, []byte("GET"))
+		input = SetInputURL(input, []byte(server.URL))
+		out := &bytes.Buffer{}
+		err := Do(NewNetHttpClient(NetHttpClientOptions{HTTPVersion: HTTPVersionH2C}), background, input, out)
+		assert.NoError(t, err)
+		assert.Equal(t, "ok", out.String())
+	})
+}
+
+func decompress(t testing.TB, algorithm string, body io.Reader) io.Reader {
+	switch algorithm {
+	case CompressionGzip:
+		reader, err := gzip.NewReader(body)
+		require.NoError(t, err)
+		return reader
+	case CompressionBrotli:
+		return brotli.NewReader(body)
+	}
+	return body
+}
+
+func BenchmarkDo(b *testing.B) {
+	representations := &bytes.Buffer{}
+	representations.WriteString(`{"query":"query($representations: [_Any!]!){_entities(representations: $representations){... on Product {name price}}}","variables":{"representations":[`)
+	for i := 0; i < 10000; i++ {
+		if i != 0 {
+			representations.WriteString(",")
+		}
+		representations.WriteString(fmt.Sprintf(`{"__typename":"Product","upc":"top-%d"}`, i))
+	}
+	representations.WriteString(`]}}`)
+	body := representations.Bytes()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(ioutil.Discard, decompress(b, r.Header.Get("Content-Encoding"), r.Body))
+		_, _ = w.Write([]byte(`{"data":{"_entities":[]}}`))
+	})
+
+	run := func(client *http.Client, server *httptest.Server, compression string) func(b *testing.B) {
+		return func(b *testing.B) {
+			var input []byte
+			input = SetInputMethod(input, []byte("POST"))
+			input = SetInputURL(input, []byte(server.URL))
+			input = SetInputBody(input, body)
+			input = SetInputRequestCompression(input, compression)
+			ctx := context.Background()
+
+			b.SetBytes(int64(len(body)))
+			b.ReportAllocs()
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				out := &bytes.Buffer{}
+				for pb.Next() {
+					out.Reset()
+					if err := Do(client, ctx, input, out); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+
+	http1Server := httptest.NewServer(handler)
+	defer http1Server.Close()
+	h2cServer := httptest.NewServer(h2c.NewHandler(handler, &http2.Server{}))
+	defer h2cServer.Close()
+
+	http1Client := NewNetHttpClient(NetHttpClientOptions{})
+	h2cClient := NewNetHttpClient(NetHttpClientOptions{HTTPVersion: HTTPVersionH2C})
+
+	b.Run("http1 uncompressed", run(http1Client, http1Server, ""))
+	b.Run("http1 gzip", run(http1Client, http1Server, CompressionGzip))
+	b.Run("http1 brotli", run(http1Client, http1Server, CompressionBrotli))
+	b.Run("h2c uncompressed", run(h2cClient, h2cServer, ""))
+	b.Run("h2c gzip", run(h2cClient, h2cServer, CompressionGzip))
 }
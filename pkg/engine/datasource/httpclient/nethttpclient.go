@@ -2,15 +2,17 @@ package httpclient
 
 import (
 	"bytes"
-	"compress/flate"
-	"compress/gzip"
 	"context"
+	"crypto/tls"
 	"io"
+	"net"
 	"net/http"
 	"time"
 
 	"github.com/buger/jsonparser"
+	"golang.org/x/net/http2"
 
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/upstreamheader"
 	"github.com/wundergraph/graphql-go-tools/pkg/lexer/literal"
 )
 
@@ -33,15 +35,95 @@ var (
 	}
 )
 
+type HTTPVersion string
+
+const (
+	// HTTPVersion1 uses HTTP/1.1 only
+	HTTPVersion1 HTTPVersion = "http1"
+	// HTTPVersion2 negotiates HTTP/2 for TLS upstreams and falls back to HTTP/1.1
+	HTTPVersion2 HTTPVersion = "http2"
+	// HTTPVersionH2C uses HTTP/2 without TLS (prior knowledge), all requests to a host are multiplexed on a single connection
+	HTTPVersionH2C HTTPVersion = "h2c"
+)
+
+type NetHttpClientOptions struct {
+	// Timeout of a request including reading the response, defaults to 10 seconds
+	Timeout time.Duration
+	// MaxIdleConnsPerHost defaults to 1024, it doesn't apply to h2c
+	MaxIdleConnsPerHost int
+	HTTPVersion         HTTPVersion
+	TLSClientConfig     *tls.Config
+}
+
+// NewNetHttpClient creates a http.Client for upstream requests, e.g. to be used as the client of a DataSource Factory
+func NewNetHttpClient(options NetHttpClientOptions) *http.Client {
+	if options.Timeout == 0 {
+		options.Timeout = time.Second * 10
+	}
+	if options.MaxIdleConnsPerHost == 0 {
+		options.MaxIdleConnsPerHost = 1024
+	}
+
+	client := &http.Client{
+		Timeout: options.Timeout,
+	}
+
+	switch options.HTTPVersion {
+	case HTTPVersionH2C:
+		dialer := &net.Dialer{}
+		client.Transport = &http2.Transport{
+			AllowHTTP: true,
+			DialTLS: func(network, addr string, _ *tls.Config) (net.Conn, error) {
+				return dialer.Dial(network, addr)
+			},
+		}
+	case HTTPVersion2:
+		client.Transport = &http.Transport{
+			MaxIdleConnsPerHost: options.MaxIdleConnsPerHost,
+			TLSClientConfig:     options.TLSClientConfig,
+			ForceAttemptHTTP2:   true,
+		}
+	default:
+		client.Transport = &http.Transport{
+			MaxIdleConnsPerHost: options.MaxIdleConnsPerHost,
+			TLSClientConfig:     options.TLSClientConfig,
+			// a non-nil empty map disables HTTP/2
+			TLSNextProto: map[string]func(string, *tls.Conn) http.RoundTripper{},
+		}
+	}
+
+	return client
+}
+
 func Do(client *http.Client, ctx context.Context, requestInput []byte, out io.Writer) (err error) {
 
-	url, method, body, headers, queryParams := requestInputParams(requestInput)
+	url, method, body, headers, queryParams, urlEncodeBody := requestInputParams(requestInput)
 
-	request, err := http.NewRequestWithContext(ctx, string(method), string(url), bytes.NewReader(body))
+	requestCompression, decompressResponse := requestEncodingParams(requestInput)
+	compressRequest := len(requestCompression) != 0 && len(body) != 0 && !urlEncodeBody
+	if compressRequest {
+		body, err = compressBody(string(requestCompression), body)
+		if err != nil {
+			return err
+		}
+	}
+
+	var bodyReader io.Reader
+	if !urlEncodeBody {
+		bodyReader = bytes.NewReader(body)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, string(method), string(url), bodyReader)
 	if err != nil {
 		return err
 	}
 
+	if urlEncodeBody && body != nil {
+		if err = encodeBodyAsQuery(request, body); err != nil {
+			return err
+		}
+	}
+
 	if headers != nil {
 		err = jsonparser.ObjectEach(headers, func(key []byte, value []byte, dataType jsonparser.ValueType, offset int) error {
 			_, err := jsonparser.ArrayEach(value, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
@@ -90,8 +172,18 @@ func Do(client *http.Client, ctx context.Context, requestInput []byte, out io.Wr
 		request.URL.RawQuery = query.Encode()
 	}
 
-	request.Header.Add("accept", "application/json")
-	request.Header.Add("content-type", "application/json")
+	if request.Header.Get("accept") == "" {
+		request.Header.Add("accept", "application/json")
+	}
+	if request.Header.Get("content-type") == "" {
+		request.Header.Add("content-type", "application/json")
+	}
+	if compressRequest {
+		request.Header.Set(ContentEncodingHeader, string(requestCompression))
+	}
+	if decompressResponse && request.Header.Get(AcceptEncodingHeader) == "" {
+		request.Header.Set(AcceptEncodingHeader, supportedEncodings)
+	}
 
 	response, err := client.Do(request)
 	if err != nil {
@@ -99,6 +191,8 @@ func Do(client *http.Client, ctx context.Context, requestInput []byte, out io.Wr
 	}
 	defer response.Body.Close()
 
+	upstreamheader.Set(ctx, response.Header)
+
 	respReader, err := respBodyReader(request, response)
 	if err != nil {
 		return err
@@ -108,17 +202,28 @@ func Do(client *http.Client, ctx context.Context, requestInput []byte, out io.Wr
 	return
 }
 
-func respBodyReader(req *http.Request, resp *http.Response) (io.ReadCloser, error) {
-	if req.Header.Get(AcceptEncodingHeader) == "" {
-		return resp.Body, nil
-	}
-
-	switch resp.Header.Get(ContentEncodingHeader) {
-	case "gzip":
-		return gzip.NewReader(resp.Body)
-	case "deflate":
-		return flate.NewReader(resp.Body), nil
+// encodeBodyAsQuery adds each field of the JSON body object as a query parameter
+// String values are added unquoted, all other values as their JSON representation, null values are omitted
+func encodeBodyAsQuery(request *http.Request, body []byte) error {
+	query := request.URL.Query()
+	err := jsonparser.ObjectEach(body, func(key []byte, value []byte, dataType jsonparser.ValueType, offset int) error {
+		switch dataType {
+		case jsonparser.Null:
+			return nil
+		case jsonparser.String:
+			unescaped, err := jsonparser.ParseString(value)
+			if err != nil {
+				return err
+			}
+			query.Set(string(key), unescaped)
+		default:
+			query.Set(string(key), string(value))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
-
-	return resp.Body, nil
+	request.URL.RawQuery = query.Encode()
+	return nil
 }
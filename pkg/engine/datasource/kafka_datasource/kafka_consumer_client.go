@@ -0,0 +1,611 @@
+package kafka_datasource
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/cespare/xxhash/v2"
+	"github.com/jensneuse/abstractlogger"
+)
+
+const defaultKafkaVersion = "2.0.0"
+
+// subscriberQueueSize is the number of messages buffered per subscription
+const subscriberQueueSize = 64
+
+// defaultSlowSubscriptionTimeout is how long the consumer waits for a subscription with a full queue
+// A subscription which doesn't catch up in time gets disconnected, so that it can't hold up the other subscriptions of a consumer.
+const defaultSlowSubscriptionTimeout = 5 * time.Second
+
+// KafkaConsumerClient consumes Kafka topics on behalf of subscriptions
+// It takes care of sharing consumers between subscriptions
+// If Hash(Brokers,Topic,GroupID,ClientID,Version,StartOffset) results in the same result, an existing consumer is re-used
+// Subscriptions starting at the earliest or a fixed offset without a consumer group get a consumer of their own
+// Key and header filters as well as the payload decoding are applied per subscription
+type KafkaConsumerClient struct {
+	ctx        context.Context
+	log        abstractlogger.Logger
+	hashPool   sync.Pool
+	handlers   map[uint64]*consumerHandler
+	handlersMu sync.Mutex
+
+	slowSubscriptionTimeout time.Duration
+}
+
+type Options func(options *opts)
+
+func WithLogger(log abstractlogger.Logger) Options {
+	return func(options *opts) {
+		options.log = log
+	}
+}
+
+type opts struct {
+	log abstractlogger.Logger
+}
+
+func NewKafkaConsumerClient(ctx context.Context, options ...Options) *KafkaConsumerClient {
+	op := &opts{
+		log: abstractlogger.NoopLogger,
+	}
+	for _, option := range options {
+		option(op)
+	}
+	return &KafkaConsumerClient{
+		ctx:                     ctx,
+		log:                     op.log,
+		handlers:                map[uint64]*consumerHandler{},
+		slowSubscriptionTimeout: defaultSlowSubscriptionTimeout,
+		hashPool: sync.Pool{
+			New: func() interface{} {
+				return xxhash.New()
+			},
+		},
+	}
+}
+
+// Subscribe starts consuming the topic defined by options and sends every matching message to next
+// If a consumer for the same topic and offset semantics exists, it is being re-used
+// Subscribe returns once the consumer is connected, consumption ends when ctx is done
+func (c *KafkaConsumerClient) Subscribe(ctx context.Context, options SubscriptionOptions, next chan<- []byte) error {
+	decoder, err := newPayloadDecoder(options.SubscriptionConfiguration)
+	if err != nil {
+		return err
+	}
+
+	sub := &subscriber{
+		ctx:         ctx,
+		next:        next,
+		queue:       make(chan []byte, subscriberQueueSize),
+		keyFilter:   options.KeyFilter,
+		headers:     options.HeaderFilters,
+		decoder:     decoder,
+		responseKey: options.ResponseKey,
+	}
+
+	handlerID := c.generateHandlerIDHash(options)
+	shared := sharesConsumer(options)
+
+	if shared && c.addToExistingHandler(handlerID, sub) {
+		return nil
+	}
+
+	// connecting to the brokers might take a while, other subscriptions must not wait for it
+	source, err := c.newMessageSource(options)
+	if err != nil {
+		return err
+	}
+
+	c.handlersMu.Lock()
+	if handler, exists := c.handlers[handlerID]; shared && exists && handler.add(sub) {
+		// another subscription started a consumer in the meantime
+		c.handlersMu.Unlock()
+		if err := source.Close(); err != nil {
+			c.log.Error("kafka_datasource.KafkaConsumerClient.Subscribe", abstractlogger.Error(err))
+		}
+		return nil
+	}
+	handler := newConsumerHandler(c, handlerID, source)
+	handler.add(sub)
+	if shared {
+		c.handlers[handlerID] = handler
+	}
+	c.handlersMu.Unlock()
+
+	go handler.startBlocking()
+
+	return nil
+}
+
+func (c *KafkaConsumerClient) addToExistingHandler(handlerID uint64, sub *subscriber) bool {
+	c.handlersMu.Lock()
+	defer c.handlersMu.Unlock()
+	handler, exists := c.handlers[handlerID]
+	return exists && handler.add(sub)
+}
+
+// sharesConsumer returns true if subscriptions with the same options can share a consumer
+// Without a consumer group, a subscription starting at the earliest or a fixed offset expects all messages from its start offset on.
+// A shared consumer would only deliver the messages consumed after the subscription joined.
+func sharesConsumer(options SubscriptionOptions) bool {
+	if options.GroupID != "" {
+		return true
+	}
+	return options.StartOffset == StartOffsetLatest || options.StartOffset == ""
+}
+
+func (c *KafkaConsumerClient) removeHandler(handler *consumerHandler) {
+	c.handlersMu.Lock()
+	defer c.handlersMu.Unlock()
+	if c.handlers[handler.id] == handler {
+		delete(c.handlers, handler.id)
+	}
+}
+
+func (c *KafkaConsumerClient) newMessageSource(options SubscriptionOptions) (messageSource, error) {
+	config, err := c.saramaConfig(options)
+	if err != nil {
+		return nil, err
+	}
+	if options.GroupID != "" {
+		return newGroupMessageSource(c.ctx, c.log, config, options)
+	}
+	return newPartitionMessageSource(c.ctx, config, options)
+}
+
+func (c *KafkaConsumerClient) saramaConfig(options SubscriptionOptions) (*sarama.Config, error) {
+	kafkaVersion := options.KafkaVersion
+	if kafkaVersion == "" {
+		kafkaVersion = defaultKafkaVersion
+	}
+	version, err := sarama.ParseKafkaVersion(kafkaVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	config := sarama.NewConfig()
+	config.Version = version
+	if options.ClientID != "" {
+		config.ClientID = options.ClientID
+	}
+
+	switch options.StartOffset {
+	case StartOffsetLatest, "":
+		config.Consumer.Offsets.Initial = sarama.OffsetNewest
+	case StartOffsetEarliest:
+		config.Consumer.Offsets.Initial = sarama.OffsetOldest
+	case StartOffsetFixed:
+		if options.Offset < 0 {
+			return nil, fmt.Errorf("invalid start offset: %d", options.Offset)
+		}
+		config.Consumer.Offsets.Initial = sarama.OffsetOldest
+	default:
+		return nil, fmt.Errorf("unknown start offset: %s", options.StartOffset)
+	}
+
+	return config, nil
+}
+
+// generateHandlerIDHash generates a Hash based on all options that influence which messages are consumed
+// Filters, decoding and the response key are applied per subscription and are therefore not part of the Hash
+func (c *KafkaConsumerClient) generateHandlerIDHash(options SubscriptionOptions) uint64 {
+	xxh := c.hashPool.Get().(*xxhash.Digest)
+	defer c.hashPool.Put(xxh)
+	xxh.Reset()
+
+	for i := range options.BrokerAddresses {
+		_, _ = xxh.WriteString(options.BrokerAddresses[i])
+		_, _ = xxh.Write([]byte{0})
+	}
+	for _, s := range []string{options.Topic, options.GroupID, options.ClientID, options.KafkaVersion, string(options.StartOffset)} {
+		_, _ = xxh.WriteString(s)
+		_, _ = xxh.Write([]byte{0})
+	}
+	if options.StartOffset == StartOffsetFixed {
+		_, _ = xxh.WriteString(strconv.FormatInt(options.Offset, 10))
+	}
+
+	return xxh.Sum64()
+}
+
+type subscriber struct {
+	ctx         context.Context
+	next        chan<- []byte
+	queue       chan []byte
+	keyFilter   string
+	headers     map[string]string
+	decoder     payloadDecoder
+	responseKey string
+}
+
+func (s *subscriber) matches(msg *sarama.ConsumerMessage) bool {
+	if s.keyFilter != "" && string(msg.Key) != s.keyFilter {
+		return false
+	}
+Headers:
+	for name, value := range s.headers {
+		if value == "" {
+			continue
+		}
+		for _, header := range msg.Headers {
+			if header != nil && string(header.Key) == name && string(header.Value) == value {
+				continue Headers
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// forward sends the queued messages to next, next gets closed once the queue is closed and drained
+func (s *subscriber) forward() {
+	for data := range s.queue {
+		select {
+		case s.next <- data:
+		case <-s.ctx.Done():
+			return
+		}
+	}
+	close(s.next)
+}
+
+func (s *subscriber) render(msg *sarama.ConsumerMessage) ([]byte, error) {
+	payload, err := s.decoder.Decode(msg.Value)
+	if err != nil {
+		return nil, err
+	}
+	if s.responseKey == "" {
+		return payload, nil
+	}
+	buf := bytes.NewBuffer(make([]byte, 0, len(payload)+len(s.responseKey)+5))
+	buf.WriteString(`{"`)
+	buf.WriteString(s.responseKey)
+	buf.WriteString(`":`)
+	buf.Write(payload)
+	buf.WriteString(`}`)
+	return buf.Bytes(), nil
+}
+
+// consumerHandler is responsible for a single consumer of a topic
+// it dispatches the consumed messages to the queues of all subscriptions sharing the consumer
+// once all subscriptions are done, the consumer is closed and the handler terminates
+// The queues are only written and closed by the dispatch loop.
+type consumerHandler struct {
+	id          uint64
+	client      *KafkaConsumerClient
+	source      messageSource
+	ctx         context.Context
+	cancel      context.CancelFunc
+	mu          sync.Mutex
+	closed      bool
+	subscribers map[*subscriber]struct{}
+}
+
+func newConsumerHandler(client *KafkaConsumerClient, id uint64, source messageSource) *consumerHandler {
+	ctx, cancel := context.WithCancel(client.ctx)
+	return &consumerHandler{
+		id:          id,
+		client:      client,
+		source:      source,
+		ctx:         ctx,
+		cancel:      cancel,
+		subscribers: map[*subscriber]struct{}{},
+	}
+}
+
+// add registers a subscriber, it returns false if the handler is already shutting down
+func (h *consumerHandler) add(sub *subscriber) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return false
+	}
+	h.subscribers[sub] = struct{}{}
+	go sub.forward()
+	go func() {
+		select {
+		case <-sub.ctx.Done():
+			h.remove(sub)
+		case <-h.ctx.Done():
+		}
+	}()
+	return true
+}
+
+func (h *consumerHandler) remove(sub *subscriber) {
+	h.mu.Lock()
+	delete(h.subscribers, sub)
+	if len(h.subscribers) != 0 || h.closed {
+		h.mu.Unlock()
+		return
+	}
+	h.closed = true
+	h.mu.Unlock()
+
+	h.client.removeHandler(h)
+	h.cancel()
+}
+
+func (h *consumerHandler) activeSubscribers() []*subscriber {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	out := make([]*subscriber, 0, len(h.subscribers))
+	for sub := range h.subscribers {
+		out = append(out, sub)
+	}
+	return out
+}
+
+// startBlocking runs the dispatch loop of the handler
+// if the global context returns or all subscriptions are done, it will stop
+func (h *consumerHandler) startBlocking() {
+	defer h.shutdown()
+	messages := h.source.Messages()
+	for {
+		select {
+		case <-h.ctx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+			h.dispatch(msg)
+		}
+	}
+}
+
+func (h *consumerHandler) dispatch(msg *sarama.ConsumerMessage) {
+	for _, sub := range h.activeSubscribers() {
+		if !sub.matches(msg) {
+			continue
+		}
+		data, err := sub.render(msg)
+		if err != nil {
+			h.client.log.Error("kafka_datasource.consumerHandler.dispatch",
+				abstractlogger.String("topic", msg.Topic),
+				abstractlogger.Any("partition", msg.Partition),
+				abstractlogger.Any("offset", msg.Offset),
+				abstractlogger.Error(err),
+			)
+			continue
+		}
+		select {
+		case sub.queue <- data:
+			continue
+		default:
+		}
+		if !h.awaitQueue(sub, data) {
+			h.client.log.Error("kafka_datasource.consumerHandler.dispatch",
+				abstractlogger.String("topic", msg.Topic),
+				abstractlogger.String("reason", "subscription doesn't keep up with the consumer, disconnecting it"),
+			)
+			h.disconnect(sub)
+		}
+	}
+}
+
+// awaitQueue waits for a subscription with a full queue to catch up, it returns false if it didn't in time
+// Blocking without a timeout would hold up all other subscriptions of the consumer.
+func (h *consumerHandler) awaitQueue(sub *subscriber, data []byte) bool {
+	timer := time.NewTimer(h.client.slowSubscriptionTimeout)
+	defer timer.Stop()
+	select {
+	case sub.queue <- data:
+		return true
+	case <-sub.ctx.Done():
+		return true
+	case <-h.ctx.Done():
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+// disconnect removes a subscriber and ends its subscription once the queued messages are sent
+func (h *consumerHandler) disconnect(sub *subscriber) {
+	h.remove(sub)
+	close(sub.queue)
+}
+
+func (h *consumerHandler) shutdown() {
+	h.mu.Lock()
+	h.closed = true
+	remaining := h.subscribers
+	h.subscribers = map[*subscriber]struct{}{}
+	h.mu.Unlock()
+
+	h.client.removeHandler(h)
+	h.cancel()
+
+	if err := h.source.Close(); err != nil {
+		h.client.log.Error("kafka_datasource.consumerHandler.shutdown", abstractlogger.Error(err))
+	}
+
+	for sub := range remaining {
+		close(sub.queue)
+	}
+}
+
+type messageSource interface {
+	Messages() <-chan *sarama.ConsumerMessage
+	Close() error
+}
+
+// partitionMessageSource consumes all partitions of a topic without committing offsets
+type partitionMessageSource struct {
+	ctx        context.Context
+	cancel     context.CancelFunc
+	consumer   sarama.Consumer
+	partitions []sarama.PartitionConsumer
+	messages   chan *sarama.ConsumerMessage
+}
+
+func newPartitionMessageSource(ctx context.Context, config *sarama.Config, options SubscriptionOptions) (*partitionMessageSource, error) {
+	consumer, err := sarama.NewConsumer(options.BrokerAddresses, config)
+	if err != nil {
+		return nil, err
+	}
+	partitions, err := consumer.Partitions(options.Topic)
+	if err != nil {
+		_ = consumer.Close()
+		return nil, err
+	}
+
+	offset := config.Consumer.Offsets.Initial
+	if options.StartOffset == StartOffsetFixed {
+		offset = options.Offset
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	source := &partitionMessageSource{
+		ctx:      ctx,
+		cancel:   cancel,
+		consumer: consumer,
+		messages: make(chan *sarama.ConsumerMessage),
+	}
+
+	for _, partition := range partitions {
+		partitionConsumer, err := consumer.ConsumePartition(options.Topic, partition, offset)
+		if err != nil {
+			_ = source.Close()
+			return nil, err
+		}
+		source.partitions = append(source.partitions, partitionConsumer)
+		go source.forward(partitionConsumer)
+	}
+
+	return source, nil
+}
+
+func (p *partitionMessageSource) forward(partitionConsumer sarama.PartitionConsumer) {
+	for msg := range partitionConsumer.Messages() {
+		select {
+		case p.messages <- msg:
+		case <-p.ctx.Done():
+			return
+		}
+	}
+}
+
+func (p *partitionMessageSource) Messages() <-chan *sarama.ConsumerMessage {
+	return p.messages
+}
+
+func (p *partitionMessageSource) Close() error {
+	p.cancel()
+	for i := range p.partitions {
+		p.partitions[i].AsyncClose()
+	}
+	return p.consumer.Close()
+}
+
+// groupMessageSource consumes a topic as a member of a consumer group
+// consumed messages are marked and committed, so that a restarted group continues where it left off
+type groupMessageSource struct {
+	ctx      context.Context
+	cancel   context.CancelFunc
+	log      abstractlogger.Logger
+	group    sarama.ConsumerGroup
+	options  SubscriptionOptions
+	messages chan *sarama.ConsumerMessage
+	done     chan struct{}
+
+	resetMu sync.Mutex
+	reset   map[int32]bool
+}
+
+func newGroupMessageSource(ctx context.Context, log abstractlogger.Logger, config *sarama.Config, options SubscriptionOptions) (*groupMessageSource, error) {
+	group, err := sarama.NewConsumerGroup(options.BrokerAddresses, options.GroupID, config)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	source := &groupMessageSource{
+		ctx:      ctx,
+		cancel:   cancel,
+		log:      log,
+		group:    group,
+		options:  options,
+		messages: make(chan *sarama.ConsumerMessage),
+		done:     make(chan struct{}),
+		reset:    map[int32]bool{},
+	}
+
+	go source.consume()
+
+	return source, nil
+}
+
+func (g *groupMessageSource) consume() {
+	defer close(g.done)
+	topics := []string{g.options.Topic}
+	for {
+		err := g.group.Consume(g.ctx, topics, g)
+		if err != nil {
+			g.log.Error("kafka_datasource.groupMessageSource.consume", abstractlogger.Error(err))
+		}
+		if g.ctx.Err() != nil || err == sarama.ErrClosedConsumerGroup {
+			return
+		}
+	}
+}
+
+// Setup moves the offsets of newly claimed partitions to the configured fixed offset
+// It is applied once per partition, so that a rebalance doesn't replay already consumed messages
+func (g *groupMessageSource) Setup(session sarama.ConsumerGroupSession) error {
+	if g.options.StartOffset != StartOffsetFixed {
+		return nil
+	}
+	g.resetMu.Lock()
+	defer g.resetMu.Unlock()
+	for _, partition := range session.Claims()[g.options.Topic] {
+		if g.reset[partition] {
+			continue
+		}
+		// ResetOffset only moves the offset backwards, MarkOffset only forwards
+		session.ResetOffset(g.options.Topic, partition, g.options.Offset, "")
+		session.MarkOffset(g.options.Topic, partition, g.options.Offset, "")
+		g.reset[partition] = true
+	}
+	return nil
+}
+
+func (g *groupMessageSource) Cleanup(_ sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (g *groupMessageSource) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for {
+		select {
+		case <-session.Context().Done():
+			return nil
+		case msg, ok := <-claim.Messages():
+			if !ok {
+				return nil
+			}
+			select {
+			case g.messages <- msg:
+				session.MarkMessage(msg, "")
+			case <-session.Context().Done():
+				return nil
+			}
+		}
+	}
+}
+
+func (g *groupMessageSource) Messages() <-chan *sarama.ConsumerMessage {
+	return g.messages
+}
+
+func (g *groupMessageSource) Close() error {
+	g.cancel()
+	err := g.group.Close()
+	<-g.done
+	return err
+}
@@ -0,0 +1,386 @@
+package kafka_datasource
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTopic = "orders"
+
+type testMessage struct {
+	key     string
+	value   string
+	headers map[string]string
+}
+
+// newTestBroker starts a fake broker speaking the Kafka wire protocol
+// it serves a single partition of testTopic containing the messages starting at offset 0
+func newTestBroker(t *testing.T, messages ...testMessage) *sarama.MockBroker {
+	broker := sarama.NewMockBroker(t, 1)
+
+	fetchResponse := &sarama.FetchResponse{
+		Version: 7,
+	}
+	for i, message := range messages {
+		var key sarama.Encoder
+		if message.key != "" {
+			key = sarama.StringEncoder(message.key)
+		}
+		fetchResponse.AddRecord(testTopic, 0, key, sarama.StringEncoder(message.value), int64(i))
+	}
+	if len(messages) == 0 {
+		fetchResponse.AddError(testTopic, 0, sarama.ErrNoError)
+	} else {
+		records := fetchResponse.GetBlock(testTopic, 0).RecordsSet[0].RecordBatch.Records
+		for i, message := range messages {
+			for name, value := range message.headers {
+				records[i].Headers = append(records[i].Headers, &sarama.RecordHeader{Key: []byte(name), Value: []byte(value)})
+			}
+		}
+	}
+	fetchResponse.GetBlock(testTopic, 0).HighWaterMarkOffset = int64(len(messages))
+
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetLeader(testTopic, 0, broker.BrokerID()),
+		"OffsetRequest": sarama.NewMockOffsetResponse(t).
+			SetOffset(testTopic, 0, sarama.OffsetOldest, 0).
+			SetOffset(testTopic, 0, sarama.OffsetNewest, int64(len(messages))),
+		"FetchRequest":           sarama.NewMockWrapper(fetchResponse),
+		"FindCoordinatorRequest": sarama.NewMockFindCoordinatorResponse(t).SetCoordinator(sarama.CoordinatorGroup, "gateway", broker),
+		"HeartbeatRequest":       sarama.NewMockHeartbeatResponse(t),
+		"JoinGroupRequest":       sarama.NewMockJoinGroupResponse(t),
+		"SyncGroupRequest": sarama.NewMockSyncGroupResponse(t).SetMemberAssignment(&sarama.ConsumerGroupMemberAssignment{
+			Version: 0,
+			Topics: map[string][]int32{
+				testTopic: {0},
+			},
+		}),
+		"OffsetFetchRequest":  sarama.NewMockOffsetFetchResponse(t).SetOffset("gateway", testTopic, 0, -1, "", sarama.ErrNoError).SetError(sarama.ErrNoError),
+		"OffsetCommitRequest": sarama.NewMockOffsetCommitResponse(t),
+		"LeaveGroupRequest":   sarama.NewMockLeaveGroupResponse(t),
+	})
+
+	return broker
+}
+
+func testOptions(broker *sarama.MockBroker) SubscriptionOptions {
+	return SubscriptionOptions{
+		SubscriptionConfiguration: SubscriptionConfiguration{
+			BrokerAddresses: []string{broker.Addr()},
+			Topic:           testTopic,
+			StartOffset:     StartOffsetEarliest,
+		},
+	}
+}
+
+func receive(t *testing.T, next <-chan []byte) string {
+	t.Helper()
+	select {
+	case data := <-next:
+		return string(data)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for message")
+		return ""
+	}
+}
+
+func assertNoMessage(t *testing.T, next <-chan []byte) {
+	t.Helper()
+	select {
+	case data := <-next:
+		t.Fatalf("unexpected message: %s", string(data))
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestKafkaConsumerClient_Subscribe(t *testing.T) {
+	messages := []testMessage{
+		{key: "1", value: `{"id":"1","status":"created"}`, headers: map[string]string{"source": "checkout"}},
+		{key: "2", value: `{"id":"2","status":"created"}`, headers: map[string]string{"source": "backoffice"}},
+		{key: "1", value: `{"id":"1","status":"shipped"}`, headers: map[string]string{"source": "checkout"}},
+	}
+
+	t.Run("consume from earliest", func(t *testing.T) {
+		broker := newTestBroker(t, messages...)
+		defer broker.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		client := NewKafkaConsumerClient(ctx)
+		next := make(chan []byte)
+		options := testOptions(broker)
+		options.ResponseKey = "orderUpdated"
+		require.NoError(t, client.Subscribe(ctx, options, next))
+
+		assert.Equal(t, `{"orderUpdated":{"id":"1","status":"created"}}`, receive(t, next))
+		assert.Equal(t, `{"orderUpdated":{"id":"2","status":"created"}}`, receive(t, next))
+		assert.Equal(t, `{"orderUpdated":{"id":"1","status":"shipped"}}`, receive(t, next))
+	})
+
+	t.Run("consume from offset", func(t *testing.T) {
+		broker := newTestBroker(t, messages...)
+		defer broker.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		client := NewKafkaConsumerClient(ctx)
+		next := make(chan []byte)
+		options := testOptions(broker)
+		options.StartOffset = StartOffsetFixed
+		options.Offset = 2
+		require.NoError(t, client.Subscribe(ctx, options, next))
+
+		assert.Equal(t, `{"id":"1","status":"shipped"}`, receive(t, next))
+		assertNoMessage(t, next)
+	})
+
+	t.Run("consume latest", func(t *testing.T) {
+		broker := newTestBroker(t, messages...)
+		defer broker.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		client := NewKafkaConsumerClient(ctx)
+		next := make(chan []byte)
+		options := testOptions(broker)
+		options.StartOffset = StartOffsetLatest
+		require.NoError(t, client.Subscribe(ctx, options, next))
+
+		assertNoMessage(t, next)
+	})
+
+	t.Run("key and header filters", func(t *testing.T) {
+		broker := newTestBroker(t, messages...)
+		defer broker.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		client := NewKafkaConsumerClient(ctx)
+
+		byKey := make(chan []byte)
+		options := testOptions(broker)
+		options.KeyFilter = "2"
+		require.NoError(t, client.Subscribe(ctx, options, byKey))
+		assert.Equal(t, `{"id":"2","status":"created"}`, receive(t, byKey))
+		assertNoMessage(t, byKey)
+	})
+
+	t.Run("header filters", func(t *testing.T) {
+		broker := newTestBroker(t, messages...)
+		defer broker.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		client := NewKafkaConsumerClient(ctx)
+
+		byHeader := make(chan []byte)
+		options := testOptions(broker)
+		options.HeaderFilters = map[string]string{"source": "checkout"}
+		require.NoError(t, client.Subscribe(ctx, options, byHeader))
+		assert.Equal(t, `{"id":"1","status":"created"}`, receive(t, byHeader))
+		assert.Equal(t, `{"id":"1","status":"shipped"}`, receive(t, byHeader))
+		assertNoMessage(t, byHeader)
+	})
+
+	t.Run("shared consumption", func(t *testing.T) {
+		broker := newTestBroker(t, messages...)
+		defer broker.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		client := NewKafkaConsumerClient(ctx)
+
+		// joining the group takes several round trips, so the second subscription joins before the first message is consumed
+		first, second := make(chan []byte), make(chan []byte)
+		firstOptions := testOptions(broker)
+		firstOptions.GroupID = "gateway"
+		firstOptions.KeyFilter = "1"
+		secondOptions := testOptions(broker)
+		secondOptions.GroupID = "gateway"
+		secondOptions.KeyFilter = "2"
+
+		require.NoError(t, client.Subscribe(ctx, firstOptions, first))
+		require.NoError(t, client.Subscribe(ctx, secondOptions, second))
+
+		client.handlersMu.Lock()
+		assert.Len(t, client.handlers, 1)
+		client.handlersMu.Unlock()
+
+		assert.Equal(t, `{"id":"1","status":"created"}`, receive(t, first))
+		assert.Equal(t, `{"id":"2","status":"created"}`, receive(t, second))
+		assert.Equal(t, `{"id":"1","status":"shipped"}`, receive(t, first))
+	})
+
+	t.Run("subscription not reading doesn't block the other subscriptions", func(t *testing.T) {
+		manyMessages := make([]testMessage, subscriberQueueSize+10)
+		for i := range manyMessages {
+			manyMessages[i] = testMessage{value: fmt.Sprintf(`{"id":"%d"}`, i)}
+		}
+		broker := newTestBroker(t, manyMessages...)
+		defer broker.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		client := NewKafkaConsumerClient(ctx)
+		client.slowSubscriptionTimeout = 100 * time.Millisecond
+
+		options := testOptions(broker)
+		options.GroupID = "gateway"
+		slow, fast := make(chan []byte), make(chan []byte)
+		require.NoError(t, client.Subscribe(ctx, options, slow))
+		require.NoError(t, client.Subscribe(ctx, options, fast))
+
+		for i := range manyMessages {
+			assert.Equal(t, manyMessages[i].value, receive(t, fast))
+		}
+
+		// the slow subscription got disconnected as it didn't catch up, the queued messages are still delivered
+		// the forwarder might have taken one more message from the queue already
+		var received []string
+	loop:
+		for {
+			select {
+			case msg, ok := <-slow:
+				if !ok {
+					break loop
+				}
+				received = append(received, string(msg))
+			case <-time.After(5 * time.Second):
+				t.Fatal("timeout waiting for the slow subscription to be closed")
+			}
+		}
+		require.GreaterOrEqual(t, len(received), subscriberQueueSize)
+		require.LessOrEqual(t, len(received), subscriberQueueSize+1)
+		for i := range received {
+			assert.Equal(t, manyMessages[i].value, received[i])
+		}
+	})
+
+	t.Run("late subscriptions from earliest don't share the consumer", func(t *testing.T) {
+		broker := newTestBroker(t, messages...)
+		defer broker.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		client := NewKafkaConsumerClient(ctx)
+
+		first := make(chan []byte)
+		require.NoError(t, client.Subscribe(ctx, testOptions(broker), first))
+		assert.Equal(t, `{"id":"1","status":"created"}`, receive(t, first))
+
+		second := make(chan []byte)
+		require.NoError(t, client.Subscribe(ctx, testOptions(broker), second))
+		assert.Equal(t, `{"id":"1","status":"created"}`, receive(t, second))
+		assert.Equal(t, `{"id":"2","status":"created"}`, receive(t, second))
+		assert.Equal(t, `{"id":"2","status":"created"}`, receive(t, first))
+	})
+
+	t.Run("consumer is closed after last subscription is done", func(t *testing.T) {
+		broker := newTestBroker(t)
+		defer broker.Close()
+
+		client := NewKafkaConsumerClient(context.Background())
+
+		firstCtx, cancelFirst := context.WithCancel(context.Background())
+		secondCtx, cancelSecond := context.WithCancel(context.Background())
+
+		options := testOptions(broker)
+		options.StartOffset = StartOffsetLatest
+		require.NoError(t, client.Subscribe(firstCtx, options, make(chan []byte)))
+		require.NoError(t, client.Subscribe(secondCtx, options, make(chan []byte)))
+
+		handlerCount := func() int {
+			client.handlersMu.Lock()
+			defer client.handlersMu.Unlock()
+			return len(client.handlers)
+		}
+
+		assert.Equal(t, 1, handlerCount())
+		cancelFirst()
+		assert.Never(t, func() bool { return handlerCount() == 0 }, 50*time.Millisecond, 10*time.Millisecond)
+		cancelSecond()
+		assert.Eventually(t, func() bool { return handlerCount() == 0 }, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("next is closed when the client is done", func(t *testing.T) {
+		broker := newTestBroker(t)
+		defer broker.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		client := NewKafkaConsumerClient(ctx)
+
+		next := make(chan []byte)
+		require.NoError(t, client.Subscribe(context.Background(), testOptions(broker), next))
+		cancel()
+
+		select {
+		case _, ok := <-next:
+			assert.False(t, ok)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout waiting for next to be closed")
+		}
+	})
+
+	t.Run("consumer group", func(t *testing.T) {
+		broker := newTestBroker(t, messages...)
+		defer broker.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		client := NewKafkaConsumerClient(ctx)
+		next := make(chan []byte)
+		options := testOptions(broker)
+		options.GroupID = "gateway"
+		options.StartOffset = StartOffsetFixed
+		options.Offset = 1
+		require.NoError(t, client.Subscribe(ctx, options, next))
+
+		assert.Equal(t, `{"id":"2","status":"created"}`, receive(t, next))
+		assert.Equal(t, `{"id":"1","status":"shipped"}`, receive(t, next))
+	})
+
+	t.Run("invalid payloads are skipped", func(t *testing.T) {
+		broker := newTestBroker(t,
+			testMessage{value: `not json`},
+			testMessage{value: `{"id":"1"}`},
+		)
+		defer broker.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		client := NewKafkaConsumerClient(ctx)
+		next := make(chan []byte)
+		require.NoError(t, client.Subscribe(ctx, testOptions(broker), next))
+
+		assert.Equal(t, `{"id":"1"}`, receive(t, next))
+	})
+
+	t.Run("unknown start offset", func(t *testing.T) {
+		broker := newTestBroker(t)
+		defer broker.Close()
+
+		client := NewKafkaConsumerClient(context.Background())
+		options := testOptions(broker)
+		options.StartOffset = "somewhere"
+		err := client.Subscribe(context.Background(), options, make(chan []byte))
+		assert.EqualError(t, err, "unknown start offset: somewhere")
+	})
+}
@@ -0,0 +1,164 @@
+package kafka_datasource
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/plan"
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/resolve"
+)
+
+type StartOffset string
+
+const (
+	// StartOffsetLatest starts consuming with the next message produced after the subscription started
+	StartOffsetLatest StartOffset = "latest"
+	// StartOffsetEarliest starts consuming with the oldest message still available on the topic
+	StartOffsetEarliest StartOffset = "earliest"
+	// StartOffsetFixed starts consuming at SubscriptionConfiguration.Offset on every partition
+	StartOffsetFixed StartOffset = "offset"
+)
+
+type PayloadFormat string
+
+const (
+	PayloadFormatJSON PayloadFormat = "json"
+	PayloadFormatAvro PayloadFormat = "avro"
+)
+
+type Configuration struct {
+	Subscription SubscriptionConfiguration
+}
+
+func ConfigJSON(config Configuration) json.RawMessage {
+	out, _ := json.Marshal(config)
+	return out
+}
+
+// SubscriptionConfiguration defines how a subscription root field is mapped to a Kafka topic
+// Topic, KeyFilter and the values of HeaderFilters may contain templates, e.g. {{ .arguments.orderId }}
+// An empty filter, e.g. when an optional argument is omitted, matches all messages
+type SubscriptionConfiguration struct {
+	BrokerAddresses []string `json:"broker_addresses"`
+	Topic           string   `json:"topic"`
+	// GroupID enables consumer group semantics
+	// Committed offsets of the group take precedence over StartOffset "latest" and "earliest"
+	// Multiple engine instances using the same GroupID share the partitions of the topic between each other
+	GroupID      string `json:"group_id,omitempty"`
+	ClientID     string `json:"client_id,omitempty"`
+	KafkaVersion string `json:"kafka_version,omitempty"`
+	// StartOffset defaults to StartOffsetLatest
+	// Without a GroupID, every subscription starting at StartOffsetEarliest or StartOffsetFixed consumes the topic on its own
+	StartOffset StartOffset `json:"start_offset,omitempty"`
+	// Offset is used when StartOffset is set to StartOffsetFixed
+	Offset        int64             `json:"offset,omitempty"`
+	KeyFilter     string            `json:"key_filter,omitempty"`
+	HeaderFilters map[string]string `json:"header_filters,omitempty"`
+	// PayloadFormat defaults to PayloadFormatJSON
+	PayloadFormat PayloadFormat `json:"payload_format,omitempty"`
+	// AvroSchema is the writer schema of the messages, it's required for PayloadFormatAvro
+	AvroSchema string `json:"avro_schema,omitempty"`
+	// ConfluentWireFormat strips the magic byte and schema id prepended by the Confluent serializers
+	ConfluentWireFormat bool `json:"confluent_wire_format,omitempty"`
+}
+
+// SubscriptionOptions is the rendered input of a SubscriptionSource
+type SubscriptionOptions struct {
+	SubscriptionConfiguration
+	// ResponseKey wraps the decoded payload into an object, so that it can be resolved as the root field
+	// If empty, the payload is passed to the resolver unmodified
+	ResponseKey string `json:"response_key,omitempty"`
+}
+
+type Factory struct {
+	client     *KafkaConsumerClient
+	clientOnce sync.Once
+}
+
+func (f *Factory) Planner(ctx context.Context) plan.DataSourcePlanner {
+	f.clientOnce.Do(func() {
+		if f.client == nil {
+			f.client = NewKafkaConsumerClient(ctx)
+		}
+	})
+	return &Planner{
+		client: f.client,
+	}
+}
+
+type Planner struct {
+	client      *KafkaConsumerClient
+	v           *plan.Visitor
+	config      Configuration
+	responseKey string
+	rootField   int
+}
+
+func (p *Planner) Register(visitor *plan.Visitor, configuration plan.DataSourceConfiguration, _ bool) error {
+	p.v = visitor
+	p.rootField = -1
+	visitor.Walker.RegisterEnterFieldVisitor(p)
+	return json.Unmarshal(configuration.Custom, &p.config)
+}
+
+func (p *Planner) EnterField(ref int) {
+	if p.rootField != -1 {
+		return
+	}
+	p.rootField = ref
+
+	typeName := p.v.Walker.EnclosingTypeDefinition.NameString(p.v.Definition)
+	fieldName := p.v.Operation.FieldNameString(ref)
+	fieldConfig := p.v.Config.Fields.ForTypeField(typeName, fieldName)
+	if fieldConfig != nil && (fieldConfig.DisableDefaultMapping || len(fieldConfig.Path) != 0) {
+		// the field configuration maps into the payload itself
+		return
+	}
+	p.responseKey = fieldName
+}
+
+func (p *Planner) ConfigureFetch() plan.FetchConfiguration {
+	return plan.FetchConfiguration{}
+}
+
+func (p *Planner) ConfigureSubscription() plan.SubscriptionConfiguration {
+	input, _ := json.Marshal(SubscriptionOptions{
+		SubscriptionConfiguration: p.config.Subscription,
+		ResponseKey:               p.responseKey,
+	})
+	return plan.SubscriptionConfiguration{
+		Input: string(input),
+		DataSource: &SubscriptionSource{
+			client: p.client,
+		},
+	}
+}
+
+func (p *Planner) DataSourcePlanningBehavior() plan.DataSourcePlanningBehavior {
+	return plan.DataSourcePlanningBehavior{
+		MergeAliasedRootNodes:      false,
+		OverrideFieldPathFromAlias: false,
+	}
+}
+
+func (p *Planner) DownstreamResponseFieldAlias(_ int) (alias string, exists bool) {
+	// the Kafka DataSourcePlanner doesn't rewrite upstream fields: skip
+	return
+}
+
+type SubscriptionSource struct {
+	client *KafkaConsumerClient
+}
+
+func (s *SubscriptionSource) Start(ctx context.Context, input []byte, next chan<- []byte) error {
+	var options SubscriptionOptions
+	err := json.Unmarshal(input, &options)
+	if err != nil {
+		return err
+	}
+	if options.Topic == "" || len(options.BrokerAddresses) == 0 {
+		return resolve.ErrUnableToResolve
+	}
+	return s.client.Subscribe(ctx, options, next)
+}
@@ -0,0 +1,201 @@
+package kafka_datasource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/datasourcetesting"
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/plan"
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/resolve"
+)
+
+const schema = `
+	type Query {
+		_: Boolean
+	}
+
+	type Subscription {
+		orderUpdated(orderId: ID): Order!
+	}
+
+	type Order {
+		id: ID!
+		status: String!
+	}
+`
+
+func TestKafkaDataSourcePlanning(t *testing.T) {
+	factory := &Factory{}
+
+	t.Run("subscription with key filter from argument", datasourcetesting.RunTest(schema, `
+		subscription OrderUpdated($orderId: ID) {
+			orderUpdated(orderId: $orderId) {
+				id
+				status
+			}
+		}
+	`, "OrderUpdated", &plan.SubscriptionResponsePlan{
+		Response: &resolve.GraphQLSubscription{
+			Trigger: resolve.GraphQLSubscriptionTrigger{
+				Input: []byte(`{"broker_addresses":["localhost:9092"],"topic":"orders","group_id":"gateway","start_offset":"latest","key_filter":"$$0$$","header_filters":{"source":"checkout"},"response_key":"orderUpdated"}`),
+				Variables: resolve.NewVariables(
+					&resolve.ContextVariable{
+						Path:     []string{"orderId"},
+						Renderer: resolve.NewPlainVariableRendererWithValidation(`{"type":["string","integer","null"]}`),
+					},
+				),
+				Source: &SubscriptionSource{
+					client: factory.client,
+				},
+			},
+			Response: &resolve.GraphQLResponse{
+				Data: &resolve.Object{
+					Fields: []*resolve.Field{
+						{
+							Name: []byte("orderUpdated"),
+							Value: &resolve.Object{
+								Path: []string{"orderUpdated"},
+								Fields: []*resolve.Field{
+									{
+										Name: []byte("id"),
+										Value: &resolve.String{
+											Path: []string{"id"},
+										},
+									},
+									{
+										Name: []byte("status"),
+										Value: &resolve.String{
+											Path: []string{"status"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, plan.Configuration{
+		DataSources: []plan.DataSourceConfiguration{
+			{
+				RootNodes: []plan.TypeField{
+					{
+						TypeName:   "Subscription",
+						FieldNames: []string{"orderUpdated"},
+					},
+				},
+				ChildNodes: []plan.TypeField{
+					{
+						TypeName:   "Order",
+						FieldNames: []string{"id", "status"},
+					},
+				},
+				Custom: ConfigJSON(Configuration{
+					Subscription: SubscriptionConfiguration{
+						BrokerAddresses: []string{"localhost:9092"},
+						Topic:           "orders",
+						GroupID:         "gateway",
+						StartOffset:     StartOffsetLatest,
+						KeyFilter:       "{{ .arguments.orderId }}",
+						HeaderFilters: map[string]string{
+							"source": "checkout",
+						},
+					},
+				}),
+				Factory: factory,
+			},
+		},
+		DisableResolveFieldPositions: true,
+	}))
+
+	t.Run("subscription with disabled default mapping", datasourcetesting.RunTest(schema, `
+		subscription OrderUpdated {
+			orderUpdated {
+				id
+			}
+		}
+	`, "OrderUpdated", &plan.SubscriptionResponsePlan{
+		Response: &resolve.GraphQLSubscription{
+			Trigger: resolve.GraphQLSubscriptionTrigger{
+				Input: []byte(`{"broker_addresses":["localhost:9092"],"topic":"orders","start_offset":"offset","offset":42,"payload_format":"avro","avro_schema":"{\"type\":\"record\",\"name\":\"Order\",\"fields\":[{\"name\":\"id\",\"type\":\"string\"}]}"}`),
+				Source: &SubscriptionSource{
+					client: factory.client,
+				},
+			},
+			Response: &resolve.GraphQLResponse{
+				Data: &resolve.Object{
+					Fields: []*resolve.Field{
+						{
+							Name: []byte("orderUpdated"),
+							Value: &resolve.Object{
+								Fields: []*resolve.Field{
+									{
+										Name: []byte("id"),
+										Value: &resolve.String{
+											Path: []string{"id"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, plan.Configuration{
+		DataSources: []plan.DataSourceConfiguration{
+			{
+				RootNodes: []plan.TypeField{
+					{
+						TypeName:   "Subscription",
+						FieldNames: []string{"orderUpdated"},
+					},
+				},
+				ChildNodes: []plan.TypeField{
+					{
+						TypeName:   "Order",
+						FieldNames: []string{"id", "status"},
+					},
+				},
+				Custom: ConfigJSON(Configuration{
+					Subscription: SubscriptionConfiguration{
+						BrokerAddresses: []string{"localhost:9092"},
+						Topic:           "orders",
+						StartOffset:     StartOffsetFixed,
+						Offset:          42,
+						PayloadFormat:   PayloadFormatAvro,
+						AvroSchema:      `{"type":"record","name":"Order","fields":[{"name":"id","type":"string"}]}`,
+					},
+				}),
+				Factory: factory,
+			},
+		},
+		Fields: []plan.FieldConfiguration{
+			{
+				TypeName:              "Subscription",
+				FieldName:             "orderUpdated",
+				DisableDefaultMapping: true,
+			},
+		},
+		DisableResolveFieldPositions: true,
+	}))
+}
+
+func TestSubscriptionSource_Start(t *testing.T) {
+	t.Run("unable to resolve without topic", func(t *testing.T) {
+		source := &SubscriptionSource{
+			client: NewKafkaConsumerClient(context.Background()),
+		}
+		err := source.Start(context.Background(), []byte(`{"broker_addresses":["localhost:9092"],"topic":""}`), make(chan []byte))
+		assert.ErrorIs(t, err, resolve.ErrUnableToResolve)
+	})
+	t.Run("invalid input", func(t *testing.T) {
+		source := &SubscriptionSource{
+			client: NewKafkaConsumerClient(context.Background()),
+		}
+		err := source.Start(context.Background(), []byte(`{`), make(chan []byte))
+		assert.Error(t, err)
+	})
+}
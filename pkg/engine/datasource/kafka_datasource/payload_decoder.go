@@ -0,0 +1,276 @@
+package kafka_datasource
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/linkedin/goavro/v2"
+)
+
+const confluentWireFormatHeaderLength = 5
+
+var errInvalidJSONPayload = errors.New("message payload is not valid JSON")
+
+type payloadDecoder interface {
+	// Decode returns the JSON representation of a message payload
+	Decode(payload []byte) ([]byte, error)
+}
+
+func newPayloadDecoder(config SubscriptionConfiguration) (payloadDecoder, error) {
+	switch config.PayloadFormat {
+	case PayloadFormatJSON, "":
+		return &jsonPayloadDecoder{
+			confluentWireFormat: config.ConfluentWireFormat,
+		}, nil
+	case PayloadFormatAvro:
+		return newAvroPayloadDecoder(config.AvroSchema, config.ConfluentWireFormat)
+	default:
+		return nil, fmt.Errorf("unknown payload format: %s", config.PayloadFormat)
+	}
+}
+
+func stripConfluentWireFormat(payload []byte) ([]byte, error) {
+	if len(payload) < confluentWireFormatHeaderLength || payload[0] != 0 {
+		return nil, errors.New("message payload is not in confluent wire format")
+	}
+	return payload[confluentWireFormatHeaderLength:], nil
+}
+
+type jsonPayloadDecoder struct {
+	confluentWireFormat bool
+}
+
+func (j *jsonPayloadDecoder) Decode(payload []byte) ([]byte, error) {
+	var err error
+	if j.confluentWireFormat {
+		payload, err = stripConfluentWireFormat(payload)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !json.Valid(payload) {
+		return nil, errInvalidJSONPayload
+	}
+	return payload, nil
+}
+
+// avroPayloadDecoder decodes binary Avro payloads into plain JSON
+// Unlike the Avro JSON encoding, unions are not wrapped into an object with the branch name as key
+// This way, the decoded payload has the same shape as the GraphQL field it gets resolved into
+type avroPayloadDecoder struct {
+	codec               *goavro.Codec
+	schema              interface{}
+	namedTypes          map[string]interface{}
+	confluentWireFormat bool
+}
+
+func newAvroPayloadDecoder(schema string, confluentWireFormat bool) (*avroPayloadDecoder, error) {
+	if schema == "" {
+		return nil, errors.New("avro payload format requires an avro schema")
+	}
+	codec, err := goavro.NewCodec(schema)
+	if err != nil {
+		return nil, err
+	}
+	decoder := &avroPayloadDecoder{
+		codec:               codec,
+		namedTypes:          map[string]interface{}{},
+		confluentWireFormat: confluentWireFormat,
+	}
+	if err = json.Unmarshal([]byte(schema), &decoder.schema); err != nil {
+		return nil, err
+	}
+	decoder.collectNamedTypes(decoder.schema, "")
+	return decoder, nil
+}
+
+func (a *avroPayloadDecoder) Decode(payload []byte) ([]byte, error) {
+	var err error
+	if a.confluentWireFormat {
+		payload, err = stripConfluentWireFormat(payload)
+		if err != nil {
+			return nil, err
+		}
+	}
+	native, _, err := a.codec.NativeFromBinary(payload)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(a.toJSON(a.schema, "", native))
+}
+
+func (a *avroPayloadDecoder) collectNamedTypes(schema interface{}, namespace string) {
+	switch s := schema.(type) {
+	case []interface{}:
+		for i := range s {
+			a.collectNamedTypes(s[i], namespace)
+		}
+	case map[string]interface{}:
+		switch s["type"] {
+		case "record", "error", "enum", "fixed":
+			fullName, ns := avroFullName(s, namespace)
+			a.namedTypes[fullName] = s
+			if fields, ok := s["fields"].([]interface{}); ok {
+				for i := range fields {
+					if field, ok := fields[i].(map[string]interface{}); ok {
+						a.collectNamedTypes(field["type"], ns)
+					}
+				}
+			}
+		case "array":
+			a.collectNamedTypes(s["items"], namespace)
+		case "map":
+			a.collectNamedTypes(s["values"], namespace)
+		default:
+			if _, isComplex := s["type"].(string); !isComplex {
+				a.collectNamedTypes(s["type"], namespace)
+			}
+		}
+	}
+}
+
+func (a *avroPayloadDecoder) lookupNamedType(name, namespace string) (interface{}, string, bool) {
+	if !strings.Contains(name, ".") && namespace != "" {
+		if schema, ok := a.namedTypes[namespace+"."+name]; ok {
+			return schema, namespace, true
+		}
+	}
+	schema, ok := a.namedTypes[name]
+	if !ok {
+		return nil, "", false
+	}
+	ns := ""
+	if i := strings.LastIndex(name, "."); i != -1 {
+		ns = name[:i]
+	}
+	return schema, ns, true
+}
+
+func (a *avroPayloadDecoder) toJSON(schema interface{}, namespace string, value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	switch s := schema.(type) {
+	case string:
+		switch s {
+		case "null", "boolean", "int", "long", "float", "double", "string":
+			return value
+		case "bytes":
+			if b, ok := value.([]byte); ok {
+				return string(b)
+			}
+			return value
+		}
+		named, ns, ok := a.lookupNamedType(s, namespace)
+		if !ok {
+			return value
+		}
+		return a.toJSON(named, ns, value)
+	case []interface{}:
+		branches, ok := value.(map[string]interface{})
+		if !ok {
+			return value
+		}
+		for branchName, branchValue := range branches {
+			for i := range s {
+				if a.branchName(s[i], namespace) == branchName {
+					return a.toJSON(s[i], namespace, branchValue)
+				}
+			}
+			return branchValue
+		}
+		return nil
+	case map[string]interface{}:
+		switch s["type"] {
+		case "record", "error":
+			_, ns := avroFullName(s, namespace)
+			record, ok := value.(map[string]interface{})
+			if !ok {
+				return value
+			}
+			fields, _ := s["fields"].([]interface{})
+			out := make(map[string]interface{}, len(fields))
+			for i := range fields {
+				field, ok := fields[i].(map[string]interface{})
+				if !ok {
+					continue
+				}
+				name, _ := field["name"].(string)
+				out[name] = a.toJSON(field["type"], ns, record[name])
+			}
+			return out
+		case "array":
+			items, ok := value.([]interface{})
+			if !ok {
+				return value
+			}
+			out := make([]interface{}, len(items))
+			for i := range items {
+				out[i] = a.toJSON(s["items"], namespace, items[i])
+			}
+			return out
+		case "map":
+			entries, ok := value.(map[string]interface{})
+			if !ok {
+				return value
+			}
+			out := make(map[string]interface{}, len(entries))
+			for key := range entries {
+				out[key] = a.toJSON(s["values"], namespace, entries[key])
+			}
+			return out
+		case "fixed":
+			if b, ok := value.([]byte); ok {
+				return string(b)
+			}
+			return value
+		case "enum":
+			return value
+		default:
+			return a.toJSON(s["type"], namespace, value)
+		}
+	}
+	return value
+}
+
+// branchName returns the name goavro uses to identify a union branch
+func (a *avroPayloadDecoder) branchName(schema interface{}, namespace string) string {
+	switch s := schema.(type) {
+	case string:
+		if _, ns, ok := a.lookupNamedType(s, namespace); ok && !strings.Contains(s, ".") && ns != "" {
+			return ns + "." + s
+		}
+		return s
+	case map[string]interface{}:
+		switch s["type"] {
+		case "record", "error", "enum", "fixed":
+			fullName, _ := avroFullName(s, namespace)
+			return fullName
+		case "array", "map":
+			return s["type"].(string)
+		default:
+			if logicalType, ok := s["logicalType"].(string); ok {
+				return a.branchName(s["type"], namespace) + "." + logicalType
+			}
+			return a.branchName(s["type"], namespace)
+		}
+	}
+	return ""
+}
+
+func avroFullName(schema map[string]interface{}, enclosingNamespace string) (fullName, namespace string) {
+	name, _ := schema["name"].(string)
+	if i := strings.LastIndex(name, "."); i != -1 {
+		return name, name[:i]
+	}
+	namespace = enclosingNamespace
+	if ns, ok := schema["namespace"].(string); ok {
+		namespace = ns
+	}
+	if namespace == "" {
+		return name, ""
+	}
+	return namespace + "." + name, namespace
+}
@@ -0,0 +1,92 @@
+package kafka_datasource
+
+import (
+	"testing"
+
+	"github.com/linkedin/goavro/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const orderAvroSchema = `{
+	"type": "record",
+	"name": "Order",
+	"namespace": "com.example",
+	"fields": [
+		{"name": "id", "type": "string"},
+		{"name": "quantity", "type": "int"},
+		{"name": "note", "type": ["null", "string"], "default": null},
+		{"name": "customer", "type": ["null", {
+			"type": "record",
+			"name": "Customer",
+			"fields": [
+				{"name": "name", "type": "string"}
+			]
+		}]},
+		{"name": "previous", "type": ["null", "Customer"], "default": null},
+		{"name": "tags", "type": {"type": "array", "items": "string"}},
+		{"name": "status", "type": {"type": "enum", "name": "Status", "symbols": ["CREATED", "SHIPPED"]}}
+	]
+}`
+
+func TestPayloadDecoder(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		decoder, err := newPayloadDecoder(SubscriptionConfiguration{})
+		require.NoError(t, err)
+
+		out, err := decoder.Decode([]byte(`{"id":"1"}`))
+		assert.NoError(t, err)
+		assert.Equal(t, `{"id":"1"}`, string(out))
+
+		_, err = decoder.Decode([]byte(`{"id":`))
+		assert.Equal(t, errInvalidJSONPayload, err)
+	})
+
+	t.Run("json with confluent wire format", func(t *testing.T) {
+		decoder, err := newPayloadDecoder(SubscriptionConfiguration{ConfluentWireFormat: true})
+		require.NoError(t, err)
+
+		out, err := decoder.Decode(append([]byte{0, 0, 0, 0, 7}, `{"id":"1"}`...))
+		assert.NoError(t, err)
+		assert.Equal(t, `{"id":"1"}`, string(out))
+
+		_, err = decoder.Decode([]byte(`{"id":"1"}`))
+		assert.Error(t, err)
+	})
+
+	t.Run("avro", func(t *testing.T) {
+		codec, err := goavro.NewCodec(orderAvroSchema)
+		require.NoError(t, err)
+		payload, err := codec.BinaryFromNative(nil, map[string]interface{}{
+			"id":       "1",
+			"quantity": 3,
+			"note":     goavro.Union("string", "fragile"),
+			"customer": goavro.Union("com.example.Customer", map[string]interface{}{"name": "Jens"}),
+			"previous": nil,
+			"tags":     []interface{}{"a", "b"},
+			"status":   "SHIPPED",
+		})
+		require.NoError(t, err)
+
+		decoder, err := newPayloadDecoder(SubscriptionConfiguration{
+			PayloadFormat:       PayloadFormatAvro,
+			AvroSchema:          orderAvroSchema,
+			ConfluentWireFormat: true,
+		})
+		require.NoError(t, err)
+
+		out, err := decoder.Decode(append([]byte{0, 0, 0, 0, 1}, payload...))
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"id":"1","quantity":3,"note":"fragile","customer":{"name":"Jens"},"previous":null,"tags":["a","b"],"status":"SHIPPED"}`, string(out))
+	})
+
+	t.Run("avro without schema", func(t *testing.T) {
+		_, err := newPayloadDecoder(SubscriptionConfiguration{PayloadFormat: PayloadFormatAvro})
+		assert.EqualError(t, err, "avro payload format requires an avro schema")
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		_, err := newPayloadDecoder(SubscriptionConfiguration{PayloadFormat: "xml"})
+		assert.EqualError(t, err, "unknown payload format: xml")
+	})
+}
@@ -0,0 +1,85 @@
+package local_datasource
+
+import (
+	"fmt"
+
+	"github.com/buger/jsonparser"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/resolve"
+	"github.com/wundergraph/graphql-go-tools/pkg/fastbuffer"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/literal"
+)
+
+// BatchFactory merges the inputs of a field for several parents into a single input with a list of parents
+// All inputs of a batch are rendered from the same fetch, so they only differ in their parent.
+type BatchFactory struct{}
+
+func (b *BatchFactory) CreateBatch(inputs [][]byte) (resolve.DataSourceBatch, error) {
+	if len(inputs) == 0 {
+		return nil, nil
+	}
+
+	parents := make([]byte, 0, 64*len(inputs))
+	parents = append(parents, literal.LBRACK...)
+	for i := range inputs {
+		parent, _, _, err := jsonparser.Get(inputs[i], "parent")
+		if err != nil {
+			return nil, err
+		}
+		if i != 0 {
+			parents = append(parents, literal.COMMA...)
+		}
+		parents = append(parents, parent...)
+	}
+	parents = append(parents, literal.RBRACK...)
+
+	// jsonparser.Delete modifies its input, so the first input gets copied
+	input := jsonparser.Delete(append([]byte(nil), inputs[0]...), "parent")
+	input, err := jsonparser.Set(input, parents, "parents")
+	if err != nil {
+		return nil, err
+	}
+
+	batchInput := fastbuffer.New()
+	batchInput.WriteBytes(input)
+
+	return &Batch{
+		input:     batchInput,
+		batchSize: len(inputs),
+	}, nil
+}
+
+// Batch splits the list of results of a batch into the results of each parent
+type Batch struct {
+	input     *fastbuffer.FastBuffer
+	batchSize int
+}
+
+func (b *Batch) Input() *fastbuffer.FastBuffer {
+	return b.input
+}
+
+func (b *Batch) Demultiplex(responseBufPair *resolve.BufPair, bufPairs []*resolve.BufPair) (err error) {
+	if b.batchSize != len(bufPairs) {
+		return fmt.Errorf("expected %d buf pairs", b.batchSize)
+	}
+
+	if responseBufPair.HasData() {
+		index := 0
+		_, err = jsonparser.ArrayEach(responseBufPair.Data.Bytes(), func(value []byte, _ jsonparser.ValueType, _ int, _ error) {
+			if index < len(bufPairs) {
+				bufPairs[index].Data.WriteBytes(value)
+			}
+			index++
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if responseBufPair.HasErrors() {
+		bufPairs[0].Errors.WriteBytes(responseBufPair.Errors.Bytes())
+	}
+
+	return nil
+}
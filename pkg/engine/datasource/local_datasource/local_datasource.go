@@ -0,0 +1,327 @@
+// Package local_datasource implements fields with Go functions inside the engine.
+//
+// It's meant for small computed fields, e.g. formatting or feature flags, which don't justify a separate service.
+// Resolvers are registered by type and field name and receive the arguments of the field and the JSON of the parent objects.
+// Fields which are nested in lists are batched, so that a resolver gets called once with all parents if the data loader is enabled.
+// Fields of the parent object which a resolver depends on have to be configured as RequiresFields of the plan.FieldConfiguration,
+// otherwise they're only part of the parent object if they are selected in the operation.
+package local_datasource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/buger/jsonparser"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/plan"
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/resolve"
+	"github.com/wundergraph/graphql-go-tools/pkg/graphqljsonschema"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/literal"
+)
+
+// ResolveFunc resolves a field for a batch of parent objects
+// arguments is a JSON object of the arguments of the field, parents contains the JSON of each parent object.
+// Root fields have a single parent which is null.
+// The returned slice must contain the JSON value of the field for each parent in the same order.
+// A returned error is added to the errors of the response and the field is null for all parents.
+type ResolveFunc func(ctx context.Context, arguments json.RawMessage, parents []json.RawMessage) ([]json.RawMessage, error)
+
+// ForEachParent creates a ResolveFunc which resolves the field for one parent at a time
+func ForEachParent(resolveParent func(ctx context.Context, arguments, parent json.RawMessage) (json.RawMessage, error)) ResolveFunc {
+	return func(ctx context.Context, arguments json.RawMessage, parents []json.RawMessage) ([]json.RawMessage, error) {
+		results := make([]json.RawMessage, len(parents))
+		for i := range parents {
+			result, err := resolveParent(ctx, arguments, parents[i])
+			if err != nil {
+				return nil, err
+			}
+			results[i] = result
+		}
+		return results, nil
+	}
+}
+
+// Resolvers holds the ResolveFunc of each field by type and field name
+type Resolvers struct {
+	resolvers map[string]ResolveFunc
+}
+
+func NewResolvers() *Resolvers {
+	return &Resolvers{
+		resolvers: map[string]ResolveFunc{},
+	}
+}
+
+// Register adds the resolver of a field, an existing resolver of the same field gets replaced
+func (r *Resolvers) Register(typeName, fieldName string, resolveFunc ResolveFunc) {
+	r.resolvers[typeName+"."+fieldName] = resolveFunc
+}
+
+func (r *Resolvers) resolver(typeName, fieldName string) (ResolveFunc, bool) {
+	resolveFunc, ok := r.resolvers[typeName+"."+fieldName]
+	return resolveFunc, ok
+}
+
+type Factory struct {
+	Resolvers *Resolvers
+}
+
+func (f *Factory) Planner(ctx context.Context) plan.DataSourcePlanner {
+	return &Planner{
+		resolvers: f.Resolvers,
+	}
+}
+
+type Planner struct {
+	resolvers           *Resolvers
+	v                   *plan.Visitor
+	isNested            bool
+	operationDefinition int
+	typeName            string
+	fieldName           string
+	arguments           string
+	variables           resolve.Variables
+	depth               int
+}
+
+func (p *Planner) DownstreamResponseFieldAlias(_ int) (alias string, exists bool) {
+	// the local DataSourcePlanner doesn't rewrite fields: skip
+	return
+}
+
+func (p *Planner) DataSourcePlanningBehavior() plan.DataSourcePlanningBehavior {
+	return plan.DataSourcePlanningBehavior{
+		MergeAliasedRootNodes:      false,
+		OverrideFieldPathFromAlias: false,
+	}
+}
+
+func (p *Planner) Register(visitor *plan.Visitor, _ plan.DataSourceConfiguration, isNested bool) error {
+	p.v = visitor
+	p.isNested = isNested
+	visitor.Walker.RegisterEnterFieldVisitor(p)
+	visitor.Walker.RegisterLeaveFieldVisitor(p)
+	visitor.Walker.RegisterEnterOperationVisitor(p)
+	return nil
+}
+
+func (p *Planner) EnterOperationDefinition(ref int) {
+	p.operationDefinition = ref
+}
+
+func (p *Planner) EnterField(ref int) {
+	p.depth++
+	if p.depth != 1 {
+		// only the root field of the planner is resolved by a resolver, nested fields are part of its result
+		return
+	}
+	p.typeName = p.v.Walker.EnclosingTypeDefinition.NameString(p.v.Definition)
+	p.fieldName = p.v.Operation.FieldNameString(ref)
+	if _, ok := p.resolvers.resolver(p.typeName, p.fieldName); !ok {
+		p.v.Walker.StopWithInternalErr(fmt.Errorf("local_datasource: no resolver registered for field %s.%s", p.typeName, p.fieldName))
+		return
+	}
+	p.arguments = p.renderArguments(ref)
+}
+
+func (p *Planner) LeaveField(_ int) {
+	p.depth--
+}
+
+// renderArguments renders the arguments of a field as JSON object, arguments with variables are rendered as context variables
+func (p *Planner) renderArguments(ref int) string {
+	arguments := "{"
+	for i, argumentRef := range p.v.Operation.FieldArguments(ref) {
+		if i != 0 {
+			arguments += ","
+		}
+		argumentName := p.v.Operation.ArgumentNameBytes(argumentRef)
+		argumentDefinition := p.v.Definition.NodeFieldDefinitionArgumentDefinitionByName(p.v.Walker.EnclosingTypeDefinition, p.v.Operation.FieldNameBytes(ref), argumentName)
+		arguments += fmt.Sprintf(`"%s":%s`, argumentName, p.renderArgumentValue(p.v.Operation.ArgumentValue(argumentRef), argumentDefinition))
+	}
+	return arguments + "}"
+}
+
+func (p *Planner) renderArgumentValue(value ast.Value, argumentDefinition int) string {
+	if value.Kind != ast.ValueKindVariable {
+		valueJSON, err := p.v.Operation.ValueToJSON(value)
+		if err != nil {
+			p.v.Walker.StopWithInternalErr(err)
+			return "null"
+		}
+		valueJSON, err = p.parseCustomScalarValue(valueJSON, argumentDefinition)
+		if err != nil {
+			p.v.Walker.StopWithInternalErr(err)
+			return "null"
+		}
+		return string(valueJSON)
+	}
+
+	variableName := p.v.Operation.VariableValueNameBytes(value.Ref)
+	variableDefinition, ok := p.v.Operation.VariableDefinitionByNameAndOperation(p.operationDefinition, variableName)
+	if !ok {
+		return "null"
+	}
+	renderer, err := resolve.NewJSONVariableRendererWithValidationFromTypeRef(p.v.Operation, p.v.Definition, p.v.Operation.VariableDefinitions[variableDefinition].Type, graphqljsonschema.WithScalarRegistry(p.v.Config.Scalars))
+	if err != nil {
+		p.v.Walker.StopWithInternalErr(err)
+		return "null"
+	}
+	placeholder, _ := p.variables.AddVariable(&resolve.ContextVariable{
+		Path:     []string{string(variableName)},
+		Renderer: renderer,
+	})
+	return placeholder
+}
+
+// parseCustomScalarValue parses the literal value of an argument of a custom scalar type with the scalar of the registry
+// Variables are parsed when they're validated, so that resolvers receive the same value for literals and variables.
+func (p *Planner) parseCustomScalarValue(value []byte, argumentDefinition int) ([]byte, error) {
+	if argumentDefinition == -1 {
+		return value, nil
+	}
+	typeRef := p.v.Definition.InputValueDefinitions[argumentDefinition].Type
+	if p.v.Definition.TypeIsList(typeRef) {
+		return value, nil
+	}
+	scalar, ok := p.v.Config.Scalars.Scalar(p.v.Definition.ResolveTypeNameString(typeRef))
+	if !ok {
+		return value, nil
+	}
+	literalValue, dataType, _, err := jsonparser.Get(value)
+	if err != nil || dataType == jsonparser.Null {
+		return value, nil
+	}
+	parsed, err := scalar.ParseValue(literalValue, dataType)
+	if err != nil {
+		return nil, err
+	}
+	if len(parsed) == 0 {
+		return value, nil
+	}
+	return parsed, nil
+}
+
+func (p *Planner) ConfigureFetch() plan.FetchConfiguration {
+	parent := string(literal.NULL)
+	if p.isNested {
+		parent, _ = p.variables.AddVariable(&resolve.ObjectVariable{
+			Renderer: resolve.NewJSONVariableRenderer(),
+		})
+	}
+
+	return plan.FetchConfiguration{
+		Input:      fmt.Sprintf(`{"type":"%s","field":"%s","arguments":%s,"parent":%s}`, p.typeName, p.fieldName, p.arguments, parent),
+		Variables:  p.variables,
+		DataSource: &Source{resolvers: p.resolvers},
+		// resolvers may have side effects, e.g. feature flags which depend on the request context
+		DisallowSingleFlight: true,
+		ProcessResponseConfig: resolve.ProcessResponseConfig{
+			ExtractGraphqlResponse: true,
+		},
+		BatchConfig: plan.BatchConfig{
+			AllowBatch:   p.isNested,
+			BatchFactory: &BatchFactory{},
+		},
+	}
+}
+
+func (p *Planner) ConfigureSubscription() plan.SubscriptionConfiguration {
+	return plan.SubscriptionConfiguration{}
+}
+
+// Source calls the resolver of the field of the input
+// The input contains either a single parent or a batch of parents, see BatchFactory.
+// The response is a GraphQL response with an object for the single parent or a list of objects for the batch.
+type Source struct {
+	resolvers *Resolvers
+}
+
+func (s *Source) Load(ctx context.Context, input []byte, w io.Writer) (err error) {
+	typeName, err := jsonparser.GetString(input, "type")
+	if err != nil {
+		return err
+	}
+	fieldName, err := jsonparser.GetString(input, "field")
+	if err != nil {
+		return err
+	}
+	arguments, _, _, err := jsonparser.Get(input, "arguments")
+	if err != nil {
+		return err
+	}
+	parents, isBatch, err := inputParents(input)
+	if err != nil {
+		return err
+	}
+
+	values, resolveErr := s.resolve(ctx, typeName, fieldName, arguments, parents)
+
+	results := make([]map[string]json.RawMessage, len(parents))
+	for i := range results {
+		value := json.RawMessage(literal.NULL)
+		if resolveErr == nil && values[i] != nil {
+			value = values[i]
+		}
+		results[i] = map[string]json.RawMessage{fieldName: value}
+	}
+
+	var data interface{} = results
+	if !isBatch {
+		data = results[0]
+	}
+	response := graphqlResponse{
+		Data: data,
+	}
+	if resolveErr != nil {
+		response.Errors = []graphqlError{{Message: resolveErr.Error()}}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(response)
+}
+
+func (s *Source) resolve(ctx context.Context, typeName, fieldName string, arguments json.RawMessage, parents []json.RawMessage) ([]json.RawMessage, error) {
+	resolveFunc, ok := s.resolvers.resolver(typeName, fieldName)
+	if !ok {
+		return nil, fmt.Errorf("no resolver registered for field %s.%s", typeName, fieldName)
+	}
+	values, err := resolveFunc(ctx, arguments, parents)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) != len(parents) {
+		return nil, fmt.Errorf("resolver of field %s.%s returned %d values for %d parents", typeName, fieldName, len(values), len(parents))
+	}
+	return values, nil
+}
+
+func inputParents(input []byte) (parents []json.RawMessage, isBatch bool, err error) {
+	batch, dataType, _, err := jsonparser.Get(input, "parents")
+	if err == nil && dataType == jsonparser.Array {
+		_, err = jsonparser.ArrayEach(batch, func(parent []byte, _ jsonparser.ValueType, _ int, _ error) {
+			parents = append(parents, parent)
+		})
+		return parents, true, err
+	}
+
+	// parents are objects or null, so they don't need to be quoted
+	parent, _, _, err := jsonparser.Get(input, "parent")
+	if err != nil {
+		return nil, false, err
+	}
+	return []json.RawMessage{parent}, false, nil
+}
+
+type graphqlResponse struct {
+	Data   interface{}    `json:"data"`
+	Errors []graphqlError `json:"errors,omitempty"`
+}
+
+type graphqlError struct {
+	Message string `json:"message"`
+}
@@ -0,0 +1,348 @@
+package local_datasource
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/buger/jsonparser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/datasource/staticdatasource"
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/datasourcetesting"
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/plan"
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/resolve"
+	"github.com/wundergraph/graphql-go-tools/pkg/fastbuffer"
+	"github.com/wundergraph/graphql-go-tools/pkg/scalars"
+)
+
+const definition = `
+schema {
+	query: Query
+}
+
+type Query {
+	greeting(name: String!): String!
+	user: User
+	node(id: UUID!): String
+}
+
+scalar UUID
+
+type User {
+	firstName: String!
+	lastName: String!
+	fullName: String!
+}
+`
+
+func testResolvers() *Resolvers {
+	resolvers := NewResolvers()
+	resolvers.Register("Query", "greeting", ForEachParent(func(ctx context.Context, arguments, parent json.RawMessage) (json.RawMessage, error) {
+		name, err := jsonparser.GetString(arguments, "name")
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal("Hello " + name)
+	}))
+	resolvers.Register("Query", "node", ForEachParent(func(ctx context.Context, arguments, parent json.RawMessage) (json.RawMessage, error) {
+		id, err := jsonparser.GetString(arguments, "id")
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(id)
+	}))
+	resolvers.Register("User", "fullName", func(ctx context.Context, arguments json.RawMessage, parents []json.RawMessage) ([]json.RawMessage, error) {
+		results := make([]json.RawMessage, len(parents))
+		for i := range parents {
+			firstName, _ := jsonparser.GetString(parents[i], "firstName")
+			lastName, _ := jsonparser.GetString(parents[i], "lastName")
+			results[i], _ = json.Marshal(firstName + " " + lastName)
+		}
+		return results, nil
+	})
+	return resolvers
+}
+
+func TestLocalDataSourcePlanning(t *testing.T) {
+	resolvers := testResolvers()
+
+	t.Run("root field with argument", datasourcetesting.RunTest(definition, `query Greeting { greeting(name: "Jens") }`, "Greeting",
+		&plan.SynchronousResponsePlan{
+			Response: &resolve.GraphQLResponse{
+				Data: &resolve.Object{
+					Fetch: &resolve.SingleFetch{
+						BufferId: 0,
+						Input:    `{"type":"Query","field":"greeting","arguments":{"name":$$0$$},"parent":null}`,
+						Variables: resolve.NewVariables(
+							&resolve.ContextVariable{
+								Path:     []string{"a"},
+								Renderer: resolve.NewJSONVariableRendererWithValidation(`{"type":["string"]}`),
+							},
+						),
+						DataSource:           &Source{resolvers: resolvers},
+						DataSourceIdentifier: []byte("local_datasource.Source"),
+						DisallowSingleFlight: true,
+						ProcessResponseConfig: resolve.ProcessResponseConfig{
+							ExtractGraphqlResponse: true,
+						},
+					},
+					Fields: []*resolve.Field{
+						{
+							BufferID:  0,
+							HasBuffer: true,
+							Name:      []byte("greeting"),
+							Value: &resolve.String{
+								Path: []string{"greeting"},
+							},
+						},
+					},
+				},
+			},
+		},
+		plan.Configuration{
+			DataSources: []plan.DataSourceConfiguration{
+				{
+					RootNodes: []plan.TypeField{
+						{TypeName: "Query", FieldNames: []string{"greeting"}},
+					},
+					Factory: &Factory{Resolvers: resolvers},
+				},
+			},
+			Fields: []plan.FieldConfiguration{
+				{
+					TypeName:  "Query",
+					FieldName: "greeting",
+					Arguments: []plan.ArgumentConfiguration{
+						{Name: "name", SourceType: plan.FieldArgumentSource},
+					},
+				},
+			},
+			DisableResolveFieldPositions: true,
+		},
+	))
+
+	t.Run("custom scalar argument", datasourcetesting.RunTest(definition, `query Node { node(id: "7E57D004-2B97-0E7A-B45F-5387367791CD") }`, "Node",
+		&plan.SynchronousResponsePlan{
+			Response: &resolve.GraphQLResponse{
+				Data: &resolve.Object{
+					Fetch: &resolve.SingleFetch{
+						BufferId: 0,
+						Input:    `{"type":"Query","field":"node","arguments":{"id":$$0$$},"parent":null}`,
+						Variables: resolve.NewVariables(
+							&resolve.ContextVariable{
+								Path:     []string{"a"},
+								Renderer: resolve.NewJSONVariableRendererWithValidation(`{"type":"string","pattern":"^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$"}`),
+							},
+						),
+						DataSource:           &Source{resolvers: resolvers},
+						DataSourceIdentifier: []byte("local_datasource.Source"),
+						DisallowSingleFlight: true,
+						ProcessResponseConfig: resolve.ProcessResponseConfig{
+							ExtractGraphqlResponse: true,
+						},
+					},
+					Fields: []*resolve.Field{
+						{
+							BufferID:  0,
+							HasBuffer: true,
+							Name:      []byte("node"),
+							Value: &resolve.String{
+								Path:     []string{"node"},
+								Nullable: true,
+							},
+						},
+					},
+				},
+			},
+		},
+		plan.Configuration{
+			DataSources: []plan.DataSourceConfiguration{
+				{
+					RootNodes: []plan.TypeField{
+						{TypeName: "Query", FieldNames: []string{"node"}},
+					},
+					Factory: &Factory{Resolvers: resolvers},
+				},
+			},
+			Fields: []plan.FieldConfiguration{
+				{
+					TypeName:  "Query",
+					FieldName: "node",
+					Arguments: []plan.ArgumentConfiguration{
+						{Name: "id", SourceType: plan.FieldArgumentSource},
+					},
+				},
+			},
+			Scalars:                      scalars.NewRegistry(scalars.UUID()),
+			DisableResolveFieldPositions: true,
+		},
+	))
+
+	t.Run("nested field is batched", datasourcetesting.RunTest(definition, `query User { user { firstName lastName fullName } }`, "User",
+		&plan.SynchronousResponsePlan{
+			Response: &resolve.GraphQLResponse{
+				Data: &resolve.Object{
+					Fetch: &resolve.SingleFetch{
+						BufferId:             0,
+						Input:                `{"firstName":"Jens","lastName":"Neuse"}`,
+						DataSource:           staticdatasource.Source{},
+						DataSourceIdentifier: []byte("staticdatasource.Source"),
+						DisableDataLoader:    true,
+						DisallowSingleFlight: true,
+					},
+					Fields: []*resolve.Field{
+						{
+							BufferID:  0,
+							HasBuffer: true,
+							Name:      []byte("user"),
+							Value: &resolve.Object{
+								Nullable: true,
+								Fetch: &resolve.BatchFetch{
+									Fetch: &resolve.SingleFetch{
+										BufferId: 1,
+										Input:    `{"type":"User","field":"fullName","arguments":{},"parent":$$0$$}`,
+										Variables: resolve.NewVariables(
+											&resolve.ObjectVariable{
+												Renderer: resolve.NewJSONVariableRenderer(),
+											},
+										),
+										DataSource:           &Source{resolvers: resolvers},
+										DataSourceIdentifier: []byte("local_datasource.Source"),
+										DisallowSingleFlight: true,
+										ProcessResponseConfig: resolve.ProcessResponseConfig{
+											ExtractGraphqlResponse: true,
+										},
+									},
+									BatchFactory: &BatchFactory{},
+								},
+								Fields: []*resolve.Field{
+									{
+										Name: []byte("firstName"),
+										Value: &resolve.String{
+											Path: []string{"firstName"},
+										},
+									},
+									{
+										Name: []byte("lastName"),
+										Value: &resolve.String{
+											Path: []string{"lastName"},
+										},
+									},
+									{
+										BufferID:  1,
+										HasBuffer: true,
+										Name:      []byte("fullName"),
+										Value: &resolve.String{
+											Path: []string{"fullName"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		plan.Configuration{
+			DataSources: []plan.DataSourceConfiguration{
+				{
+					RootNodes: []plan.TypeField{
+						{TypeName: "Query", FieldNames: []string{"user"}},
+					},
+					ChildNodes: []plan.TypeField{
+						{TypeName: "User", FieldNames: []string{"firstName", "lastName"}},
+					},
+					Factory: &staticdatasource.Factory{},
+					Custom: staticdatasource.ConfigJSON(staticdatasource.Configuration{
+						Data: `{"firstName":"Jens","lastName":"Neuse"}`,
+					}),
+				},
+				{
+					RootNodes: []plan.TypeField{
+						{TypeName: "User", FieldNames: []string{"fullName"}},
+					},
+					Factory: &Factory{Resolvers: resolvers},
+				},
+			},
+			Fields: []plan.FieldConfiguration{
+				{
+					TypeName:              "Query",
+					FieldName:             "user",
+					DisableDefaultMapping: true,
+				},
+			},
+			DisableResolveFieldPositions: true,
+		},
+	))
+}
+
+func TestSource_Load(t *testing.T) {
+	source := &Source{resolvers: testResolvers()}
+
+	load := func(t *testing.T, input string) string {
+		out := &bytes.Buffer{}
+		require.NoError(t, source.Load(context.Background(), []byte(input), out))
+		return strings.TrimSpace(out.String())
+	}
+
+	t.Run("single parent", func(t *testing.T) {
+		assert.Equal(t, `{"data":{"greeting":"Hello Jens"}}`,
+			load(t, `{"type":"Query","field":"greeting","arguments":{"name":"Jens"},"parent":null}`))
+	})
+
+	t.Run("custom scalar argument", func(t *testing.T) {
+		assert.Equal(t, `{"data":{"node":"7E57D004-2B97-0E7A-B45F-5387367791CD"}}`,
+			load(t, `{"type":"Query","field":"node","arguments":{"id":"7E57D004-2B97-0E7A-B45F-5387367791CD"},"parent":null}`))
+	})
+
+	t.Run("batch of parents", func(t *testing.T) {
+		assert.Equal(t, `{"data":[{"fullName":"Jens Neuse"},{"fullName":"Sergiy Petrunin"}]}`,
+			load(t, `{"type":"User","field":"fullName","arguments":{},"parents":[{"firstName":"Jens","lastName":"Neuse"},{"firstName":"Sergiy","lastName":"Petrunin"}]}`))
+	})
+
+	t.Run("resolver error", func(t *testing.T) {
+		resolvers := NewResolvers()
+		resolvers.Register("Query", "greeting", func(ctx context.Context, arguments json.RawMessage, parents []json.RawMessage) ([]json.RawMessage, error) {
+			return nil, errors.New("greeting is not available")
+		})
+		out := &bytes.Buffer{}
+		err := (&Source{resolvers: resolvers}).Load(context.Background(), []byte(`{"type":"Query","field":"greeting","arguments":{},"parent":null}`), out)
+		require.NoError(t, err)
+		assert.Equal(t, `{"data":{"greeting":null},"errors":[{"message":"greeting is not available"}]}`, strings.TrimSpace(out.String()))
+	})
+
+	t.Run("wrong number of results", func(t *testing.T) {
+		resolvers := NewResolvers()
+		resolvers.Register("User", "fullName", func(ctx context.Context, arguments json.RawMessage, parents []json.RawMessage) ([]json.RawMessage, error) {
+			return []json.RawMessage{[]byte(`"Jens Neuse"`)}, nil
+		})
+		out := &bytes.Buffer{}
+		err := (&Source{resolvers: resolvers}).Load(context.Background(), []byte(`{"type":"User","field":"fullName","arguments":{},"parents":[{},{}]}`), out)
+		require.NoError(t, err)
+		assert.Equal(t, `{"data":[{"fullName":null},{"fullName":null}],"errors":[{"message":"resolver of field User.fullName returned 1 values for 2 parents"}]}`, strings.TrimSpace(out.String()))
+	})
+}
+
+func TestBatch(t *testing.T) {
+	batch, err := (&BatchFactory{}).CreateBatch([][]byte{
+		[]byte(`{"type":"User","field":"fullName","arguments":{},"parent":{"firstName":"Jens"}}`),
+		[]byte(`{"type":"User","field":"fullName","arguments":{},"parent":{"firstName":"Sergiy"}}`),
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"User","field":"fullName","arguments":{},"parents":[{"firstName":"Jens"},{"firstName":"Sergiy"}]}`, batch.Input().String())
+
+	response := &resolve.BufPair{Data: fastbuffer.New(), Errors: fastbuffer.New()}
+	response.Data.WriteBytes([]byte(`[{"fullName":"Jens"},{"fullName":"Sergiy"}]`))
+	bufPairs := []*resolve.BufPair{
+		{Data: fastbuffer.New(), Errors: fastbuffer.New()},
+		{Data: fastbuffer.New(), Errors: fastbuffer.New()},
+	}
+	require.NoError(t, batch.Demultiplex(response, bufPairs))
+	assert.Equal(t, `{"fullName":"Jens"}`, bufPairs[0].Data.String())
+	assert.Equal(t, `{"fullName":"Sergiy"}`, bufPairs[1].Data.String())
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/wundergraph/graphql-go-tools/pkg/ast"
 	"github.com/wundergraph/graphql-go-tools/pkg/engine/datasource/httpclient"
 	"github.com/wundergraph/graphql-go-tools/pkg/engine/plan"
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/resolve"
 	"github.com/wundergraph/graphql-go-tools/pkg/lexer/literal"
 )
 
@@ -70,6 +71,12 @@ type FetchConfiguration struct {
 	Header http.Header
 	Query  []QueryConfiguration
 	Body   string
+	// Transformation adapts the response to the shape of the schema, e.g. to unwrap or rename fields
+	Transformation *resolve.Transformation `json:",omitempty"`
+	// RequestCompression compresses the request body, httpclient.CompressionGzip or httpclient.CompressionBrotli
+	RequestCompression string `json:",omitempty"`
+	// DecompressResponse requests compressed responses from the upstream, independent of the client request headers
+	DecompressResponse bool `json:",omitempty"`
 }
 
 type QueryConfiguration struct {
@@ -93,6 +100,8 @@ func (p *Planner) configureInput() []byte {
 	input := httpclient.SetInputURL(nil, []byte(p.config.Fetch.URL))
 	input = httpclient.SetInputMethod(input, []byte(p.config.Fetch.Method))
 	input = httpclient.SetInputBody(input, []byte(p.config.Fetch.Body))
+	input = httpclient.SetInputRequestCompression(input, p.config.Fetch.RequestCompression)
+	input = httpclient.SetInputDecompressResponse(input, p.config.Fetch.DecompressResponse)
 
 	header, err := json.Marshal(p.config.Fetch.Header)
 	if err == nil && len(header) != 0 && !bytes.Equal(header, literal.NULL) {
@@ -116,6 +125,7 @@ func (p *Planner) ConfigureFetch() plan.FetchConfiguration {
 		},
 		DisallowSingleFlight: p.config.Fetch.Method != "GET",
 		DisableDataLoader:    true,
+		Transformation:       p.config.Fetch.Transformation,
 	}
 }
 
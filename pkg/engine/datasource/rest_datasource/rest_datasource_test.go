@@ -848,6 +848,96 @@ func TestFastHttpJsonDataSourcePlanning(t *testing.T) {
 			DisableResolveFieldPositions: true,
 		},
 	))
+	t.Run("get request with response transformation", datasourcetesting.RunTest(schema, simpleOperation, "",
+		&plan.SynchronousResponsePlan{
+			Response: &resolve.GraphQLResponse{
+				Data: &resolve.Object{
+					Fetch: &resolve.SingleFetch{
+						BufferId:             0,
+						Input:                `{"method":"GET","url":"https://example.com/friend"}`,
+						DataSource:           &Source{},
+						DisallowSingleFlight: false,
+						DataSourceIdentifier: []byte("rest_datasource.Source"),
+						DisableDataLoader:    true,
+						Transformation: &resolve.Transformation{
+							Steps: []resolve.TransformationStep{
+								{
+									Kind: resolve.TransformationStepKindExtract,
+									Path: "$.results[0]",
+								},
+								{
+									Kind: resolve.TransformationStepKindMapping,
+									Mappings: []resolve.TransformationMapping{
+										{From: "$.full_name", To: "name"},
+									},
+								},
+							},
+						},
+					},
+					Fields: []*resolve.Field{
+						{
+							BufferID:  0,
+							HasBuffer: true,
+							Name:      []byte("friend"),
+							Value: &resolve.Object{
+								Nullable: true,
+								Fields: []*resolve.Field{
+									{
+										Name: []byte("name"),
+										Value: &resolve.String{
+											Path:     []string{"name"},
+											Nullable: true,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		plan.Configuration{
+			DataSources: []plan.DataSourceConfiguration{
+				{
+					RootNodes: []plan.TypeField{
+						{
+							TypeName:   "Query",
+							FieldNames: []string{"friend"},
+						},
+					},
+					Custom: ConfigJSON(Configuration{
+						Fetch: FetchConfiguration{
+							URL:    "https://example.com/friend",
+							Method: "GET",
+							Transformation: &resolve.Transformation{
+								Steps: []resolve.TransformationStep{
+									{
+										Kind: resolve.TransformationStepKindExtract,
+										Path: "$.results[0]",
+									},
+									{
+										Kind: resolve.TransformationStepKindMapping,
+										Mappings: []resolve.TransformationMapping{
+											{From: "$.full_name", To: "name"},
+										},
+									},
+								},
+							},
+						},
+					}),
+					Factory: &Factory{},
+				},
+			},
+			Fields: []plan.FieldConfiguration{
+				{
+					TypeName:              "Query",
+					FieldName:             "friend",
+					DisableDefaultMapping: true,
+				},
+			},
+			DisableResolveFieldPositions: true,
+		},
+	))
 	t.Run("get request with headers", datasourcetesting.RunTest(schema, simpleOperation, "",
 		&plan.SynchronousResponsePlan{
 			Response: &resolve.GraphQLResponse{
@@ -9,12 +9,16 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/buger/jsonparser"
+
 	"github.com/wundergraph/graphql-go-tools/pkg/ast"
 	"github.com/wundergraph/graphql-go-tools/pkg/astimport"
 	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
 	"github.com/wundergraph/graphql-go-tools/pkg/engine/resolve"
+	"github.com/wundergraph/graphql-go-tools/pkg/graphqljsonschema"
 	"github.com/wundergraph/graphql-go-tools/pkg/lexer/literal"
 	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+	"github.com/wundergraph/graphql-go-tools/pkg/scalars"
 )
 
 type Planner struct {
@@ -32,6 +36,8 @@ type Configuration struct {
 	DataSources                []DataSourceConfiguration
 	Fields                     FieldConfigurations
 	Types                      TypeConfigurations
+	// Scalars are the custom scalars which are used to validate variables and to serialize resolved values
+	Scalars *scalars.Registry
 	// DisableResolveFieldPositions should be set to true for testing purposes
 	// This setting removes position information from all fields
 	// In production, this should be set to false so that error messages are easier to understand
@@ -165,6 +171,8 @@ type DataSourceConfiguration struct {
 	Directives DirectiveConfigurations
 	Factory    PlannerFactory
 	Custom     json.RawMessage
+	// HeaderRules propagate headers between the client and the upstream of the DataSource, they're optional
+	HeaderRules *resolve.HeaderRules
 }
 
 func (d *DataSourceConfiguration) HasRootNode(typeName, fieldName string) bool {
@@ -378,6 +386,7 @@ type Visitor struct {
 	fieldConfigs                 map[int]*FieldConfiguration
 	exportedVariables            map[string]struct{}
 	skipIncludeFields            map[int]skipIncludeField
+	deferredFragments            map[int]*resolve.DeferField
 	disableResolveFieldPositions bool
 }
 
@@ -401,6 +410,7 @@ type objectFetchConfiguration struct {
 	isSubscription     bool
 	fieldRef           int
 	fieldDefinitionRef int
+	headerRules        *resolve.HeaderRules
 }
 
 func (v *Visitor) AllowVisitor(kind astvisitor.VisitorKind, ref int, visitor interface{}) bool {
@@ -454,19 +464,93 @@ func (v *Visitor) EnterDirective(ref int) {
 	case ast.NodeKindField:
 		switch directiveName {
 		case "stream":
+			disabled, ifVariableName := v.directiveCondition(ref)
+			if disabled {
+				return
+			}
 			initialBatchSize := 0
-			if value, ok := v.Operation.DirectiveArgumentValueByName(ref, literal.INITIAL_BATCH_SIZE); ok {
-				if value.Kind == ast.ValueKindInteger {
-					initialBatchSize = int(v.Operation.IntValueAsInt32(value.Ref))
-				}
+			value, ok := v.Operation.DirectiveArgumentValueByName(ref, literal.INITIAL_COUNT)
+			if !ok {
+				value, ok = v.Operation.DirectiveArgumentValueByName(ref, literal.INITIAL_BATCH_SIZE)
+			}
+			if ok && value.Kind == ast.ValueKindInteger {
+				initialBatchSize = int(v.Operation.IntValueAsInt32(value.Ref))
 			}
 			v.currentField.Stream = &resolve.StreamField{
 				InitialBatchSize: initialBatchSize,
+				Label:            v.directiveLabel(ref),
+				IfVariableName:   ifVariableName,
 			}
 		case "defer":
-			v.currentField.Defer = &resolve.DeferField{}
+			disabled, ifVariableName := v.directiveCondition(ref)
+			if disabled {
+				return
+			}
+			v.currentField.Defer = &resolve.DeferField{
+				Label:          v.directiveLabel(ref),
+				IfVariableName: ifVariableName,
+			}
+		}
+	}
+}
+
+// directiveCondition returns the "if" argument of a @defer or @stream directive
+// disabled is true if the argument is the literal false.
+// If the argument is a variable its name is returned, the variable is evaluated when resolving the response.
+func (v *Visitor) directiveCondition(ref int) (disabled bool, variableName string) {
+	value, ok := v.Operation.DirectiveArgumentValueByName(ref, literal.IF)
+	if !ok {
+		return false, ""
+	}
+	switch value.Kind {
+	case ast.ValueKindBoolean:
+		return !bool(v.Operation.BooleanValue(value.Ref)), ""
+	case ast.ValueKindVariable:
+		return false, v.Operation.VariableValueNameString(value.Ref)
+	default:
+		return false, ""
+	}
+}
+
+// directiveLabel returns the value of the "label" argument, escape sequences of the GraphQL string are resolved
+func (v *Visitor) directiveLabel(ref int) []byte {
+	value, ok := v.Operation.DirectiveArgumentValueByName(ref, literal.LABEL)
+	if !ok || value.Kind != ast.ValueKindString {
+		return nil
+	}
+	label := v.Operation.StringValueContentBytes(value.Ref)
+	if v.Operation.StringValueIsBlockString(value.Ref) {
+		return label
+	}
+	unescaped, err := jsonparser.ParseString(label)
+	if err != nil {
+		return label
+	}
+	return []byte(unescaped)
+}
+
+// enclosingDeferredInlineFragment returns the @defer directive of the closest inline fragment enclosing the current field
+// Only inline fragments between the field and its parent field are taken into account
+func (v *Visitor) enclosingDeferredInlineFragment() (directiveRef int, ok bool) {
+	for i := len(v.Walker.Ancestors) - 1; i >= 0; i-- {
+		ancestor := v.Walker.Ancestors[i]
+		switch ancestor.Kind {
+		case ast.NodeKindSelectionSet:
+			continue
+		case ast.NodeKindInlineFragment:
+			for _, directive := range v.Operation.InlineFragments[ancestor.Ref].Directives.Refs {
+				if v.Operation.DirectiveNameString(directive) != "defer" {
+					continue
+				}
+				if disabled, _ := v.directiveCondition(directive); !disabled {
+					return directive, true
+				}
+			}
+		default:
+			return -1, false
 		}
 	}
+	return -1, false
 }
 
 func (v *Visitor) EnterInlineFragment(ref int) {
@@ -578,6 +662,20 @@ func (v *Visitor) EnterField(ref int) {
 		IncludeVariableName:     includeVariableName,
 	}
 
+	if directive, ok := v.enclosingDeferredInlineFragment(); ok {
+		deferField, exists := v.deferredFragments[directive]
+		if !exists {
+			_, ifVariableName := v.directiveCondition(directive)
+			deferField = &resolve.DeferField{
+				Label:          v.directiveLabel(directive),
+				Fragment:       true,
+				IfVariableName: ifVariableName,
+			}
+			v.deferredFragments[directive] = deferField
+		}
+		v.currentField.Defer = deferField
+	}
+
 	*v.currentFields[len(v.currentFields)-1].fields = append(*v.currentFields[len(v.currentFields)-1].fields, v.currentField)
 
 	typeName := v.Walker.EnclosingTypeDefinition.NameString(v.Definition)
@@ -738,6 +836,14 @@ func (v *Visitor) resolveFieldValue(fieldRef, typeRef int, nullable bool, path [
 					Export:   fieldExport,
 				}
 			default:
+				if scalar, ok := v.Config.Scalars.Scalar(typeName); ok {
+					return &resolve.CustomScalar{
+						Path:     path,
+						Nullable: nullable,
+						Export:   fieldExport,
+						Scalar:   scalar,
+					}
+				}
 				return &resolve.String{
 					Path:                 path,
 					Nullable:             nullable,
@@ -937,6 +1043,7 @@ func (v *Visitor) EnterDocument(operation, definition *ast.Document) {
 	v.fieldConfigs = map[int]*FieldConfiguration{}
 	v.exportedVariables = map[string]struct{}{}
 	v.skipIncludeFields = map[int]skipIncludeField{}
+	v.deferredFragments = map[int]*resolve.DeferField{}
 }
 
 func (v *Visitor) LeaveDocument(operation, definition *ast.Document) {
@@ -1068,13 +1175,13 @@ func (v *Visitor) resolveInputTemplates(config objectFetchConfiguration, input *
 						}
 						variable.Renderer = renderer
 					case RenderArgumentAsGraphQLValue:
-						renderer, err := resolve.NewGraphQLVariableRendererFromTypeRef(v.Operation, v.Definition, variableTypeRef)
+						renderer, err := resolve.NewGraphQLVariableRendererFromTypeRef(v.Operation, v.Definition, variableTypeRef, graphqljsonschema.WithScalarRegistry(v.Config.Scalars))
 						if err != nil {
 							break
 						}
 						variable.Renderer = renderer
 					case RenderArgumentAsJSONValue:
-						renderer, err := resolve.NewJSONVariableRendererWithValidationFromTypeRef(v.Operation, v.Definition, variableTypeRef)
+						renderer, err := resolve.NewJSONVariableRendererWithValidationFromTypeRef(v.Operation, v.Definition, variableTypeRef, graphqljsonschema.WithScalarRegistry(v.Config.Scalars))
 						if err != nil {
 							break
 						}
@@ -1150,7 +1257,7 @@ func (v *Visitor) renderJSONValueTemplate(value ast.Value, variables *resolve.Va
 	case ast.ValueKindVariable:
 		variablePath := v.Operation.VariableValueNameString(value.Ref)
 		inputType := v.Definition.InputValueDefinitions[inputValueDefinition].Type
-		renderer, err := resolve.NewJSONVariableRendererWithValidationFromTypeRef(v.Definition, v.Definition, inputType)
+		renderer, err := resolve.NewJSONVariableRendererWithValidationFromTypeRef(v.Definition, v.Definition, inputType, graphqljsonschema.WithScalarRegistry(v.Config.Scalars))
 		if err != nil {
 			renderer = resolve.NewJSONVariableRenderer()
 		}
@@ -1219,6 +1326,20 @@ func (v *Visitor) configureFetch(internal objectFetchConfiguration, external Fet
 		DataSourceIdentifier:  []byte(dataSourceType),
 		ProcessResponseConfig: external.ProcessResponseConfig,
 		DisableDataLoader:     external.DisableDataLoader,
+		Transformation:        external.Transformation,
+		HeaderRules:           internal.headerRules,
+	}
+
+	if external.Transformation != nil {
+		if err := external.Transformation.Validate(); err != nil {
+			v.Walker.StopWithInternalErr(err)
+		}
+	}
+
+	if internal.headerRules != nil {
+		if err := internal.headerRules.Validate(); err != nil {
+			v.Walker.StopWithInternalErr(err)
+		}
 	}
 
 	// if a field depends on an exported variable, data loader needs to be disabled
@@ -1359,7 +1480,10 @@ type FetchConfiguration struct {
 	// e.g. if a field depends on an exported variable which doesn't work with DataLoader
 	DisableDataLoader     bool
 	ProcessResponseConfig resolve.ProcessResponseConfig
-	BatchConfig           BatchConfig
+	// Transformation adapts the response of the DataSource to the shape of the schema before resolving
+	// It allows using upstreams with a different response structure without writing a custom DataSource
+	Transformation *resolve.Transformation
+	BatchConfig    BatchConfig
 }
 
 type BatchConfig struct {
@@ -1544,6 +1668,7 @@ func (c *configurationVisitor) EnterField(ref int) {
 				isSubscription:     isSubscription,
 				fieldRef:           ref,
 				fieldDefinitionRef: fieldDefinition,
+				headerRules:        config.HeaderRules,
 			})
 			return
 		}
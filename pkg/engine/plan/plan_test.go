@@ -208,6 +208,151 @@ func TestPlanner_Plan(t *testing.T) {
 		DefaultFlushIntervalMillis: 0,
 	}))
 
+	t.Run("stream & defer Query with labels and deferred inline fragment", test(testDefinition, `
+		query MyQuery($id: ID!) {
+			droid(id: $id){
+				name
+				friends @stream(label: "friends", initialCount: 2) {
+					name
+				}
+				... on Droid @defer(label: "details \"droid\"") {
+					primaryFunction
+				}
+				... on Droid @defer(if: false) {
+					favoriteEpisode
+				}
+			}
+		}
+	`, "MyQuery", &SynchronousResponsePlan{
+		Response: &resolve.GraphQLResponse{
+			Data: &resolve.Object{
+				Fields: []*resolve.Field{
+					{
+						Name: []byte("droid"),
+						Value: &resolve.Object{
+							Path:     []string{"droid"},
+							Nullable: true,
+							Fields: []*resolve.Field{
+								{
+									Name: []byte("name"),
+									Value: &resolve.String{
+										Path: []string{"name"},
+									},
+								},
+								{
+									Name: []byte("friends"),
+									Stream: &resolve.StreamField{
+										InitialBatchSize: 2,
+										Label:            []byte("friends"),
+									},
+									Value: &resolve.Array{
+										Nullable: true,
+										Path:     []string{"friends"},
+										Item: &resolve.Object{
+											Nullable: true,
+											Fields: []*resolve.Field{
+												{
+													Name: []byte("name"),
+													Value: &resolve.String{
+														Path: []string{"name"},
+													},
+												},
+											},
+										},
+									},
+								},
+								{
+									Name: []byte("primaryFunction"),
+									Defer: &resolve.DeferField{
+										Label:    []byte(`details "droid"`),
+										Fragment: true,
+									},
+									OnTypeName: []byte("Droid"),
+									Value: &resolve.String{
+										Path: []string{"primaryFunction"},
+									},
+								},
+								{
+									Name:       []byte("favoriteEpisode"),
+									OnTypeName: []byte("Droid"),
+									Value: &resolve.String{
+										Nullable: true,
+										Path:     []string{"favoriteEpisode"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, Configuration{
+		DisableResolveFieldPositions: true,
+	}))
+
+	t.Run("stream & defer Query with variable if arguments", test(testDefinition, `
+		query MyQuery($id: ID!, $stream: Boolean!, $defer: Boolean!) {
+			droid(id: $id){
+				friends @stream(if: $stream) {
+					name
+				}
+				... on Droid @defer(if: $defer) {
+					primaryFunction
+				}
+			}
+		}
+	`, "MyQuery", &SynchronousResponsePlan{
+		Response: &resolve.GraphQLResponse{
+			Data: &resolve.Object{
+				Fields: []*resolve.Field{
+					{
+						Name: []byte("droid"),
+						Value: &resolve.Object{
+							Path:     []string{"droid"},
+							Nullable: true,
+							Fields: []*resolve.Field{
+								{
+									Name: []byte("friends"),
+									Stream: &resolve.StreamField{
+										IfVariableName: "stream",
+									},
+									Value: &resolve.Array{
+										Nullable: true,
+										Path:     []string{"friends"},
+										Item: &resolve.Object{
+											Nullable: true,
+											Fields: []*resolve.Field{
+												{
+													Name: []byte("name"),
+													Value: &resolve.String{
+														Path: []string{"name"},
+													},
+												},
+											},
+										},
+									},
+								},
+								{
+									Name: []byte("primaryFunction"),
+									Defer: &resolve.DeferField{
+										Fragment:       true,
+										IfVariableName: "defer",
+									},
+									OnTypeName: []byte("Droid"),
+									Value: &resolve.String{
+										Path: []string{"primaryFunction"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, Configuration{
+		DisableResolveFieldPositions: true,
+	}))
+
 	t.Run("operation selection", func(t *testing.T) {
 		t.Run("should successfully plan a single named query by providing an operation name", test(testDefinition, `
 				query MyHero {
@@ -358,11 +503,11 @@ var expectedMyHeroPlanWithFragment = &SynchronousResponsePlan{
 
 const testDefinition = `
 
-directive @defer on FIELD
+directive @defer(if: Boolean! = true, label: String) on FIELD | INLINE_FRAGMENT | FRAGMENT_SPREAD
 
 directive @flushInterval(milliSeconds: Int!) on QUERY | SUBSCRIPTION
 
-directive @stream(initialBatchSize: Int) on FIELD
+directive @stream(if: Boolean! = true, initialBatchSize: Int, initialCount: Int, label: String) on FIELD
 
 union SearchResult = Human | Droid | Starship
 
@@ -2,10 +2,13 @@ package resolve
 
 import (
 	"hash"
+	"io"
+	"net/http"
 	"sync"
 
 	"github.com/cespare/xxhash/v2"
 
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/upstreamheader"
 	"github.com/wundergraph/graphql-go-tools/pkg/fastbuffer"
 	"github.com/wundergraph/graphql-go-tools/pkg/pool"
 )
@@ -48,16 +51,37 @@ func NewFetcher(enableSingleFlightLoader bool) *Fetcher {
 }
 
 func (f *Fetcher) Fetch(ctx *Context, fetch *SingleFetch, preparedInput *fastbuffer.FastBuffer, buf *BufPair) (err error) {
+	return f.fetch(ctx, fetch, preparedInput, buf, fetch.Transformation)
+}
+
+// fetch loads the response of the fetch and applies transformation to it
+// Batches pass a nil transformation, the transformation of the fetch is applied to the demultiplexed responses instead.
+func (f *Fetcher) fetch(ctx *Context, fetch *SingleFetch, preparedInput *fastbuffer.FastBuffer, buf *BufPair, transformation *Transformation) (err error) {
 	dataBuf := pool.BytesBuffer.Get()
 	defer pool.BytesBuffer.Put(dataBuf)
 
+	input := preparedInput.Bytes()
+	if fetch.HeaderRules != nil {
+		input, err = fetch.HeaderRules.applyRequestRules(ctx, input)
+		if err != nil {
+			return err
+		}
+	}
+
 	if ctx.beforeFetchHook != nil {
-		ctx.beforeFetchHook.OnBeforeFetch(f.hookCtx(ctx), preparedInput.Bytes())
+		ctx.beforeFetchHook.OnBeforeFetch(f.hookCtx(ctx), input)
 	}
 
 	if !f.EnableSingleFlightLoader || fetch.DisallowSingleFlight {
-		err = fetch.DataSource.Load(ctx.Context, preparedInput.Bytes(), dataBuf)
+		var header http.Header
+		header, err = f.load(ctx, fetch, input, dataBuf)
 		extractResponse(dataBuf.Bytes(), buf, fetch.ProcessResponseConfig)
+		if err == nil {
+			err = transformResponse(buf, transformation)
+		}
+		if err == nil && header != nil {
+			err = fetch.HeaderRules.applyResponseRules(ctx, header)
+		}
 
 		if ctx.afterFetchHook != nil {
 			if buf.HasData() {
@@ -71,7 +95,11 @@ func (f *Fetcher) Fetch(ctx *Context, fetch *SingleFetch, preparedInput *fastbuf
 	}
 
 	hash64 := f.getHash64()
-	_, _ = hash64.Write(preparedInput.Bytes())
+	_, _ = hash64.Write(input)
+	if transformation != fetch.Transformation {
+		// the untransformed response of a batch must not be shared with a fetch expecting the transformed one
+		_, _ = hash64.Write([]byte{0})
+	}
 	fetchID := hash64.Sum64()
 	f.putHash64(hash64)
 
@@ -82,6 +110,11 @@ func (f *Fetcher) Fetch(ctx *Context, fetch *SingleFetch, preparedInput *fastbuf
 		defer inflight.waitFree.Done()
 		f.inflightFetchMu.Unlock()
 		inflight.waitLoad.Wait()
+		if inflight.err == nil && inflight.header != nil {
+			if err = fetch.HeaderRules.applyResponseRules(ctx, inflight.header); err != nil {
+				return err
+			}
+		}
 		if inflight.bufPair.HasData() {
 			if ctx.afterFetchHook != nil {
 				ctx.afterFetchHook.OnData(f.hookCtx(ctx), inflight.bufPair.Data.Bytes(), true)
@@ -103,8 +136,14 @@ func (f *Fetcher) Fetch(ctx *Context, fetch *SingleFetch, preparedInput *fastbuf
 
 	f.inflightFetchMu.Unlock()
 
-	err = fetch.DataSource.Load(ctx.Context, preparedInput.Bytes(), dataBuf)
+	inflight.header, err = f.load(ctx, fetch, input, dataBuf)
 	extractResponse(dataBuf.Bytes(), &inflight.bufPair, fetch.ProcessResponseConfig)
+	if err == nil {
+		err = transformResponse(&inflight.bufPair, transformation)
+	}
+	if err == nil && inflight.header != nil {
+		err = fetch.HeaderRules.applyResponseRules(ctx, inflight.header)
+	}
 	inflight.err = err
 
 	if inflight.bufPair.HasData() {
@@ -135,6 +174,16 @@ func (f *Fetcher) Fetch(ctx *Context, fetch *SingleFetch, preparedInput *fastbuf
 	return
 }
 
+// load calls the DataSource and returns the upstream response headers if the HeaderRules of the fetch select any
+func (f *Fetcher) load(ctx *Context, fetch *SingleFetch, input []byte, out io.Writer) (http.Header, error) {
+	if !fetch.HeaderRules.hasResponseRules() {
+		return nil, fetch.DataSource.Load(ctx.Context, input, out)
+	}
+	loadCtx, recorder := upstreamheader.WithRecorder(ctx.Context)
+	err := fetch.DataSource.Load(loadCtx, input, out)
+	return recorder.Header, err
+}
+
 func (f *Fetcher) FetchBatch(ctx *Context, fetch *BatchFetch, preparedInputs []*fastbuffer.FastBuffer, bufs []*BufPair) (err error) {
 	inputs := make([][]byte, len(preparedInputs))
 	for i := range preparedInputs {
@@ -149,7 +198,8 @@ func (f *Fetcher) FetchBatch(ctx *Context, fetch *BatchFetch, preparedInputs []*
 	buf := f.getBufPair()
 	defer f.freeBufPair(buf)
 
-	if err = f.Fetch(ctx, fetch.Fetch, batch.Input(), buf); err != nil {
+	// the transformation applies to the response of a single entity, not to the combined response of the batch
+	if err = f.fetch(ctx, fetch.Fetch, batch.Input(), buf, nil); err != nil {
 		return err
 	}
 
@@ -157,6 +207,12 @@ func (f *Fetcher) FetchBatch(ctx *Context, fetch *BatchFetch, preparedInputs []*
 		return err
 	}
 
+	for i := range bufs {
+		if err = transformResponse(bufs[i], fetch.Fetch.Transformation); err != nil {
+			return err
+		}
+	}
+
 	return
 }
 
@@ -177,6 +233,7 @@ func (f *Fetcher) freeInflightFetch(inflightFetch *inflightFetch) {
 	inflightFetch.bufPair.Data.Reset()
 	inflightFetch.bufPair.Errors.Reset()
 	inflightFetch.err = nil
+	inflightFetch.header = nil
 	f.inflightFetchPool.Put(inflightFetch)
 }
 
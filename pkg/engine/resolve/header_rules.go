@@ -0,0 +1,492 @@
+package resolve
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/buger/jsonparser"
+	"github.com/tidwall/sjson"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/upstreamheader"
+)
+
+type RequestHeaderRuleKind string
+
+const (
+	// RequestHeaderRuleKindPropagate copies the client request headers selected by Name or Matching to the upstream request
+	// The header can be renamed using Rename, Default is used if the client request doesn't contain the header
+	RequestHeaderRuleKindPropagate RequestHeaderRuleKind = "propagate"
+	// RequestHeaderRuleKindSet sets the header Name to Value, the Claim of the bearer token or the value of ContextKey
+	// Default is used if the value is empty
+	RequestHeaderRuleKindSet RequestHeaderRuleKind = "set"
+	// RequestHeaderRuleKindRemove removes the headers selected by Name or Matching from the upstream request,
+	// including static headers of the DataSource configuration
+	RequestHeaderRuleKindRemove RequestHeaderRuleKind = "remove"
+)
+
+type ResponseHeaderMergeAlgorithm string
+
+const (
+	// ResponseHeaderMergeAlgorithmAppend adds the values of all upstream responses, e.g. for Set-Cookie
+	ResponseHeaderMergeAlgorithmAppend ResponseHeaderMergeAlgorithm = "append"
+	// ResponseHeaderMergeAlgorithmFirstWrite keeps the value of the first upstream response
+	ResponseHeaderMergeAlgorithmFirstWrite ResponseHeaderMergeAlgorithm = "first_write"
+	// ResponseHeaderMergeAlgorithmLastWrite keeps the value of the last upstream response
+	ResponseHeaderMergeAlgorithmLastWrite ResponseHeaderMergeAlgorithm = "last_write"
+	// ResponseHeaderMergeAlgorithmMostRestrictiveCacheControl merges Cache-Control headers,
+	// the client response is cacheable only as long and as public as all upstream responses.
+	// Upstream responses without Cache-Control are treated as no-store.
+	ResponseHeaderMergeAlgorithmMostRestrictiveCacheControl ResponseHeaderMergeAlgorithm = "most_restrictive_cache_control"
+)
+
+// HeaderRuleContextKey is the key of context values which can be set as upstream request headers
+// e.g. context.WithValue(ctx, resolve.HeaderRuleContextKey("tenant"), "acme")
+type HeaderRuleContextKey string
+
+// HeaderRules declare how headers are propagated between the client and the upstream of a DataSource
+// Request rules are applied in order to the "header" object of the fetch input, as used by the httpclient package
+// Response rules merge the headers of upstream responses into Context.ResponseHeader
+type HeaderRules struct {
+	Request  []RequestHeaderRule  `json:",omitempty"`
+	Response []ResponseHeaderRule `json:",omitempty"`
+
+	compileOnce sync.Once
+	compiled    []compiledHeaderRule
+	compileErr  error
+}
+
+type RequestHeaderRule struct {
+	Kind RequestHeaderRuleKind
+	// Name selects a header by name
+	Name string `json:",omitempty"`
+	// Matching selects all headers matching the regular expression, case-insensitive
+	Matching string `json:",omitempty"`
+	// Rename sets the name of the propagated header in the upstream request
+	Rename string `json:",omitempty"`
+	// Default is used if the header is missing or the value is empty
+	Default string `json:",omitempty"`
+	// Value is the static value of a set rule
+	Value string `json:",omitempty"`
+	// Claim is the dot delimited path of a claim in the bearer token of the Authorization header, e.g. "sub" or "org.id"
+	// The token isn't verified, it's expected to be verified before the operation gets executed
+	Claim string `json:",omitempty"`
+	// ContextKey sets the value of HeaderRuleContextKey(ContextKey) from the request context
+	ContextKey string `json:",omitempty"`
+}
+
+type ResponseHeaderRule struct {
+	// Name selects a header of the upstream response by name
+	Name string `json:",omitempty"`
+	// Matching selects all headers of the upstream response matching the regular expression, case-insensitive
+	Matching string `json:",omitempty"`
+	// Algorithm defines how values of multiple upstream responses are merged, defaults to append
+	Algorithm ResponseHeaderMergeAlgorithm `json:",omitempty"`
+}
+
+type compiledHeaderRule struct {
+	name     string
+	matching *regexp.Regexp
+}
+
+func (c *compiledHeaderRule) matches(name string) bool {
+	if c.name != "" {
+		return c.name == name
+	}
+	return c.matching.MatchString(name)
+}
+
+// Validate compiles all rules and returns the first configuration error
+func (h *HeaderRules) Validate() error {
+	h.compile()
+	return h.compileErr
+}
+
+func (h *HeaderRules) hasResponseRules() bool {
+	return h != nil && len(h.Response) != 0
+}
+
+func (h *HeaderRules) compile() {
+	h.compileOnce.Do(func() {
+		h.compiled = make([]compiledHeaderRule, 0, len(h.Request)+len(h.Response))
+		for i := range h.Request {
+			rule := h.Request[i]
+			switch rule.Kind {
+			case RequestHeaderRuleKindPropagate, RequestHeaderRuleKindRemove:
+			case RequestHeaderRuleKindSet:
+				if rule.Name == "" {
+					h.compileErr = fmt.Errorf("request header rule %d (%s): name is required", i, rule.Kind)
+					return
+				}
+			default:
+				h.compileErr = fmt.Errorf("request header rule %d (%s): unknown request header rule kind", i, rule.Kind)
+				return
+			}
+			compiled, err := compileHeaderSelector(rule.Name, rule.Matching)
+			if err != nil {
+				h.compileErr = fmt.Errorf("request header rule %d (%s): %w", i, rule.Kind, err)
+				return
+			}
+			h.compiled = append(h.compiled, compiled)
+		}
+		for i := range h.Response {
+			switch h.Response[i].Algorithm {
+			case "", ResponseHeaderMergeAlgorithmAppend, ResponseHeaderMergeAlgorithmFirstWrite,
+				ResponseHeaderMergeAlgorithmLastWrite, ResponseHeaderMergeAlgorithmMostRestrictiveCacheControl:
+			default:
+				h.compileErr = fmt.Errorf("response header rule %d: unknown merge algorithm '%s'", i, h.Response[i].Algorithm)
+				return
+			}
+			compiled, err := compileHeaderSelector(h.Response[i].Name, h.Response[i].Matching)
+			if err != nil {
+				h.compileErr = fmt.Errorf("response header rule %d: %w", i, err)
+				return
+			}
+			h.compiled = append(h.compiled, compiled)
+		}
+	})
+}
+
+func compileHeaderSelector(name, matching string) (compiledHeaderRule, error) {
+	switch {
+	case name != "" && matching != "":
+		return compiledHeaderRule{}, fmt.Errorf("either name or matching must be set")
+	case name != "":
+		return compiledHeaderRule{name: http.CanonicalHeaderKey(name)}, nil
+	case matching != "":
+		expression, err := regexp.Compile("(?i)" + matching)
+		if err != nil {
+			return compiledHeaderRule{}, err
+		}
+		return compiledHeaderRule{matching: expression}, nil
+	default:
+		return compiledHeaderRule{}, fmt.Errorf("name or matching is required")
+	}
+}
+
+// SetUpstreamResponseHeader reports the headers of an upstream response to the resolver
+// DataSources call it with the context passed to Load, the headers are merged according to the HeaderRules of the fetch.
+// It is the same as upstreamheader.Set, which data sources should use to not depend on the resolve package.
+func SetUpstreamResponseHeader(ctx context.Context, header http.Header) {
+	upstreamheader.Set(ctx, header)
+}
+
+// applyRequestRules evaluates the request rules against the client request and sets the resulting headers in the fetch input
+func (h *HeaderRules) applyRequestRules(ctx *Context, input []byte) ([]byte, error) {
+	if len(h.Request) == 0 {
+		return input, nil
+	}
+	h.compile()
+	if h.compileErr != nil {
+		return nil, h.compileErr
+	}
+
+	upstream := http.Header{}
+	if existing, dataType, _, err := jsonparser.Get(input, "header"); err == nil && dataType == jsonparser.Object {
+		var static map[string][]string
+		if err := json.Unmarshal(existing, &static); err != nil {
+			return nil, err
+		}
+		for name, values := range static {
+			for _, value := range values {
+				upstream.Add(name, value)
+			}
+		}
+	}
+
+	for i := range h.Request {
+		rule := &h.Request[i]
+		selector := &h.compiled[i]
+		switch rule.Kind {
+		case RequestHeaderRuleKindPropagate:
+			propagated := false
+			for name, values := range ctx.Request.Header {
+				name = http.CanonicalHeaderKey(name)
+				if !selector.matches(name) {
+					continue
+				}
+				if rule.Rename != "" {
+					name = rule.Rename
+				}
+				upstream.Del(name)
+				for _, value := range values {
+					upstream.Add(name, value)
+				}
+				propagated = true
+			}
+			if !propagated && rule.Default != "" && rule.Name != "" {
+				name := rule.Name
+				if rule.Rename != "" {
+					name = rule.Rename
+				}
+				upstream.Set(name, rule.Default)
+			}
+		case RequestHeaderRuleKindSet:
+			value := rule.Value
+			if rule.Claim != "" {
+				value = bearerTokenClaim(ctx.Request.Header, rule.Claim)
+			}
+			if rule.ContextKey != "" {
+				value = contextValue(ctx.Context, rule.ContextKey)
+			}
+			if value == "" {
+				value = rule.Default
+			}
+			if value != "" {
+				upstream.Set(rule.Name, value)
+			}
+		case RequestHeaderRuleKindRemove:
+			for name := range upstream {
+				if selector.matches(name) {
+					delete(upstream, name)
+				}
+			}
+		}
+	}
+
+	header, err := json.Marshal(upstream)
+	if err != nil {
+		return nil, err
+	}
+	return sjson.SetRawBytes(input, "header", header)
+}
+
+// defaultResponseHeaderMu guards the ResponseHeader of Contexts which aren't created using NewContext
+var defaultResponseHeaderMu sync.Mutex
+
+// responseHeaderLock returns the mutex guarding ResponseHeader, it's shared with all clones of the Context
+func (c *Context) responseHeaderLock() *sync.Mutex {
+	if c.responseHeaderMu == nil {
+		return &defaultResponseHeaderMu
+	}
+	return c.responseHeaderMu
+}
+
+// applyResponseRules merges the selected headers of an upstream response into Context.ResponseHeader
+// upstream is nil if the DataSource doesn't report response headers, those fetches don't affect the response headers.
+func (h *HeaderRules) applyResponseRules(ctx *Context, upstream http.Header) error {
+	if ctx.ResponseHeader == nil || upstream == nil {
+		return nil
+	}
+	h.compile()
+	if h.compileErr != nil {
+		return h.compileErr
+	}
+
+	mu := ctx.responseHeaderLock()
+	mu.Lock()
+	defer mu.Unlock()
+
+	for i := range h.Response {
+		rule := &h.Response[i]
+		selector := &h.compiled[len(h.Request)+i]
+		matched := false
+		for name, values := range upstream {
+			name = http.CanonicalHeaderKey(name)
+			if !selector.matches(name) || len(values) == 0 {
+				continue
+			}
+			matched = true
+			switch rule.Algorithm {
+			case ResponseHeaderMergeAlgorithmFirstWrite:
+				if _, exists := ctx.ResponseHeader[name]; !exists {
+					ctx.ResponseHeader[name] = append([]string(nil), values...)
+				}
+			case ResponseHeaderMergeAlgorithmLastWrite:
+				ctx.ResponseHeader[name] = append([]string(nil), values...)
+			case ResponseHeaderMergeAlgorithmMostRestrictiveCacheControl:
+				merged := strings.Join(values, ", ")
+				if existing := ctx.ResponseHeader.Get(name); existing != "" {
+					merged = mergeCacheControl(existing, merged)
+				}
+				if merged == "" {
+					ctx.ResponseHeader.Del(name)
+					continue
+				}
+				ctx.ResponseHeader.Set(name, merged)
+			default:
+				for _, value := range values {
+					ctx.ResponseHeader.Add(name, value)
+				}
+			}
+		}
+		if !matched && rule.Algorithm == ResponseHeaderMergeAlgorithmMostRestrictiveCacheControl {
+			// an upstream response without Cache-Control must not be cached,
+			// otherwise the merged policy would be more cacheable than this response
+			name := rule.Name
+			if name == "" {
+				name = "Cache-Control"
+			}
+			ctx.ResponseHeader.Set(http.CanonicalHeaderKey(name), "no-store")
+		}
+	}
+	return nil
+}
+
+func bearerTokenClaim(header http.Header, claim string) string {
+	authorization := header.Get("Authorization")
+	if len(authorization) < 7 || !strings.EqualFold(authorization[:7], "bearer ") {
+		return ""
+	}
+	parts := strings.Split(strings.TrimSpace(authorization[7:]), ".")
+	if len(parts) != 3 {
+		return ""
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return ""
+	}
+	value, dataType, _, err := jsonparser.Get(payload, strings.Split(claim, ".")...)
+	if err != nil {
+		return ""
+	}
+	if dataType == jsonparser.String {
+		unescaped, err := jsonparser.ParseString(value)
+		if err != nil {
+			return ""
+		}
+		return unescaped
+	}
+	return string(value)
+}
+
+func contextValue(ctx context.Context, key string) string {
+	if ctx == nil {
+		return ""
+	}
+	switch value := ctx.Value(HeaderRuleContextKey(key)).(type) {
+	case string:
+		return value
+	case fmt.Stringer:
+		return value.String()
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// mergeCacheControl returns the most restrictive combination of two Cache-Control header values
+// Unknown directives are dropped, so the result might be empty.
+func mergeCacheControl(a, b string) string {
+	left, right := parseCacheControl(a), parseCacheControl(b)
+	if left.noStore || right.noStore {
+		return "no-store"
+	}
+	merged := cacheControl{
+		noCache:         left.noCache || right.noCache,
+		private:         left.private || right.private,
+		public:          left.public && right.public,
+		mustRevalidate:  left.mustRevalidate || right.mustRevalidate,
+		proxyRevalidate: left.proxyRevalidate || right.proxyRevalidate,
+		maxAge:          minMaxAge(left.maxAge, right.maxAge),
+		sMaxAge:         -1,
+	}
+	if left.sMaxAge != -1 || right.sMaxAge != -1 {
+		// shared caches fall back to max-age, so it limits the s-maxage of the merged value as well
+		merged.sMaxAge = minMaxAge(left.sharedMaxAge(), right.sharedMaxAge())
+	}
+	if merged.private {
+		// shared caches must not store private responses at all
+		merged.public = false
+		merged.proxyRevalidate = false
+		merged.sMaxAge = -1
+	}
+	return merged.String()
+}
+
+type cacheControl struct {
+	noStore         bool
+	noCache         bool
+	private         bool
+	public          bool
+	mustRevalidate  bool
+	proxyRevalidate bool
+	// maxAge is -1 if not set
+	maxAge int
+	// sMaxAge is -1 if not set
+	sMaxAge int
+}
+
+func parseCacheControl(value string) cacheControl {
+	out := cacheControl{maxAge: -1, sMaxAge: -1}
+	for _, directive := range strings.Split(value, ",") {
+		directive = strings.ToLower(strings.TrimSpace(directive))
+		switch {
+		case directive == "no-store":
+			out.noStore = true
+		case directive == "no-cache":
+			out.noCache = true
+		case directive == "private":
+			out.private = true
+		case directive == "public":
+			out.public = true
+		case directive == "must-revalidate":
+			out.mustRevalidate = true
+		case directive == "proxy-revalidate":
+			out.proxyRevalidate = true
+		case strings.HasPrefix(directive, "max-age="):
+			if maxAge, err := strconv.Atoi(strings.TrimPrefix(directive, "max-age=")); err == nil {
+				out.maxAge = maxAge
+			}
+		case strings.HasPrefix(directive, "s-maxage="):
+			if sMaxAge, err := strconv.Atoi(strings.TrimPrefix(directive, "s-maxage=")); err == nil {
+				out.sMaxAge = sMaxAge
+			}
+		}
+	}
+	return out
+}
+
+// sharedMaxAge returns the max age for shared caches, which is max-age if s-maxage is not set
+func (c cacheControl) sharedMaxAge() int {
+	if c.sMaxAge != -1 {
+		return c.sMaxAge
+	}
+	return c.maxAge
+}
+
+func minMaxAge(a, b int) int {
+	switch {
+	case a == -1:
+		return b
+	case b == -1:
+		return a
+	case a < b:
+		return a
+	default:
+		return b
+	}
+}
+
+func (c cacheControl) String() string {
+	directives := make([]string, 0, 7)
+	if c.noCache {
+		directives = append(directives, "no-cache")
+	}
+	if c.private {
+		directives = append(directives, "private")
+	}
+	if c.public {
+		directives = append(directives, "public")
+	}
+	if c.mustRevalidate {
+		directives = append(directives, "must-revalidate")
+	}
+	if c.proxyRevalidate {
+		directives = append(directives, "proxy-revalidate")
+	}
+	if c.maxAge != -1 {
+		directives = append(directives, "max-age="+strconv.Itoa(c.maxAge))
+	}
+	if c.sMaxAge != -1 {
+		directives = append(directives, "s-maxage="+strconv.Itoa(c.sMaxAge))
+	}
+	return strings.Join(directives, ", ")
+}
@@ -0,0 +1,278 @@
+package resolve
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeaderRules_applyRequestRules(t *testing.T) {
+	token := "eyJhbGciOiJIUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"user-1","org":{"id":42}}`)) + ".signature"
+
+	run := func(rules []RequestHeaderRule, input string, expectedOutput string) func(t *testing.T) {
+		return func(t *testing.T) {
+			ctx := NewContext(context.WithValue(context.Background(), HeaderRuleContextKey("tenant"), "acme"))
+			ctx.Request.Header = http.Header{
+				"Authorization":   {"Bearer " + token},
+				"X-Request-Id":    {"123"},
+				"X-Feature-A":     {"on"},
+				"X-Feature-B":     {"off"},
+				"Accept-Language": {"de", "en"},
+			}
+			headerRules := &HeaderRules{Request: rules}
+			require.NoError(t, headerRules.Validate())
+			out, err := headerRules.applyRequestRules(ctx, []byte(input))
+			require.NoError(t, err)
+			assert.JSONEq(t, expectedOutput, string(out))
+		}
+	}
+
+	t.Run("propagate by name", run([]RequestHeaderRule{
+		{Kind: RequestHeaderRuleKindPropagate, Name: "x-request-id"},
+		{Kind: RequestHeaderRuleKindPropagate, Name: "Accept-Language"},
+	}, `{"url":"https://example.com"}`, `{"url":"https://example.com","header":{"X-Request-Id":["123"],"Accept-Language":["de","en"]}}`))
+
+	t.Run("propagate matching", run([]RequestHeaderRule{
+		{Kind: RequestHeaderRuleKindPropagate, Matching: "^x-feature-"},
+	}, `{}`, `{"header":{"X-Feature-A":["on"],"X-Feature-B":["off"]}}`))
+
+	t.Run("propagate with rename and default", run([]RequestHeaderRule{
+		{Kind: RequestHeaderRuleKindPropagate, Name: "X-Request-Id", Rename: "X-Correlation-Id"},
+		{Kind: RequestHeaderRuleKindPropagate, Name: "X-Missing", Rename: "X-Other", Default: "fallback"},
+	}, `{}`, `{"header":{"X-Correlation-Id":["123"],"X-Other":["fallback"]}}`))
+
+	t.Run("propagate overrides static headers", run([]RequestHeaderRule{
+		{Kind: RequestHeaderRuleKindPropagate, Name: "X-Request-Id"},
+	}, `{"header":{"x-request-id":["static"],"Static":["value"]}}`, `{"header":{"X-Request-Id":["123"],"Static":["value"]}}`))
+
+	t.Run("set", run([]RequestHeaderRule{
+		{Kind: RequestHeaderRuleKindSet, Name: "X-Static", Value: "value"},
+		{Kind: RequestHeaderRuleKindSet, Name: "X-User-Id", Claim: "sub"},
+		{Kind: RequestHeaderRuleKindSet, Name: "X-Org-Id", Claim: "org.id"},
+		{Kind: RequestHeaderRuleKindSet, Name: "X-Missing-Claim", Claim: "missing", Default: "anonymous"},
+		{Kind: RequestHeaderRuleKindSet, Name: "X-Tenant", ContextKey: "tenant"},
+		{Kind: RequestHeaderRuleKindSet, Name: "X-Empty", ContextKey: "missing"},
+	}, `{}`, `{"header":{"X-Static":["value"],"X-User-Id":["user-1"],"X-Org-Id":["42"],"X-Missing-Claim":["anonymous"],"X-Tenant":["acme"]}}`))
+
+	t.Run("remove", run([]RequestHeaderRule{
+		{Kind: RequestHeaderRuleKindPropagate, Matching: ".*"},
+		{Kind: RequestHeaderRuleKindRemove, Name: "Authorization"},
+		{Kind: RequestHeaderRuleKindRemove, Matching: "^x-feature-"},
+		{Kind: RequestHeaderRuleKindRemove, Name: "Static"},
+	}, `{"header":{"Static":["value"]}}`, `{"header":{"X-Request-Id":["123"],"Accept-Language":["de","en"]}}`))
+
+	t.Run("invalid rules", func(t *testing.T) {
+		for _, rules := range [][]RequestHeaderRule{
+			{{Kind: "unknown", Name: "a"}},
+			{{Kind: RequestHeaderRuleKindPropagate}},
+			{{Kind: RequestHeaderRuleKindPropagate, Name: "a", Matching: "a"}},
+			{{Kind: RequestHeaderRuleKindRemove, Matching: "("}},
+			{{Kind: RequestHeaderRuleKindSet, Matching: "a"}},
+		} {
+			assert.Error(t, (&HeaderRules{Request: rules}).Validate())
+		}
+		assert.EqualError(t, (&HeaderRules{Response: []ResponseHeaderRule{{Name: "Set-Cookie", Algorithm: "max"}}}).Validate(),
+			"response header rule 0: unknown merge algorithm 'max'")
+	})
+}
+
+func TestHeaderRules_applyResponseRules(t *testing.T) {
+	headerRules := &HeaderRules{
+		Response: []ResponseHeaderRule{
+			{Name: "Set-Cookie"},
+			{Name: "Cache-Control", Algorithm: ResponseHeaderMergeAlgorithmMostRestrictiveCacheControl},
+			{Matching: "^x-upstream-", Algorithm: ResponseHeaderMergeAlgorithmFirstWrite},
+		},
+	}
+	require.NoError(t, headerRules.Validate())
+
+	ctx := NewContext(context.Background())
+	ctx.ResponseHeader = http.Header{}
+
+	require.NoError(t, headerRules.applyResponseRules(ctx, http.Header{
+		"Set-Cookie":    {"a=1"},
+		"Cache-Control": {"public, max-age=300"},
+		"X-Upstream-Id": {"first"},
+		"Content-Type":  {"application/json"},
+	}))
+	require.NoError(t, headerRules.applyResponseRules(ctx, http.Header{
+		"Set-Cookie":    {"b=2"},
+		"Cache-Control": {"max-age=60, private"},
+		"X-Upstream-Id": {"second"},
+	}))
+
+	assert.Equal(t, http.Header{
+		"Set-Cookie":    {"a=1", "b=2"},
+		"Cache-Control": {"private, max-age=60"},
+		"X-Upstream-Id": {"first"},
+	}, ctx.ResponseHeader)
+
+	require.NoError(t, headerRules.applyResponseRules(ctx, http.Header{
+		"Cache-Control": {"no-store"},
+	}))
+	assert.Equal(t, "no-store", ctx.ResponseHeader.Get("Cache-Control"))
+
+	t.Run("upstream without cache control", func(t *testing.T) {
+		for _, upstreams := range [][]http.Header{
+			{{"Cache-Control": {"public, max-age=300"}}, {"Content-Type": {"application/json"}}},
+			{{}, {"Cache-Control": {"public, max-age=300"}}},
+		} {
+			ctx := NewContext(context.Background())
+			ctx.ResponseHeader = http.Header{}
+			for _, upstream := range upstreams {
+				require.NoError(t, headerRules.applyResponseRules(ctx, upstream))
+			}
+			assert.Equal(t, "no-store", ctx.ResponseHeader.Get("Cache-Control"))
+		}
+	})
+
+	t.Run("data source not reporting headers", func(t *testing.T) {
+		ctx := NewContext(context.Background())
+		ctx.ResponseHeader = http.Header{}
+		require.NoError(t, headerRules.applyResponseRules(ctx, http.Header{"Cache-Control": {"public, max-age=300"}}))
+		require.NoError(t, headerRules.applyResponseRules(ctx, nil))
+		assert.Equal(t, "public, max-age=300", ctx.ResponseHeader.Get("Cache-Control"))
+	})
+}
+
+func TestMergeCacheControl(t *testing.T) {
+	for _, tc := range []struct {
+		name, left, right, expected string
+	}{
+		{name: "no-store wins", left: "public, max-age=300", right: "no-store", expected: "no-store"},
+		{name: "minimum max-age", left: "max-age=300", right: "max-age=60", expected: "max-age=60"},
+		{name: "max-age of one side", left: "max-age=300", right: "no-cache", expected: "no-cache, max-age=300"},
+		{name: "public on both sides", left: "public", right: "public", expected: "public"},
+		{name: "public on one side", left: "public, max-age=300", right: "max-age=60", expected: "max-age=60"},
+		{name: "private wins over public", left: "public, s-maxage=300, proxy-revalidate", right: "private, max-age=60", expected: "private, max-age=60"},
+		{name: "minimum s-maxage", left: "public, s-maxage=300", right: "public, s-maxage=60", expected: "public, s-maxage=60"},
+		{name: "s-maxage limited by max-age", left: "public, max-age=600, s-maxage=300", right: "public, max-age=60", expected: "public, max-age=60, s-maxage=60"},
+		{name: "s-maxage of one side", left: "public, s-maxage=300", right: "public", expected: "public, s-maxage=300"},
+		{name: "revalidation directives", left: "must-revalidate, max-age=60", right: "Proxy-Revalidate, max-age=30", expected: "must-revalidate, proxy-revalidate, max-age=30"},
+		{name: "unknown directives", left: "immutable", right: "stale-while-revalidate=60", expected: ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, mergeCacheControl(tc.left, tc.right))
+			assert.Equal(t, tc.expected, mergeCacheControl(tc.right, tc.left))
+		})
+	}
+}
+
+func TestHeaderRules_applyResponseRules_CacheControl(t *testing.T) {
+	headerRules := &HeaderRules{
+		Response: []ResponseHeaderRule{
+			{Name: "Cache-Control", Algorithm: ResponseHeaderMergeAlgorithmMostRestrictiveCacheControl},
+		},
+	}
+	require.NoError(t, headerRules.Validate())
+
+	run := func(upstreams []string, expected http.Header) func(t *testing.T) {
+		return func(t *testing.T) {
+			ctx := NewContext(context.Background())
+			ctx.ResponseHeader = http.Header{}
+			for _, upstream := range upstreams {
+				require.NoError(t, headerRules.applyResponseRules(ctx, http.Header{"Cache-Control": {upstream}}))
+			}
+			assert.Equal(t, expected, ctx.ResponseHeader)
+		}
+	}
+
+	t.Run("public upstreams", run([]string{"public", "public"}, http.Header{"Cache-Control": {"public"}}))
+	t.Run("shared caches", run([]string{"public, s-maxage=300, must-revalidate", "public, max-age=60"},
+		http.Header{"Cache-Control": {"public, must-revalidate, max-age=60, s-maxage=60"}}))
+	t.Run("empty merge result", run([]string{"immutable", "immutable"}, http.Header{}))
+}
+
+func TestHeaderRules_applyResponseRules_ContextLiteral(t *testing.T) {
+	headerRules := &HeaderRules{
+		Response: []ResponseHeaderRule{
+			{Name: "Set-Cookie"},
+		},
+	}
+	require.NoError(t, headerRules.Validate())
+
+	ctx := &Context{
+		Context:        context.Background(),
+		ResponseHeader: http.Header{},
+	}
+	require.NoError(t, headerRules.applyResponseRules(ctx, http.Header{
+		"Set-Cookie": {"a=1"},
+	}))
+	assert.Equal(t, http.Header{"Set-Cookie": {"a=1"}}, ctx.ResponseHeader)
+}
+
+type headerDataSource struct {
+	inputs [][]byte
+}
+
+func (h *headerDataSource) Load(ctx context.Context, input []byte, w io.Writer) (err error) {
+	h.inputs = append(h.inputs, input)
+	SetUpstreamResponseHeader(ctx, http.Header{
+		"Set-Cookie":   {"session=1"},
+		"Content-Type": {"application/json"},
+	})
+	_, err = w.Write([]byte(`{"name":"Jens"}`))
+	return
+}
+
+func TestResolver_ResolveGraphQLResponse_WithHeaderRules(t *testing.T) {
+	rCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for _, singleFlight := range []bool{false, true} {
+		r := newResolver(rCtx, singleFlight, false)
+		dataSource := &headerDataSource{}
+
+		response := &GraphQLResponse{
+			Data: &Object{
+				Fetch: &SingleFetch{
+					BufferId: 0,
+					InputTemplate: InputTemplate{
+						Segments: []TemplateSegment{
+							{
+								Data:        []byte(`{"url":"https://example.com","header":{"Static":["value"]}}`),
+								SegmentType: StaticSegmentType,
+							},
+						},
+					},
+					DataSource: dataSource,
+					HeaderRules: &HeaderRules{
+						Request: []RequestHeaderRule{
+							{Kind: RequestHeaderRuleKindPropagate, Name: "Authorization"},
+						},
+						Response: []ResponseHeaderRule{
+							{Name: "Set-Cookie"},
+						},
+					},
+				},
+				Fields: []*Field{
+					{
+						BufferID:  0,
+						HasBuffer: true,
+						Name:      []byte("name"),
+						Value: &String{
+							Path: []string{"name"},
+						},
+					},
+				},
+			},
+		}
+
+		ctx := NewContext(context.Background())
+		ctx.Request.Header = http.Header{"Authorization": {"Bearer 123"}}
+		ctx.ResponseHeader = http.Header{}
+		buf := &bytes.Buffer{}
+		err := r.ResolveGraphQLResponse(ctx, response, nil, buf)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"data":{"name":"Jens"}}`, buf.String())
+		require.Len(t, dataSource.inputs, 1)
+		assert.JSONEq(t, `{"url":"https://example.com","header":{"Static":["value"],"Authorization":["Bearer 123"]}}`, string(dataSource.inputs[0]))
+		assert.Equal(t, http.Header{"Set-Cookie": {"session=1"}}, ctx.ResponseHeader)
+	}
+}
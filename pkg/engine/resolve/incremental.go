@@ -0,0 +1,336 @@
+package resolve
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/buger/jsonparser"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/literal"
+	"github.com/wundergraph/graphql-go-tools/pkg/pool"
+)
+
+// ResolveGraphQLIncrementalResponse resolves a streaming response (@defer, @stream) using the incremental delivery format
+// The initial payload contains "data" and "hasNext", all subsequent payloads contain a list of "incremental" results and "hasNext"
+// Each payload is terminated by a call to writer.Flush, so that transports (e.g. multipart/mixed) are able to delimit them
+func (r *Resolver) ResolveGraphQLIncrementalResponse(ctx *Context, response *GraphQLStreamingResponse, data []byte, writer FlushWriter) (err error) {
+
+	if err := r.validateContext(ctx); err != nil {
+		return err
+	}
+
+	initial := pool.BytesBuffer.Get()
+	defer pool.BytesBuffer.Put(initial)
+
+	err = r.ResolveGraphQLResponse(ctx, response.InitialResponse, data, initial)
+	if err != nil {
+		return err
+	}
+
+	hasNext := ctx.hasPendingPatches()
+
+	// the initial response is a JSON object, hasNext gets added as the last field
+	initialResponse := initial.Bytes()
+	err = writeSafe(err, writer, initialResponse[:len(initialResponse)-1])
+	err = writeSafe(err, writer, comma)
+	err = writeHasNext(err, writer, hasNext)
+	err = writeSafe(err, writer, rBrace)
+	if err != nil {
+		return err
+	}
+	writer.Flush()
+
+	nextFlush := time.Now().Add(time.Millisecond * time.Duration(response.FlushInterval))
+
+	incremental := pool.BytesBuffer.Get()
+	defer pool.BytesBuffer.Put(incremental)
+
+	done := ctx.Context.Done()
+
+	for hasNext {
+		select {
+		case <-done:
+			return
+		default:
+		}
+
+		next, _ := ctx.popNextPatch()
+		if next.index < len(response.Patches) {
+			err = r.resolveIncrementalPatch(ctx, response.Patches[next.index], next, incremental)
+			if err != nil {
+				return err
+			}
+		}
+
+		hasNext = ctx.hasPendingPatches()
+		if hasNext && time.Now().Before(nextFlush) {
+			continue
+		}
+
+		err = writeIncrementalPayload(writer, incremental.Bytes(), hasNext)
+		if err != nil {
+			return err
+		}
+		writer.Flush()
+		incremental.Reset()
+		nextFlush = time.Now().Add(time.Millisecond * time.Duration(response.FlushInterval))
+	}
+
+	return nil
+}
+
+// resolveIncrementalPatch appends a single incremental result to the list of results of the next payload
+// Deferred fields are written as {"data":{"field":value},"path":[...]}, streamed items as {"items":[value],"path":[...,index]}
+// The fields of a deferred fragment are written together as {"data":{"a":value,"b":value},"path":[...]}
+func (r *Resolver) resolveIncrementalPatch(ctx *Context, patch *GraphQLResponsePatch, next patch, writer *bytes.Buffer) (err error) {
+
+	buf := r.getBufPair()
+	defer r.freeBufPair(buf)
+
+	ctx.pathPrefix = append(next.path, next.extraPath...)
+	if patch.DeferredFragment {
+		// the path points to the first field of the fragment, the fields are resolved relative to the enclosing object
+		ctx.pathPrefix = ctx.pathPrefix[:bytes.LastIndexByte(ctx.pathPrefix, '/')]
+	}
+	pathElements := incrementalPathElements(ctx.pathPrefix)
+	if len(pathElements) == 0 && !patch.DeferredFragment {
+		return nil
+	}
+
+	if patch.DeferredFragment {
+		err = r.resolveDeferredFragment(ctx, patch, next, buf)
+	} else {
+		var data []byte
+		data, err = r.resolvePatchFetch(ctx, patch, next.data, buf)
+		if err != nil {
+			return err
+		}
+		err = r.resolveNode(ctx, patch.Value, data, buf)
+	}
+	if err != nil {
+		if !errors.Is(err, errNonNullableFieldValueIsNull) {
+			return err
+		}
+		buf.Data.Reset()
+	}
+	value := buf.Data.Bytes()
+	if len(value) == 0 {
+		value = null
+	}
+
+	if writer.Len() != 0 {
+		writer.Write(comma)
+	}
+	writer.Write(lBrace)
+	switch {
+	case bytes.Equal(patch.Operation, literal.ADD):
+		writeQuotedKey(writer, literal.ITEMS)
+		writer.Write(lBrack)
+		writer.Write(value)
+		writer.Write(rBrack)
+	case patch.DeferredFragment:
+		writeQuotedKey(writer, literalData)
+		writer.Write(value)
+	default:
+		// a deferred field is delivered as an object containing the field, the path points to the enclosing object
+		writeQuotedKey(writer, literalData)
+		writer.Write(lBrace)
+		writeQuotedKey(writer, pathElements[len(pathElements)-1])
+		writer.Write(value)
+		writer.Write(rBrace)
+		pathElements = pathElements[:len(pathElements)-1]
+	}
+	writer.Write(comma)
+	writeQuotedKey(writer, literal.PATH)
+	writeIncrementalPath(writer, pathElements)
+	if len(patch.Label) != 0 {
+		writer.Write(comma)
+		writeQuotedKey(writer, literal.LABEL)
+		writeJSONString(writer, patch.Label)
+	}
+	if buf.Errors.Len() != 0 {
+		writer.Write(comma)
+		writeQuotedKey(writer, literalErrors)
+		writer.Write(lBrack)
+		writer.Write(buf.Errors.Bytes())
+		writer.Write(rBrack)
+	}
+	writer.Write(rBrace)
+
+	return nil
+}
+
+// ifArgumentTrue evaluates the "if" argument of @defer and @stream
+// The argument defaults to true, so a directive without a variable or a missing variable enables incremental delivery.
+func ifArgumentTrue(ctx *Context, variableName string) bool {
+	if variableName == "" {
+		return true
+	}
+	value, err := jsonparser.GetBoolean(ctx.Variables, variableName)
+	return err != nil || value
+}
+
+func containsDeferredFragment(fragments []*DeferredFragment, fragment *DeferredFragment) bool {
+	for i := range fragments {
+		if fragments[i] == fragment {
+			return true
+		}
+	}
+	return false
+}
+
+// prepareFragmentPatch prepares the patch of a deferred fragment
+// The fields are resolved later on, so the patch keeps the buffers of the object used by the fields besides the data of the object.
+func (r *Resolver) prepareFragmentPatch(ctx *Context, object *Object, fragment *DeferredFragment, data []byte, set *resultSet) {
+	r.preparePatch(ctx, fragment.PatchIndex, nil, data)
+	if set == nil {
+		return
+	}
+	prepared := &ctx.patches[len(ctx.patches)-1]
+	for i := range object.Fields {
+		if object.Fields[i].DeferredFragment != fragment || !object.Fields[i].HasBuffer || containsPatchBuffer(prepared.buffers, object.Fields[i].BufferID) {
+			continue
+		}
+		buffer, ok := set.buffers[object.Fields[i].BufferID]
+		if !ok {
+			continue
+		}
+		out := pool.BytesBuffer.Get()
+		ctx.usedBuffers = append(ctx.usedBuffers, out)
+		_, _ = out.Write(buffer.Data.Bytes())
+		prepared.buffers = append(prepared.buffers, patchBuffer{
+			id:   object.Fields[i].BufferID,
+			data: out.Bytes(),
+		})
+	}
+}
+
+func containsPatchBuffer(buffers []patchBuffer, id int) bool {
+	for i := range buffers {
+		if buffers[i].id == id {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveDeferredFragment resolves the fields of a deferred fragment with the data and the buffers of the enclosing object
+// If the fetch of the fields has been moved into the patch, it gets loaded first.
+func (r *Resolver) resolveDeferredFragment(ctx *Context, patch *GraphQLResponsePatch, next patch, buf *BufPair) error {
+	object, ok := patch.Value.(*Object)
+	if !ok {
+		return r.resolveNode(ctx, patch.Value, next.data, buf)
+	}
+
+	set := r.getResultSet()
+	defer r.freeResultSet(set)
+	for _, buffer := range next.buffers {
+		bufPair := r.getBufPair()
+		bufPair.Data.WriteBytes(buffer.data)
+		set.buffers[buffer.id] = bufPair
+	}
+
+	if singleFetch, ok := patch.Fetch.(*SingleFetch); ok {
+		err := r.resolveFetch(ctx, singleFetch, next.data, set)
+		if err != nil {
+			return err
+		}
+		result := set.buffers[singleFetch.BufferId]
+		r.MergeBufPairErrors(result, buf)
+		if singleFetch.ProcessResponseConfig.ExtractFederationEntities {
+			// a deferred entity fetch loads the representation of exactly one entity
+			if entity, _, _, err := jsonparser.Get(result.Data.Bytes(), "[0]"); err == nil {
+				entity = append([]byte(nil), entity...)
+				result.Data.Reset()
+				result.Data.WriteBytes(entity)
+			}
+		}
+	}
+
+	return r.resolveObjectFields(ctx, object, next.data, set, buf)
+}
+
+func (c *Context) hasPendingPatches() bool {
+	return c.currentPatch < c.maxPatch
+}
+
+// incrementalPathElements splits a patch path, e.g. /data/users/0/name, into its elements without the data prefix
+func incrementalPathElements(path []byte) [][]byte {
+	elements := bytes.Split(bytes.TrimPrefix(path, literal.SLASH), literal.SLASH)
+	if len(elements) != 0 && bytes.Equal(elements[0], literal.DATA) {
+		elements = elements[1:]
+	}
+	return elements
+}
+
+func writeIncrementalPath(writer *bytes.Buffer, elements [][]byte) {
+	writer.Write(lBrack)
+	for i := range elements {
+		if i != 0 {
+			writer.Write(comma)
+		}
+		if isArrayIndex(elements[i]) {
+			writer.Write(elements[i])
+			continue
+		}
+		writer.Write(quote)
+		writer.Write(elements[i])
+		writer.Write(quote)
+	}
+	writer.Write(rBrack)
+}
+
+func isArrayIndex(element []byte) bool {
+	if len(element) == 0 {
+		return false
+	}
+	for _, b := range element {
+		if b < '0' || b > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func writeQuotedKey(writer *bytes.Buffer, key []byte) {
+	writer.Write(quote)
+	writer.Write(key)
+	writer.Write(quote)
+	writer.Write(colon)
+}
+
+// writeJSONString writes value as an escaped JSON string, e.g. a label containing quotes
+func writeJSONString(writer *bytes.Buffer, value []byte) {
+	escaped, _ := json.Marshal(string(value))
+	writer.Write(escaped)
+}
+
+func writeHasNext(err error, writer io.Writer, hasNext bool) error {
+	err = writeSafe(err, writer, quote)
+	err = writeSafe(err, writer, literal.HAS_NEXT)
+	err = writeSafe(err, writer, quote)
+	err = writeSafe(err, writer, colon)
+	if hasNext {
+		return writeSafe(err, writer, literal.TRUE)
+	}
+	return writeSafe(err, writer, literal.FALSE)
+}
+
+func writeIncrementalPayload(writer io.Writer, incremental []byte, hasNext bool) (err error) {
+	err = writeSafe(err, writer, lBrace)
+	if len(incremental) != 0 {
+		err = writeSafe(err, writer, quote)
+		err = writeSafe(err, writer, literal.INCREMENTAL)
+		err = writeSafe(err, writer, quote)
+		err = writeSafe(err, writer, colon)
+		err = writeSafe(err, writer, lBrack)
+		err = writeSafe(err, writer, incremental)
+		err = writeSafe(err, writer, rBrack)
+		err = writeSafe(err, writer, comma)
+	}
+	err = writeHasNext(err, writer, hasNext)
+	return writeSafe(err, writer, rBrace)
+}
@@ -0,0 +1,439 @@
+package resolve
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/literal"
+)
+
+func TestResolver_ResolveGraphQLIncrementalResponse(t *testing.T) {
+	rCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	friend := &Object{
+		Fields: []*Field{
+			{
+				Name: []byte("name"),
+				Value: &String{
+					Path: []string{"name"},
+				},
+			},
+		},
+	}
+
+	run := func(response *GraphQLStreamingResponse, expectedPayloads ...string) func(t *testing.T) {
+		return func(t *testing.T) {
+			r := newResolver(rCtx, false, false)
+			ctx := NewContext(context.Background())
+			writer := &TestFlushWriter{}
+			err := r.ResolveGraphQLIncrementalResponse(ctx, response, nil, writer)
+			assert.NoError(t, err)
+			assert.Equal(t, expectedPayloads, writer.flushed)
+		}
+	}
+
+	t.Run("defer and stream", run(&GraphQLStreamingResponse{
+		InitialResponse: &GraphQLResponse{
+			Data: &Object{
+				Fetch: &SingleFetch{
+					BufferId:   0,
+					DataSource: FakeDataSource(`{"user":{"name":"Jens","bio":"developer","friends":[{"name":"a"},{"name":"b"},{"name":"c"}]}}`),
+				},
+				Fields: []*Field{
+					{
+						BufferID:  0,
+						HasBuffer: true,
+						Name:      []byte("user"),
+						Value: &Object{
+							Path: []string{"user"},
+							Fields: []*Field{
+								{
+									Name: []byte("name"),
+									Value: &String{
+										Path: []string{"name"},
+									},
+								},
+								{
+									Name: []byte("bio"),
+									Value: &Null{
+										Defer: Defer{
+											Enabled:    true,
+											PatchIndex: 0,
+										},
+									},
+								},
+								{
+									Name: []byte("friends"),
+									Value: &Array{
+										Path: []string{"friends"},
+										Item: friend,
+										Stream: Stream{
+											Enabled:          true,
+											InitialBatchSize: 1,
+											PatchIndex:       1,
+											Label:            []byte("friends"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Patches: []*GraphQLResponsePatch{
+			{
+				Operation: literal.REPLACE,
+				Value: &String{
+					Path: []string{"bio"},
+				},
+				Label: []byte("bio"),
+			},
+			{
+				Operation: literal.ADD,
+				Value:     friend,
+				Label:     []byte("friends"),
+			},
+		},
+	},
+		`{"data":{"user":{"name":"Jens","bio":null,"friends":[{"name":"a"}]}},"hasNext":true}`,
+		`{"incremental":[{"data":{"bio":"developer"},"path":["user"],"label":"bio"}],"hasNext":true}`,
+		`{"incremental":[{"items":[{"name":"b"}],"path":["user","friends",1],"label":"friends"}],"hasNext":true}`,
+		`{"incremental":[{"items":[{"name":"c"}],"path":["user","friends",2],"label":"friends"}],"hasNext":false}`,
+	))
+
+	t.Run("flush interval batches incremental results", run(&GraphQLStreamingResponse{
+		FlushInterval: 1000,
+		InitialResponse: &GraphQLResponse{
+			Data: &Object{
+				Fetch: &SingleFetch{
+					BufferId:   0,
+					DataSource: FakeDataSource(`{"friends":[{"name":"a"},{"name":"b"}]}`),
+				},
+				Fields: []*Field{
+					{
+						BufferID:  0,
+						HasBuffer: true,
+						Name:      []byte("friends"),
+						Value: &Array{
+							Path: []string{"friends"},
+							Item: friend,
+							Stream: Stream{
+								Enabled:    true,
+								PatchIndex: 0,
+							},
+						},
+					},
+				},
+			},
+		},
+		Patches: []*GraphQLResponsePatch{
+			{
+				Operation: literal.ADD,
+				Value:     friend,
+			},
+		},
+	},
+		`{"data":{"friends":[]},"hasNext":true}`,
+		`{"incremental":[{"items":[{"name":"a"}],"path":["friends",0]},{"items":[{"name":"b"}],"path":["friends",1]}],"hasNext":false}`,
+	))
+
+	t.Run("label is escaped", run(&GraphQLStreamingResponse{
+		InitialResponse: &GraphQLResponse{
+			Data: &Object{
+				Fetch: &SingleFetch{
+					BufferId:   0,
+					DataSource: FakeDataSource(`{"friends":[{"name":"a"}]}`),
+				},
+				Fields: []*Field{
+					{
+						BufferID:  0,
+						HasBuffer: true,
+						Name:      []byte("friends"),
+						Value: &Array{
+							Path: []string{"friends"},
+							Item: friend,
+							Stream: Stream{
+								Enabled:    true,
+								PatchIndex: 0,
+							},
+						},
+					},
+				},
+			},
+		},
+		Patches: []*GraphQLResponsePatch{
+			{
+				Operation: literal.ADD,
+				Value:     friend,
+				Label:     []byte(`"quoted" \ label`),
+			},
+		},
+	},
+		`{"data":{"friends":[]},"hasNext":true}`,
+		`{"incremental":[{"items":[{"name":"a"}],"path":["friends",0],"label":"\"quoted\" \\ label"}],"hasNext":false}`,
+	))
+
+	t.Run("deferred fragment", func(t *testing.T) {
+		fragment := &DeferredFragment{PatchIndex: 0}
+		run(&GraphQLStreamingResponse{
+			InitialResponse: &GraphQLResponse{
+				Data: &Object{
+					Fetch: &SingleFetch{
+						BufferId:   0,
+						DataSource: FakeDataSource(`{"user":{"id":1,"name":"Jens"}}`),
+					},
+					Fields: []*Field{
+						{
+							BufferID:  0,
+							HasBuffer: true,
+							Name:      []byte("user"),
+							Value: &Object{
+								Path: []string{"user"},
+								Fetch: &SingleFetch{
+									BufferId:   1,
+									DataSource: FakeDataSource(`{"bio":"developer"}`),
+								},
+								Fields: []*Field{
+									{
+										Name: []byte("id"),
+										Value: &Integer{
+											Path: []string{"id"},
+										},
+									},
+									{
+										Name:             []byte("name"),
+										DeferredFragment: fragment,
+										Value: &String{
+											Path: []string{"name"},
+										},
+									},
+									{
+										Name:             []byte("bio"),
+										HasBuffer:        true,
+										BufferID:         1,
+										DeferredFragment: fragment,
+										Value: &String{
+											Path: []string{"bio"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Patches: []*GraphQLResponsePatch{
+				{
+					Operation:        literal.REPLACE,
+					DeferredFragment: true,
+					Label:            []byte("details"),
+					Value: &Object{
+						Nullable: true,
+						Fields: []*Field{
+							{
+								Name: []byte("name"),
+								Value: &String{
+									Path: []string{"name"},
+								},
+							},
+							{
+								Name:      []byte("bio"),
+								HasBuffer: true,
+								BufferID:  1,
+								Value: &String{
+									Path: []string{"bio"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+			`{"data":{"user":{"id":1}},"hasNext":true}`,
+			`{"incremental":[{"data":{"name":"Jens","bio":"developer"},"path":["user"],"label":"details"}],"hasNext":false}`,
+		)(t)
+	})
+
+	t.Run("deferred fragment with all fields non-nullable", func(t *testing.T) {
+		fragment := &DeferredFragment{PatchIndex: 0}
+		run(&GraphQLStreamingResponse{
+			InitialResponse: &GraphQLResponse{
+				Data: &Object{
+					Fetch: &SingleFetch{
+						BufferId:   0,
+						DataSource: FakeDataSource(`{"user":{"name":"Jens"}}`),
+					},
+					Fields: []*Field{
+						{
+							BufferID:  0,
+							HasBuffer: true,
+							Name:      []byte("user"),
+							Value: &Object{
+								Path: []string{"user"},
+								Fields: []*Field{
+									{
+										Name:             []byte("name"),
+										DeferredFragment: fragment,
+										Value: &String{
+											Path: []string{"name"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Patches: []*GraphQLResponsePatch{
+				{
+					Operation:        literal.REPLACE,
+					DeferredFragment: true,
+					Value: &Object{
+						Fields: []*Field{
+							{
+								Name: []byte("name"),
+								Value: &String{
+									Path: []string{"name"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+			`{"data":{"user":{}},"hasNext":true}`,
+			`{"incremental":[{"data":{"name":"Jens"},"path":["user"]}],"hasNext":false}`,
+		)(t)
+	})
+
+	t.Run("variable if arguments", func(t *testing.T) {
+		fragment := &DeferredFragment{PatchIndex: 0, IfVariableName: "defer"}
+		response := &GraphQLStreamingResponse{
+			InitialResponse: &GraphQLResponse{
+				Data: &Object{
+					Fetch: &SingleFetch{
+						BufferId:   0,
+						DataSource: FakeDataSource(`{"user":{"name":"Jens","bio":"developer","friends":[{"name":"a"},{"name":"b"}]}}`),
+					},
+					Fields: []*Field{
+						{
+							BufferID:  0,
+							HasBuffer: true,
+							Name:      []byte("user"),
+							Value: &Object{
+								Path: []string{"user"},
+								Fields: []*Field{
+									{
+										Name:             []byte("name"),
+										DeferredFragment: fragment,
+										Value: &String{
+											Path: []string{"name"},
+										},
+									},
+									{
+										Name:             []byte("bio"),
+										DeferredFragment: fragment,
+										Value: &String{
+											Path: []string{"bio"},
+										},
+									},
+									{
+										Name: []byte("friends"),
+										Value: &Array{
+											Path: []string{"friends"},
+											Item: friend,
+											Stream: Stream{
+												Enabled:        true,
+												PatchIndex:     1,
+												IfVariableName: "stream",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Patches: []*GraphQLResponsePatch{
+				{
+					Operation:        literal.REPLACE,
+					DeferredFragment: true,
+					Value: &Object{
+						Nullable: true,
+						Fields: []*Field{
+							{
+								Name: []byte("name"),
+								Value: &String{
+									Path: []string{"name"},
+								},
+							},
+							{
+								Name: []byte("bio"),
+								Value: &String{
+									Path: []string{"bio"},
+								},
+							},
+						},
+					},
+				},
+				{
+					Operation: literal.ADD,
+					Value:     friend,
+				},
+			},
+		}
+
+		resolveWithVariables := func(t *testing.T, variables string) []string {
+			r := newResolver(rCtx, false, false)
+			ctx := NewContext(context.Background())
+			ctx.Variables = []byte(variables)
+			writer := &TestFlushWriter{}
+			err := r.ResolveGraphQLIncrementalResponse(ctx, response, nil, writer)
+			assert.NoError(t, err)
+			return writer.flushed
+		}
+
+		assert.Equal(t, []string{
+			`{"data":{"user":{"name":"Jens","bio":"developer","friends":[{"name":"a"},{"name":"b"}]}},"hasNext":false}`,
+		}, resolveWithVariables(t, `{"defer":false,"stream":false}`))
+
+		assert.Equal(t, []string{
+			`{"data":{"user":{"friends":[{"name":"a"},{"name":"b"}]}},"hasNext":true}`,
+			`{"incremental":[{"data":{"name":"Jens","bio":"developer"},"path":["user"]}],"hasNext":false}`,
+		}, resolveWithVariables(t, `{"defer":true,"stream":false}`))
+
+		assert.Equal(t, []string{
+			`{"data":{"user":{"name":"Jens","bio":"developer","friends":[]}},"hasNext":true}`,
+			`{"incremental":[{"items":[{"name":"a"}],"path":["user","friends",0]}],"hasNext":true}`,
+			`{"incremental":[{"items":[{"name":"b"}],"path":["user","friends",1]}],"hasNext":false}`,
+		}, resolveWithVariables(t, `{"defer":false,"stream":true}`))
+	})
+
+	t.Run("without patches", run(&GraphQLStreamingResponse{
+		InitialResponse: &GraphQLResponse{
+			Data: &Object{
+				Fetch: &SingleFetch{
+					BufferId:   0,
+					DataSource: FakeDataSource(`{"name":"Jens"}`),
+				},
+				Fields: []*Field{
+					{
+						BufferID:  0,
+						HasBuffer: true,
+						Name:      []byte("name"),
+						Value: &String{
+							Path: []string{"name"},
+						},
+					},
+				},
+			},
+		},
+	},
+		`{"data":{"name":"Jens"},"hasNext":false}`,
+	))
+}
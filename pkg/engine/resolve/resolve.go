@@ -5,6 +5,7 @@ package resolve
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -20,6 +21,7 @@ import (
 	"github.com/wundergraph/graphql-go-tools/pkg/fastbuffer"
 	"github.com/wundergraph/graphql-go-tools/pkg/lexer/literal"
 	"github.com/wundergraph/graphql-go-tools/pkg/pool"
+	"github.com/wundergraph/graphql-go-tools/pkg/scalars"
 )
 
 var (
@@ -94,6 +96,7 @@ const (
 	NodeKindBoolean
 	NodeKindInteger
 	NodeKindFloat
+	NodeKindCustomScalar
 
 	FetchKindSingle FetchKind = iota + 1
 	FetchKindParallel
@@ -115,8 +118,11 @@ type AfterFetchHook interface {
 
 type Context struct {
 	context.Context
-	Variables        []byte
-	Request          Request
+	Variables []byte
+	Request   Request
+	// ResponseHeader receives the upstream response headers selected by the HeaderRules of the fetches, it's optional
+	ResponseHeader   http.Header
+	responseHeaderMu *sync.Mutex
 	pathElements     [][]byte
 	responseElements []string
 	lastFetchID      int
@@ -148,6 +154,8 @@ func NewContext(ctx context.Context) *Context {
 		maxPatch:     -1,
 		position:     Position{},
 		dataLoader:   nil,
+
+		responseHeaderMu: &sync.Mutex{},
 	}
 }
 
@@ -172,20 +180,28 @@ func (c *Context) Clone() Context {
 		copy(patches[i].path, c.patches[i].path)
 		copy(patches[i].extraPath, c.patches[i].extraPath)
 		copy(patches[i].data, c.patches[i].data)
+		for _, buffer := range c.patches[i].buffers {
+			patches[i].buffers = append(patches[i].buffers, patchBuffer{
+				id:   buffer.id,
+				data: append([]byte(nil), buffer.data...),
+			})
+		}
 	}
 	return Context{
-		Context:         c.Context,
-		Variables:       variables,
-		Request:         c.Request,
-		pathElements:    pathElements,
-		patches:         patches,
-		usedBuffers:     make([]*bytes.Buffer, 0, 48),
-		currentPatch:    c.currentPatch,
-		maxPatch:        c.maxPatch,
-		pathPrefix:      pathPrefix,
-		beforeFetchHook: c.beforeFetchHook,
-		afterFetchHook:  c.afterFetchHook,
-		position:        c.position,
+		Context:          c.Context,
+		Variables:        variables,
+		Request:          c.Request,
+		ResponseHeader:   c.ResponseHeader,
+		responseHeaderMu: c.responseHeaderMu,
+		pathElements:     pathElements,
+		patches:          patches,
+		usedBuffers:      make([]*bytes.Buffer, 0, 48),
+		currentPatch:     c.currentPatch,
+		maxPatch:         c.maxPatch,
+		pathPrefix:       pathPrefix,
+		beforeFetchHook:  c.beforeFetchHook,
+		afterFetchHook:   c.afterFetchHook,
+		position:         c.position,
 	}
 }
 
@@ -204,6 +220,7 @@ func (c *Context) Free() {
 	c.beforeFetchHook = nil
 	c.afterFetchHook = nil
 	c.Request.Header = nil
+	c.ResponseHeader = nil
 	c.position = Position{}
 	c.dataLoader = nil
 	c.RenameTypeNames = nil
@@ -290,6 +307,13 @@ func (c *Context) popNextPatch() (patch patch, ok bool) {
 type patch struct {
 	path, extraPath, data []byte
 	index                 int
+	// buffers are the results of the fetches of the enclosing object used by the fields of a deferred fragment
+	buffers []patchBuffer
+}
+
+type patchBuffer struct {
+	id   int
+	data []byte
 }
 
 type Fetch interface {
@@ -334,6 +358,7 @@ type inflightFetch struct {
 	waitFree sync.WaitGroup
 	err      error
 	bufPair  BufPair
+	header   http.Header
 }
 
 // New returns a new Resolver, ctx.Done() is used to cancel all active subscriptions & streams
@@ -409,6 +434,8 @@ func (r *Resolver) resolveNode(ctx *Context, node Node, data []byte, bufPair *Bu
 		return r.resolveInteger(ctx, n, data, bufPair)
 	case *Float:
 		return r.resolveFloat(ctx, n, data, bufPair)
+	case *CustomScalar:
+		return r.resolveCustomScalar(ctx, n, data, bufPair)
 	case *EmptyObject:
 		r.resolveEmptyObject(bufPair.Data)
 		return
@@ -636,18 +663,9 @@ func (r *Resolver) ResolveGraphQLResponsePatch(ctx *Context, patch *GraphQLRespo
 
 	ctx.pathPrefix = append(path, extraPath...)
 
-	if patch.Fetch != nil {
-		set := r.getResultSet()
-		defer r.freeResultSet(set)
-		err = r.resolveFetch(ctx, patch.Fetch, data, set)
-		if err != nil {
-			return err
-		}
-		_, ok := set.buffers[0]
-		if ok {
-			r.MergeBufPairErrors(set.buffers[0], buf)
-			data = set.buffers[0].Data.Bytes()
-		}
+	data, err = r.resolvePatchFetch(ctx, patch, data, buf)
+	if err != nil {
+		return err
 	}
 
 	err = r.resolveNode(ctx, patch.Value, data, buf)
@@ -694,6 +712,36 @@ func (r *Resolver) ResolveGraphQLResponsePatch(ctx *Context, patch *GraphQLRespo
 	return
 }
 
+// resolvePatchFetch loads the data of a patch if the patch has its own fetch, otherwise data is returned as is
+func (r *Resolver) resolvePatchFetch(ctx *Context, patch *GraphQLResponsePatch, data []byte, buf *BufPair) ([]byte, error) {
+	if patch.Fetch == nil {
+		return data, nil
+	}
+	set := r.getResultSet()
+	defer r.freeResultSet(set)
+	err := r.resolveFetch(ctx, patch.Fetch, data, set)
+	if err != nil {
+		return nil, err
+	}
+	result, ok := set.buffers[0]
+	if !ok {
+		return data, nil
+	}
+	r.MergeBufPairErrors(result, buf)
+	// the data of the result set gets freed, so it has to be copied
+	out := pool.BytesBuffer.Get()
+	ctx.usedBuffers = append(ctx.usedBuffers, out)
+	fetched := result.Data.Bytes()
+	if singleFetch, ok := patch.Fetch.(*SingleFetch); ok && singleFetch.ProcessResponseConfig.ExtractFederationEntities {
+		// a deferred entity fetch loads the representation of exactly one entity
+		if entity, _, _, err := jsonparser.Get(fetched, "[0]"); err == nil {
+			fetched = entity
+		}
+	}
+	_, _ = out.Write(fetched)
+	return out.Bytes(), nil
+}
+
 func (r *Resolver) resolveEmptyArray(b *fastbuffer.FastBuffer) {
 	b.WriteBytes(lBrack)
 	b.WriteBytes(rBrack)
@@ -744,13 +792,15 @@ func (r *Resolver) resolveArray(ctx *Context, array *Array, data []byte, arrayBu
 	ctx.addResponseArrayElements(array.Path)
 	defer func() { ctx.removeResponseArrayLastElements(array.Path) }()
 
-	if array.ResolveAsynchronous && !array.Stream.Enabled && !r.dataLoaderEnabled {
+	stream := array.Stream.Enabled && ifArgumentTrue(ctx, array.Stream.IfVariableName)
+
+	if array.ResolveAsynchronous && !stream && !r.dataLoaderEnabled {
 		return r.resolveArrayAsynchronous(ctx, array, arrayItems, arrayBuf)
 	}
-	return r.resolveArraySynchronous(ctx, array, arrayItems, arrayBuf)
+	return r.resolveArraySynchronous(ctx, array, stream, arrayItems, arrayBuf)
 }
 
-func (r *Resolver) resolveArraySynchronous(ctx *Context, array *Array, arrayItems *[][]byte, arrayBuf *BufPair) (err error) {
+func (r *Resolver) resolveArraySynchronous(ctx *Context, array *Array, stream bool, arrayItems *[][]byte, arrayBuf *BufPair) (err error) {
 
 	itemBuf := r.getBufPair()
 	defer r.freeBufPair(itemBuf)
@@ -762,7 +812,7 @@ func (r *Resolver) resolveArraySynchronous(ctx *Context, array *Array, arrayItem
 	)
 	for i := range *arrayItems {
 
-		if array.Stream.Enabled {
+		if stream {
 			if i > array.Stream.InitialBatchSize-1 {
 				ctx.addIntegerPathElement(i)
 				r.preparePatch(ctx, array.Stream.PatchIndex, nil, (*arrayItems)[i])
@@ -900,6 +950,31 @@ func (r *Resolver) resolveFloat(ctx *Context, floatValue *Float, data []byte, fl
 	return nil
 }
 
+func (r *Resolver) resolveCustomScalar(ctx *Context, customScalar *CustomScalar, data []byte, scalarBuf *BufPair) error {
+	value, dataType, _, err := jsonparser.Get(data, customScalar.Path...)
+	if err == nil && dataType != jsonparser.Null {
+		value, err = customScalar.Scalar.Serialize(value, dataType)
+		if err != nil {
+			// the value is invalid, unlike a missing value this is reported to the client
+			r.addError(ctx, scalarBuf, escapeErrorMessage(err.Error()))
+		}
+	}
+	if err != nil || dataType == jsonparser.Null {
+		if !customScalar.Nullable {
+			return errNonNullableFieldValueIsNull
+		}
+		r.resolveNull(scalarBuf.Data)
+		return nil
+	}
+	scalarBuf.Data.WriteBytes(value)
+	if dataType == jsonparser.String && len(value) > 1 && value[0] == '"' {
+		// exports of strings expect the value without quotes, see FieldExport.AsString
+		value = value[1 : len(value)-1]
+	}
+	r.exportField(ctx, customScalar.Export, value)
+	return nil
+}
+
 func (r *Resolver) resolveBoolean(ctx *Context, boolean *Boolean, data []byte, booleanBuf *BufPair) error {
 	value, valueType, _, err := jsonparser.Get(data, boolean.Path...)
 	if err != nil || valueType != jsonparser.Boolean {
@@ -982,6 +1057,12 @@ func (r *Resolver) resolveNull(b *fastbuffer.FastBuffer) {
 }
 
 func (r *Resolver) addResolveError(ctx *Context, objectBuf *BufPair) {
+	r.addError(ctx, objectBuf, unableToResolveMsg)
+}
+
+// addError adds an error with the location and the path of the field being resolved
+// The message must be escaped, see escapeErrorMessage.
+func (r *Resolver) addError(ctx *Context, objectBuf *BufPair, message []byte) {
 	locations, path := pool.BytesBuffer.Get(), pool.BytesBuffer.Get()
 	defer pool.BytesBuffer.Put(locations)
 	defer pool.BytesBuffer.Put(path)
@@ -1014,7 +1095,13 @@ func (r *Resolver) addResolveError(ctx *Context, objectBuf *BufPair) {
 		pathBytes = path.Bytes()
 	}
 
-	objectBuf.WriteErr(unableToResolveMsg, locations.Bytes(), pathBytes, nil)
+	objectBuf.WriteErr(message, locations.Bytes(), pathBytes, nil)
+}
+
+// escapeErrorMessage escapes message to be written as a JSON string by BufPair.WriteErr
+func escapeErrorMessage(message string) []byte {
+	escaped, _ := json.Marshal(message)
+	return escaped[1 : len(escaped)-1]
 }
 
 func (r *Resolver) resolveObject(ctx *Context, object *Object, data []byte, objectBuf *BufPair) (err error) {
@@ -1052,6 +1139,11 @@ func (r *Resolver) resolveObject(ctx *Context, object *Object, data []byte, obje
 		}
 	}
 
+	return r.resolveObjectFields(ctx, object, data, set, objectBuf)
+}
+
+// resolveObjectFields resolves the fields of an object, fields with a buffer are resolved from the buffers of the result set
+func (r *Resolver) resolveObjectFields(ctx *Context, object *Object, data []byte, set *resultSet, objectBuf *BufPair) (err error) {
 	fieldBuf := r.getBufPair()
 	defer r.freeBufPair(fieldBuf)
 
@@ -1061,6 +1153,8 @@ func (r *Resolver) resolveObject(ctx *Context, object *Object, data []byte, obje
 	typeNameSkip := false
 	first := true
 	skipCount := 0
+	hasDeferredFields := false
+	var preparedFragments []*DeferredFragment
 	for i := range object.Fields {
 
 		if object.Fields[i].SkipDirectiveDefined {
@@ -1102,6 +1196,20 @@ func (r *Resolver) resolveObject(ctx *Context, object *Object, data []byte, obje
 			}
 		}
 
+		if fragment := object.Fields[i].DeferredFragment; fragment != nil && ifArgumentTrue(ctx, fragment.IfVariableName) {
+			// the fields of a deferred fragment are omitted from the initial response
+			hasDeferredFields = true
+			if !containsDeferredFragment(preparedFragments, fragment) {
+				preparedFragments = append(preparedFragments, fragment)
+				ctx.addPathElement(object.Fields[i].Name)
+				r.prepareFragmentPatch(ctx, object, fragment, data, set)
+				ctx.removeLastPathElement()
+			}
+			ctx.responseElements = responseElements
+			ctx.lastFetchID = lastFetchID
+			continue
+		}
+
 		if first {
 			objectBuf.Data.WriteBytes(lBrace)
 			first = false
@@ -1150,6 +1258,12 @@ func (r *Resolver) resolveObject(ctx *Context, object *Object, data []byte, obje
 		objectBuf.Data.WriteBytes(rBrace)
 		return
 	}
+	if first && hasDeferredFields {
+		// all resolved fields are deferred, the fields get delivered by the patches of their fragments
+		objectBuf.Data.WriteBytes(lBrace)
+		objectBuf.Data.WriteBytes(rBrace)
+		return
+	}
 	if first {
 		if typeNameSkip && !object.Nullable {
 			return errTypeNameSkipped
@@ -1311,6 +1425,8 @@ type Field struct {
 	SkipVariableName        string
 	IncludeDirectiveDefined bool
 	IncludeVariableName     string
+	// DeferredFragment is set for the fields of a deferred fragment, the fields are omitted from the initial response
+	DeferredFragment *DeferredFragment
 }
 
 type Position struct {
@@ -1320,9 +1436,28 @@ type Position struct {
 
 type StreamField struct {
 	InitialBatchSize int
+	Label            []byte `json:",omitempty"`
+	// IfVariableName is the variable of the "if" argument, the list is streamed only if the variable is true
+	IfVariableName string `json:",omitempty"`
 }
 
-type DeferField struct{}
+type DeferField struct {
+	Label []byte `json:",omitempty"`
+	// Fragment is true if the field is part of a deferred fragment
+	// All fields of the fragment share the same DeferField and are delivered together
+	Fragment bool `json:",omitempty"`
+	// IfVariableName is the variable of the "if" argument, the field is deferred only if the variable is true
+	IfVariableName string `json:",omitempty"`
+}
+
+// DeferredFragment is shared by all fields of a deferred fragment within an Object
+// The first field of the fragment which gets resolved prepares the patch delivering all fields of the fragment.
+type DeferredFragment struct {
+	PatchIndex int
+	// IfVariableName is the variable of the "if" argument of @defer
+	// If the variable is false the fields are resolved in place, otherwise they are deferred.
+	IfVariableName string
+}
 
 type Null struct {
 	Defer Defer
@@ -1355,6 +1490,10 @@ type SingleFetch struct {
 	InputTemplate         InputTemplate
 	DataSourceIdentifier  []byte
 	ProcessResponseConfig ProcessResponseConfig
+	// Transformation is applied to the response data before resolving, it's optional
+	Transformation *Transformation `json:",omitempty"`
+	// HeaderRules propagate headers between the client request/response and the upstream, they're optional
+	HeaderRules *HeaderRules `json:",omitempty"`
 }
 
 type ProcessResponseConfig struct {
@@ -1432,6 +1571,18 @@ func (_ *Integer) NodeKind() NodeKind {
 	return NodeKindInteger
 }
 
+// CustomScalar is a value of a scalar of the scalar registry, which gets serialized by the scalar
+type CustomScalar struct {
+	Path     []string
+	Nullable bool
+	Export   *FieldExport `json:"export,omitempty"`
+	Scalar   scalars.Scalar
+}
+
+func (_ *CustomScalar) NodeKind() NodeKind {
+	return NodeKindCustomScalar
+}
+
 type Array struct {
 	Path                 []string
 	Nullable             bool
@@ -1445,6 +1596,9 @@ type Stream struct {
 	Enabled          bool
 	InitialBatchSize int
 	PatchIndex       int
+	Label            []byte `json:",omitempty"`
+	// IfVariableName is the variable of the "if" argument of @stream, all items are resolved in place if the variable is false
+	IfVariableName string `json:",omitempty"`
 }
 
 func (_ *Array) NodeKind() NodeKind {
@@ -1487,6 +1641,11 @@ type GraphQLResponsePatch struct {
 	Value     Node
 	Fetch     Fetch
 	Operation []byte
+	// Label is the label argument of the @defer or @stream directive which created the patch
+	Label []byte `json:",omitempty"`
+	// DeferredFragment is true if Value is an Object with the fields of a deferred fragment
+	// The fields are resolved relative to the enclosing object and delivered together as the data of one incremental result
+	DeferredFragment bool `json:",omitempty"`
 }
 
 type BufPair struct {
@@ -15,6 +15,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/wundergraph/graphql-go-tools/pkg/fastbuffer"
+	"github.com/wundergraph/graphql-go-tools/pkg/scalars"
 )
 
 type _fakeDataSource struct {
@@ -162,6 +163,107 @@ func TestResolver_ResolveNode(t *testing.T) {
 			},
 		}, Context{Context: context.Background()}, `{"foo":null}`
 	}))
+	t.Run("custom scalars", testFn(false, false, func(t *testing.T, ctrl *gomock.Controller) (node Node, ctx Context, expectedOutput string) {
+		return &Object{
+			Fetch: &SingleFetch{
+				BufferId:   0,
+				DataSource: FakeDataSource(`{"id":"9B2E3F6A-1C4D-4E5F-8A9B-0C1D2E3F4A5B","data":{"a":[1,true]},"count":"12345678901234567890"}`),
+			},
+			Fields: []*Field{
+				{
+					BufferID:  0,
+					HasBuffer: true,
+					Name:      []byte("id"),
+					Value: &CustomScalar{
+						Path:   []string{"id"},
+						Scalar: scalars.UUID(),
+					},
+				},
+				{
+					BufferID:  0,
+					HasBuffer: true,
+					Name:      []byte("data"),
+					Value: &CustomScalar{
+						Path:   []string{"data"},
+						Scalar: scalars.JSON(),
+					},
+				},
+				{
+					BufferID:  0,
+					HasBuffer: true,
+					Name:      []byte("count"),
+					Value: &CustomScalar{
+						Path:   []string{"count"},
+						Scalar: scalars.BigInt(),
+					},
+				},
+				{
+					BufferID:  0,
+					HasBuffer: true,
+					Name:      []byte("missing"),
+					Value: &CustomScalar{
+						Path:     []string{"missing"},
+						Nullable: true,
+						Scalar:   scalars.DateTime(),
+					},
+				},
+			},
+		}, Context{Context: context.Background()}, `{"id":"9b2e3f6a-1c4d-4e5f-8a9b-0c1d2e3f4a5b","data":{"a":[1,true]},"count":"12345678901234567890","missing":null}`
+	}))
+	t.Run("nullable custom scalar with invalid value", func(t *testing.T) {
+		c, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		r := newResolver(c, false, false)
+		node := &Object{
+			Fetch: &SingleFetch{
+				BufferId:   0,
+				DataSource: FakeDataSource(`{"createdAt":"tomorrow"}`),
+			},
+			Fields: []*Field{
+				{
+					BufferID:  0,
+					HasBuffer: true,
+					Name:      []byte("createdAt"),
+					Position: Position{
+						Line:   3,
+						Column: 5,
+					},
+					Value: &CustomScalar{
+						Path:     []string{"createdAt"},
+						Nullable: true,
+						Scalar:   scalars.DateTime(),
+					},
+				},
+			},
+		}
+		buf := &BufPair{
+			Data:   fastbuffer.New(),
+			Errors: fastbuffer.New(),
+		}
+		err := r.resolveNode(&Context{Context: context.Background()}, node, nil, buf)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"createdAt":null}`, buf.Data.String())
+		assert.Equal(t, `{"message":"DateTime cannot represent an invalid date-time string: \"tomorrow\"","locations":[{"line":3,"column":5}],"path":["createdAt"]}`, buf.Errors.String())
+	})
+	t.Run("non nullable custom scalar with invalid value", testErrFn(func(t *testing.T, r *Resolver, ctrl *gomock.Controller) (node Node, ctx Context, expectedErr string) {
+		return &Object{
+			Fetch: &SingleFetch{
+				BufferId:   0,
+				DataSource: FakeDataSource(`{"id":"1"}`),
+			},
+			Fields: []*Field{
+				{
+					BufferID:  0,
+					HasBuffer: true,
+					Name:      []byte("id"),
+					Value: &CustomScalar{
+						Path:   []string{"id"},
+						Scalar: scalars.UUID(),
+					},
+				},
+			},
+		}, Context{Context: context.Background()}, errNonNullableFieldValueIsNull.Error()
+	}))
 	t.Run("default graphql object", testFn(false, false, func(t *testing.T, ctrl *gomock.Controller) (node Node, ctx Context, expectedOutput string) {
 		return &Object{
 			Fields: []*Field{
@@ -0,0 +1,257 @@
+package resolve
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/jensneuse/pipeline/pkg/step"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/jsonpath"
+)
+
+type TransformationStepKind string
+
+const (
+	// TransformationStepKindExtract replaces the data with the value selected by Path
+	TransformationStepKindExtract TransformationStepKind = "extract"
+	// TransformationStepKindMapping builds a new object from Mappings, lists are mapped item by item
+	TransformationStepKindMapping TransformationStepKind = "mapping"
+	// TransformationStepKindTemplate renders Template with the data as input, the output must be valid JSON
+	// Templates use the text/template syntax including the sprig functions, as the Pipeline steps of the v1 executor
+	TransformationStepKindTemplate TransformationStepKind = "template"
+)
+
+// Transformation is a declarative stage to adapt the response of a DataSource to the shape of the schema
+// It runs after DataSource.Load (and ProcessResponseConfig) and before the response gets resolved
+// Steps are applied in order, each step gets the output of the previous step as input
+type Transformation struct {
+	Steps []TransformationStep
+
+	compileOnce sync.Once
+	compiled    []transformationStep
+	compileErr  error
+}
+
+type TransformationStep struct {
+	Kind TransformationStepKind
+	// Path is the JSONPath expression of an extract step, e.g. $.results[*].item
+	Path string `json:",omitempty"`
+	// Mappings define the fields of the object created by a mapping step
+	Mappings []TransformationMapping `json:",omitempty"`
+	// KeepUnmappedFields keeps all fields of the input object which are not the source of a mapping
+	// Together with single field mappings, this allows renaming fields
+	KeepUnmappedFields bool `json:",omitempty"`
+	// Template is the text/template of a template step
+	Template string `json:",omitempty"`
+}
+
+type TransformationMapping struct {
+	// From is a JSONPath expression relative to the input object
+	From string
+	// To is the dot delimited path of the field in the output object
+	To string
+}
+
+type transformationStep interface {
+	transform(data interface{}) (interface{}, error)
+}
+
+// Validate compiles all steps and returns the first configuration error
+func (t *Transformation) Validate() error {
+	t.compile()
+	return t.compileErr
+}
+
+// Transform applies all steps to the JSON data and returns the transformed JSON
+func (t *Transformation) Transform(data []byte) ([]byte, error) {
+	t.compile()
+	if t.compileErr != nil {
+		return nil, t.compileErr
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	var err error
+	for i := range t.compiled {
+		value, err = t.compiled[i].transform(value)
+		if err != nil {
+			return nil, fmt.Errorf("transformation step %d (%s): %w", i, t.Steps[i].Kind, err)
+		}
+	}
+
+	return marshalTransformed(value)
+}
+
+func (t *Transformation) compile() {
+	t.compileOnce.Do(func() {
+		t.compiled = make([]transformationStep, 0, len(t.Steps))
+		for i := range t.Steps {
+			compiled, err := t.Steps[i].compile()
+			if err != nil {
+				t.compileErr = fmt.Errorf("transformation step %d (%s): %w", i, t.Steps[i].Kind, err)
+				return
+			}
+			t.compiled = append(t.compiled, compiled)
+		}
+	})
+}
+
+func (s *TransformationStep) compile() (transformationStep, error) {
+	switch s.Kind {
+	case TransformationStepKindExtract:
+		path, err := jsonpath.Parse(s.Path)
+		if err != nil {
+			return nil, err
+		}
+		return &extractStep{path: path}, nil
+	case TransformationStepKindMapping:
+		mapping := &mappingStep{
+			keepUnmappedFields: s.KeepUnmappedFields,
+			mappings:           make([]compiledMapping, 0, len(s.Mappings)),
+		}
+		for i := range s.Mappings {
+			from, err := jsonpath.Parse(s.Mappings[i].From)
+			if err != nil {
+				return nil, err
+			}
+			if s.Mappings[i].To == "" {
+				return nil, fmt.Errorf("mapping from '%s' has no target", s.Mappings[i].From)
+			}
+			mapping.mappings = append(mapping.mappings, compiledMapping{
+				from: from,
+				to:   strings.Split(s.Mappings[i].To, "."),
+			})
+		}
+		return mapping, nil
+	case TransformationStepKindTemplate:
+		tmpl, err := step.NewJSON(s.Template)
+		if err != nil {
+			return nil, err
+		}
+		return &templateStep{step: tmpl}, nil
+	default:
+		return nil, fmt.Errorf("unknown transformation step kind")
+	}
+}
+
+type extractStep struct {
+	path *jsonpath.Path
+}
+
+func (e *extractStep) transform(data interface{}) (interface{}, error) {
+	value, _ := e.path.Select(data)
+	return value, nil
+}
+
+type compiledMapping struct {
+	from *jsonpath.Path
+	to   []string
+}
+
+type mappingStep struct {
+	mappings           []compiledMapping
+	keepUnmappedFields bool
+}
+
+func (m *mappingStep) transform(data interface{}) (interface{}, error) {
+	if list, ok := data.([]interface{}); ok {
+		out := make([]interface{}, len(list))
+		for i := range list {
+			out[i] = m.mapObject(list[i])
+		}
+		return out, nil
+	}
+	return m.mapObject(data), nil
+}
+
+func (m *mappingStep) mapObject(data interface{}) interface{} {
+	object, ok := data.(map[string]interface{})
+	if !ok {
+		return data
+	}
+	out := make(map[string]interface{}, len(m.mappings))
+	if m.keepUnmappedFields {
+		for key, value := range object {
+			out[key] = value
+		}
+		for i := range m.mappings {
+			if name, ok := m.mappings[i].from.IsSimpleChild(); ok {
+				delete(out, name)
+			}
+		}
+	}
+	for i := range m.mappings {
+		value, exists := m.mappings[i].from.Select(object)
+		if !exists {
+			continue
+		}
+		setPath(out, m.mappings[i].to, value)
+	}
+	return out
+}
+
+func setPath(object map[string]interface{}, path []string, value interface{}) {
+	for i := 0; i < len(path)-1; i++ {
+		child, ok := object[path[i]].(map[string]interface{})
+		if !ok {
+			child = map[string]interface{}{}
+			object[path[i]] = child
+		}
+		object = child
+	}
+	object[path[len(path)-1]] = value
+}
+
+type templateStep struct {
+	step step.JsonStep
+}
+
+func (t *templateStep) transform(data interface{}) (interface{}, error) {
+	input, err := marshalTransformed(data)
+	if err != nil {
+		return nil, err
+	}
+	out := &bytes.Buffer{}
+	if err = t.step.Invoke(bytes.NewReader(input), out); err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(out)
+	decoder.UseNumber()
+	var value interface{}
+	if err = decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("template output is not valid JSON: %w", err)
+	}
+	return value, nil
+}
+
+// transformResponse applies the Transformation of a fetch to the extracted data of the response
+func transformResponse(buf *BufPair, transformation *Transformation) error {
+	if transformation == nil || !buf.HasData() {
+		return nil
+	}
+	transformed, err := transformation.Transform(buf.Data.Bytes())
+	if err != nil {
+		return err
+	}
+	buf.Data.Reset()
+	buf.Data.WriteBytes(transformed)
+	return nil
+}
+
+func marshalTransformed(value interface{}) ([]byte, error) {
+	out := &bytes.Buffer{}
+	encoder := json.NewEncoder(out)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(out.Bytes(), []byte("\n")), nil
+}
@@ -0,0 +1,206 @@
+package resolve
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/buger/jsonparser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/fastbuffer"
+)
+
+func TestTransformation_Transform(t *testing.T) {
+	run := func(input string, steps []TransformationStep, expectedOutput string) func(t *testing.T) {
+		return func(t *testing.T) {
+			transformation := &Transformation{Steps: steps}
+			out, err := transformation.Transform([]byte(input))
+			require.NoError(t, err)
+			assert.Equal(t, expectedOutput, string(out))
+		}
+	}
+
+	t.Run("extract", run(`{"meta":{"count":1},"results":[{"id":1},{"id":2}]}`, []TransformationStep{
+		{Kind: TransformationStepKindExtract, Path: "$.results"},
+	}, `[{"id":1},{"id":2}]`))
+
+	t.Run("extract with wildcard", run(`{"results":[{"item":{"id":1}},{"item":{"id":2}}]}`, []TransformationStep{
+		{Kind: TransformationStepKindExtract, Path: "$.results[*].item"},
+	}, `[{"id":1},{"id":2}]`))
+
+	t.Run("extract missing value", run(`{"results":[]}`, []TransformationStep{
+		{Kind: TransformationStepKindExtract, Path: "$.results[0]"},
+	}, `null`))
+
+	t.Run("mapping", run(`{"first_name":"Jens","address":{"zip_code":"10115"},"internal":true}`, []TransformationStep{
+		{
+			Kind: TransformationStepKindMapping,
+			Mappings: []TransformationMapping{
+				{From: "first_name", To: "firstName"},
+				{From: "$.address.zip_code", To: "location.zip"},
+			},
+		},
+	}, `{"firstName":"Jens","location":{"zip":"10115"}}`))
+
+	t.Run("rename keeping unmapped fields", run(`[{"first_name":"Jens","id":1},{"first_name":"Jannik","id":2}]`, []TransformationStep{
+		{
+			Kind:               TransformationStepKindMapping,
+			KeepUnmappedFields: true,
+			Mappings: []TransformationMapping{
+				{From: "first_name", To: "firstName"},
+			},
+		},
+	}, `[{"firstName":"Jens","id":1},{"firstName":"Jannik","id":2}]`))
+
+	t.Run("template", run(`{"first":"Jens","last":"Neuse","tags":["a","b"]}`, []TransformationStep{
+		{
+			Kind:     TransformationStepKindTemplate,
+			Template: `{"name":"{{ .first }} {{ .last }}","tags":"{{ join "," .tags }}"}`,
+		},
+	}, `{"name":"Jens Neuse","tags":"a,b"}`))
+
+	t.Run("steps are chained", run(`{"data":{"users":[{"user_name":"jens"}]}}`, []TransformationStep{
+		{Kind: TransformationStepKindExtract, Path: "$.data.users"},
+		{
+			Kind: TransformationStepKindMapping,
+			Mappings: []TransformationMapping{
+				{From: "user_name", To: "name"},
+			},
+		},
+	}, `[{"name":"jens"}]`))
+
+	t.Run("numbers keep their precision", run(`{"id":12345678901234567890}`, []TransformationStep{
+		{Kind: TransformationStepKindExtract, Path: "$.id"},
+	}, `12345678901234567890`))
+
+	t.Run("invalid template output", func(t *testing.T) {
+		transformation := &Transformation{Steps: []TransformationStep{
+			{Kind: TransformationStepKindTemplate, Template: `{{ .name }}`},
+		}}
+		_, err := transformation.Transform([]byte(`{"name":"foo"}`))
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		transformation := &Transformation{Steps: []TransformationStep{
+			{Kind: TransformationStepKindExtract, Path: "$.results[0"},
+		}}
+		assert.Error(t, transformation.Validate())
+
+		transformation = &Transformation{Steps: []TransformationStep{
+			{Kind: "unknown"},
+		}}
+		assert.EqualError(t, transformation.Validate(), "transformation step 0 (unknown): unknown transformation step kind")
+	})
+}
+
+func TestResolver_ResolveGraphQLResponse_WithTransformation(t *testing.T) {
+	rCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for _, singleFlight := range []bool{false, true} {
+		r := newResolver(rCtx, singleFlight, false)
+
+		response := &GraphQLResponse{
+			Data: &Object{
+				Fetch: &SingleFetch{
+					BufferId:   0,
+					DataSource: FakeDataSource(`{"results":[{"user_name":"jens"}]}`),
+					Transformation: &Transformation{
+						Steps: []TransformationStep{
+							{Kind: TransformationStepKindExtract, Path: "$.results[0]"},
+							{
+								Kind: TransformationStepKindMapping,
+								Mappings: []TransformationMapping{
+									{From: "user_name", To: "name"},
+								},
+							},
+						},
+					},
+				},
+				Fields: []*Field{
+					{
+						BufferID:  0,
+						HasBuffer: true,
+						Name:      []byte("user"),
+						Value: &Object{
+							Fields: []*Field{
+								{
+									Name: []byte("name"),
+									Value: &String{
+										Path: []string{"name"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		ctx := NewContext(context.Background())
+		buf := &bytes.Buffer{}
+		err := r.ResolveGraphQLResponse(ctx, response, nil, buf)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"data":{"user":{"name":"jens"}}}`, buf.String())
+	}
+}
+
+// entitiesBatch splits the combined _entities response of a batch into the responses of the single entities
+type entitiesBatch struct {
+	input *fastbuffer.FastBuffer
+}
+
+func (b *entitiesBatch) Input() *fastbuffer.FastBuffer {
+	return b.input
+}
+
+func (b *entitiesBatch) Demultiplex(responseBufPair *BufPair, bufPairs []*BufPair) (err error) {
+	i := 0
+	_, err = jsonparser.ArrayEach(responseBufPair.Data.Bytes(), func(value []byte, _ jsonparser.ValueType, _ int, _ error) {
+		bufPairs[i].Data.WriteBytes(value)
+		i++
+	}, "_entities")
+	return err
+}
+
+type entitiesBatchFactory struct{}
+
+func (entitiesBatchFactory) CreateBatch(inputs [][]byte) (DataSourceBatch, error) {
+	input := fastbuffer.New()
+	input.WriteBytes(bytes.Join(inputs, []byte(",")))
+	return &entitiesBatch{input: input}, nil
+}
+
+func TestFetcher_FetchBatch_WithTransformation(t *testing.T) {
+	for _, singleFlight := range []bool{false, true} {
+		fetch := &BatchFetch{
+			Fetch: &SingleFetch{
+				DataSource: FakeDataSource(`{"_entities":[{"user_name":"jens"},{"user_name":"sergiy"}]}`),
+				Transformation: &Transformation{
+					Steps: []TransformationStep{
+						{
+							Kind: TransformationStepKindMapping,
+							Mappings: []TransformationMapping{
+								{From: "user_name", To: "name"},
+							},
+						},
+					},
+				},
+			},
+			BatchFactory: entitiesBatchFactory{},
+		}
+
+		first, second := fastbuffer.New(), fastbuffer.New()
+		first.WriteBytes([]byte(`{"id":1}`))
+		second.WriteBytes([]byte(`{"id":2}`))
+		bufs := []*BufPair{NewBufPair(), NewBufPair()}
+
+		err := NewFetcher(singleFlight).FetchBatch(NewContext(context.Background()), fetch, []*fastbuffer.FastBuffer{first, second}, bufs)
+		require.NoError(t, err)
+		assert.Equal(t, `{"name":"jens"}`, bufs[0].Data.String())
+		assert.Equal(t, `{"name":"sergiy"}`, bufs[1].Data.String())
+	}
+}
@@ -82,8 +82,8 @@ func NewJSONVariableRendererWithValidation(jsonSchema string) *JSONVariableRende
 
 // NewJSONVariableRendererWithValidationFromTypeRef creates a new JSONVariableRenderer
 // The argument typeRef must exist on the operation ast.Document, otherwise it will panic!
-func NewJSONVariableRendererWithValidationFromTypeRef(operation, definition *ast.Document, variableTypeRef int) (*JSONVariableRenderer, error) {
-	jsonSchema := graphqljsonschema.FromTypeRef(operation, definition, variableTypeRef)
+func NewJSONVariableRendererWithValidationFromTypeRef(operation, definition *ast.Document, variableTypeRef int, opts ...graphqljsonschema.Option) (*JSONVariableRenderer, error) {
+	jsonSchema := graphqljsonschema.FromTypeRef(operation, definition, variableTypeRef, opts...)
 	validator, err := graphqljsonschema.NewValidatorFromSchema(jsonSchema)
 	if err != nil {
 		return nil, err
@@ -174,8 +174,8 @@ func (p *PlainVariableRenderer) RenderVariable(ctx context.Context, data []byte,
 
 // NewGraphQLVariableRendererFromTypeRef creates a new GraphQLVariableRenderer
 // The argument typeRef must exist on the operation ast.Document, otherwise it will panic!
-func NewGraphQLVariableRendererFromTypeRef(operation, definition *ast.Document, variableTypeRef int) (*GraphQLVariableRenderer, error) {
-	jsonSchema := graphqljsonschema.FromTypeRef(operation, definition, variableTypeRef)
+func NewGraphQLVariableRendererFromTypeRef(operation, definition *ast.Document, variableTypeRef int, opts ...graphqljsonschema.Option) (*GraphQLVariableRenderer, error) {
+	jsonSchema := graphqljsonschema.FromTypeRef(operation, definition, variableTypeRef, opts...)
 	validator, err := graphqljsonschema.NewValidatorFromSchema(jsonSchema)
 	if err != nil {
 		return nil, err
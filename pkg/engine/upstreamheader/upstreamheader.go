@@ -0,0 +1,28 @@
+// Package upstreamheader passes the headers of upstream responses from data sources to the resolver.
+// It has no dependencies, so data sources can report headers without depending on the resolve package.
+package upstreamheader
+
+import (
+	"context"
+	"net/http"
+)
+
+type contextKey struct{}
+
+// Recorder receives the headers of an upstream response
+type Recorder struct {
+	Header http.Header
+}
+
+// WithRecorder returns a context with a Recorder for the headers which data sources report using Set
+func WithRecorder(ctx context.Context) (context.Context, *Recorder) {
+	recorder := &Recorder{}
+	return context.WithValue(ctx, contextKey{}, recorder), recorder
+}
+
+// Set reports the headers of an upstream response, it's a no-op if the context has no Recorder
+func Set(ctx context.Context, header http.Header) {
+	if recorder, ok := ctx.Value(contextKey{}).(*Recorder); ok {
+		recorder.Header = header
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
 	"github.com/wundergraph/graphql-go-tools/pkg/execution/datasource"
 	"github.com/wundergraph/graphql-go-tools/pkg/lexer/literal"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/position"
 	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
 )
 
@@ -82,7 +83,7 @@ func (p *planningVisitor) EnterDocument(operation, definition *ast.Document) {
 	p.isSingleOperation = p.countOperationDefinitionsInRootNodes() == 1
 
 	if len(operation.OperationDefinitions) == 0 {
-		p.Walker.StopWithExternalErr(operationreport.ErrDocumentDoesntContainExecutableOperation())
+		p.Walker.StopWithExternalErr(operationreport.ErrDocumentDoesntContainExecutableOperation(position.Position{}))
 		return
 	}
 
@@ -4,6 +4,7 @@ import (
 	"github.com/wundergraph/graphql-go-tools/pkg/ast"
 	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
 	"github.com/wundergraph/graphql-go-tools/pkg/engine/plan"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/position"
 	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
 )
 
@@ -33,7 +34,7 @@ func (c *collectEntitiesVisitor) EnterDocument(operation, _ *ast.Document) {
 func (c *collectEntitiesVisitor) EnterInterfaceTypeDefinition(ref int) {
 	interfaceType := c.document.InterfaceTypeDefinitions[ref]
 	name := c.document.InterfaceTypeDefinitionNameString(ref)
-	if err := c.resolvePotentialEntity(name, interfaceType.Directives.Refs); err != nil {
+	if err := c.resolvePotentialEntity(name, interfaceType.Directives.Refs, interfaceType.Position); err != nil {
 		c.StopWithExternalErr(*err)
 	}
 }
@@ -41,14 +42,14 @@ func (c *collectEntitiesVisitor) EnterInterfaceTypeDefinition(ref int) {
 func (c *collectEntitiesVisitor) EnterObjectTypeDefinition(ref int) {
 	objectType := c.document.ObjectTypeDefinitions[ref]
 	name := c.document.ObjectTypeDefinitionNameString(ref)
-	if err := c.resolvePotentialEntity(name, objectType.Directives.Refs); err != nil {
+	if err := c.resolvePotentialEntity(name, objectType.Directives.Refs, objectType.Position); err != nil {
 		c.StopWithExternalErr(*err)
 	}
 }
 
-func (c *collectEntitiesVisitor) resolvePotentialEntity(name string, directiveRefs []int) *operationreport.ExternalError {
+func (c *collectEntitiesVisitor) resolvePotentialEntity(name string, directiveRefs []int, position position.Position) *operationreport.ExternalError {
 	if _, exists := c.collectedEntities[name]; exists {
-		err := operationreport.ErrEntitiesMustNotBeDuplicated(name)
+		err := operationreport.ErrEntitiesMustNotBeDuplicated(name, position)
 		return &err
 	}
 	for _, directiveRef := range directiveRefs {
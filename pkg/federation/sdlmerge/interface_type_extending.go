@@ -42,12 +42,12 @@ func (e *extendInterfaceTypeDefinitionVisitor) EnterInterfaceTypeExtension(ref i
 			continue
 		}
 		if nodeToExtend != nil {
-			e.StopWithExternalErr(*multipleExtensionError(isEntity, nameBytes))
+			e.StopWithExternalErr(*multipleExtensionError(isEntity, nameBytes, e.document.InterfaceTypeExtensions[ref].Position))
 			return
 		}
 		var err *operationreport.ExternalError
 		extension := e.document.InterfaceTypeExtensions[ref]
-		if isEntity, err = e.collectedEntities.isExtensionForEntity(nameBytes, extension.Directives.Refs, e.document); err != nil {
+		if isEntity, err = e.collectedEntities.isExtensionForEntity(nameBytes, extension.Directives.Refs, extension.Position, e.document); err != nil {
 			e.StopWithExternalErr(*err)
 			return
 		}
@@ -55,7 +55,7 @@ func (e *extendInterfaceTypeDefinitionVisitor) EnterInterfaceTypeExtension(ref i
 	}
 
 	if nodeToExtend == nil {
-		e.StopWithExternalErr(operationreport.ErrExtensionOrphansMustResolveInSupergraph(e.document.InterfaceTypeExtensionNameBytes(ref)))
+		e.StopWithExternalErr(operationreport.ErrExtensionOrphansMustResolveInSupergraph(e.document.InterfaceTypeExtensionNameBytes(ref), e.document.InterfaceTypeExtensions[ref].Position))
 		return
 	}
 
@@ -42,12 +42,12 @@ func (e *extendObjectTypeDefinitionVisitor) EnterObjectTypeExtension(ref int) {
 			continue
 		}
 		if nodeToExtend != nil {
-			e.StopWithExternalErr(*multipleExtensionError(isEntity, nameBytes))
+			e.StopWithExternalErr(*multipleExtensionError(isEntity, nameBytes, e.document.ObjectTypeExtensions[ref].Position))
 			return
 		}
 		var err *operationreport.ExternalError
 		extension := e.document.ObjectTypeExtensions[ref]
-		if isEntity, err = e.collectedEntities.isExtensionForEntity(nameBytes, extension.Directives.Refs, e.document); err != nil {
+		if isEntity, err = e.collectedEntities.isExtensionForEntity(nameBytes, extension.Directives.Refs, extension.Position, e.document); err != nil {
 			e.StopWithExternalErr(*err)
 			return
 		}
@@ -58,7 +58,7 @@ func (e *extendObjectTypeDefinitionVisitor) EnterObjectTypeExtension(ref int) {
 	}
 
 	if nodeToExtend == nil {
-		e.StopWithExternalErr(operationreport.ErrExtensionOrphansMustResolveInSupergraph(nameBytes))
+		e.StopWithExternalErr(operationreport.ErrExtensionOrphansMustResolveInSupergraph(nameBytes, e.document.ObjectTypeExtensions[ref].Position))
 		return
 	}
 
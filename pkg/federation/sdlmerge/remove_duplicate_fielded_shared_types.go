@@ -50,7 +50,7 @@ func (r *removeDuplicateFieldedSharedTypesVisitor) EnterInputObjectTypeDefinitio
 	input, exists := r.sharedTypeSet[name]
 	if exists {
 		if !input.areFieldsIdentical(refs) {
-			r.StopWithExternalErr(operationreport.ErrSharedTypesMustBeIdenticalToFederate(name))
+			r.StopWithExternalErr(operationreport.ErrSharedTypesMustBeIdenticalToFederate(name, r.document.InputObjectTypeDefinitions[ref].Position))
 			return
 		}
 		r.rootNodesToRemove = append(r.rootNodesToRemove, ast.Node{Kind: ast.NodeKindInputObjectTypeDefinition, Ref: ref})
@@ -70,7 +70,7 @@ func (r *removeDuplicateFieldedSharedTypesVisitor) EnterInterfaceTypeDefinition(
 	iFace, exists := r.sharedTypeSet[name]
 	if exists {
 		if !iFace.areFieldsIdentical(refs) {
-			r.StopWithExternalErr(operationreport.ErrSharedTypesMustBeIdenticalToFederate(name))
+			r.StopWithExternalErr(operationreport.ErrSharedTypesMustBeIdenticalToFederate(name, r.document.InterfaceTypeDefinitions[ref].Position))
 			return
 		}
 		r.rootNodesToRemove = append(r.rootNodesToRemove, ast.Node{Kind: ast.NodeKindInterfaceTypeDefinition, Ref: ref})
@@ -90,7 +90,7 @@ func (r *removeDuplicateFieldedSharedTypesVisitor) EnterObjectTypeDefinition(ref
 	object, exists := r.sharedTypeSet[name]
 	if exists {
 		if !object.areFieldsIdentical(refs) {
-			r.StopWithExternalErr(operationreport.ErrSharedTypesMustBeIdenticalToFederate(name))
+			r.StopWithExternalErr(operationreport.ErrSharedTypesMustBeIdenticalToFederate(name, r.document.ObjectTypeDefinitions[ref].Position))
 			return
 		}
 		r.rootNodesToRemove = append(r.rootNodesToRemove, ast.Node{Kind: ast.NodeKindObjectTypeDefinition, Ref: ref})
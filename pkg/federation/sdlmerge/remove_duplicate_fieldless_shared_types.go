@@ -49,7 +49,7 @@ func (r *removeDuplicateFieldlessSharedTypesVisitor) EnterEnumTypeDefinition(ref
 	enum, exists := r.sharedTypeSet[name]
 	if exists {
 		if !enum.areValuesIdentical(r.document.EnumTypeDefinitions[ref].EnumValuesDefinition.Refs) {
-			r.StopWithExternalErr(operationreport.ErrSharedTypesMustBeIdenticalToFederate(name))
+			r.StopWithExternalErr(operationreport.ErrSharedTypesMustBeIdenticalToFederate(name, r.document.EnumTypeDefinitions[ref].Position))
 			return
 		}
 		r.rootNodesToRemove = append(r.rootNodesToRemove, ast.Node{Kind: ast.NodeKindEnumTypeDefinition, Ref: ref})
@@ -81,7 +81,7 @@ func (r *removeDuplicateFieldlessSharedTypesVisitor) EnterUnionTypeDefinition(re
 	union, exists := r.sharedTypeSet[name]
 	if exists {
 		if !union.areValuesIdentical(r.document.UnionTypeDefinitions[ref].UnionMemberTypes.Refs) {
-			r.StopWithExternalErr(operationreport.ErrSharedTypesMustBeIdenticalToFederate(name))
+			r.StopWithExternalErr(operationreport.ErrSharedTypesMustBeIdenticalToFederate(name, r.document.UnionTypeDefinitions[ref].Position))
 			return
 		}
 		r.rootNodesToRemove = append(r.rootNodesToRemove, ast.Node{Kind: ast.NodeKindUnionTypeDefinition, Ref: ref})
@@ -12,6 +12,7 @@ import (
 	"github.com/wundergraph/graphql-go-tools/pkg/astparser"
 	"github.com/wundergraph/graphql-go-tools/pkg/astprinter"
 	"github.com/wundergraph/graphql-go-tools/pkg/astvisitor"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/position"
 	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
 )
 
@@ -54,10 +55,13 @@ func MergeSDLs(SDLs ...string) (string, error) {
 
 	astnormalization.NormalizeSubgraphSDL(&doc, &report)
 	if report.HasErrors() {
+		removeLocations(&report)
 		return "", fmt.Errorf("merge ast: %s", report.Error())
 	}
 
-	if err := MergeAST(&doc); err != nil {
+	merger := normalizer{omitLocations: true}
+	merger.setupWalkers()
+	if err := merger.normalize(&doc); err != nil {
 		return "", fmt.Errorf("merge ast: %s", err.Error())
 	}
 
@@ -111,6 +115,8 @@ func normalizeSubgraphs(subgraphs []string) error {
 
 type normalizer struct {
 	walkers []*astvisitor.Walker
+	// omitLocations removes the locations of errors which don't refer to the SDL of a subgraph
+	omitLocations bool
 }
 
 type entitySet map[string]struct{}
@@ -157,6 +163,9 @@ func (m *normalizer) normalize(operation *ast.Document) error {
 	for _, walker := range m.walkers {
 		walker.Walk(operation, nil, &report)
 		if report.HasErrors() {
+			if m.omitLocations {
+				removeLocations(&report)
+			}
 			return fmt.Errorf("walk: %s", report.Error())
 		}
 	}
@@ -164,24 +173,32 @@ func (m *normalizer) normalize(operation *ast.Document) error {
 	return nil
 }
 
-func (e entitySet) isExtensionForEntity(nameBytes []byte, directiveRefs []int, document *ast.Document) (bool, *operationreport.ExternalError) {
+// removeLocations removes the locations of the external errors of the report
+// MergeSDLs merges a concatenation of the printed subgraphs, the positions of its nodes don't refer to the SDL of any subgraph.
+func removeLocations(report *operationreport.Report) {
+	for i := range report.ExternalErrors {
+		report.ExternalErrors[i].Locations = nil
+	}
+}
+
+func (e entitySet) isExtensionForEntity(nameBytes []byte, directiveRefs []int, position position.Position, document *ast.Document) (bool, *operationreport.ExternalError) {
 	name := string(nameBytes)
 	hasDirectives := len(directiveRefs) > 0
 	if _, exists := e[name]; !exists {
 		if !hasDirectives || !isEntityExtension(directiveRefs, document) {
 			return false, nil
 		}
-		err := operationreport.ErrExtensionWithKeyDirectiveMustExtendEntity(name)
+		err := operationreport.ErrExtensionWithKeyDirectiveMustExtendEntity(name, position)
 		return false, &err
 	}
 	if !hasDirectives {
-		err := operationreport.ErrEntityExtensionMustHaveKeyDirective(name)
+		err := operationreport.ErrEntityExtensionMustHaveKeyDirective(name, position)
 		return false, &err
 	}
 	if isEntityExtension(directiveRefs, document) {
 		return true, nil
 	}
-	err := operationreport.ErrEntityExtensionMustHaveKeyDirective(name)
+	err := operationreport.ErrEntityExtensionMustHaveKeyDirective(name, position)
 	return false, &err
 }
 
@@ -194,11 +211,11 @@ func isEntityExtension(directiveRefs []int, document *ast.Document) bool {
 	return false
 }
 
-func multipleExtensionError(isEntity bool, nameBytes []byte) *operationreport.ExternalError {
+func multipleExtensionError(isEntity bool, nameBytes []byte, position position.Position) *operationreport.ExternalError {
 	if isEntity {
-		err := operationreport.ErrEntitiesMustNotBeDuplicated(string(nameBytes))
+		err := operationreport.ErrEntitiesMustNotBeDuplicated(string(nameBytes), position)
 		return &err
 	}
-	err := operationreport.ErrSharedTypesMustNotBeExtended(string(nameBytes))
+	err := operationreport.ErrSharedTypesMustNotBeExtended(string(nameBytes), position)
 	return &err
 }
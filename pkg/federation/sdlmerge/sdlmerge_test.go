@@ -137,7 +137,7 @@ func TestMergeSDLs(t *testing.T) {
 	))
 
 	t.Run("The first type encountered without a body should return an error", runMergeTestAndExpectError(
-		emptyTypeBodyErrorMessage("object", "Message"),
+		emptyTypeBodyErrorMessage("object", "Message", 25, 3),
 		accountSchema, negativeTestingProductSchema,
 	))
 }
@@ -638,8 +638,8 @@ func sharedTypeExtensionErrorMessage(typeName string) string {
 	return fmt.Sprintf("the type named '%s' cannot be extended because it is a shared type", typeName)
 }
 
-func emptyTypeBodyErrorMessage(definitionType, typeName string) string {
-	return fmt.Sprintf("validate schema: external: the %s named '%s' is invalid due to an empty body, locations: [], path: []", definitionType, typeName)
+func emptyTypeBodyErrorMessage(definitionType, typeName string, line, column int) string {
+	return fmt.Sprintf("validate schema: external: the %s named '%s' is invalid due to an empty body, locations: [{Line:%d Column:%d}], path: []", definitionType, typeName, line, column)
 }
 
 func unresolvedExtensionOrphansErrorMessage(typeName string) string {
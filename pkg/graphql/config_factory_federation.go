@@ -13,7 +13,8 @@ import (
 )
 
 type federationEngineConfigFactoryOptions struct {
-	httpClient *http.Client
+	httpClient          *http.Client
+	incrementalDelivery bool
 }
 
 type FederationEngineConfigFactoryOption func(options *federationEngineConfigFactoryOptions)
@@ -24,6 +25,13 @@ func WithFederationHttpClient(client *http.Client) FederationEngineConfigFactory
 	}
 }
 
+// WithFederationIncrementalDelivery adds the @defer and @stream directives to the merged schema
+func WithFederationIncrementalDelivery() FederationEngineConfigFactoryOption {
+	return func(options *federationEngineConfigFactoryOptions) {
+		options.incrementalDelivery = true
+	}
+}
+
 func NewFederationEngineConfigFactory(dataSourceConfigs []graphqlDataSource.Configuration, batchFactory resolve.DataSourceBatchFactory, opts ...FederationEngineConfigFactoryOption) *FederationEngineConfigFactory {
 	options := federationEngineConfigFactoryOptions{
 		httpClient: &http.Client{
@@ -40,18 +48,20 @@ func NewFederationEngineConfigFactory(dataSourceConfigs []graphqlDataSource.Conf
 	}
 
 	return &FederationEngineConfigFactory{
-		httpClient:        options.httpClient,
-		dataSourceConfigs: dataSourceConfigs,
-		batchFactory:      batchFactory,
+		httpClient:          options.httpClient,
+		dataSourceConfigs:   dataSourceConfigs,
+		batchFactory:        batchFactory,
+		incrementalDelivery: options.incrementalDelivery,
 	}
 }
 
 // FederationEngineConfigFactory is used to create a v2 engine config for a supergraph with multiple data sources for subgraphs.
 type FederationEngineConfigFactory struct {
-	httpClient        *http.Client
-	dataSourceConfigs []graphqlDataSource.Configuration
-	schema            *Schema
-	batchFactory      resolve.DataSourceBatchFactory
+	httpClient          *http.Client
+	dataSourceConfigs   []graphqlDataSource.Configuration
+	schema              *Schema
+	batchFactory        resolve.DataSourceBatchFactory
+	incrementalDelivery bool
 }
 
 func (f *FederationEngineConfigFactory) SetMergedSchemaFromString(mergedSchema string) (err error) {
@@ -77,6 +87,10 @@ func (f *FederationEngineConfigFactory) MergedSchema() (*Schema, error) {
 		return nil, fmt.Errorf("build base schema: %v", err)
 	}
 
+	if f.incrementalDelivery {
+		rawBaseSchema += IncrementalDeliveryDirectives
+	}
+
 	if f.schema, err = NewSchemaFromString(rawBaseSchema); err != nil {
 		return nil, fmt.Errorf("parse schema from strinig: %v", err)
 	}
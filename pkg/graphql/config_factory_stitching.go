@@ -0,0 +1,428 @@
+package graphql
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astparser"
+	"github.com/wundergraph/graphql-go-tools/pkg/astprinter"
+	graphqlDataSource "github.com/wundergraph/graphql-go-tools/pkg/engine/datasource/graphql_datasource"
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/plan"
+)
+
+type stitchingEngineConfigFactoryOptions struct {
+	httpClient *http.Client
+}
+
+type StitchingEngineConfigFactoryOption func(options *stitchingEngineConfigFactoryOptions)
+
+func WithStitchingHttpClient(client *http.Client) StitchingEngineConfigFactoryOption {
+	return func(options *stitchingEngineConfigFactoryOptions) {
+		options.httpClient = client
+	}
+}
+
+// StitchingUpstream is a plain GraphQL upstream which gets merged into the schema of a StitchingEngineConfigFactory.
+type StitchingUpstream struct {
+	// Name identifies the upstream in the lookups of type merges
+	Name string
+	// SDL is the schema of the upstream
+	SDL string
+	// TypePrefix is prepended to the types of the upstream which conflict with types of a previous upstream, e.g. Reviews for ReviewsAddress
+	// Types which are merged, root operation types and scalars don't conflict.
+	TypePrefix string
+	// DataSourceConfig configures how the upstream is called, the upstream schema and the lookups are set by the factory
+	DataSourceConfig graphqlDataSource.Configuration
+}
+
+// StitchingTypeMerge merges the definitions of a type of multiple upstreams into one type.
+// Fields of the type which are defined by another upstream than the one which returned the object are loaded
+// through the lookup field of the other upstream using the key fields of the object.
+type StitchingTypeMerge struct {
+	TypeName  string
+	KeyFields []string
+	Lookups   []StitchingLookup
+}
+
+// StitchingLookup is a root field of an upstream which loads an object of a merged type by its key fields
+type StitchingLookup struct {
+	Upstream  string
+	FieldName string
+	// Arguments map the arguments of the lookup field to the key fields
+	// If no arguments are configured, every key field is passed as argument with the same name.
+	Arguments []graphqlDataSource.LookupArgument
+}
+
+func NewStitchingEngineConfigFactory(upstreams []StitchingUpstream, typeMerges []StitchingTypeMerge, opts ...StitchingEngineConfigFactoryOption) *StitchingEngineConfigFactory {
+	options := stitchingEngineConfigFactoryOptions{
+		httpClient: &http.Client{
+			Timeout: time.Second * 10,
+			Transport: &http.Transport{
+				MaxIdleConnsPerHost: 1024,
+				TLSHandshakeTimeout: 0 * time.Second,
+			},
+		},
+	}
+
+	for _, optFunc := range opts {
+		optFunc(&options)
+	}
+
+	return &StitchingEngineConfigFactory{
+		httpClient: options.httpClient,
+		upstreams:  upstreams,
+		typeMerges: typeMerges,
+	}
+}
+
+// StitchingEngineConfigFactory is used to create a v2 engine config which merges the schemas of multiple plain GraphQL upstreams.
+type StitchingEngineConfigFactory struct {
+	httpClient *http.Client
+	upstreams  []StitchingUpstream
+	typeMerges []StitchingTypeMerge
+
+	schema    *Schema
+	stitched  []stitchedUpstream
+	typeNames plan.TypeConfigurations
+}
+
+// stitchedUpstream is an upstream whose conflicting types are renamed
+type stitchedUpstream struct {
+	upstream StitchingUpstream
+	document ast.Document
+	sdl      string
+}
+
+func (f *StitchingEngineConfigFactory) MergedSchema() (*Schema, error) {
+	if f.schema != nil {
+		return f.schema, nil
+	}
+
+	if err := f.validateTypeMerges(); err != nil {
+		return nil, err
+	}
+
+	if err := f.stitchUpstreams(); err != nil {
+		return nil, err
+	}
+
+	mergedSDL, err := f.mergeSDLs()
+	if err != nil {
+		return nil, fmt.Errorf("merge schemas: %v", err)
+	}
+
+	if f.schema, err = NewSchemaFromString(mergedSDL); err != nil {
+		return nil, fmt.Errorf("parse schema from string: %v", err)
+	}
+
+	return f.schema, nil
+}
+
+func (f *StitchingEngineConfigFactory) EngineV2Configuration() (conf EngineV2Configuration, err error) {
+	schema, err := f.MergedSchema()
+	if err != nil {
+		return conf, fmt.Errorf("get schema: %v", err)
+	}
+
+	conf = NewEngineV2Configuration(schema)
+	conf.SetFieldConfigurations(f.engineConfigFieldConfigs(schema))
+	conf.SetDataSources(f.engineConfigDataSources())
+	conf.SetTypeConfigurations(f.typeNames)
+
+	return conf, nil
+}
+
+func (f *StitchingEngineConfigFactory) validateTypeMerges() error {
+	upstreamNames := make(map[string]struct{}, len(f.upstreams))
+	for i := range f.upstreams {
+		if _, exists := upstreamNames[f.upstreams[i].Name]; exists {
+			return fmt.Errorf("upstream name '%s' is not unique", f.upstreams[i].Name)
+		}
+		upstreamNames[f.upstreams[i].Name] = struct{}{}
+	}
+
+	for i := range f.typeMerges {
+		if len(f.typeMerges[i].KeyFields) == 0 {
+			return fmt.Errorf("type merge of '%s' has no key fields", f.typeMerges[i].TypeName)
+		}
+		for _, lookup := range f.typeMerges[i].Lookups {
+			if _, exists := upstreamNames[lookup.Upstream]; !exists {
+				return fmt.Errorf("lookup of type '%s' references unknown upstream '%s'", f.typeMerges[i].TypeName, lookup.Upstream)
+			}
+		}
+	}
+
+	return nil
+}
+
+// stitchUpstreams parses the upstream schemas and renames types which conflict with types of previous upstreams
+func (f *StitchingEngineConfigFactory) stitchUpstreams() error {
+	f.stitched = make([]stitchedUpstream, 0, len(f.upstreams))
+	f.typeNames = nil
+
+	definedBy := map[string]string{}
+	for i := range f.upstreams {
+		doc, report := astparser.ParseGraphqlDocumentString(f.upstreams[i].SDL)
+		if report.HasErrors() {
+			return fmt.Errorf("parse schema of upstream '%s': %s", f.upstreams[i].Name, report.Error())
+		}
+
+		var conflictingTypeNames []string
+		for _, node := range doc.RootNodes {
+			if !isStitchableTypeDefinition(node.Kind) {
+				continue
+			}
+			typeName := doc.NodeNameString(node)
+			previousUpstream, conflicts := definedBy[typeName]
+			if !conflicts {
+				definedBy[typeName] = f.upstreams[i].Name
+				continue
+			}
+			if node.Kind == ast.NodeKindScalarTypeDefinition || isRootOperationTypeName(typeName) || f.typeMerge(typeName) != nil {
+				continue
+			}
+			if f.upstreams[i].TypePrefix == "" {
+				return fmt.Errorf("type '%s' of upstream '%s' conflicts with upstream '%s', add a type merge or a type prefix", typeName, f.upstreams[i].Name, previousUpstream)
+			}
+			conflictingTypeNames = append(conflictingTypeNames, typeName)
+		}
+
+		for _, typeName := range conflictingTypeNames {
+			renameTo := f.upstreams[i].TypePrefix + typeName
+			if previousUpstream, exists := definedBy[renameTo]; exists {
+				return fmt.Errorf("renamed type '%s' of upstream '%s' conflicts with upstream '%s'", renameTo, f.upstreams[i].Name, previousUpstream)
+			}
+			definedBy[renameTo] = f.upstreams[i].Name
+			renameTypes(&doc, typeName, renameTo)
+			f.typeNames = append(f.typeNames, plan.TypeConfiguration{
+				TypeName: renameTo,
+				RenameTo: typeName,
+			})
+		}
+
+		sdl, err := astprinter.PrintString(&doc, nil)
+		if err != nil {
+			return fmt.Errorf("print schema of upstream '%s': %v", f.upstreams[i].Name, err)
+		}
+		// the renamed schema is parsed again to index the renamed types
+		doc, report = astparser.ParseGraphqlDocumentString(sdl)
+		if report.HasErrors() {
+			return fmt.Errorf("parse renamed schema of upstream '%s': %s", f.upstreams[i].Name, report.Error())
+		}
+
+		f.stitched = append(f.stitched, stitchedUpstream{
+			upstream: f.upstreams[i],
+			document: doc,
+			sdl:      sdl,
+		})
+	}
+
+	return nil
+}
+
+// mergeSDLs merges the fields of types which are defined by multiple upstreams into the first definition of the type
+func (f *StitchingEngineConfigFactory) mergeSDLs() (string, error) {
+	SDLs := make([]string, len(f.stitched))
+	for i := range f.stitched {
+		SDLs[i] = f.stitched[i].sdl
+	}
+
+	doc, report := astparser.ParseGraphqlDocumentString(strings.Join(SDLs, "\n"))
+	if report.HasErrors() {
+		return "", fmt.Errorf("parse graphql document string: %s", report.Error())
+	}
+
+	definitions := map[string]ast.Node{}
+	rootNodes := make([]ast.Node, 0, len(doc.RootNodes))
+	for _, node := range doc.RootNodes {
+		switch node.Kind {
+		case ast.NodeKindSchemaDefinition, ast.NodeKindSchemaExtension:
+			// the root operation types are Query, Mutation and Subscription
+			continue
+		}
+		if !isStitchableTypeDefinition(node.Kind) {
+			rootNodes = append(rootNodes, node)
+			continue
+		}
+		typeName := doc.NodeNameString(node)
+		first, exists := definitions[typeName]
+		if !exists {
+			definitions[typeName] = node
+			rootNodes = append(rootNodes, node)
+			continue
+		}
+		if node.Kind != ast.NodeKindObjectTypeDefinition || first.Kind != ast.NodeKindObjectTypeDefinition {
+			// only scalars may be defined by multiple upstreams besides object types
+			continue
+		}
+		for _, fieldRef := range doc.ObjectTypeDefinitions[node.Ref].FieldsDefinition.Refs {
+			fieldName := doc.FieldDefinitionNameBytes(fieldRef)
+			if doc.ObjectTypeDefinitionHasField(first.Ref, fieldName) {
+				if isRootOperationTypeName(typeName) {
+					return "", fmt.Errorf("field '%s.%s' is defined by multiple upstreams", typeName, fieldName)
+				}
+				continue
+			}
+			doc.ObjectTypeDefinitions[first.Ref].FieldsDefinition.Refs = append(doc.ObjectTypeDefinitions[first.Ref].FieldsDefinition.Refs, fieldRef)
+			doc.ObjectTypeDefinitions[first.Ref].HasFieldDefinitions = true
+		}
+	}
+	doc.RootNodes = rootNodes
+
+	return astprinter.PrintString(&doc, nil)
+}
+
+func (f *StitchingEngineConfigFactory) engineConfigFieldConfigs(schema *Schema) plan.FieldConfigurations {
+	var planFieldConfigs plan.FieldConfigurations
+
+	for _, typeMerge := range f.typeMerges {
+		for _, lookup := range typeMerge.Lookups {
+			for _, fieldName := range f.lookupFieldNames(lookup.Upstream, typeMerge) {
+				if planFieldConfigs.ForTypeField(typeMerge.TypeName, fieldName) != nil {
+					continue
+				}
+				planFieldConfigs = append(planFieldConfigs, plan.FieldConfiguration{
+					TypeName:       typeMerge.TypeName,
+					FieldName:      fieldName,
+					RequiresFields: typeMerge.KeyFields,
+				})
+			}
+		}
+	}
+
+	return newGraphQLFieldConfigsV2Generator(schema).Generate(planFieldConfigs...)
+}
+
+func (f *StitchingEngineConfigFactory) engineConfigDataSources() (planDataSources []plan.DataSourceConfiguration) {
+	for i := range f.stitched {
+		dataSourceConfig := f.stitched[i].upstream.DataSourceConfig
+		if dataSourceConfig.UpstreamSchema == "" {
+			dataSourceConfig.UpstreamSchema = f.stitched[i].upstream.SDL
+		}
+
+		var lookupNodes []plan.TypeField
+		for _, typeMerge := range f.typeMerges {
+			for _, lookup := range typeMerge.Lookups {
+				if lookup.Upstream != f.stitched[i].upstream.Name {
+					continue
+				}
+				dataSourceConfig.Lookups = append(dataSourceConfig.Lookups, graphqlDataSource.LookupConfiguration{
+					TypeName:  typeMerge.TypeName,
+					FieldName: lookup.FieldName,
+					Arguments: lookupArguments(typeMerge, lookup),
+				})
+				lookupNodes = append(lookupNodes, plan.TypeField{
+					TypeName:   typeMerge.TypeName,
+					FieldNames: f.lookupFieldNames(lookup.Upstream, typeMerge),
+				})
+			}
+		}
+
+		planDataSource := newGraphQLDataSourceV2Generator(&f.stitched[i].document).Generate(dataSourceConfig, nil, f.httpClient)
+		// fields which can be loaded by a lookup are root nodes, so that they're planned as nested fetch of objects of other upstreams
+		planDataSource.RootNodes = append(planDataSource.RootNodes, lookupNodes...)
+		planDataSources = append(planDataSources, planDataSource)
+	}
+
+	return planDataSources
+}
+
+// lookupFieldNames returns the fields of a merged type which an upstream defines, except for the key fields
+func (f *StitchingEngineConfigFactory) lookupFieldNames(upstreamName string, typeMerge StitchingTypeMerge) (fieldNames []string) {
+	for i := range f.stitched {
+		if f.stitched[i].upstream.Name != upstreamName {
+			continue
+		}
+		doc := &f.stitched[i].document
+		node, exists := doc.Index.FirstNodeByNameStr(typeMerge.TypeName)
+		if !exists || node.Kind != ast.NodeKindObjectTypeDefinition {
+			return nil
+		}
+		for _, fieldRef := range doc.ObjectTypeDefinitions[node.Ref].FieldsDefinition.Refs {
+			fieldName := doc.FieldDefinitionNameString(fieldRef)
+			if !isKeyField(typeMerge, fieldName) {
+				fieldNames = append(fieldNames, fieldName)
+			}
+		}
+	}
+	return fieldNames
+}
+
+func (f *StitchingEngineConfigFactory) typeMerge(typeName string) *StitchingTypeMerge {
+	for i := range f.typeMerges {
+		if f.typeMerges[i].TypeName == typeName {
+			return &f.typeMerges[i]
+		}
+	}
+	return nil
+}
+
+func lookupArguments(typeMerge StitchingTypeMerge, lookup StitchingLookup) []graphqlDataSource.LookupArgument {
+	if len(lookup.Arguments) != 0 {
+		return lookup.Arguments
+	}
+	arguments := make([]graphqlDataSource.LookupArgument, len(typeMerge.KeyFields))
+	for i, keyField := range typeMerge.KeyFields {
+		arguments[i] = graphqlDataSource.LookupArgument{
+			Name:     keyField,
+			KeyField: keyField,
+		}
+	}
+	return arguments
+}
+
+func isKeyField(typeMerge StitchingTypeMerge, fieldName string) bool {
+	for _, keyField := range typeMerge.KeyFields {
+		if keyField == fieldName {
+			return true
+		}
+	}
+	return false
+}
+
+func isRootOperationTypeName(typeName string) bool {
+	return typeName == "Query" || typeName == "Mutation" || typeName == "Subscription"
+}
+
+func isStitchableTypeDefinition(kind ast.NodeKind) bool {
+	switch kind {
+	case ast.NodeKindObjectTypeDefinition,
+		ast.NodeKindInterfaceTypeDefinition,
+		ast.NodeKindUnionTypeDefinition,
+		ast.NodeKindEnumTypeDefinition,
+		ast.NodeKindInputObjectTypeDefinition,
+		ast.NodeKindScalarTypeDefinition:
+		return true
+	}
+	return false
+}
+
+// renameTypes renames the definition of a type and all references to it
+func renameTypes(doc *ast.Document, typeName, renameTo string) {
+	name := doc.Input.AppendInputString(renameTo)
+	for _, node := range doc.RootNodes {
+		if !isStitchableTypeDefinition(node.Kind) || doc.NodeNameString(node) != typeName {
+			continue
+		}
+		switch node.Kind {
+		case ast.NodeKindObjectTypeDefinition:
+			doc.ObjectTypeDefinitions[node.Ref].Name = name
+		case ast.NodeKindInterfaceTypeDefinition:
+			doc.InterfaceTypeDefinitions[node.Ref].Name = name
+		case ast.NodeKindUnionTypeDefinition:
+			doc.UnionTypeDefinitions[node.Ref].Name = name
+		case ast.NodeKindEnumTypeDefinition:
+			doc.EnumTypeDefinitions[node.Ref].Name = name
+		case ast.NodeKindInputObjectTypeDefinition:
+			doc.InputObjectTypeDefinitions[node.Ref].Name = name
+		}
+	}
+	for i := range doc.Types {
+		if doc.Types[i].TypeKind == ast.TypeKindNamed && doc.Input.ByteSliceString(doc.Types[i].Name) == typeName {
+			doc.Types[i].Name = name
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/jensneuse/abstractlogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/astparser"
+	"github.com/wundergraph/graphql-go-tools/pkg/astprinter"
+	graphqlDataSource "github.com/wundergraph/graphql-go-tools/pkg/engine/datasource/graphql_datasource"
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/plan"
+)
+
+const stitchingAccountsSchema = `
+	type Query {
+		me: User
+		user(id: ID!): User
+	}
+	type User {
+		id: ID!
+		name: String!
+		address: Address
+	}
+	type Address {
+		city: String!
+	}
+`
+
+const stitchingReviewsSchema = `
+	type Query {
+		topReviews: [Review!]!
+		reviewAuthor(userId: ID!): User
+	}
+	type User {
+		id: ID!
+		reviews: [Review!]!
+	}
+	type Review {
+		body: String!
+		author: User!
+		address: Address
+	}
+	type Address {
+		street: String!
+	}
+`
+
+func stitchingTestFactory(httpClient *http.Client) *StitchingEngineConfigFactory {
+	return NewStitchingEngineConfigFactory(
+		[]StitchingUpstream{
+			{
+				Name: "accounts",
+				SDL:  stitchingAccountsSchema,
+				DataSourceConfig: graphqlDataSource.Configuration{
+					Fetch: graphqlDataSource.FetchConfiguration{URL: "http://accounts.service"},
+				},
+			},
+			{
+				Name:       "reviews",
+				SDL:        stitchingReviewsSchema,
+				TypePrefix: "Reviews",
+				DataSourceConfig: graphqlDataSource.Configuration{
+					Fetch: graphqlDataSource.FetchConfiguration{URL: "http://reviews.service"},
+				},
+			},
+		},
+		[]StitchingTypeMerge{
+			{
+				TypeName:  "User",
+				KeyFields: []string{"id"},
+				Lookups: []StitchingLookup{
+					{Upstream: "accounts", FieldName: "user"},
+					{Upstream: "reviews", FieldName: "reviewAuthor", Arguments: []graphqlDataSource.LookupArgument{{Name: "userId", KeyField: "id"}}},
+				},
+			},
+		},
+		WithStitchingHttpClient(httpClient),
+	)
+}
+
+func TestStitchingEngineConfigFactory_MergedSchema(t *testing.T) {
+	t.Run("should merge types and rename conflicting types", func(t *testing.T) {
+		schema, err := stitchingTestFactory(http.DefaultClient).MergedSchema()
+		require.NoError(t, err)
+
+		expected, report := astparser.ParseGraphqlDocumentString(`
+			type Query {
+				me: User
+				user(id: ID!): User
+				topReviews: [Review!]!
+				reviewAuthor(userId: ID!): User
+			}
+			type User {
+				id: ID!
+				name: String!
+				address: Address
+				reviews: [Review!]!
+			}
+			type Address {
+				city: String!
+			}
+			type Review {
+				body: String!
+				author: User!
+				address: ReviewsAddress
+			}
+			type ReviewsAddress {
+				street: String!
+			}
+		`)
+		require.False(t, report.HasErrors())
+		expectedSchema, err := astprinter.PrintString(&expected, nil)
+		require.NoError(t, err)
+
+		actual, report := astparser.ParseGraphqlDocumentString(string(schema.Input()))
+		require.False(t, report.HasErrors())
+		actualSchema, err := astprinter.PrintString(&actual, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, expectedSchema, actualSchema)
+	})
+
+	t.Run("should fail on conflicting types without type prefix", func(t *testing.T) {
+		factory := NewStitchingEngineConfigFactory([]StitchingUpstream{
+			{Name: "accounts", SDL: stitchingAccountsSchema},
+			{Name: "reviews", SDL: stitchingReviewsSchema},
+		}, nil)
+		_, err := factory.MergedSchema()
+		assert.EqualError(t, err, "type 'User' of upstream 'reviews' conflicts with upstream 'accounts', add a type merge or a type prefix")
+	})
+
+	t.Run("should fail on root fields of multiple upstreams", func(t *testing.T) {
+		factory := NewStitchingEngineConfigFactory([]StitchingUpstream{
+			{Name: "a", SDL: `type Query { hello: String }`},
+			{Name: "b", SDL: `type Query { hello: String }`},
+		}, nil)
+		_, err := factory.MergedSchema()
+		assert.EqualError(t, err, "merge schemas: field 'Query.hello' is defined by multiple upstreams")
+	})
+
+	t.Run("should fail on lookup of unknown upstream", func(t *testing.T) {
+		factory := NewStitchingEngineConfigFactory([]StitchingUpstream{
+			{Name: "accounts", SDL: stitchingAccountsSchema},
+		}, []StitchingTypeMerge{
+			{TypeName: "User", KeyFields: []string{"id"}, Lookups: []StitchingLookup{{Upstream: "users", FieldName: "user"}}},
+		})
+		_, err := factory.MergedSchema()
+		assert.EqualError(t, err, "lookup of type 'User' references unknown upstream 'users'")
+	})
+}
+
+func TestStitchingEngineConfigFactory_EngineV2Configuration(t *testing.T) {
+	config, err := stitchingTestFactory(http.DefaultClient).EngineV2Configuration()
+	require.NoError(t, err)
+
+	assert.Equal(t, plan.TypeConfigurations{
+		{TypeName: "ReviewsAddress", RenameTo: "Address"},
+	}, config.TypeConfigurations())
+
+	fieldConfigs := config.FieldConfigurations()
+	for _, fieldName := range []string{"name", "address", "reviews"} {
+		fieldConfig := fieldConfigs.ForTypeField("User", fieldName)
+		require.NotNil(t, fieldConfig, fieldName)
+		assert.Equal(t, []string{"id"}, fieldConfig.RequiresFields)
+	}
+	assert.Nil(t, fieldConfigs.ForTypeField("User", "id"), "key fields aren't loaded by lookups")
+
+	dataSources := config.DataSources()
+	require.Len(t, dataSources, 2)
+	assert.Contains(t, dataSources[0].RootNodes, plan.TypeField{TypeName: "User", FieldNames: []string{"name", "address"}})
+	assert.Contains(t, dataSources[1].RootNodes, plan.TypeField{TypeName: "User", FieldNames: []string{"reviews"}})
+	assert.Contains(t, dataSources[1].ChildNodes, plan.TypeField{TypeName: "ReviewsAddress", FieldNames: []string{"street"}})
+
+	assert.Equal(t, graphqlDataSource.ConfigJson(graphqlDataSource.Configuration{
+		Fetch:          graphqlDataSource.FetchConfiguration{URL: "http://reviews.service"},
+		UpstreamSchema: stitchingReviewsSchema,
+		Lookups: []graphqlDataSource.LookupConfiguration{
+			{
+				TypeName:  "User",
+				FieldName: "reviewAuthor",
+				Arguments: []graphqlDataSource.LookupArgument{{Name: "userId", KeyField: "id"}},
+			},
+		},
+	}), dataSources[1].Custom)
+}
+
+func TestExecutionEngineV2_Stitching(t *testing.T) {
+	var (
+		mu             sync.Mutex
+		upstreamBodies = map[string][]string{}
+	)
+	upstreamResponses := map[string]string{
+		`{"query":"{me {name id}}"}`: `{"data":{"me":{"name":"Jens","id":"1"}}}`,
+		`{"query":"query($a: ID!){reviewAuthor(userId: $a){reviews {body address {street}}}}","variables":{"a":"1"}}`: `{"data":{"reviewAuthor":{"reviews":[{"body":"great","address":{"street":"Main Street"}}]}}}`,
+		`{"query":"{topReviews {body author {id}}}"}`:                                                                 `{"data":{"topReviews":[{"body":"great","author":{"id":"1"}},{"body":"okay","author":{"id":"2"}}]}}`,
+		`{"query":"query($a: ID!){user(id: $a){name}}","variables":{"a":"1"}}`:                                        `{"data":{"user":{"name":"Jens"}}}`,
+		`{"query":"query($a: ID!){user(id: $a){name}}","variables":{"a":"2"}}`:                                        `{"data":{"user":{"name":"Stefan"}}}`,
+	}
+	roundTripper := testRoundTripper(func(req *http.Request) *http.Response {
+		body, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+		mu.Lock()
+		upstreamBodies[req.URL.Host] = append(upstreamBodies[req.URL.Host], string(body))
+		mu.Unlock()
+		response, ok := upstreamResponses[string(body)]
+		assert.True(t, ok, "unexpected upstream request: %s", body)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(response)),
+		}
+	})
+
+	config, err := stitchingTestFactory(&http.Client{Transport: roundTripper}).EngineV2Configuration()
+	require.NoError(t, err)
+	engine, err := NewExecutionEngineV2(context.Background(), abstractlogger.NoopLogger, config)
+	require.NoError(t, err)
+
+	execute := func(t *testing.T, query string) string {
+		request := Request{Query: query}
+		resultWriter := NewEngineResultWriter()
+		require.NoError(t, engine.Execute(context.Background(), &request, &resultWriter))
+		return resultWriter.String()
+	}
+
+	t.Run("fields of other upstream on root object", func(t *testing.T) {
+		response := execute(t, `{ me { name reviews { body address { street } } } }`)
+		assert.Equal(t, `{"data":{"me":{"name":"Jens","reviews":[{"body":"great","address":{"street":"Main Street"}}]}}}`, response)
+	})
+
+	t.Run("fields of other upstream on nested objects", func(t *testing.T) {
+		response := execute(t, `{ topReviews { body author { name } } }`)
+		assert.Equal(t, `{"data":{"topReviews":[{"body":"great","author":{"name":"Jens"}},{"body":"okay","author":{"name":"Stefan"}}]}}`, response)
+		assert.Len(t, upstreamBodies["accounts.service"], 3)
+	})
+}
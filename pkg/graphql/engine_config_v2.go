@@ -7,6 +7,7 @@ import (
 	graphqlDataSource "github.com/wundergraph/graphql-go-tools/pkg/engine/datasource/graphql_datasource"
 	"github.com/wundergraph/graphql-go-tools/pkg/engine/plan"
 	"github.com/wundergraph/graphql-go-tools/pkg/engine/resolve"
+	"github.com/wundergraph/graphql-go-tools/pkg/scalars"
 )
 
 const (
@@ -56,6 +57,11 @@ func (e *EngineV2Configuration) SetFieldConfigurations(fieldConfigs plan.FieldCo
 	e.plannerConfig.Fields = fieldConfigs
 }
 
+// SetTypeConfigurations sets the types which are renamed in the schema, see plan.TypeConfiguration
+func (e *EngineV2Configuration) SetTypeConfigurations(typeConfigs plan.TypeConfigurations) {
+	e.plannerConfig.Types = typeConfigs
+}
+
 func (e *EngineV2Configuration) DataSources() []plan.DataSourceConfiguration {
 	return e.plannerConfig.DataSources
 }
@@ -64,10 +70,21 @@ func (e *EngineV2Configuration) FieldConfigurations() plan.FieldConfigurations {
 	return e.plannerConfig.Fields
 }
 
+func (e *EngineV2Configuration) TypeConfigurations() plan.TypeConfigurations {
+	return e.plannerConfig.Types
+}
+
 func (e *EngineV2Configuration) EnableDataLoader(enable bool) {
 	e.dataLoaderConfig.EnableDataLoader = enable
 }
 
+// SetScalarRegistry sets the custom scalars of the schema
+// Literals and variables of registered scalars are validated and coerced by the scalars,
+// resolved values are serialized by them.
+func (e *EngineV2Configuration) SetScalarRegistry(registry *scalars.Registry) {
+	e.plannerConfig.Scalars = registry
+}
+
 // SetWebsocketBeforeStartHook - sets before start hook which will be called before processing any operation sent over websockets
 func (e *EngineV2Configuration) SetWebsocketBeforeStartHook(hook WebsocketBeforeStartHook) {
 	e.websocketBeforeStartHook = hook
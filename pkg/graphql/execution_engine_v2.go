@@ -16,13 +16,16 @@ import (
 	"github.com/wundergraph/graphql-go-tools/pkg/engine/datasource/introspection_datasource"
 
 	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astnormalization"
 	"github.com/wundergraph/graphql-go-tools/pkg/astprinter"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvalidation"
 	"github.com/wundergraph/graphql-go-tools/pkg/engine/datasource/httpclient"
 	"github.com/wundergraph/graphql-go-tools/pkg/engine/plan"
 	"github.com/wundergraph/graphql-go-tools/pkg/engine/resolve"
 	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
 	"github.com/wundergraph/graphql-go-tools/pkg/pool"
 	"github.com/wundergraph/graphql-go-tools/pkg/postprocess"
+	"github.com/wundergraph/graphql-go-tools/pkg/variablesvalidation"
 )
 
 type EngineResultWriter struct {
@@ -141,11 +144,14 @@ func (e *internalExecutionContext) reset() {
 type ExecutionEngineV2 struct {
 	logger                       abstractlogger.Logger
 	config                       EngineV2Configuration
-	planner                      *plan.Planner
-	plannerMu                    sync.Mutex
+	plannerPool                  sync.Pool
+	inflightPlans                *inflightPlans
 	resolver                     *resolve.Resolver
 	internalExecutionContextPool sync.Pool
 	executionPlanCache           *lru.Cache
+	preparedOperationCache       *lru.Cache
+	variablesNormalizerPool      sync.Pool
+	variablesValidatorPool       sync.Pool
 }
 
 type WebsocketBeforeStartHook interface {
@@ -193,11 +199,23 @@ func WithAdditionalHttpHeaders(headers http.Header, excludeByKeys ...string) Exe
 	}
 }
 
+// WithUpstreamResponseHeaders merges the upstream response headers selected by the HeaderRules of the DataSources into header,
+// e.g. the header of the http.ResponseWriter to propagate Set-Cookie or Cache-Control to the client
+func WithUpstreamResponseHeaders(header http.Header) ExecutionOptionsV2 {
+	return func(ctx *internalExecutionContext) {
+		ctx.resolveContext.ResponseHeader = header
+	}
+}
+
 func NewExecutionEngineV2(ctx context.Context, logger abstractlogger.Logger, engineConfig EngineV2Configuration) (*ExecutionEngineV2, error) {
 	executionPlanCache, err := lru.New(1024)
 	if err != nil {
 		return nil, err
 	}
+	preparedOperationCache, err := lru.New(1024)
+	if err != nil {
+		return nil, err
+	}
 	fetcher := resolve.NewFetcher(engineConfig.dataLoaderConfig.EnableSingleFlightLoader)
 
 	introspectionCfg, err := introspection_datasource.NewIntrospectionConfigFactory(&engineConfig.schema.document)
@@ -211,41 +229,43 @@ func NewExecutionEngineV2(ctx context.Context, logger abstractlogger.Logger, eng
 	}
 
 	return &ExecutionEngineV2{
-		logger:   logger,
-		config:   engineConfig,
-		planner:  plan.NewPlanner(ctx, engineConfig.plannerConfig),
-		resolver: resolve.New(ctx, fetcher, engineConfig.dataLoaderConfig.EnableDataLoader),
+		logger: logger,
+		config: engineConfig,
+		plannerPool: sync.Pool{
+			New: func() interface{} {
+				return plan.NewPlanner(ctx, engineConfig.plannerConfig)
+			},
+		},
+		inflightPlans: newInflightPlans(),
+		resolver:      resolve.New(ctx, fetcher, engineConfig.dataLoaderConfig.EnableDataLoader),
 		internalExecutionContextPool: sync.Pool{
 			New: func() interface{} {
 				return newInternalExecutionContext()
 			},
 		},
-		executionPlanCache: executionPlanCache,
+		executionPlanCache:     executionPlanCache,
+		preparedOperationCache: preparedOperationCache,
+		variablesNormalizerPool: sync.Pool{
+			New: func() interface{} {
+				return astnormalization.NewVariablesNormalizer()
+			},
+		},
+		variablesValidatorPool: sync.Pool{
+			New: func() interface{} {
+				return variablesvalidation.NewVariablesValidator(variablesvalidation.WithScalarRegistry(engineConfig.plannerConfig.Scalars))
+			},
+		},
 	}, nil
 }
 
 func (e *ExecutionEngineV2) Execute(ctx context.Context, operation *Request, writer resolve.FlushWriter, options ...ExecutionOptionsV2) error {
-	if !operation.IsNormalized() {
-		result, err := operation.Normalize(e.config.schema)
-		if err != nil {
-			return err
-		}
-
-		if !result.Successful {
-			return result.Errors
-		}
-	}
+	execContext := e.getExecutionCtx()
+	defer e.putExecutionCtx(execContext)
 
-	result, err := operation.ValidateForSchema(e.config.schema)
+	cachedPlan, err := e.getPreparedPlan(execContext, operation)
 	if err != nil {
 		return err
 	}
-	if !result.Valid {
-		return result.Errors
-	}
-
-	execContext := e.getExecutionCtx()
-	defer e.putExecutionCtx(execContext)
 
 	execContext.prepare(ctx, operation.Variables, operation.request)
 
@@ -253,15 +273,11 @@ func (e *ExecutionEngineV2) Execute(ctx context.Context, operation *Request, wri
 		options[i](execContext)
 	}
 
-	var report operationreport.Report
-	cachedPlan := e.getCachedPlan(execContext, &operation.document, &e.config.schema.document, operation.OperationName, &report)
-	if report.HasErrors() {
-		return report
-	}
-
 	switch p := cachedPlan.(type) {
 	case *plan.SynchronousResponsePlan:
 		err = e.resolver.ResolveGraphQLResponse(execContext.resolveContext, p.Response, nil, writer)
+	case *plan.StreamingResponsePlan:
+		err = e.resolver.ResolveGraphQLIncrementalResponse(execContext.resolveContext, p.Response, nil, writer)
 	case *plan.SubscriptionResponsePlan:
 		err = e.resolver.ResolveGraphQLSubscription(execContext.resolveContext, p.Response, writer)
 	default:
@@ -271,35 +287,166 @@ func (e *ExecutionEngineV2) Execute(ctx context.Context, operation *Request, wri
 	return err
 }
 
-func (e *ExecutionEngineV2) getCachedPlan(ctx *internalExecutionContext, operation, definition *ast.Document, operationName string, report *operationreport.Report) plan.Plan {
+// getPreparedPlan normalizes and validates the operation and returns its plan
+// Operations which haven't been normalized yet are looked up in the prepared operation cache first.
+// On a cache hit parsing, normalization, validation and printing of the operation are skipped,
+// only the variables of the request get normalized.
+func (e *ExecutionEngineV2) getPreparedPlan(ctx *internalExecutionContext, operation *Request) (plan.Plan, error) {
+	if operation.IsNormalized() {
+		cachedPlan, _, err := e.validateAndPlan(ctx, operation)
+		if err != nil {
+			return nil, err
+		}
+		return cachedPlan, e.validateVariables(&operation.document, operation)
+	}
+
+	preparedKey := preparedOperationCacheKey(operation, e.config.schema)
+	if cachedPlan, ok, err := e.getPreparedOperationPlan(preparedKey, operation); ok || err != nil {
+		return cachedPlan, err
+	}
+
+	report := operation.parseQueryOnce()
+	var variables operationVariables
+	if !report.HasErrors() {
+		var err error
+		variables, err = newOperationVariables(&operation.document, operation.OperationName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := operation.Normalize(e.config.schema)
+	if err != nil {
+		return nil, err
+	}
+	if !result.Successful {
+		return nil, result.Errors
+	}
 
+	cachedPlan, printedOperation, err := e.validateAndPlan(ctx, operation)
+	if err != nil {
+		return nil, err
+	}
+
+	prepared, err := newPreparedOperation(printedOperation, operation.OperationName, printedOperationHash(printedOperation), variables, operation.Variables)
+	if err != nil {
+		return nil, err
+	}
+	e.preparedOperationCache.Add(preparedKey, prepared)
+
+	return cachedPlan, e.validateVariables(&operation.document, operation)
+}
+
+// getPreparedOperationPlan returns the plan of a cached prepared operation and normalizes the variables of the request
+// If the plan has been evicted from the plan cache in the meantime the operation has to be prepared again.
+func (e *ExecutionEngineV2) getPreparedOperationPlan(preparedKey uint64, operation *Request) (plan.Plan, bool, error) {
+	cached, ok := e.preparedOperationCache.Get(preparedKey)
+	if !ok {
+		return nil, false, nil
+	}
+	prepared := cached.(*preparedOperation)
+
+	cachedPlan, ok := e.executionPlanCache.Get(prepared.planCacheKey)
+	if !ok {
+		return nil, false, nil
+	}
+
+	normalizer := e.variablesNormalizerPool.Get().(*astnormalization.VariablesNormalizer)
+	variables, err := prepared.normalizeVariables(normalizer, &e.config.schema.document, operation.Variables)
+	e.variablesNormalizerPool.Put(normalizer)
+	if err != nil {
+		return nil, false, err
+	}
+
+	operation.Variables = variables
+	return cachedPlan.(plan.Plan), true, e.validateVariables(&prepared.document, operation)
+}
+
+// validateVariables validates the normalized variables of the request against the variable definitions of the normalized operation
+func (e *ExecutionEngineV2) validateVariables(document *ast.Document, operation *Request) error {
+	validator := e.variablesValidatorPool.Get().(*variablesvalidation.VariablesValidator)
+	defer e.variablesValidatorPool.Put(validator)
+
+	var report operationreport.Report
+	variables := validator.Validate(document, &e.config.schema.document, []byte(operation.OperationName), operation.Variables, &report)
+	if len(report.InternalErrors) != 0 {
+		return report.InternalErrors[0]
+	}
+	if report.HasErrors() {
+		return RequestErrorsFromOperationReport(report)
+	}
+	operation.Variables = variables
+	return nil
+}
+
+// validateAndPlan validates the normalized operation and returns its plan together with the printed operation
+func (e *ExecutionEngineV2) validateAndPlan(ctx *internalExecutionContext, operation *Request) (plan.Plan, []byte, error) {
+	result, err := operation.validateForSchema(e.config.schema, astvalidation.WithScalarRegistry(e.config.plannerConfig.Scalars))
+	if err != nil {
+		return nil, nil, err
+	}
+	if !result.Valid {
+		return nil, nil, result.Errors
+	}
+
+	printedOperation, err := astprinter.PrintString(&operation.document, &e.config.schema.document)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var report operationreport.Report
+	cachedPlan := e.getCachedPlanByKey(ctx, printedOperationHash([]byte(printedOperation)), &operation.document, &e.config.schema.document, operation.OperationName, &report)
+	if report.HasErrors() {
+		return nil, nil, report
+	}
+
+	return cachedPlan, []byte(printedOperation), nil
+}
+
+func printedOperationHash(printedOperation []byte) uint64 {
 	hash := pool.Hash64.Get()
 	hash.Reset()
 	defer pool.Hash64.Put(hash)
-	err := astprinter.Print(operation, definition, hash)
-	if err != nil {
-		report.AddInternalError(err)
-		return nil
+	_, _ = hash.Write(printedOperation)
+	return hash.Sum64()
+}
+
+func (e *ExecutionEngineV2) getCachedPlanByKey(ctx *internalExecutionContext, cacheKey uint64, operation, definition *ast.Document, operationName string, report *operationreport.Report) plan.Plan {
+	if cached, ok := e.executionPlanCache.Get(cacheKey); ok {
+		if p, ok := cached.(plan.Plan); ok {
+			return p
+		}
 	}
 
-	cacheKey := hash.Sum64()
+	// concurrent cache misses for the same operation are planned only once
+	inflight, isLeader := e.inflightPlans.acquire(cacheKey)
+	if !isLeader {
+		inflight.wait()
+		inflight.copyReport(report)
+		return inflight.plan
+	}
+	defer e.inflightPlans.release(cacheKey, inflight)
 
+	// the plan might have been added to the cache while waiting for the inflight plan to be acquired
 	if cached, ok := e.executionPlanCache.Get(cacheKey); ok {
 		if p, ok := cached.(plan.Plan); ok {
+			inflight.plan = p
 			return p
 		}
 	}
 
-	e.plannerMu.Lock()
-	defer e.plannerMu.Unlock()
-	planResult := e.planner.Plan(operation, definition, operationName, report)
-	if report.HasErrors() {
+	planner := e.plannerPool.Get().(*plan.Planner)
+	planResult := planner.Plan(operation, definition, operationName, &inflight.report)
+	e.plannerPool.Put(planner)
+
+	inflight.copyReport(report)
+	if inflight.report.HasErrors() {
 		return nil
 	}
 
-	p := ctx.postProcessor.Process(planResult)
-	e.executionPlanCache.Add(cacheKey, p)
-	return p
+	inflight.plan = ctx.postProcessor.Process(planResult)
+	e.executionPlanCache.Add(cacheKey, inflight.plan)
+	return inflight.plan
 }
 
 func (e *ExecutionEngineV2) GetWebsocketBeforeStartHook() WebsocketBeforeStartHook {
@@ -1,28 +1,35 @@
 package graphql
 
 import (
+	"bytes"
 	"compress/flate"
 	"compress/gzip"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/buger/jsonparser"
 	"github.com/jensneuse/abstractlogger"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/wundergraph/graphql-go-tools/pkg/astprinter"
 	"github.com/wundergraph/graphql-go-tools/pkg/engine/datasource/graphql_datasource"
 	"github.com/wundergraph/graphql-go-tools/pkg/engine/datasource/httpclient"
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/datasource/local_datasource"
 	"github.com/wundergraph/graphql-go-tools/pkg/engine/datasource/rest_datasource"
 	"github.com/wundergraph/graphql-go-tools/pkg/engine/datasource/staticdatasource"
 	"github.com/wundergraph/graphql-go-tools/pkg/engine/plan"
 	"github.com/wundergraph/graphql-go-tools/pkg/engine/resolve"
 	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+	"github.com/wundergraph/graphql-go-tools/pkg/scalars"
 	"github.com/wundergraph/graphql-go-tools/pkg/starwars"
 	"github.com/wundergraph/graphql-go-tools/pkg/testing/federationtesting"
 	accounts "github.com/wundergraph/graphql-go-tools/pkg/testing/federationtesting/accounts/graph"
@@ -306,7 +313,7 @@ func TestExecutionEngineV2_Execute(t *testing.T) {
 				operation: func(t *testing.T) Request {
 					return requestForQuery(t, starwars.FileIntrospectionQuery)
 				},
-				expectedResponse: `{"data":{"__schema":{"queryType":{"name":"Query"},"mutationType":{"name":"Mutation"},"subscriptionType":{"name":"Subscription"},"types":[{"kind":"UNION","name":"SearchResult","description":"","fields":null,"inputFields":[],"interfaces":[],"enumValues":null,"possibleTypes":[{"kind":"OBJECT","name":"Human","ofType":null},{"kind":"OBJECT","name":"Droid","ofType":null},{"kind":"OBJECT","name":"Starship","ofType":null}]},{"kind":"OBJECT","name":"Query","description":"","fields":[{"name":"hero","description":"","args":[],"type":{"kind":"INTERFACE","name":"Character","ofType":null},"isDeprecated":true,"deprecationReason":"No longer supported"},{"name":"droid","description":"","args":[{"name":"id","description":"","type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"SCALAR","name":"ID","ofType":null}},"defaultValue":null}],"type":{"kind":"OBJECT","name":"Droid","ofType":null},"isDeprecated":false,"deprecationReason":null},{"name":"search","description":"","args":[{"name":"name","description":"","type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"SCALAR","name":"String","ofType":null}},"defaultValue":null}],"type":{"kind":"UNION","name":"SearchResult","ofType":null},"isDeprecated":false,"deprecationReason":null}],"inputFields":[],"interfaces":[],"enumValues":null,"possibleTypes":[]},{"kind":"OBJECT","name":"Mutation","description":"","fields":[{"name":"createReview","description":"","args":[{"name":"episode","description":"","type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"ENUM","name":"Episode","ofType":null}},"defaultValue":null},{"name":"review","description":"","type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"INPUT_OBJECT","name":"ReviewInput","ofType":null}},"defaultValue":null}],"type":{"kind":"OBJECT","name":"Review","ofType":null},"isDeprecated":false,"deprecationReason":null}],"inputFields":[],"interfaces":[],"enumValues":null,"possibleTypes":[]},{"kind":"OBJECT","name":"Subscription","description":"","fields":[{"name":"remainingJedis","description":"","args":[],"type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"SCALAR","name":"Int","ofType":null}},"isDeprecated":false,"deprecationReason":null}],"inputFields":[],"interfaces":[],"enumValues":null,"possibleTypes":[]},{"kind":"INPUT_OBJECT","name":"ReviewInput","description":"","fields":null,"inputFields":[{"name":"stars","description":"","type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"SCALAR","name":"Int","ofType":null}},"defaultValue":null},{"name":"commentary","description":"","type":{"kind":"SCALAR","name":"String","ofType":null},"defaultValue":null}],"interfaces":[],"enumValues":null,"possibleTypes":[]},{"kind":"OBJECT","name":"Review","description":"","fields":[{"name":"id","description":"","args":[],"type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"SCALAR","name":"ID","ofType":null}},"isDeprecated":false,"deprecationReason":null},{"name":"stars","description":"","args":[],"type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"SCALAR","name":"Int","ofType":null}},"isDeprecated":false,"deprecationReason":null},{"name":"commentary","description":"","args":[],"type":{"kind":"SCALAR","name":"String","ofType":null},"isDeprecated":false,"deprecationReason":null}],"inputFields":[],"interfaces":[],"enumValues":null,"possibleTypes":[]},{"kind":"ENUM","name":"Episode","description":"","fields":null,"inputFields":[],"interfaces":[],"enumValues":[{"name":"NEWHOPE","description":"","isDeprecated":false,"deprecationReason":null},{"name":"EMPIRE","description":"","isDeprecated":false,"deprecationReason":null},{"name":"JEDI","description":"","isDeprecated":true,"deprecationReason":"No longer supported"}],"possibleTypes":[]},{"kind":"INTERFACE","name":"Character","description":"","fields":[{"name":"name","description":"","args":[],"type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"SCALAR","name":"String","ofType":null}},"isDeprecated":false,"deprecationReason":null},{"name":"friends","description":"","args":[],"type":{"kind":"LIST","name":null,"ofType":{"kind":"INTERFACE","name":"Character","ofType":null}},"isDeprecated":false,"deprecationReason":null}],"inputFields":[],"interfaces":[],"enumValues":null,"possibleTypes":[{"kind":"OBJECT","name":"Human","ofType":null},{"kind":"OBJECT","name":"Droid","ofType":null}]},{"kind":"OBJECT","name":"Human","description":"","fields":[{"name":"name","description":"","args":[],"type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"SCALAR","name":"String","ofType":null}},"isDeprecated":false,"deprecationReason":null},{"name":"height","description":"","args":[],"type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"SCALAR","name":"String","ofType":null}},"isDeprecated":true,"deprecationReason":"No longer supported"},{"name":"friends","description":"","args":[],"type":{"kind":"LIST","name":null,"ofType":{"kind":"INTERFACE","name":"Character","ofType":null}},"isDeprecated":false,"deprecationReason":null}],"inputFields":[],"interfaces":[{"kind":"INTERFACE","name":"Character","ofType":null}],"enumValues":null,"possibleTypes":[]},{"kind":"OBJECT","name":"Droid","description":"","fields":[{"name":"name","description":"","args":[],"type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"SCALAR","name":"String","ofType":null}},"isDeprecated":false,"deprecationReason":null},{"name":"primaryFunction","description":"","args":[],"type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"SCALAR","name":"String","ofType":null}},"isDeprecated":false,"deprecationReason":null},{"name":"friends","description":"","args":[],"type":{"kind":"LIST","name":null,"ofType":{"kind":"INTERFACE","name":"Character","ofType":null}},"isDeprecated":false,"deprecationReason":null}],"inputFields":[],"interfaces":[{"kind":"INTERFACE","name":"Character","ofType":null}],"enumValues":null,"possibleTypes":[]},{"kind":"OBJECT","name":"Starship","description":"","fields":[{"name":"name","description":"","args":[],"type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"SCALAR","name":"String","ofType":null}},"isDeprecated":false,"deprecationReason":null},{"name":"length","description":"","args":[],"type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"SCALAR","name":"Float","ofType":null}},"isDeprecated":false,"deprecationReason":null}],"inputFields":[],"interfaces":[],"enumValues":null,"possibleTypes":[]},{"kind":"SCALAR","name":"Int","description":"The 'Int' scalar type represents non-fractional signed whole numeric values. Int can represent values between -(2^31) and 2^31 - 1.","fields":null,"inputFields":[],"interfaces":[],"enumValues":null,"possibleTypes":[]},{"kind":"SCALAR","name":"Float","description":"The 'Float' scalar type represents signed double-precision fractional values as specified by [IEEE 754](http://en.wikipedia.org/wiki/IEEE_floating_point).","fields":null,"inputFields":[],"interfaces":[],"enumValues":null,"possibleTypes":[]},{"kind":"SCALAR","name":"String","description":"The 'String' scalar type represents textual data, represented as UTF-8 character sequences. The String type is most often used by GraphQL to represent free-form human-readable text.","fields":null,"inputFields":[],"interfaces":[],"enumValues":null,"possibleTypes":[]},{"kind":"SCALAR","name":"Boolean","description":"The 'Boolean' scalar type represents 'true' or 'false' .","fields":null,"inputFields":[],"interfaces":[],"enumValues":null,"possibleTypes":[]},{"kind":"SCALAR","name":"ID","description":"The 'ID' scalar type represents a unique identifier, often used to refetch an object or as key for a cache. The ID type appears in a JSON response as a String; however, it is not intended to be human-readable. When expected as an input type, any string (such as '4') or integer (such as 4) input value will be accepted as an ID.","fields":null,"inputFields":[],"interfaces":[],"enumValues":null,"possibleTypes":[]}],"directives":[{"name":"include","description":"Directs the executor to include this field or fragment only when the argument is true.","locations":["FIELD","FRAGMENT_SPREAD","INLINE_FRAGMENT"],"args":[{"name":"if","description":"Included when true.","type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"SCALAR","name":"Boolean","ofType":null}},"defaultValue":null}]},{"name":"skip","description":"Directs the executor to skip this field or fragment when the argument is true.","locations":["FIELD","FRAGMENT_SPREAD","INLINE_FRAGMENT"],"args":[{"name":"if","description":"Skipped when true.","type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"SCALAR","name":"Boolean","ofType":null}},"defaultValue":null}]},{"name":"deprecated","description":"Marks an element of a GraphQL schema as no longer supported.","locations":["FIELD_DEFINITION","ENUM_VALUE"],"args":[{"name":"reason","description":"Explains why this element was deprecated, usually also including a suggestion\n    for how to access supported similar data. Formatted in\n    [Markdown](https://daringfireball.net/projects/markdown/).","type":{"kind":"SCALAR","name":"String","ofType":null},"defaultValue":"\"No longer supported\""}]}]}}}`,
+				expectedResponse: `{"data":{"__schema":{"queryType":{"name":"Query"},"mutationType":{"name":"Mutation"},"subscriptionType":{"name":"Subscription"},"types":[{"kind":"UNION","name":"SearchResult","description":"","fields":null,"inputFields":[],"interfaces":[],"enumValues":null,"possibleTypes":[{"kind":"OBJECT","name":"Human","ofType":null},{"kind":"OBJECT","name":"Droid","ofType":null},{"kind":"OBJECT","name":"Starship","ofType":null}]},{"kind":"OBJECT","name":"Query","description":"","fields":[{"name":"hero","description":"","args":[],"type":{"kind":"INTERFACE","name":"Character","ofType":null},"isDeprecated":true,"deprecationReason":"No longer supported"},{"name":"droid","description":"","args":[{"name":"id","description":"","type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"SCALAR","name":"ID","ofType":null}},"defaultValue":null}],"type":{"kind":"OBJECT","name":"Droid","ofType":null},"isDeprecated":false,"deprecationReason":null},{"name":"search","description":"","args":[{"name":"name","description":"","type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"SCALAR","name":"String","ofType":null}},"defaultValue":null}],"type":{"kind":"UNION","name":"SearchResult","ofType":null},"isDeprecated":false,"deprecationReason":null}],"inputFields":[],"interfaces":[],"enumValues":null,"possibleTypes":[]},{"kind":"OBJECT","name":"Mutation","description":"","fields":[{"name":"createReview","description":"","args":[{"name":"episode","description":"","type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"ENUM","name":"Episode","ofType":null}},"defaultValue":null},{"name":"review","description":"","type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"INPUT_OBJECT","name":"ReviewInput","ofType":null}},"defaultValue":null}],"type":{"kind":"OBJECT","name":"Review","ofType":null},"isDeprecated":false,"deprecationReason":null}],"inputFields":[],"interfaces":[],"enumValues":null,"possibleTypes":[]},{"kind":"OBJECT","name":"Subscription","description":"","fields":[{"name":"remainingJedis","description":"","args":[],"type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"SCALAR","name":"Int","ofType":null}},"isDeprecated":false,"deprecationReason":null}],"inputFields":[],"interfaces":[],"enumValues":null,"possibleTypes":[]},{"kind":"INPUT_OBJECT","name":"ReviewInput","description":"","fields":null,"inputFields":[{"name":"stars","description":"","type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"SCALAR","name":"Int","ofType":null}},"defaultValue":null},{"name":"commentary","description":"","type":{"kind":"SCALAR","name":"String","ofType":null},"defaultValue":null}],"interfaces":[],"enumValues":null,"possibleTypes":[]},{"kind":"OBJECT","name":"Review","description":"","fields":[{"name":"id","description":"","args":[],"type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"SCALAR","name":"ID","ofType":null}},"isDeprecated":false,"deprecationReason":null},{"name":"stars","description":"","args":[],"type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"SCALAR","name":"Int","ofType":null}},"isDeprecated":false,"deprecationReason":null},{"name":"commentary","description":"","args":[],"type":{"kind":"SCALAR","name":"String","ofType":null},"isDeprecated":false,"deprecationReason":null}],"inputFields":[],"interfaces":[],"enumValues":null,"possibleTypes":[]},{"kind":"ENUM","name":"Episode","description":"","fields":null,"inputFields":[],"interfaces":[],"enumValues":[{"name":"NEWHOPE","description":"","isDeprecated":false,"deprecationReason":null},{"name":"EMPIRE","description":"","isDeprecated":false,"deprecationReason":null},{"name":"JEDI","description":"","isDeprecated":true,"deprecationReason":"No longer supported"}],"possibleTypes":[]},{"kind":"INTERFACE","name":"Character","description":"","fields":[{"name":"name","description":"","args":[],"type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"SCALAR","name":"String","ofType":null}},"isDeprecated":false,"deprecationReason":null},{"name":"friends","description":"","args":[],"type":{"kind":"LIST","name":null,"ofType":{"kind":"INTERFACE","name":"Character","ofType":null}},"isDeprecated":false,"deprecationReason":null}],"inputFields":[],"interfaces":[],"enumValues":null,"possibleTypes":[{"kind":"OBJECT","name":"Human","ofType":null},{"kind":"OBJECT","name":"Droid","ofType":null}]},{"kind":"OBJECT","name":"Human","description":"","fields":[{"name":"name","description":"","args":[],"type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"SCALAR","name":"String","ofType":null}},"isDeprecated":false,"deprecationReason":null},{"name":"height","description":"","args":[],"type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"SCALAR","name":"String","ofType":null}},"isDeprecated":true,"deprecationReason":"No longer supported"},{"name":"friends","description":"","args":[],"type":{"kind":"LIST","name":null,"ofType":{"kind":"INTERFACE","name":"Character","ofType":null}},"isDeprecated":false,"deprecationReason":null}],"inputFields":[],"interfaces":[{"kind":"INTERFACE","name":"Character","ofType":null}],"enumValues":null,"possibleTypes":[]},{"kind":"OBJECT","name":"Droid","description":"","fields":[{"name":"name","description":"","args":[],"type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"SCALAR","name":"String","ofType":null}},"isDeprecated":false,"deprecationReason":null},{"name":"primaryFunction","description":"","args":[],"type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"SCALAR","name":"String","ofType":null}},"isDeprecated":false,"deprecationReason":null},{"name":"friends","description":"","args":[],"type":{"kind":"LIST","name":null,"ofType":{"kind":"INTERFACE","name":"Character","ofType":null}},"isDeprecated":false,"deprecationReason":null}],"inputFields":[],"interfaces":[{"kind":"INTERFACE","name":"Character","ofType":null}],"enumValues":null,"possibleTypes":[]},{"kind":"OBJECT","name":"Starship","description":"","fields":[{"name":"name","description":"","args":[],"type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"SCALAR","name":"String","ofType":null}},"isDeprecated":false,"deprecationReason":null},{"name":"length","description":"","args":[],"type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"SCALAR","name":"Float","ofType":null}},"isDeprecated":false,"deprecationReason":null}],"inputFields":[],"interfaces":[],"enumValues":null,"possibleTypes":[]},{"kind":"SCALAR","name":"Int","description":"The 'Int' scalar type represents non-fractional signed whole numeric values. Int can represent values between -(2^31) and 2^31 - 1.","fields":null,"inputFields":[],"interfaces":[],"enumValues":null,"possibleTypes":[]},{"kind":"SCALAR","name":"Float","description":"The 'Float' scalar type represents signed double-precision fractional values as specified by [IEEE 754](http://en.wikipedia.org/wiki/IEEE_floating_point).","fields":null,"inputFields":[],"interfaces":[],"enumValues":null,"possibleTypes":[]},{"kind":"SCALAR","name":"String","description":"The 'String' scalar type represents textual data, represented as UTF-8 character sequences. The String type is most often used by GraphQL to represent free-form human-readable text.","fields":null,"inputFields":[],"interfaces":[],"enumValues":null,"possibleTypes":[]},{"kind":"SCALAR","name":"Boolean","description":"The 'Boolean' scalar type represents 'true' or 'false' .","fields":null,"inputFields":[],"interfaces":[],"enumValues":null,"possibleTypes":[]},{"kind":"SCALAR","name":"ID","description":"The 'ID' scalar type represents a unique identifier, often used to refetch an object or as key for a cache. The ID type appears in a JSON response as a String; however, it is not intended to be human-readable. When expected as an input type, any string (such as '4') or integer (such as 4) input value will be accepted as an ID.","fields":null,"inputFields":[],"interfaces":[],"enumValues":null,"possibleTypes":[]}],"directives":[{"name":"include","description":"Directs the executor to include this field or fragment only when the argument is true.","locations":["FIELD","FRAGMENT_SPREAD","INLINE_FRAGMENT"],"args":[{"name":"if","description":"Included when true.","type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"SCALAR","name":"Boolean","ofType":null}},"defaultValue":null}]},{"name":"skip","description":"Directs the executor to skip this field or fragment when the argument is true.","locations":["FIELD","FRAGMENT_SPREAD","INLINE_FRAGMENT"],"args":[{"name":"if","description":"Skipped when true.","type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"SCALAR","name":"Boolean","ofType":null}},"defaultValue":null}]},{"name":"deprecated","description":"Marks an element of a GraphQL schema as no longer supported.","locations":["FIELD_DEFINITION","ARGUMENT_DEFINITION","ENUM_VALUE","INPUT_FIELD_DEFINITION"],"args":[{"name":"reason","description":"Explains why this element was deprecated, usually also including a suggestion\n    for how to access supported similar data. Formatted in\n    [Markdown](https://daringfireball.net/projects/markdown/).","type":{"kind":"SCALAR","name":"String","ofType":null},"defaultValue":"\"No longer supported\""}]}]}}}`,
 			},
 		))
 	})
@@ -854,7 +861,7 @@ func TestExecutionEngineV2_Execute(t *testing.T) {
 						Custom: graphql_datasource.ConfigJson(graphql_datasource.Configuration{
 							Fetch: graphql_datasource.FetchConfiguration{
 								URL:    "https://example.com/",
-								Method: "GET",
+								Method: "POST",
 							},
 						}),
 					},
@@ -909,7 +916,7 @@ func TestExecutionEngineV2_Execute(t *testing.T) {
 						Custom: graphql_datasource.ConfigJson(graphql_datasource.Configuration{
 							Fetch: graphql_datasource.FetchConfiguration{
 								URL:    "https://example.com/",
-								Method: "GET",
+								Method: "POST",
 							},
 						}),
 					},
@@ -963,7 +970,7 @@ func TestExecutionEngineV2_Execute(t *testing.T) {
 						Custom: graphql_datasource.ConfigJson(graphql_datasource.Configuration{
 							Fetch: graphql_datasource.FetchConfiguration{
 								URL:    "https://example.com/",
-								Method: "GET",
+								Method: "POST",
 							},
 						}),
 					},
@@ -1026,7 +1033,7 @@ func TestExecutionEngineV2_Execute(t *testing.T) {
 						Custom: graphql_datasource.ConfigJson(graphql_datasource.Configuration{
 							Fetch: graphql_datasource.FetchConfiguration{
 								URL:    "https://example.com/",
-								Method: "GET",
+								Method: "POST",
 							},
 						}),
 					},
@@ -1089,7 +1096,7 @@ func TestExecutionEngineV2_Execute(t *testing.T) {
 					Custom: graphql_datasource.ConfigJson(graphql_datasource.Configuration{
 						Fetch: graphql_datasource.FetchConfiguration{
 							URL:    "https://example.com/",
-							Method: "GET",
+							Method: "POST",
 						},
 					}),
 				},
@@ -1501,13 +1508,13 @@ func TestExecutionWithOptions(t *testing.T) {
 	resultWriter := NewEngineResultWriter()
 	err = engine.Execute(context.Background(), &operation, &resultWriter, WithBeforeFetchHook(before), WithAfterFetchHook(after))
 
-	assert.Equal(t, `{"method":"GET","url":"https://example.com/","body":{"query":"{hero}"}}`, before.input)
+	assert.Equal(t, `{"url_encode_body":true,"method":"GET","url":"https://example.com/","body":{"query":"{hero}"}}`, before.input)
 	assert.Equal(t, `{"hero":{"name":"Luke Skywalker"}}`, after.data)
 	assert.Equal(t, "", after.err)
 	assert.NoError(t, err)
 }
 
-func TestExecutionEngineV2_GetCachedPlan(t *testing.T) {
+func TestExecutionEngineV2_PlanCache(t *testing.T) {
 	schema, err := NewSchemaFromString(testSubscriptionDefinition)
 	require.NoError(t, err)
 
@@ -1575,10 +1582,10 @@ func TestExecutionEngineV2_GetCachedPlan(t *testing.T) {
 			http.CanonicalHeaderKey("Authorization"): []string{"123abc"},
 		}
 
-		report := operationreport.Report{}
-		cachedPlan := engine.getCachedPlan(firstInternalExecCtx, &gqlRequest.document, &schema.document, gqlRequest.OperationName, &report)
+		firstRequest := Request{OperationName: gqlRequest.OperationName, Query: gqlRequest.Query}
+		cachedPlan, err := engine.getPreparedPlan(firstInternalExecCtx, &firstRequest)
+		require.NoError(t, err)
 		_, oldestCachedPlan, _ := engine.executionPlanCache.GetOldest()
-		assert.False(t, report.HasErrors())
 		assert.Equal(t, 1, engine.executionPlanCache.Len())
 		assert.Equal(t, cachedPlan, oldestCachedPlan.(*plan.SubscriptionResponsePlan))
 
@@ -1587,11 +1594,17 @@ func TestExecutionEngineV2_GetCachedPlan(t *testing.T) {
 			http.CanonicalHeaderKey("Authorization"): []string{"123abc"},
 		}
 
-		cachedPlan = engine.getCachedPlan(secondInternalExecCtx, &gqlRequest.document, &schema.document, gqlRequest.OperationName, &report)
-		_, oldestCachedPlan, _ = engine.executionPlanCache.GetOldest()
-		assert.False(t, report.HasErrors())
+		secondRequest := Request{OperationName: gqlRequest.OperationName, Query: gqlRequest.Query}
+		secondCachedPlan, err := engine.getPreparedPlan(secondInternalExecCtx, &secondRequest)
+		require.NoError(t, err)
 		assert.Equal(t, 1, engine.executionPlanCache.Len())
-		assert.Equal(t, cachedPlan, oldestCachedPlan.(*plan.SubscriptionResponsePlan))
+		assert.True(t, cachedPlan == secondCachedPlan, "the cached plan should be reused")
+
+		// an already normalized request bypasses the prepared operation cache and hits the plan cache directly
+		thirdCachedPlan, err := engine.getPreparedPlan(newInternalExecutionContext(), &gqlRequest)
+		require.NoError(t, err)
+		assert.Equal(t, 1, engine.executionPlanCache.Len())
+		assert.True(t, cachedPlan == thirdCachedPlan, "the cached plan should be reused")
 	})
 
 	t.Run("should create new plan and cache it", func(t *testing.T) {
@@ -1603,10 +1616,10 @@ func TestExecutionEngineV2_GetCachedPlan(t *testing.T) {
 			http.CanonicalHeaderKey("Authorization"): []string{"123abc"},
 		}
 
-		report := operationreport.Report{}
-		cachedPlan := engine.getCachedPlan(firstInternalExecCtx, &gqlRequest.document, &schema.document, gqlRequest.OperationName, &report)
+		firstRequest := Request{OperationName: gqlRequest.OperationName, Query: gqlRequest.Query}
+		cachedPlan, err := engine.getPreparedPlan(firstInternalExecCtx, &firstRequest)
+		require.NoError(t, err)
 		_, oldestCachedPlan, _ := engine.executionPlanCache.GetOldest()
-		assert.False(t, report.HasErrors())
 		assert.Equal(t, 1, engine.executionPlanCache.Len())
 		assert.Equal(t, cachedPlan, oldestCachedPlan.(*plan.SubscriptionResponsePlan))
 
@@ -1615,12 +1628,366 @@ func TestExecutionEngineV2_GetCachedPlan(t *testing.T) {
 			http.CanonicalHeaderKey("Authorization"): []string{"xyz098"},
 		}
 
-		cachedPlan = engine.getCachedPlan(secondInternalExecCtx, &differentGqlRequest.document, &schema.document, differentGqlRequest.OperationName, &report)
+		secondRequest := Request{OperationName: differentGqlRequest.OperationName, Query: differentGqlRequest.Query}
+		cachedPlan, err = engine.getPreparedPlan(secondInternalExecCtx, &secondRequest)
+		require.NoError(t, err)
 		_, oldestCachedPlan, _ = engine.executionPlanCache.GetOldest()
-		assert.False(t, report.HasErrors())
 		assert.Equal(t, 2, engine.executionPlanCache.Len())
 		assert.NotEqual(t, cachedPlan, oldestCachedPlan.(*plan.SubscriptionResponsePlan))
 	})
+
+	t.Run("should plan concurrent cache misses for the same operation only once", func(t *testing.T) {
+		t.Cleanup(engine.executionPlanCache.Purge)
+		require.Equal(t, 0, engine.executionPlanCache.Len())
+
+		const concurrency = 16
+		requests := make([]Request, concurrency)
+		for i := range requests {
+			requests[i] = Request{
+				OperationName: "LastRegisteredUser",
+				Query:         testSubscriptionLastRegisteredUserOperation,
+			}
+			normalizationResult, err := requests[i].Normalize(schema)
+			require.NoError(t, err)
+			require.True(t, normalizationResult.Successful)
+		}
+		printedOperation, err := astprinter.PrintString(&requests[0].document, &schema.document)
+		require.NoError(t, err)
+		cacheKey := printedOperationHash([]byte(printedOperation))
+
+		plans := make([]plan.Plan, concurrency)
+		reports := make([]operationreport.Report, concurrency)
+		start := make(chan struct{})
+		wg := &sync.WaitGroup{}
+		wg.Add(concurrency)
+		for i := 0; i < concurrency; i++ {
+			go func(i int) {
+				defer wg.Done()
+				<-start
+				plans[i] = engine.getCachedPlanByKey(newInternalExecutionContext(), cacheKey, &requests[i].document, &schema.document, requests[i].OperationName, &reports[i])
+			}(i)
+		}
+		close(start)
+		wg.Wait()
+
+		assert.Equal(t, 1, engine.executionPlanCache.Len())
+		for i := range plans {
+			assert.False(t, reports[i].HasErrors())
+			assert.True(t, plans[0] == plans[i], "all callers should share the same plan")
+		}
+	})
+}
+
+func TestExecutionEngineV2_PreparedOperationCache(t *testing.T) {
+	schema := inputCoercionForListSchema(t)
+
+	const query = `query Characters($ids: [Int] = [3], $unused: Int) {
+		characters: charactersByIds(ids: $ids) { name }
+		first: charactersByIds(ids: 1) { id }
+	}`
+
+	newEngine := func(t *testing.T) (*ExecutionEngineV2, *[]string) {
+		var upstreamBodies []string
+		roundTripper := testRoundTripper(func(req *http.Request) *http.Response {
+			body, err := ioutil.ReadAll(req.Body)
+			require.NoError(t, err)
+			upstreamBodies = append(upstreamBodies, string(body))
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"data":{"characters":[{"name":"Luke"}],"first":[{"id":1}]}}`)),
+			}
+		})
+
+		engineConf := NewEngineV2Configuration(schema)
+		engineConf.SetDataSources([]plan.DataSourceConfiguration{
+			{
+				RootNodes: []plan.TypeField{
+					{TypeName: "Query", FieldNames: []string{"charactersByIds"}},
+				},
+				ChildNodes: []plan.TypeField{
+					{TypeName: "Character", FieldNames: []string{"id", "name"}},
+				},
+				Factory: &graphql_datasource.Factory{
+					HTTPClient: &http.Client{Transport: roundTripper},
+				},
+				Custom: graphql_datasource.ConfigJson(graphql_datasource.Configuration{
+					Fetch: graphql_datasource.FetchConfiguration{
+						URL:    "https://example.com/",
+						Method: "POST",
+					},
+				}),
+			},
+		})
+		engineConf.SetFieldConfigurations([]plan.FieldConfiguration{
+			{
+				TypeName:  "Query",
+				FieldName: "charactersByIds",
+				Arguments: []plan.ArgumentConfiguration{
+					{
+						Name:       "ids",
+						SourceType: plan.FieldArgumentSource,
+					},
+				},
+			},
+		})
+
+		engine, err := NewExecutionEngineV2(context.Background(), abstractlogger.NoopLogger, engineConf)
+		require.NoError(t, err)
+		return engine, &upstreamBodies
+	}
+
+	execute := func(t *testing.T, engine *ExecutionEngineV2, variables string) (request Request, response string) {
+		request = Request{
+			OperationName: "Characters",
+			Variables:     []byte(variables),
+			Query:         query,
+		}
+		resultWriter := NewEngineResultWriter()
+		require.NoError(t, engine.Execute(context.Background(), &request, &resultWriter))
+		return request, resultWriter.String()
+	}
+
+	for _, variables := range []string{`{"ids":2}`, `{"ids":[1,2],"unused":1}`, `{}`, ``} {
+		t.Run("variables "+variables, func(t *testing.T) {
+			// the first request prepares the operation, the second one uses the prepared operation
+			engine, upstreamBodies := newEngine(t)
+			_, _ = execute(t, engine, `{"ids":[4,5]}`)
+			request, response := execute(t, engine, variables)
+			assert.Equal(t, 1, engine.preparedOperationCache.Len())
+			assert.False(t, request.isParsed, "the prepared operation should skip parsing")
+
+			// a new engine prepares the operation with the variables
+			uncachedEngine, uncachedUpstreamBodies := newEngine(t)
+			uncachedRequest, uncachedResponse := execute(t, uncachedEngine, variables)
+			assert.True(t, uncachedRequest.isNormalized)
+
+			assert.Equal(t, `{"data":{"characters":[{"name":"Luke"}],"first":[{"id":1}]}}`, response)
+			assert.Equal(t, uncachedResponse, response)
+			assert.JSONEq(t, string(uncachedRequest.Variables), string(request.Variables))
+			assert.Equal(t, (*uncachedUpstreamBodies)[0], (*upstreamBodies)[1])
+		})
+	}
+
+	t.Run("different operation name is prepared separately", func(t *testing.T) {
+		engine, _ := newEngine(t)
+		_, _ = execute(t, engine, `{}`)
+
+		request := Request{
+			Query: query,
+		}
+		resultWriter := NewEngineResultWriter()
+		require.NoError(t, engine.Execute(context.Background(), &request, &resultWriter))
+		assert.True(t, request.isNormalized)
+		assert.Equal(t, 2, engine.preparedOperationCache.Len())
+	})
+
+	t.Run("invalid variables are rejected", func(t *testing.T) {
+		engine, upstreamBodies := newEngine(t)
+		for i := 0; i < 2; i++ {
+			request := Request{
+				OperationName: "Characters",
+				Variables:     []byte(`{"ids":[1,"a"]}`),
+				Query:         query,
+			}
+			resultWriter := NewEngineResultWriter()
+			err := engine.Execute(context.Background(), &request, &resultWriter)
+			require.IsType(t, RequestErrors{}, err)
+			assert.Equal(t, `variable "$ids" got invalid value at "ids[1]"; Int cannot represent non-integer value: "a"`, err.(RequestErrors)[0].Message)
+		}
+		assert.Equal(t, 1, engine.preparedOperationCache.Len())
+		assert.Len(t, *upstreamBodies, 0)
+	})
+
+	t.Run("evicted plan prepares the operation again", func(t *testing.T) {
+		engine, _ := newEngine(t)
+		_, _ = execute(t, engine, `{}`)
+		engine.executionPlanCache.Purge()
+
+		request, response := execute(t, engine, `{"ids":1}`)
+		assert.True(t, request.isNormalized)
+		assert.Equal(t, `{"data":{"characters":[{"name":"Luke"}],"first":[{"id":1}]}}`, response)
+	})
+}
+
+func TestExecutionEngineV2_ScalarRegistry(t *testing.T) {
+	schema, err := NewSchemaFromString(`
+		scalar UUID
+		scalar JSON
+		type Query { node(id: UUID!): Node }
+		type Node { id: UUID! data: JSON }`)
+	require.NoError(t, err)
+
+	newEngine := func(t *testing.T, upstreamResponse string) (*ExecutionEngineV2, *[]string) {
+		var upstreamBodies []string
+		roundTripper := testRoundTripper(func(req *http.Request) *http.Response {
+			body, err := ioutil.ReadAll(req.Body)
+			require.NoError(t, err)
+			upstreamBodies = append(upstreamBodies, string(body))
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(upstreamResponse)),
+			}
+		})
+
+		engineConf := NewEngineV2Configuration(schema)
+		engineConf.SetScalarRegistry(scalars.DefaultRegistry())
+		engineConf.SetDataSources([]plan.DataSourceConfiguration{
+			{
+				RootNodes: []plan.TypeField{
+					{TypeName: "Query", FieldNames: []string{"node"}},
+				},
+				ChildNodes: []plan.TypeField{
+					{TypeName: "Node", FieldNames: []string{"id", "data"}},
+				},
+				Factory: &graphql_datasource.Factory{
+					HTTPClient: &http.Client{Transport: roundTripper},
+				},
+				Custom: graphql_datasource.ConfigJson(graphql_datasource.Configuration{
+					Fetch: graphql_datasource.FetchConfiguration{
+						URL:    "https://example.com/",
+						Method: "POST",
+					},
+				}),
+			},
+		})
+		engineConf.SetFieldConfigurations([]plan.FieldConfiguration{
+			{
+				TypeName:  "Query",
+				FieldName: "node",
+				Arguments: []plan.ArgumentConfiguration{
+					{
+						Name:       "id",
+						SourceType: plan.FieldArgumentSource,
+					},
+				},
+			},
+		})
+
+		engine, err := NewExecutionEngineV2(context.Background(), abstractlogger.NoopLogger, engineConf)
+		require.NoError(t, err)
+		return engine, &upstreamBodies
+	}
+
+	execute := func(engine *ExecutionEngineV2, query, variables string) (string, error) {
+		request := Request{
+			Query:     query,
+			Variables: []byte(variables),
+		}
+		resultWriter := NewEngineResultWriter()
+		err := engine.Execute(context.Background(), &request, &resultWriter)
+		return resultWriter.String(), err
+	}
+
+	t.Run("values are serialized and variables coerced by the scalars", func(t *testing.T) {
+		engine, upstreamBodies := newEngine(t, `{"data":{"node":{"id":"9b2e3f6a-1c4d-4e5f-8a9b-0c1d2e3f4a5b","data":{"tags":["a"],"count":1}}}}`)
+		response, err := execute(engine, `query Node($id: UUID!) { node(id: $id) { id data } }`, `{"id":"9B2E3F6A-1C4D-4E5F-8A9B-0C1D2E3F4A5B"}`)
+		require.NoError(t, err)
+		assert.Equal(t, `{"data":{"node":{"id":"9b2e3f6a-1c4d-4e5f-8a9b-0c1d2e3f4a5b","data":{"tags":["a"],"count":1}}}}`, response)
+		require.Len(t, *upstreamBodies, 1)
+		assert.Contains(t, (*upstreamBodies)[0], `"variables":{"id":"9b2e3f6a-1c4d-4e5f-8a9b-0c1d2e3f4a5b"}`)
+	})
+
+	t.Run("invalid resolved value is null and reported", func(t *testing.T) {
+		engine, _ := newEngine(t, `{"data":{"node":{"id":"1","data":null}}}`)
+		response, err := execute(engine, `query Node($id: UUID!) { node(id: $id) { id data } }`, `{"id":"9b2e3f6a-1c4d-4e5f-8a9b-0c1d2e3f4a5b"}`)
+		require.NoError(t, err)
+		assert.Equal(t, `{"errors":[{"message":"UUID cannot represent an invalid UUID string: \"1\"","locations":[{"line":1,"column":42}],"path":["node","id"]}],"data":{"node":null}}`, response)
+	})
+
+	t.Run("invalid variable is rejected", func(t *testing.T) {
+		engine, upstreamBodies := newEngine(t, `{}`)
+		_, err := execute(engine, `query Node($id: UUID!) { node(id: $id) { id } }`, `{"id":"1"}`)
+		require.IsType(t, RequestErrors{}, err)
+		assert.Equal(t, `variable "$id" got invalid value at "id"; UUID cannot represent an invalid UUID string: "1"`, err.(RequestErrors)[0].Message)
+		assert.Len(t, *upstreamBodies, 0)
+	})
+
+	t.Run("invalid literal is rejected", func(t *testing.T) {
+		engine, upstreamBodies := newEngine(t, `{}`)
+		_, err := execute(engine, `{ node(id: "1") { id } }`, ``)
+		require.Error(t, err)
+		assert.Len(t, *upstreamBodies, 0)
+	})
+}
+
+func TestExecutionEngineV2_LocalDataSource(t *testing.T) {
+	schema, err := NewSchemaFromString(`
+		type Query { users: [User!]! }
+		type User { id: ID! firstName: String! lastName: String! fullName(separator: String = " "): String! }`)
+	require.NoError(t, err)
+
+	var batches [][]json.RawMessage
+	resolvers := local_datasource.NewResolvers()
+	resolvers.Register("User", "fullName", func(ctx context.Context, arguments json.RawMessage, parents []json.RawMessage) ([]json.RawMessage, error) {
+		batches = append(batches, parents)
+		separator, _ := jsonparser.GetString(arguments, "separator")
+		results := make([]json.RawMessage, len(parents))
+		for i := range parents {
+			firstName, _ := jsonparser.GetString(parents[i], "firstName")
+			lastName, _ := jsonparser.GetString(parents[i], "lastName")
+			results[i], _ = json.Marshal(firstName + separator + lastName)
+		}
+		return results, nil
+	})
+
+	engineConf := NewEngineV2Configuration(schema)
+	engineConf.EnableDataLoader(true)
+	engineConf.SetDataSources([]plan.DataSourceConfiguration{
+		{
+			RootNodes: []plan.TypeField{
+				{TypeName: "Query", FieldNames: []string{"users"}},
+			},
+			ChildNodes: []plan.TypeField{
+				{TypeName: "User", FieldNames: []string{"id", "firstName", "lastName"}},
+			},
+			Factory: &graphql_datasource.Factory{
+				HTTPClient: &http.Client{Transport: testRoundTripper(func(req *http.Request) *http.Response {
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       ioutil.NopCloser(bytes.NewBufferString(`{"data":{"users":[{"id":"1","firstName":"Jens","lastName":"Neuse"},{"id":"2","firstName":"Sergiy","lastName":"Petrunin"}]}}`)),
+					}
+				})},
+			},
+			Custom: graphql_datasource.ConfigJson(graphql_datasource.Configuration{
+				Fetch: graphql_datasource.FetchConfiguration{
+					URL:    "https://example.com/",
+					Method: "POST",
+				},
+			}),
+		},
+		{
+			RootNodes: []plan.TypeField{
+				{TypeName: "User", FieldNames: []string{"fullName"}},
+			},
+			Factory: &local_datasource.Factory{Resolvers: resolvers},
+		},
+	})
+	engineConf.SetFieldConfigurations([]plan.FieldConfiguration{
+		{
+			TypeName:       "User",
+			FieldName:      "fullName",
+			RequiresFields: []string{"firstName", "lastName"},
+			Arguments: []plan.ArgumentConfiguration{
+				{
+					Name:       "separator",
+					SourceType: plan.FieldArgumentSource,
+				},
+			},
+		},
+	})
+
+	engine, err := NewExecutionEngineV2(context.Background(), abstractlogger.NoopLogger, engineConf)
+	require.NoError(t, err)
+
+	request := Request{
+		Query: `{ users { id fullName(separator: "-") } }`,
+	}
+	resultWriter := NewEngineResultWriter()
+	require.NoError(t, engine.Execute(context.Background(), &request, &resultWriter))
+
+	assert.Equal(t, `{"data":{"users":[{"id":"1","fullName":"Jens-Neuse"},{"id":"2","fullName":"Sergiy-Petrunin"}]}}`, resultWriter.String())
+	require.Len(t, batches, 1, "the resolver should be called once for all users")
+	assert.Len(t, batches[0], 2)
 }
 
 func BenchmarkExecutionEngineV2(b *testing.B) {
@@ -1702,6 +2069,54 @@ func BenchmarkExecutionEngineV2(b *testing.B) {
 
 }
 
+// BenchmarkExecutionEngineV2_Planning measures planning throughput with a cold plan cache
+// Run it with e.g. -cpu 1,2,4,8 to see planning scale with GOMAXPROCS
+func BenchmarkExecutionEngineV2_Planning(b *testing.B) {
+	schema, err := NewSchemaFromString(`type Query { hello: String}`)
+	require.NoError(b, err)
+
+	engineConf := NewEngineV2Configuration(schema)
+	engineConf.SetDataSources([]plan.DataSourceConfiguration{
+		{
+			RootNodes: []plan.TypeField{
+				{TypeName: "Query", FieldNames: []string{"hello"}},
+			},
+			Factory: &staticdatasource.Factory{},
+			Custom: staticdatasource.ConfigJSON(staticdatasource.Configuration{
+				Data: "world",
+			}),
+		},
+	})
+
+	engine, err := NewExecutionEngineV2(context.Background(), abstractlogger.NoopLogger, engineConf)
+	require.NoError(b, err)
+
+	var operationCount int64
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			// every operation is unique, so that each iteration misses the plan cache
+			operation := atomic.AddInt64(&operationCount, 1)
+			req := Request{
+				Query: fmt.Sprintf("{hello_%d: hello}", operation),
+			}
+			if _, err := req.Normalize(schema); err != nil {
+				b.Fatal(err)
+			}
+			report := operationreport.Report{}
+			execCtx := engine.getExecutionCtx()
+			_ = engine.getCachedPlanByKey(execCtx, uint64(operation), &req.document, &schema.document, req.OperationName, &report)
+			engine.putExecutionCtx(execCtx)
+			if report.HasErrors() {
+				b.Fatal(report)
+			}
+		}
+	})
+}
+
 type federationSetup struct {
 	accountsUpstreamServer *httptest.Server
 	productsUpstreamServer *httptest.Server
@@ -0,0 +1,9 @@
+package graphql
+
+// IncrementalDeliveryDirectives defines the @defer and @stream directives.
+// Append them to a schema to enable incremental delivery with the ExecutionEngineV2.
+// Both directives are additionally allowed on fields, the deferred field or streamed list is then delivered incrementally.
+const IncrementalDeliveryDirectives = `
+directive @defer(if: Boolean! = true, label: String) on FRAGMENT_SPREAD | INLINE_FRAGMENT | FIELD
+directive @stream(if: Boolean! = true, label: String, initialCount: Int = 0) on FIELD
+`
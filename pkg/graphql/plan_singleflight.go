@@ -0,0 +1,58 @@
+package graphql
+
+import (
+	"sync"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/plan"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+// inflightPlans deduplicates concurrent planning of the same operation
+// The first caller (leader) plans the operation, all other callers wait for the leader and share its result
+type inflightPlans struct {
+	mu    sync.Mutex
+	plans map[uint64]*inflightPlan
+}
+
+type inflightPlan struct {
+	done   chan struct{}
+	plan   plan.Plan
+	report operationreport.Report
+}
+
+func newInflightPlans() *inflightPlans {
+	return &inflightPlans{
+		plans: make(map[uint64]*inflightPlan),
+	}
+}
+
+// acquire returns the inflight plan for the key and whether the caller is the leader which has to plan the operation
+func (i *inflightPlans) acquire(key uint64) (inflight *inflightPlan, isLeader bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if inflight, ok := i.plans[key]; ok {
+		return inflight, false
+	}
+	inflight = &inflightPlan{
+		done: make(chan struct{}),
+	}
+	i.plans[key] = inflight
+	return inflight, true
+}
+
+// release removes the inflight plan and wakes up all waiting callers
+func (i *inflightPlans) release(key uint64, inflight *inflightPlan) {
+	i.mu.Lock()
+	delete(i.plans, key)
+	i.mu.Unlock()
+	close(inflight.done)
+}
+
+func (p *inflightPlan) wait() {
+	<-p.done
+}
+
+func (p *inflightPlan) copyReport(report *operationreport.Report) {
+	report.InternalErrors = append(report.InternalErrors, p.report.InternalErrors...)
+	report.ExternalErrors = append(report.ExternalErrors, p.report.ExternalErrors...)
+}
@@ -0,0 +1,161 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/buger/jsonparser"
+	"github.com/tidwall/sjson"
+
+	"github.com/wundergraph/graphql-go-tools/internal/pkg/unsafebytes"
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astnormalization"
+	"github.com/wundergraph/graphql-go-tools/pkg/astparser"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+	"github.com/wundergraph/graphql-go-tools/pkg/pool"
+)
+
+// preparedOperation is an operation which has been parsed, normalized and validated
+// The ExecutionEngineV2 caches prepared operations by query, operation name and schema,
+// so that subsequent requests of the same operation skip straight to resolving.
+// As normalization depends on the variables of a request, only the parts independent of them are cached.
+// The variables of each request get normalized using the cached document, see normalizeVariables.
+type preparedOperation struct {
+	// document is the normalized operation, it must not be modified as it's shared between requests
+	document     ast.Document
+	planCacheKey uint64
+	// variableDefaultValues are the default values of the variable definitions, which are removed during normalization
+	variableDefaultValues []byte
+	// extractedVariables are the variables created during normalization, e.g. from inline arguments
+	extractedVariables []byte
+	// removedVariables are the names of the variable definitions which were removed as they are unused
+	removedVariables []string
+}
+
+// preparedOperationCacheKey returns the key of an operation in the prepared operation cache
+func preparedOperationCacheKey(operation *Request, schema *Schema) uint64 {
+	hash := pool.Hash64.Get()
+	hash.Reset()
+	defer pool.Hash64.Put(hash)
+
+	var schemaHash [8]byte
+	binary.LittleEndian.PutUint64(schemaHash[:], schema.Hash())
+	_, _ = hash.Write(schemaHash[:])
+	_, _ = hash.Write(unsafebytes.StringToBytes(operation.OperationName))
+	_, _ = hash.Write([]byte{0})
+	_, _ = hash.Write(unsafebytes.StringToBytes(operation.Query))
+	return hash.Sum64()
+}
+
+// operationVariables records the variable definitions of an operation before it gets normalized
+type operationVariables struct {
+	names         []string
+	defaultValues []byte
+}
+
+func newOperationVariables(operation *ast.Document, operationName string) (operationVariables, error) {
+	defaultValues, err := astnormalization.VariableDefaultValues(operation, []byte(operationName))
+	if err != nil {
+		return operationVariables{}, err
+	}
+	return operationVariables{
+		names:         variableDefinitionNames(operation, operationName),
+		defaultValues: defaultValues,
+	}, nil
+}
+
+// newPreparedOperation creates a preparedOperation from the printed normalized operation
+// The variable definitions which weren't declared before normalization are the extracted ones,
+// declared variable definitions which don't exist anymore were removed.
+func newPreparedOperation(printedOperation []byte, operationName string, planCacheKey uint64, before operationVariables, normalizedVariables []byte) (*preparedOperation, error) {
+	document, report := astparser.ParseGraphqlDocumentBytes(printedOperation)
+	if report.HasErrors() {
+		return nil, report
+	}
+
+	prepared := &preparedOperation{
+		document:              document,
+		planCacheKey:          planCacheKey,
+		variableDefaultValues: before.defaultValues,
+	}
+
+	after := variableDefinitionNames(&prepared.document, operationName)
+	for _, name := range after {
+		if containsString(before.names, name) {
+			continue
+		}
+		value, dataType, _, err := jsonparser.Get(normalizedVariables, name)
+		if err != nil {
+			continue
+		}
+		if dataType == jsonparser.String {
+			value = append(append([]byte{'"'}, value...), '"')
+		}
+		prepared.extractedVariables, err = sjson.SetRawBytes(prepared.extractedVariables, name, value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, name := range before.names {
+		if !containsString(after, name) {
+			prepared.removedVariables = append(prepared.removedVariables, name)
+		}
+	}
+
+	return prepared, nil
+}
+
+// normalizeVariables normalizes the variables of a request for the prepared operation
+// The result is the same as normalizing the variables together with the operation.
+func (p *preparedOperation) normalizeVariables(normalizer *astnormalization.VariablesNormalizer, definition *ast.Document, variables []byte) ([]byte, error) {
+	// the shallow copy of the document allows to set the variables without modifying the shared document
+	operation := p.document
+	operation.Input.Variables = append([]byte(nil), variables...)
+
+	for _, name := range p.removedVariables {
+		operation.Input.Variables = jsonparser.Delete(operation.Input.Variables, name)
+	}
+
+	if len(p.extractedVariables) != 0 {
+		err := jsonparser.ObjectEach(p.extractedVariables, func(key []byte, value []byte, dataType jsonparser.ValueType, _ int) (err error) {
+			if dataType == jsonparser.String {
+				value = append(append([]byte{'"'}, value...), '"')
+			}
+			operation.Input.Variables, err = sjson.SetRawBytes(operation.Input.Variables, string(key), value)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var report operationreport.Report
+	normalizer.NormalizeOperationVariables(&operation, definition, p.variableDefaultValues, &report)
+	if report.HasErrors() {
+		return nil, report
+	}
+
+	return operation.Input.Variables, nil
+}
+
+func variableDefinitionNames(operation *ast.Document, operationName string) (names []string) {
+	for i := range operation.OperationDefinitions {
+		if operationName != "" && !bytes.Equal(operation.OperationDefinitionNameBytes(i), []byte(operationName)) {
+			continue
+		}
+		for _, ref := range operation.OperationDefinitions[i].VariableDefinitions.Refs {
+			names = append(names, operation.VariableDefinitionNameString(ref))
+		}
+	}
+	return names
+}
+
+func containsString(values []string, value string) bool {
+	for i := range values {
+		if values[i] == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,289 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/buger/jsonparser"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astprinter"
+	"github.com/wundergraph/graphql-go-tools/pkg/introspection"
+)
+
+// IntrospectionQuery is the introspection query which is sent to upstreams to load their schema
+const IntrospectionQuery = `query IntrospectionQuery {
+  __schema {
+    queryType { name }
+    mutationType { name }
+    subscriptionType { name }
+    types { ...FullType }
+    directives {
+      name
+      description
+      locations
+      args { ...InputValue }
+    }
+  }
+}
+
+fragment FullType on __Type {
+  kind
+  name
+  description
+  fields(includeDeprecated: true) {
+    name
+    description
+    args { ...InputValue }
+    type { ...TypeRef }
+    isDeprecated
+    deprecationReason
+  }
+  inputFields { ...InputValue }
+  interfaces { ...TypeRef }
+  enumValues(includeDeprecated: true) {
+    name
+    description
+    isDeprecated
+    deprecationReason
+  }
+  possibleTypes { ...TypeRef }
+}
+
+fragment InputValue on __InputValue {
+  name
+  description
+  type { ...TypeRef }
+  defaultValue
+}
+
+fragment TypeRef on __Type {
+  kind
+  name
+  ofType {
+    kind
+    name
+    ofType {
+      kind
+      name
+      ofType {
+        kind
+        name
+        ofType {
+          kind
+          name
+          ofType {
+            kind
+            name
+            ofType {
+              kind
+              name
+              ofType {
+                kind
+                name
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// builtInDefinitions are part of every introspection response, they're added again when the schema gets merged with the base schema
+var builtInDefinitions = map[string]struct{}{
+	"Int": {}, "Float": {}, "String": {}, "Boolean": {}, "ID": {},
+	"include": {}, "skip": {}, "deprecated": {}, "specifiedBy": {},
+}
+
+type schemaLoaderOptions struct {
+	httpClient *http.Client
+	header     http.Header
+}
+
+type SchemaLoaderOption func(options *schemaLoaderOptions)
+
+func WithSchemaLoaderHttpClient(client *http.Client) SchemaLoaderOption {
+	return func(options *schemaLoaderOptions) {
+		options.httpClient = client
+	}
+}
+
+// WithSchemaLoaderHeader sets the headers of the introspection requests, e.g. to authorize against the upstream
+func WithSchemaLoaderHeader(header http.Header) SchemaLoaderOption {
+	return func(options *schemaLoaderOptions) {
+		options.header = header
+	}
+}
+
+// SchemaLoader loads the schema of an upstream using the introspection query.
+// The loaded schema can be used to create a ProxyEngineConfigFactory or the SDL of a StitchingUpstream,
+// so that a proxy only needs the URL of the upstream.
+type SchemaLoader struct {
+	url        string
+	httpClient *http.Client
+	header     http.Header
+
+	mu     sync.RWMutex
+	schema *Schema
+}
+
+func NewSchemaLoader(url string, opts ...SchemaLoaderOption) *SchemaLoader {
+	options := schemaLoaderOptions{
+		httpClient: &http.Client{
+			Timeout: time.Second * 10,
+		},
+	}
+
+	for _, optFunc := range opts {
+		optFunc(&options)
+	}
+
+	return &SchemaLoader{
+		url:        url,
+		httpClient: options.httpClient,
+		header:     options.header,
+	}
+}
+
+// Schema returns the last loaded schema, it's nil if the schema hasn't been loaded yet
+func (l *SchemaLoader) Schema() *Schema {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.schema
+}
+
+// Load introspects the upstream and returns its schema
+// On errors, the previously loaded schema is returned, which is nil on the first load.
+func (l *SchemaLoader) Load(ctx context.Context) (*Schema, error) {
+	schema, _, err := l.Refresh(ctx)
+	return schema, err
+}
+
+// Refresh introspects the upstream again and reports whether the schema changed since the last load
+// If the upstream can't be introspected, the previous schema is kept and returned together with the error.
+func (l *SchemaLoader) Refresh(ctx context.Context) (schema *Schema, changed bool, err error) {
+	schema, err = l.introspect(ctx)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err != nil {
+		return l.schema, false, err
+	}
+	if l.schema != nil && l.schema.Hash() == schema.Hash() {
+		return l.schema, false, nil
+	}
+	l.schema = schema
+	return schema, true, nil
+}
+
+// RefreshPeriodically refreshes the schema in the given interval until the context is done
+// onChange is called with the new schema whenever the schema of the upstream changed,
+// onError is called if the upstream can't be introspected. Both callbacks are optional.
+func (l *SchemaLoader) RefreshPeriodically(ctx context.Context, interval time.Duration, onChange func(schema *Schema), onError func(err error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			schema, changed, err := l.Refresh(ctx)
+			if err != nil {
+				if onError != nil {
+					onError(err)
+				}
+				continue
+			}
+			if changed && onChange != nil {
+				onChange(schema)
+			}
+		}
+	}
+}
+
+func (l *SchemaLoader) introspect(ctx context.Context) (*Schema, error) {
+	body, err := json.Marshal(Request{
+		OperationName: "IntrospectionQuery",
+		Query:         IntrospectionQuery,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, l.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range l.header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	res, err := l.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("introspect upstream: %v", err)
+	}
+	defer res.Body.Close()
+
+	response, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read introspection response: %v", err)
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("introspect upstream: unexpected status code %d", res.StatusCode)
+	}
+
+	if errors, _, _, err := jsonparser.Get(response, "errors"); err == nil && len(errors) > 2 {
+		return nil, fmt.Errorf("introspect upstream: %s", errors)
+	}
+	data, _, _, err := jsonparser.Get(response, "data")
+	if err != nil {
+		return nil, fmt.Errorf("introspection response has no data: %v", err)
+	}
+
+	sdl, err := introspectionToSDL(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSchemaFromString(sdl)
+}
+
+// introspectionToSDL converts the data of an introspection response into a schema without built-in types and directives
+func introspectionToSDL(data []byte) (string, error) {
+	converter := introspection.JsonConverter{}
+	doc, err := converter.GraphQLDocument(bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+
+	rootNodes := doc.RootNodes[:0]
+	for _, node := range doc.RootNodes {
+		var name string
+		switch node.Kind {
+		case ast.NodeKindDirectiveDefinition:
+			name = doc.DirectiveDefinitionNameString(node.Ref)
+		default:
+			name = doc.NodeNameString(node)
+		}
+		if _, builtIn := builtInDefinitions[name]; builtIn || strings.HasPrefix(name, "__") {
+			continue
+		}
+		rootNodes = append(rootNodes, node)
+	}
+	doc.RootNodes = rootNodes
+
+	return astprinter.PrintStringIndent(doc, nil, "  ")
+}
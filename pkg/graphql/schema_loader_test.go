@@ -0,0 +1,145 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaLoader(t *testing.T) {
+	type upstream struct {
+		mu     sync.Mutex
+		schema *Schema
+		fail   bool
+	}
+
+	newUpstream := func(t *testing.T, schema *Schema) (*upstream, *httptest.Server) {
+		u := &upstream{schema: schema}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			var request Request
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			assert.Equal(t, IntrospectionQuery, request.Query)
+
+			u.mu.Lock()
+			defer u.mu.Unlock()
+			if u.fail {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			require.NoError(t, u.schema.IntrospectionResponse(w))
+		}))
+		return u, server
+	}
+
+	newLoader := func(server *httptest.Server) *SchemaLoader {
+		return NewSchemaLoader(server.URL,
+			WithSchemaLoaderHttpClient(server.Client()),
+			WithSchemaLoaderHeader(http.Header{"Authorization": []string{"Bearer token"}}),
+		)
+	}
+
+	t.Run("should load the schema of the upstream", func(t *testing.T) {
+		_, server := newUpstream(t, starwarsSchema(t))
+		defer server.Close()
+
+		loader := newLoader(server)
+		assert.Nil(t, loader.Schema())
+
+		schema, err := loader.Load(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, schema, loader.Schema())
+
+		result, err := schema.Validate()
+		require.NoError(t, err)
+		assert.True(t, result.Valid)
+
+		// the loaded schema has the same introspection response as the schema of the upstream
+		expected, actual := &bytes.Buffer{}, &bytes.Buffer{}
+		require.NoError(t, starwarsSchema(t).IntrospectionResponse(expected))
+		require.NoError(t, schema.IntrospectionResponse(actual))
+		assert.JSONEq(t, expected.String(), actual.String())
+	})
+
+	t.Run("should detect changes on refresh", func(t *testing.T) {
+		u, server := newUpstream(t, starwarsSchema(t))
+		defer server.Close()
+
+		loader := newLoader(server)
+		first, err := loader.Load(context.Background())
+		require.NoError(t, err)
+
+		schema, changed, err := loader.Refresh(context.Background())
+		require.NoError(t, err)
+		assert.False(t, changed)
+		assert.True(t, first == schema)
+
+		changedSchema, err := NewSchemaFromString(`type Query { hello: String }`)
+		require.NoError(t, err)
+		u.mu.Lock()
+		u.schema = changedSchema
+		u.mu.Unlock()
+
+		schema, changed, err = loader.Refresh(context.Background())
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, changedSchema.Hash(), schema.Hash())
+		assert.Equal(t, schema, loader.Schema())
+	})
+
+	t.Run("should keep the schema if the upstream fails", func(t *testing.T) {
+		u, server := newUpstream(t, starwarsSchema(t))
+		defer server.Close()
+
+		loader := newLoader(server)
+		schema, err := loader.Load(context.Background())
+		require.NoError(t, err)
+
+		u.mu.Lock()
+		u.fail = true
+		u.mu.Unlock()
+
+		previous, changed, err := loader.Refresh(context.Background())
+		assert.EqualError(t, err, "introspect upstream: unexpected status code 500")
+		assert.False(t, changed)
+		assert.True(t, schema == previous)
+		assert.True(t, schema == loader.Schema())
+	})
+
+	t.Run("should refresh periodically", func(t *testing.T) {
+		u, server := newUpstream(t, starwarsSchema(t))
+		defer server.Close()
+
+		loader := newLoader(server)
+		_, err := loader.Load(context.Background())
+		require.NoError(t, err)
+
+		changedSchema, err := NewSchemaFromString(`type Query { hello: String }`)
+		require.NoError(t, err)
+		u.mu.Lock()
+		u.schema = changedSchema
+		u.mu.Unlock()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		changes := make(chan *Schema, 1)
+		go loader.RefreshPeriodically(ctx, time.Millisecond, func(schema *Schema) {
+			changes <- schema
+		}, nil)
+
+		select {
+		case schema := <-changes:
+			assert.Equal(t, changedSchema.Hash(), schema.Hash())
+		case <-time.After(time.Second):
+			t.Fatal("schema change wasn't detected")
+		}
+	})
+}
@@ -378,6 +378,18 @@ func TestSchema_Validate(t *testing.T) {
 		1,
 	))
 
+	t.Run("should successfully validate schema with deprecated arguments and input fields as valid", run(
+		`type Query {
+					users(first: Int, limit: Int @deprecated(reason: "use first")): [String]
+				}
+				input UserFilter {
+					name: String
+					fullName: String @deprecated
+				}`,
+		true,
+		0,
+	))
+
 	t.Run("should successfully validate countries schema as valid", run(
 		countriesSchema,
 		true,
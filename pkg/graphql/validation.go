@@ -11,6 +11,10 @@ type ValidationResult struct {
 }
 
 func (r *Request) ValidateForSchema(schema *Schema) (result ValidationResult, err error) {
+	return r.validateForSchema(schema)
+}
+
+func (r *Request) validateForSchema(schema *Schema, opts ...astvalidation.OperationValidatorOption) (result ValidationResult, err error) {
 	if schema == nil {
 		return ValidationResult{Valid: false, Errors: nil}, ErrNilSchema
 	}
@@ -30,7 +34,7 @@ func (r *Request) ValidateForSchema(schema *Schema) (result ValidationResult, er
 		return operationValidationResultFromReport(report)
 	}
 
-	validator := astvalidation.DefaultOperationValidator()
+	validator := astvalidation.DefaultOperationValidator(opts...)
 	validator.Validate(&r.document, &schema.document, &report)
 	result, err = operationValidationResultFromReport(report)
 	if err != nil {
@@ -10,11 +10,13 @@ import (
 	"github.com/qri-io/jsonschema"
 
 	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/scalars"
 )
 
 type options struct {
 	overrides map[string]JsonSchema
 	path      []string
+	scalars   *scalars.Registry
 }
 
 type Option func(opts *options)
@@ -31,6 +33,13 @@ func WithPath(path []string) Option {
 	}
 }
 
+// WithScalarRegistry uses the JSON schemas of the registered custom scalars instead of allowing any value
+func WithScalarRegistry(registry *scalars.Registry) Option {
+	return func(opts *options) {
+		opts.scalars = registry
+	}
+}
+
 func FromTypeRef(operation, definition *ast.Document, typeRef int, opts ...Option) JsonSchema {
 	appliedOptions := &options{}
 	for _, opt := range opts {
@@ -41,10 +50,12 @@ func FromTypeRef(operation, definition *ast.Document, typeRef int, opts ...Optio
 	if len(appliedOptions.overrides) > 0 {
 		resolver = &fromTypeRefResolver{
 			overrides: appliedOptions.overrides,
+			scalars:   appliedOptions.scalars,
 		}
 	} else {
 		resolver = &fromTypeRefResolver{
 			overrides: map[string]JsonSchema{},
+			scalars:   appliedOptions.scalars,
 		}
 	}
 
@@ -70,6 +81,7 @@ func resolveJsonSchemaPath(jsonSchema JsonSchema, path []string) JsonSchema {
 type fromTypeRefResolver struct {
 	overrides map[string]JsonSchema
 	defs      *map[string]JsonSchema
+	scalars   *scalars.Registry
 }
 
 func (r *fromTypeRefResolver) fromTypeRef(operation, definition *ast.Document, typeRef int) JsonSchema {
@@ -126,6 +138,9 @@ func (r *fromTypeRefResolver) fromTypeRef(operation, definition *ast.Document, t
 			case "_Any":
 				return NewObjectAny(nonNull)
 			default:
+				if scalar, ok := r.scalars.Scalar(name); ok {
+					return NewRaw(scalar.JSONSchema(), nonNull)
+				}
 				return NewAny()
 			}
 		}
@@ -268,6 +283,7 @@ const (
 	AnyKind
 	IDKind
 	RefKind
+	RawKind
 )
 
 func maybeAppendNull(nonNull bool, types ...string) []string {
@@ -361,6 +377,35 @@ func NewInteger(nonNull bool) Integer {
 	}
 }
 
+// Raw is a JSON schema given as JSON, e.g. the JSON schema of a custom scalar
+// A nullable Raw schema additionally allows null.
+type Raw struct {
+	Schema  json.RawMessage
+	NonNull bool
+}
+
+func (_ Raw) Kind() Kind {
+	return RawKind
+}
+
+func NewRaw(schema []byte, nonNull bool) Raw {
+	return Raw{
+		Schema:  schema,
+		NonNull: nonNull,
+	}
+}
+
+func (r Raw) MarshalJSON() ([]byte, error) {
+	if r.NonNull {
+		return r.Schema, nil
+	}
+	nullable := make([]byte, 0, len(r.Schema)+32)
+	nullable = append(nullable, `{"anyOf":[`...)
+	nullable = append(nullable, r.Schema...)
+	nullable = append(nullable, `,{"type":"null"}]}`...)
+	return nullable, nil
+}
+
 type Ref struct {
 	Ref string `json:"$ref"`
 }
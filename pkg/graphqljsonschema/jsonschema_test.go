@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/wundergraph/graphql-go-tools/internal/pkg/unsafeparser"
+	"github.com/wundergraph/graphql-go-tools/pkg/scalars"
 )
 
 func runTest(schema, operation, expectedJsonSchema string, valid []string, invalid []string, opts ...Option) func(t *testing.T) {
@@ -243,6 +244,43 @@ func TestJsonSchema(t *testing.T) {
 		},
 		WithPath([]string{"pet", "name"}),
 	))
+	t.Run("custom scalar with registry", runTest(
+		`scalar UUID input Test { id: UUID! }`,
+		`query ($input: Test){}`,
+		`{"type":["object","null"],"properties":{"id":{"type":"string","pattern":"^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$"}},"required":["id"],"additionalProperties":false}`,
+		[]string{
+			`{"id":"0b5b8ff5-4c5a-4a3c-9f05-08b3d5e5ac66"}`,
+		},
+		[]string{
+			`{"id":"123"}`,
+			`{"id":null}`,
+		},
+		WithScalarRegistry(scalars.DefaultRegistry()),
+	))
+	t.Run("nullable custom scalar with registry", runTest(
+		`scalar BigInt`,
+		`query ($input: BigInt){}`,
+		`{"anyOf":[{"type":["integer","string"],"pattern":"^-?[0-9]+$"},{"type":"null"}]}`,
+		[]string{
+			`1`,
+			`"12345678901234567890"`,
+			`null`,
+		},
+		[]string{
+			`"a"`,
+			`true`,
+		},
+		WithScalarRegistry(scalars.DefaultRegistry()),
+	))
+	t.Run("custom scalar without registry", runTest(
+		`scalar BigInt`,
+		`query ($input: BigInt){}`,
+		`{}`,
+		[]string{
+			`"a"`,
+		},
+		[]string{},
+	))
 }
 
 const complexRecursiveSchema = `
@@ -0,0 +1,75 @@
+package http
+
+import (
+	"bytes"
+	"net/http"
+
+	log "github.com/jensneuse/abstractlogger"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/graphql"
+)
+
+// NewEngineHTTPHandler returns a handler executing GraphQL requests using the ExecutionEngineV2
+// Operations using @defer or @stream are delivered incrementally as multipart/mixed if the client accepts it,
+// all other responses are written as application/json.
+func NewEngineHTTPHandler(engine *graphql.ExecutionEngineV2, logger log.Logger) http.Handler {
+	return &EngineHTTPHandler{
+		engine: engine,
+		log:    logger,
+	}
+}
+
+type EngineHTTPHandler struct {
+	engine *graphql.ExecutionEngineV2
+	log    log.Logger
+}
+
+func (h *EngineHTTPHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var gqlRequest graphql.Request
+	if err := graphql.UnmarshalHttpRequest(r, &gqlRequest); err != nil {
+		h.log.Error("EngineHTTPHandler.UnmarshalHttpRequest",
+			log.Error(err),
+		)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if AcceptsMultipartMixed(r) {
+		h.handleMultipartMixed(w, r, &gqlRequest)
+		return
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, 4096))
+	resultWriter := graphql.NewEngineResultWriterFromBuffer(buf)
+	if err := h.engine.Execute(r.Context(), &gqlRequest, &resultWriter); err != nil {
+		h.log.Error("EngineHTTPHandler.engine.Execute",
+			log.Error(err),
+		)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add(httpHeaderContentType, httpContentTypeApplicationJson)
+	w.WriteHeader(http.StatusOK)
+	_, _ = buf.WriteTo(w)
+}
+
+// handleMultipartMixed delivers operations with @defer or @stream incrementally, all other operations as plain JSON
+func (h *EngineHTTPHandler) handleMultipartMixed(w http.ResponseWriter, r *http.Request, gqlRequest *graphql.Request) {
+	writer := NewMultipartMixedWriter(w)
+	if err := h.engine.Execute(r.Context(), gqlRequest, writer); err != nil {
+		h.log.Error("EngineHTTPHandler.engine.Execute",
+			log.Error(err),
+		)
+		if !writer.HeaderWritten() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		h.log.Error("EngineHTTPHandler.writer.Close",
+			log.Error(err),
+		)
+	}
+}
@@ -0,0 +1,94 @@
+package http
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jensneuse/abstractlogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/datasource/staticdatasource"
+	"github.com/wundergraph/graphql-go-tools/pkg/engine/plan"
+	"github.com/wundergraph/graphql-go-tools/pkg/graphql"
+)
+
+func TestEngineHTTPHandler(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	schema, err := graphql.NewSchemaFromString(`type Query { hello: String world: String }` + graphql.IncrementalDeliveryDirectives)
+	require.NoError(t, err)
+
+	staticDataSource := func(fieldName, data string) plan.DataSourceConfiguration {
+		return plan.DataSourceConfiguration{
+			RootNodes: []plan.TypeField{
+				{TypeName: "Query", FieldNames: []string{fieldName}},
+			},
+			Factory: &staticdatasource.Factory{},
+			Custom: staticdatasource.ConfigJSON(staticdatasource.Configuration{
+				Data: data,
+			}),
+		}
+	}
+
+	engineConf := graphql.NewEngineV2Configuration(schema)
+	engineConf.SetDataSources([]plan.DataSourceConfiguration{
+		staticDataSource("hello", `"hello"`),
+		staticDataSource("world", `"world"`),
+	})
+	engineConf.SetFieldConfigurations([]plan.FieldConfiguration{
+		{TypeName: "Query", FieldName: "hello", DisableDefaultMapping: true},
+		{TypeName: "Query", FieldName: "world", DisableDefaultMapping: true},
+	})
+	engine, err := graphql.NewExecutionEngineV2(ctx, abstractlogger.NoopLogger, engineConf)
+	require.NoError(t, err)
+
+	handler := NewEngineHTTPHandler(engine, abstractlogger.NoopLogger)
+
+	serve := func(query, accept string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query":"`+query+`"}`))
+		if accept != "" {
+			r.Header.Set(httpHeaderAccept, accept)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, r)
+		return recorder
+	}
+
+	t.Run("json response", func(t *testing.T) {
+		recorder := serve(`{ hello world }`, "")
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, httpContentTypeApplicationJson, recorder.Header().Get(httpHeaderContentType))
+		assert.Equal(t, `{"data":{"hello":"hello","world":"world"}}`, recorder.Body.String())
+	})
+
+	t.Run("deferred fragment as multipart/mixed", func(t *testing.T) {
+		recorder := serve(`{ hello ... @defer { world } }`, "multipart/mixed; deferSpec=20220824, application/json")
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, `multipart/mixed; boundary="-"`, recorder.Header().Get(httpHeaderContentType))
+		body, err := ioutil.ReadAll(recorder.Body)
+		require.NoError(t, err)
+		assert.Equal(t, multipartDelimiter+multipartPartHeader+`{"data":{"hello":"hello"},"hasNext":true}`+
+			multipartDelimiter+multipartPartHeader+`{"incremental":[{"data":{"world":"world"},"path":[]}],"hasNext":false}`+
+			multipartTerminator, string(body))
+	})
+
+	t.Run("without incremental delivery the client accepting multipart/mixed gets json", func(t *testing.T) {
+		recorder := serve(`{ hello }`, "multipart/mixed, application/json")
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, httpContentTypeApplicationJson, recorder.Header().Get(httpHeaderContentType))
+		assert.Equal(t, `{"data":{"hello":"hello"}}`, recorder.Body.String())
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{`))
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, r)
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+}
@@ -0,0 +1,89 @@
+package http
+
+import (
+	"bytes"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+const (
+	httpHeaderAccept string = "Accept"
+
+	httpContentTypeMultipartMixed string = "multipart/mixed"
+
+	multipartBoundary   = "-"
+	multipartDelimiter  = "\r\n--" + multipartBoundary + "\r\n"
+	multipartTerminator = "\r\n--" + multipartBoundary + "--\r\n"
+	multipartPartHeader = httpHeaderContentType + ": " + httpContentTypeApplicationJson + "; charset=utf-8\r\n\r\n"
+)
+
+// AcceptsMultipartMixed returns true if the client accepts incremental delivery (@defer, @stream) as multipart/mixed response
+func AcceptsMultipartMixed(r *http.Request) bool {
+	for _, accept := range r.Header.Values(httpHeaderAccept) {
+		for _, mediaRange := range strings.Split(accept, ",") {
+			mediaType, _, err := mime.ParseMediaType(mediaRange)
+			if err == nil && mediaType == httpContentTypeMultipartMixed {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// MultipartMixedWriter writes GraphQL responses with incremental delivery as multipart/mixed
+// Each Flush writes the buffered payload as one part and flushes it to the client.
+// If Close gets called before the first Flush, the response is written as plain application/json,
+// so that the writer can be used for all operations, regardless of whether they get delivered incrementally.
+type MultipartMixedWriter struct {
+	w           http.ResponseWriter
+	buf         bytes.Buffer
+	wroteHeader bool
+}
+
+func NewMultipartMixedWriter(w http.ResponseWriter) *MultipartMixedWriter {
+	return &MultipartMixedWriter{
+		w: w,
+	}
+}
+
+func (m *MultipartMixedWriter) Write(p []byte) (n int, err error) {
+	return m.buf.Write(p)
+}
+
+// Flush writes the buffered payload as a part of the multipart/mixed response
+func (m *MultipartMixedWriter) Flush() {
+	if m.buf.Len() == 0 {
+		return
+	}
+	if !m.wroteHeader {
+		m.w.Header().Set(httpHeaderContentType, httpContentTypeMultipartMixed+`; boundary="`+multipartBoundary+`"`)
+		m.w.WriteHeader(http.StatusOK)
+		m.wroteHeader = true
+	}
+	_, _ = m.w.Write([]byte(multipartDelimiter + multipartPartHeader))
+	_, _ = m.w.Write(m.buf.Bytes())
+	m.buf.Reset()
+	if flusher, ok := m.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Close writes the remaining payload and terminates the multipart/mixed response
+func (m *MultipartMixedWriter) Close() error {
+	if !m.wroteHeader {
+		m.w.Header().Set(httpHeaderContentType, httpContentTypeApplicationJson)
+		m.w.WriteHeader(http.StatusOK)
+		_, err := m.w.Write(m.buf.Bytes())
+		m.buf.Reset()
+		return err
+	}
+	m.Flush()
+	_, err := m.w.Write([]byte(multipartTerminator))
+	return err
+}
+
+// HeaderWritten returns true once the response status and headers have been sent to the client
+func (m *MultipartMixedWriter) HeaderWritten() bool {
+	return m.wroteHeader
+}
@@ -0,0 +1,64 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcceptsMultipartMixed(t *testing.T) {
+	run := func(accept string, expected bool) func(t *testing.T) {
+		return func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/", nil)
+			if accept != "" {
+				r.Header.Set(httpHeaderAccept, accept)
+			}
+			assert.Equal(t, expected, AcceptsMultipartMixed(r))
+		}
+	}
+
+	t.Run("without accept header", run("", false))
+	t.Run("json only", run("application/json", false))
+	t.Run("multipart mixed", run(`multipart/mixed; deferSpec=20220824, application/json`, true))
+	t.Run("multipart mixed with quality", run(`application/json;q=0.9, multipart/mixed;q=1.0`, true))
+}
+
+func TestMultipartMixedWriter(t *testing.T) {
+	t.Run("incremental response", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		writer := NewMultipartMixedWriter(recorder)
+
+		_, err := writer.Write([]byte(`{"data":{"a":null},"hasNext":true}`))
+		require.NoError(t, err)
+		writer.Flush()
+		assert.True(t, writer.HeaderWritten())
+		assert.True(t, recorder.Flushed)
+
+		_, err = writer.Write([]byte(`{"incremental":[{"data":{"a":1},"path":[]}],"hasNext":false}`))
+		require.NoError(t, err)
+		writer.Flush()
+		require.NoError(t, writer.Close())
+
+		assert.Equal(t, `multipart/mixed; boundary="-"`, recorder.Header().Get(httpHeaderContentType))
+		assert.Equal(t, "\r\n---\r\nContent-Type: application/json; charset=utf-8\r\n\r\n"+
+			`{"data":{"a":null},"hasNext":true}`+
+			"\r\n---\r\nContent-Type: application/json; charset=utf-8\r\n\r\n"+
+			`{"incremental":[{"data":{"a":1},"path":[]}],"hasNext":false}`+
+			"\r\n-----\r\n", recorder.Body.String())
+	})
+
+	t.Run("response without flush is written as json", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		writer := NewMultipartMixedWriter(recorder)
+
+		_, err := writer.Write([]byte(`{"data":{"a":1}}`))
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+
+		assert.Equal(t, httpContentTypeApplicationJson, recorder.Header().Get(httpHeaderContentType))
+		assert.Equal(t, `{"data":{"a":1}}`, recorder.Body.String())
+	})
+}
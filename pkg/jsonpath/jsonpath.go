@@ -0,0 +1,222 @@
+// Package jsonpath implements a subset of JSONPath to select values from decoded JSON documents.
+//
+// Supported expressions:
+//
+//	$             the root element, may be omitted
+//	.name         child by name
+//	['name']      child by name, for names containing special characters
+//	[0], [-1]     array element by index, negative indexes count from the end
+//	.* or [*]     all children of an object or array
+//	..name        recursive descent, all descendants named name
+package jsonpath
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type segmentKind int
+
+const (
+	segmentKindChild segmentKind = iota + 1
+	segmentKindIndex
+	segmentKindWildcard
+	segmentKindDescendant
+)
+
+type segment struct {
+	kind  segmentKind
+	name  string
+	index int
+}
+
+// Path is a compiled JSONPath expression
+type Path struct {
+	expression string
+	segments   []segment
+	definite   bool
+}
+
+// Parse compiles a JSONPath expression
+func Parse(expression string) (*Path, error) {
+	p := &Path{
+		expression: expression,
+		definite:   true,
+	}
+	rest := strings.TrimSpace(expression)
+	rest = strings.TrimPrefix(rest, "$")
+	if rest != "" && rest[0] != '.' && rest[0] != '[' {
+		rest = "." + rest
+	}
+	for len(rest) != 0 {
+		var (
+			seg segment
+			err error
+		)
+		switch {
+		case strings.HasPrefix(rest, ".."):
+			seg, rest, err = parseName(rest[2:], segmentKindDescendant)
+		case rest[0] == '.':
+			seg, rest, err = parseName(rest[1:], segmentKindChild)
+		case rest[0] == '[':
+			seg, rest, err = parseBracket(rest)
+		default:
+			err = fmt.Errorf("unexpected character '%c'", rest[0])
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid json path '%s': %w", expression, err)
+		}
+		if seg.kind == segmentKindWildcard || seg.kind == segmentKindDescendant {
+			p.definite = false
+		}
+		p.segments = append(p.segments, seg)
+	}
+	return p, nil
+}
+
+// MustParse is like Parse but panics if the expression is invalid
+func MustParse(expression string) *Path {
+	p, err := Parse(expression)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+func parseName(in string, kind segmentKind) (segment, string, error) {
+	end := strings.IndexAny(in, ".[")
+	if end == -1 {
+		end = len(in)
+	}
+	name := in[:end]
+	if name == "" {
+		return segment{}, "", fmt.Errorf("missing name")
+	}
+	if name == "*" {
+		if kind == segmentKindDescendant {
+			return segment{}, "", fmt.Errorf("recursive wildcard is not supported")
+		}
+		return segment{kind: segmentKindWildcard}, in[end:], nil
+	}
+	return segment{kind: kind, name: name}, in[end:], nil
+}
+
+func parseBracket(in string) (segment, string, error) {
+	end := strings.IndexByte(in, ']')
+	if end == -1 {
+		return segment{}, "", fmt.Errorf("missing ']'")
+	}
+	content := strings.TrimSpace(in[1:end])
+	rest := in[end+1:]
+	switch {
+	case content == "*":
+		return segment{kind: segmentKindWildcard}, rest, nil
+	case len(content) >= 2 && (content[0] == '\'' || content[0] == '"') && content[len(content)-1] == content[0]:
+		return segment{kind: segmentKindChild, name: content[1 : len(content)-1]}, rest, nil
+	}
+	index, err := strconv.Atoi(content)
+	if err != nil {
+		return segment{}, "", fmt.Errorf("invalid index '%s'", content)
+	}
+	return segment{kind: segmentKindIndex, index: index}, rest, nil
+}
+
+// IsDefinite returns true if the Path selects at most one value
+func (p *Path) IsDefinite() bool {
+	return p.definite
+}
+
+// IsSimpleChild returns the name of the selected child if the Path selects a direct child of the root by name
+func (p *Path) IsSimpleChild() (name string, ok bool) {
+	if len(p.segments) != 1 || p.segments[0].kind != segmentKindChild {
+		return "", false
+	}
+	return p.segments[0].name, true
+}
+
+func (p *Path) String() string {
+	return p.expression
+}
+
+// Select evaluates the Path against a document decoded with encoding/json
+// Definite paths return the selected value and whether it exists
+// Indefinite paths, e.g. containing wildcards, always return a (possibly empty) []interface{} of all matches
+func (p *Path) Select(data interface{}) (interface{}, bool) {
+	current := []interface{}{data}
+	for _, seg := range p.segments {
+		next := make([]interface{}, 0, len(current))
+		for _, value := range current {
+			next = seg.apply(value, next)
+		}
+		current = next
+	}
+	if !p.definite {
+		return current, true
+	}
+	if len(current) == 0 {
+		return nil, false
+	}
+	return current[0], true
+}
+
+func (s segment) apply(value interface{}, out []interface{}) []interface{} {
+	switch s.kind {
+	case segmentKindChild:
+		if object, ok := value.(map[string]interface{}); ok {
+			if child, exists := object[s.name]; exists {
+				out = append(out, child)
+			}
+		}
+	case segmentKindIndex:
+		if array, ok := value.([]interface{}); ok {
+			index := s.index
+			if index < 0 {
+				index = len(array) + index
+			}
+			if index >= 0 && index < len(array) {
+				out = append(out, array[index])
+			}
+		}
+	case segmentKindWildcard:
+		switch v := value.(type) {
+		case []interface{}:
+			out = append(out, v...)
+		case map[string]interface{}:
+			for _, key := range sortedKeys(v) {
+				out = append(out, v[key])
+			}
+		}
+	case segmentKindDescendant:
+		out = s.descendants(value, out)
+	}
+	return out
+}
+
+func (s segment) descendants(value interface{}, out []interface{}) []interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		for i := range v {
+			out = s.descendants(v[i], out)
+		}
+	case map[string]interface{}:
+		if child, exists := v[s.name]; exists {
+			out = append(out, child)
+		}
+		for _, key := range sortedKeys(v) {
+			out = s.descendants(v[key], out)
+		}
+	}
+	return out
+}
+
+// sortedKeys makes selections on objects deterministic
+func sortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,71 @@
+package jsonpath
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const document = `{
+	"store": {
+		"books": [
+			{"title": "Sayings of the Century", "price": 8.95, "author": {"name": "Nigel Rees"}},
+			{"title": "Sword of Honour", "price": 12.99, "author": {"name": "Evelyn Waugh"}}
+		],
+		"bicycle": {"color": "red", "price": 19.95},
+		"opening-hours": "9-5"
+	}
+}`
+
+func TestPath_Select(t *testing.T) {
+	var data interface{}
+	require.NoError(t, json.Unmarshal([]byte(document), &data))
+
+	run := func(expression string, expectedJSON string, expectedOk bool) func(t *testing.T) {
+		return func(t *testing.T) {
+			p, err := Parse(expression)
+			require.NoError(t, err)
+			actual, ok := p.Select(data)
+			assert.Equal(t, expectedOk, ok)
+			actualJSON, err := json.Marshal(actual)
+			require.NoError(t, err)
+			assert.JSONEq(t, expectedJSON, string(actualJSON))
+		}
+	}
+
+	t.Run("root", run("$", document, true))
+	t.Run("child", run("$.store.bicycle.color", `"red"`, true))
+	t.Run("child without root", run("store.bicycle.color", `"red"`, true))
+	t.Run("quoted child", run("$.store['opening-hours']", `"9-5"`, true))
+	t.Run("index", run("$.store.books[1].title", `"Sword of Honour"`, true))
+	t.Run("negative index", run("$.store.books[-1].title", `"Sword of Honour"`, true))
+	t.Run("missing child", run("$.store.car", `null`, false))
+	t.Run("index out of range", run("$.store.books[5]", `null`, false))
+	t.Run("wildcard", run("$.store.books[*].author.name", `["Nigel Rees","Evelyn Waugh"]`, true))
+	t.Run("object wildcard", run("$.store.bicycle.*", `["red",19.95]`, true))
+	t.Run("recursive descent", run("$..price", `[19.95,8.95,12.99]`, true))
+	t.Run("recursive descent without match", run("$..weight", `[]`, true))
+}
+
+func TestParse(t *testing.T) {
+	t.Run("definite", func(t *testing.T) {
+		assert.True(t, MustParse("$.a[0].b").IsDefinite())
+		assert.False(t, MustParse("$.a[*].b").IsDefinite())
+		assert.False(t, MustParse("$..b").IsDefinite())
+	})
+	t.Run("simple child", func(t *testing.T) {
+		name, ok := MustParse("$.first_name").IsSimpleChild()
+		assert.True(t, ok)
+		assert.Equal(t, "first_name", name)
+		_, ok = MustParse("$.a.b").IsSimpleChild()
+		assert.False(t, ok)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		for _, expression := range []string{"$.", "$[0", "$[a]", "$..*"} {
+			_, err := Parse(expression)
+			assert.Error(t, err, expression)
+		}
+	})
+}
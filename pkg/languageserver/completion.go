@@ -0,0 +1,61 @@
+package languageserver
+
+// completion returns the fields of the enclosing type or the arguments of the enclosing field at a position
+func (s *Server) completion(params TextDocumentPositionParams) []CompletionItem {
+	items := []CompletionItem{}
+	doc, ok := s.documents[params.TextDocument.URI]
+	if !ok || !s.hasSchema() {
+		return items
+	}
+	if !doc.isExecutable() && len(doc.ast.RootNodes) > 0 {
+		// schema documents
+		return items
+	}
+	_, start := doc.wordAt(doc.offset(params.Position))
+
+	schema := s.schemaDocument()
+	context := selectionContextAt(schema, doc.text, start)
+	if context.afterSpread || context.inTypeCondition || context.afterAt {
+		return items
+	}
+
+	if context.argumentsOf != "" {
+		ref, ok := fieldDefinition(schema, context.typeName, context.argumentsOf)
+		if !ok {
+			return items
+		}
+		for _, argument := range schema.FieldDefinitionArgumentsDefinitions(ref) {
+			items = append(items, CompletionItem{
+				Label:         schema.InputValueDefinitionNameString(argument),
+				Kind:          completionItemKindProperty,
+				Detail:        typeString(schema, schema.InputValueDefinitionType(argument)),
+				Documentation: markdown(schema.InputValueDefinitionDescriptionString(argument)),
+			})
+		}
+		return items
+	}
+
+	node, ok := typeNode(schema, context.typeName)
+	if !ok {
+		return items
+	}
+	for _, field := range schema.NodeFieldDefinitions(node) {
+		items = append(items, CompletionItem{
+			Label:         schema.FieldDefinitionNameString(field),
+			Kind:          completionItemKindField,
+			Detail:        typeString(schema, schema.FieldDefinitionType(field)),
+			Documentation: markdown(schema.FieldDefinitionDescriptionString(field)),
+		})
+	}
+	return items
+}
+
+func markdown(value string) *MarkupContent {
+	if value == "" {
+		return nil
+	}
+	return &MarkupContent{
+		Kind:  markupKindMarkdown,
+		Value: value,
+	}
+}
@@ -0,0 +1,123 @@
+package languageserver
+
+import (
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/keyword"
+)
+
+// selectionContext describes a position in an executable document
+type selectionContext struct {
+	// typeName is the enclosing type of the selection set, empty outside of selection sets
+	typeName string
+	// argumentsOf is the name of the field if the position is inside of its argument list
+	argumentsOf string
+	// afterSpread is true if the position follows a spread, i.e. it's a fragment spread or an inline fragment
+	afterSpread bool
+	// inTypeCondition is true if the position follows the "on" of a type condition
+	inTypeCondition bool
+	// afterAt is true if the position is the name of a directive
+	afterAt bool
+}
+
+// selectionContextAt determines the selection context in front of the byte offset of an executable document
+// The context is derived from the tokens instead of the ast, because documents are usually incomplete while they're edited.
+func selectionContextAt(schema *ast.Document, text string, offset int) selectionContext {
+	input := &ast.Input{}
+	input.ResetInputString(text[:offset])
+	tokens := &lexer.Lexer{}
+	tokens.SetInput(input)
+
+	var (
+		context selectionContext
+		// typeNames contains the enclosing types of all open selection sets
+		typeNames []string
+		// nextTypeName is the type of the next selection set given by an operation type or a type condition
+		nextTypeName string
+		lastField    string
+		// parentheses is the depth of an argument list or variable definitions
+		parentheses     int
+		argumentsOf     string
+		afterDirective  bool
+		previous        keyword.Keyword
+		previousLiteral string
+	)
+
+	for {
+		tok := tokens.Read()
+		if tok.Keyword == keyword.EOF {
+			break
+		}
+		if tok.Keyword == keyword.COMMENT {
+			continue
+		}
+		literal := input.ByteSliceString(tok.Literal)
+
+		if parentheses > 0 {
+			switch tok.Keyword {
+			case keyword.LPAREN:
+				parentheses++
+			case keyword.RPAREN:
+				parentheses--
+			}
+			previous, previousLiteral = tok.Keyword, literal
+			continue
+		}
+
+		typeConditionExpected := context.inTypeCondition
+		context.inTypeCondition = false
+		switch tok.Keyword {
+		case keyword.IDENT:
+			switch {
+			case previous == keyword.AT:
+				afterDirective = true
+			case literal == "on" && (previous == keyword.SPREAD || (len(typeNames) == 0 && previous == keyword.IDENT && previousLiteral != "on")):
+				context.inTypeCondition = true
+			case typeConditionExpected:
+				nextTypeName = literal
+			case previous == keyword.SPREAD:
+				// fragment spread
+			case len(typeNames) == 0:
+				switch literal {
+				case "query", "mutation", "subscription":
+					nextTypeName = rootTypeName(schema, literal)
+				}
+			default:
+				afterDirective = false
+				lastField = literal
+			}
+		case keyword.LPAREN:
+			parentheses = 1
+			argumentsOf = ""
+			if len(typeNames) > 0 && !afterDirective {
+				argumentsOf = lastField
+			}
+		case keyword.LBRACE:
+			switch {
+			case nextTypeName != "":
+				typeNames = append(typeNames, nextTypeName)
+			case len(typeNames) == 0:
+				typeNames = append(typeNames, rootTypeName(schema, "query"))
+			default:
+				typeNames = append(typeNames, fieldTypeName(schema, typeNames[len(typeNames)-1], lastField))
+			}
+			nextTypeName, lastField, afterDirective = "", "", false
+		case keyword.RBRACE:
+			if len(typeNames) > 0 {
+				typeNames = typeNames[:len(typeNames)-1]
+			}
+			lastField, afterDirective = "", false
+		}
+		previous, previousLiteral = tok.Keyword, literal
+	}
+
+	if len(typeNames) > 0 {
+		context.typeName = typeNames[len(typeNames)-1]
+	}
+	if parentheses > 0 {
+		context.argumentsOf = argumentsOf
+	}
+	context.afterSpread = previous == keyword.SPREAD
+	context.afterAt = previous == keyword.AT
+	return context
+}
@@ -0,0 +1,91 @@
+package languageserver
+
+import (
+	"strings"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+)
+
+// definition returns the locations of the type, fragment or directive definition of the name at a position
+func (s *Server) definition(params TextDocumentPositionParams) []Location {
+	doc, ok := s.documents[params.TextDocument.URI]
+	if !ok {
+		return nil
+	}
+	word, start := doc.wordAt(doc.offset(params.Position))
+	if word == "" {
+		return nil
+	}
+
+	preceding := doc.precedingText(start)
+	switch {
+	case strings.HasSuffix(preceding, "..."):
+		return s.fragmentDefinitions(word)
+	case strings.HasSuffix(preceding, "@"):
+		return s.schemaDefinitions(word, func(doc *ast.Document) []ast.Node {
+			var nodes []ast.Node
+			for i := range doc.DirectiveDefinitions {
+				if doc.DirectiveDefinitionNameString(i) == word {
+					nodes = append(nodes, ast.Node{Kind: ast.NodeKindDirectiveDefinition, Ref: i})
+				}
+			}
+			return nodes
+		})
+	default:
+		locations := s.schemaDefinitions(word, func(doc *ast.Document) []ast.Node {
+			node, ok := doc.Index.FirstNonExtensionNodeByNameBytes([]byte(word))
+			if !ok || node.Kind == ast.NodeKindDirectiveDefinition {
+				return nil
+			}
+			return []ast.Node{node}
+		})
+		if len(locations) > 0 {
+			return locations
+		}
+		// types which are only defined by extensions
+		return s.schemaDefinitions(word, func(doc *ast.Document) []ast.Node {
+			nodes, _ := doc.Index.NodesByNameStr(word)
+			return nodes
+		})
+	}
+}
+
+// fragmentDefinitions returns the locations of all fragment definitions with a name
+func (s *Server) fragmentDefinitions(name string) []Location {
+	var locations []Location
+	for _, uri := range s.sortedURIs() {
+		doc := s.documents[uri]
+		for i := range doc.ast.FragmentDefinitions {
+			if doc.ast.FragmentDefinitionNameString(i) != name {
+				continue
+			}
+			locations = append(locations, Location{
+				URI:   uri,
+				Range: doc.referenceRange(doc.ast.FragmentDefinitions[i].Name),
+			})
+		}
+	}
+	return locations
+}
+
+// schemaDefinitions returns the locations of the names of the nodes found by lookup in the schema documents
+func (s *Server) schemaDefinitions(name string, lookup func(doc *ast.Document) []ast.Node) []Location {
+	var locations []Location
+	for _, uri := range s.sortedURIs() {
+		doc := s.documents[uri]
+		if doc.isExecutable() {
+			continue
+		}
+		for _, node := range lookup(doc.ast) {
+			reference, ok := nodeNameReference(doc.ast, node)
+			if !ok {
+				continue
+			}
+			locations = append(locations, Location{
+				URI:   uri,
+				Range: doc.referenceRange(reference),
+			})
+		}
+	}
+	return locations
+}
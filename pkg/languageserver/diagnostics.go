@@ -0,0 +1,94 @@
+package languageserver
+
+import (
+	"github.com/wundergraph/graphql-go-tools/pkg/astvalidation"
+	"github.com/wundergraph/graphql-go-tools/pkg/graphqlerrors"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+// diagnostics returns the syntax errors of a document,
+// documents without syntax errors get validated against the schema
+func (s *Server) diagnostics(doc *document) []Diagnostic {
+	diagnostics := make([]Diagnostic, 0, len(doc.syntaxErrors))
+	for _, syntaxError := range doc.syntaxErrors {
+		diagnostics = append(diagnostics, Diagnostic{
+			Range:    doc.lspRange(syntaxError.Position),
+			Severity: diagnosticSeverityError,
+			Source:   serverName,
+			Message:  syntaxError.Message,
+		})
+	}
+	if len(diagnostics) > 0 {
+		return diagnostics
+	}
+
+	var report operationreport.Report
+	if doc.isExecutable() {
+		report = s.validateOperation(doc)
+	} else {
+		report = s.validateSchema(doc)
+	}
+
+	for _, externalError := range report.ExternalErrors {
+		diagnostic, ok := s.validationDiagnostic(doc, externalError)
+		if !ok {
+			continue
+		}
+		diagnostics = append(diagnostics, diagnostic)
+	}
+	return diagnostics
+}
+
+func (s *Server) validateOperation(doc *document) operationreport.Report {
+	report := operationreport.Report{}
+	if !s.hasSchema() {
+		return report
+	}
+	astvalidation.DefaultOperationValidator().Validate(doc.ast, s.schemaDocument(), &report)
+	return report
+}
+
+// validateSchema validates the schema with the document in front of all other schema documents,
+// so that the locations of errors of the document are valid
+func (s *Server) validateSchema(doc *document) operationreport.Report {
+	report := operationreport.Report{}
+	if len(doc.ast.RootNodes) == 0 {
+		return report
+	}
+	astvalidation.DefaultDefinitionValidator().Validate(s.buildSchema(doc.uri), &report)
+	return report
+}
+
+// validationDiagnostic converts a validation error into a diagnostic
+// Errors located in other documents are skipped, errors without location are located at the start of the document.
+func (s *Server) validationDiagnostic(doc *document, externalError operationreport.ExternalError) (Diagnostic, bool) {
+	diagnostic := Diagnostic{
+		Severity: diagnosticSeverityError,
+		Source:   serverName,
+		Message:  externalError.Message,
+	}
+	if len(externalError.Locations) == 0 {
+		return diagnostic, true
+	}
+
+	location := externalError.Locations[0]
+	if int(location.Line) > doc.lineCount() {
+		return Diagnostic{}, false
+	}
+	diagnostic.Range = s.nameRange(doc, location)
+	return diagnostic, true
+}
+
+// nameRange returns the range of the name at a location
+func (s *Server) nameRange(doc *document, location graphqlerrors.Location) Range {
+	start := doc.lspPosition(location.Line, location.Column)
+	offset := doc.offset(start)
+	end := offset
+	for end < len(doc.text) && isNameByte(doc.text[end]) {
+		end++
+	}
+	return Range{
+		Start: start,
+		End:   doc.positionAt(end),
+	}
+}
@@ -0,0 +1,174 @@
+package languageserver
+
+import (
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astparser"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/position"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+// document is a GraphQL document of the workspace, either opened by the client or a schema file loaded from disk
+type document struct {
+	uri  string
+	text string
+	// lineOffsets contains the byte offset of the start of each line
+	lineOffsets []int
+	ast         *ast.Document
+	// syntaxErrors are the errors of the recovering parse, the ast only contains the valid definitions
+	syntaxErrors []astparser.Diagnostic
+	open         bool
+}
+
+func newDocument(uri, text string, open bool) *document {
+	doc := &document{
+		uri:         uri,
+		text:        text,
+		lineOffsets: []int{0},
+		ast:         ast.NewDocument(),
+		open:        open,
+	}
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\n' {
+			doc.lineOffsets = append(doc.lineOffsets, i+1)
+		}
+	}
+
+	doc.ast.Input.ResetInputString(text)
+	report := operationreport.Report{}
+	parser := astparser.NewParser()
+	parser.KeepComments(true)
+	doc.syntaxErrors = parser.ParseWithRecovery(doc.ast, &report)
+
+	return doc
+}
+
+// isExecutable returns true for documents with operations or fragments, all other documents are part of the schema
+func (d *document) isExecutable() bool {
+	return len(d.ast.OperationDefinitions) > 0 || len(d.ast.FragmentDefinitions) > 0
+}
+
+// lineCount returns the number of lines of the document
+func (d *document) lineCount() int {
+	return len(d.lineOffsets)
+}
+
+func (d *document) line(line int) string {
+	start := d.lineOffsets[line]
+	end := len(d.text)
+	if line+1 < len(d.lineOffsets) {
+		end = d.lineOffsets[line+1]
+	}
+	return strings.TrimRight(d.text[start:end], "\r\n")
+}
+
+// lspPosition converts a one based line and byte column, as used by the lexer, into a position
+func (d *document) lspPosition(line, column uint32) Position {
+	if line == 0 {
+		return Position{}
+	}
+	lspLine := int(line) - 1
+	if lspLine >= d.lineCount() {
+		lspLine = d.lineCount() - 1
+	}
+
+	text := d.line(lspLine)
+	byteColumn := int(column) - 1
+	if byteColumn < 0 {
+		byteColumn = 0
+	}
+	if byteColumn > len(text) {
+		byteColumn = len(text)
+	}
+
+	return Position{
+		Line:      lspLine,
+		Character: utf16Length(text[:byteColumn]),
+	}
+}
+
+// lspRange converts a position of the lexer into a range
+func (d *document) lspRange(pos position.Position) Range {
+	return Range{
+		Start: d.lspPosition(pos.LineStart, pos.CharStart),
+		End:   d.lspPosition(pos.LineEnd, pos.CharEnd),
+	}
+}
+
+// referenceRange returns the range of a byte slice reference of the document, e.g. a name
+func (d *document) referenceRange(reference ast.ByteSliceReference) Range {
+	return d.lspRange(d.ast.Input.ByteSliceReferencePosition(reference))
+}
+
+// offset converts a position into a byte offset of the text
+func (d *document) offset(pos Position) int {
+	if pos.Line < 0 {
+		return 0
+	}
+	if pos.Line >= d.lineCount() {
+		return len(d.text)
+	}
+
+	lineStart := d.lineOffsets[pos.Line]
+	text := d.line(pos.Line)
+	characters := 0
+	for i, r := range text {
+		if characters >= pos.Character {
+			return lineStart + i
+		}
+		characters += utf16.RuneLen(r)
+	}
+	return lineStart + len(text)
+}
+
+// positionAt converts a byte offset of the text into a position
+func (d *document) positionAt(offset int) Position {
+	line := 0
+	for line+1 < len(d.lineOffsets) && d.lineOffsets[line+1] <= offset {
+		line++
+	}
+	return Position{
+		Line:      line,
+		Character: utf16Length(d.text[d.lineOffsets[line]:offset]),
+	}
+}
+
+// end returns the position at the end of the document
+func (d *document) end() Position {
+	return d.positionAt(len(d.text))
+}
+
+// wordAt returns the name at the byte offset and its start offset
+func (d *document) wordAt(offset int) (word string, start int) {
+	start, end := offset, offset
+	for start > 0 && isNameByte(d.text[start-1]) {
+		start--
+	}
+	for end < len(d.text) && isNameByte(d.text[end]) {
+		end++
+	}
+	return d.text[start:end], start
+}
+
+// precedingText returns the text in front of offset on the same line without trailing whitespace
+func (d *document) precedingText(offset int) string {
+	lineStart := strings.LastIndexByte(d.text[:offset], '\n') + 1
+	return strings.TrimRight(d.text[lineStart:offset], " \t")
+}
+
+func isNameByte(b byte) bool {
+	return b == '_' || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || (b >= '0' && b <= '9')
+}
+
+func utf16Length(text string) int {
+	length := 0
+	for len(text) > 0 {
+		r, size := utf8.DecodeRuneInString(text)
+		length += utf16.RuneLen(r)
+		text = text[size:]
+	}
+	return length
+}
@@ -0,0 +1,31 @@
+package languageserver
+
+import (
+	"github.com/wundergraph/graphql-go-tools/pkg/astprinter"
+)
+
+// formatting returns an edit replacing the whole document with the formatted ast including its comments
+// Documents with syntax errors are left untouched, otherwise invalid definitions would be dropped.
+func (s *Server) formatting(params DocumentFormattingParams) []TextEdit {
+	edits := []TextEdit{}
+	doc, ok := s.documents[params.TextDocument.URI]
+	if !ok || len(doc.syntaxErrors) > 0 || len(doc.ast.RootNodes) == 0 {
+		return edits
+	}
+
+	formatted, err := astprinter.FormatString(doc.ast, astprinter.FormatOptions{})
+	if err != nil {
+		return edits
+	}
+	if formatted == doc.text {
+		return edits
+	}
+
+	return append(edits, TextEdit{
+		Range: Range{
+			Start: Position{},
+			End:   doc.end(),
+		},
+		NewText: formatted,
+	})
+}
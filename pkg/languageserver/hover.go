@@ -0,0 +1,75 @@
+package languageserver
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+)
+
+// hover returns the signature and description of the field, argument, type or directive at a position
+func (s *Server) hover(params TextDocumentPositionParams) *Hover {
+	doc, ok := s.documents[params.TextDocument.URI]
+	if !ok || !s.hasSchema() {
+		return nil
+	}
+	word, start := doc.wordAt(doc.offset(params.Position))
+	if word == "" {
+		return nil
+	}
+
+	schema := s.schemaDocument()
+	signature, description, ok := s.hoverContent(doc, schema, word, start)
+	if !ok {
+		return nil
+	}
+
+	value := fmt.Sprintf("```graphql\n%s\n```", signature)
+	if description != "" {
+		value += "\n\n" + description
+	}
+	wordRange := Range{
+		Start: doc.positionAt(start),
+		End:   doc.positionAt(start + len(word)),
+	}
+	return &Hover{
+		Contents: MarkupContent{
+			Kind:  markupKindMarkdown,
+			Value: value,
+		},
+		Range: &wordRange,
+	}
+}
+
+func (s *Server) hoverContent(doc *document, schema *ast.Document, word string, start int) (signature, description string, ok bool) {
+	if strings.HasSuffix(doc.precedingText(start), "@") {
+		for i := range schema.DirectiveDefinitions {
+			if schema.DirectiveDefinitionNameString(i) == word {
+				return "directive @" + word, schema.DirectiveDefinitionDescriptionString(i), true
+			}
+		}
+		return "", "", false
+	}
+
+	if doc.isExecutable() {
+		context := selectionContextAt(schema, doc.text, start)
+		switch {
+		case context.argumentsOf != "":
+			ref, ok := argumentDefinition(schema, context.typeName, context.argumentsOf, word)
+			if !ok {
+				return "", "", false
+			}
+			return word + ": " + typeString(schema, schema.InputValueDefinitionType(ref)), schema.InputValueDefinitionDescriptionString(ref), true
+		case context.typeName != "" && !context.afterSpread && !context.inTypeCondition:
+			if ref, ok := fieldDefinition(schema, context.typeName, word); ok {
+				return context.typeName + "." + word + ": " + typeString(schema, schema.FieldDefinitionType(ref)), schema.FieldDefinitionDescriptionString(ref), true
+			}
+		}
+	}
+
+	node, ok := typeNode(schema, word)
+	if !ok || node.Kind == ast.NodeKindDirectiveDefinition {
+		return "", "", false
+	}
+	return nodeKeyword(node) + " " + word, nodeDescription(schema, node), true
+}
@@ -0,0 +1,365 @@
+// Package languageserver implements a Language Server Protocol server for GraphQL schemas and operations.
+//
+// The server communicates with JSON-RPC 2.0 messages, e.g. via stdio, and is backed by astparser, astvalidation and astprinter.
+// All documents without operations or fragments are considered to be part of the schema,
+// operations are validated against the schema merged from these documents.
+package languageserver
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astnormalization"
+	"github.com/wundergraph/graphql-go-tools/pkg/astparser"
+	"github.com/wundergraph/graphql-go-tools/pkg/asttransform"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+const serverName = "graphql-go-tools"
+
+// Server is a GraphQL language server
+// use NewServer() to create a server and Serve to handle the messages of a client
+type Server struct {
+	documents map[string]*document
+	// schemaFiles contains the paths of the schema files loaded from disk by uri
+	schemaFiles map[string]string
+	// schema is the merged schema of all schema documents, it's built lazily
+	schema *ast.Document
+
+	writer     io.Writer
+	writeMutex sync.Mutex
+	shutdown   bool
+}
+
+// NewServer returns a new language server without documents
+func NewServer() *Server {
+	return &Server{
+		documents:   map[string]*document{},
+		schemaFiles: map[string]string{},
+	}
+}
+
+// LoadSchemaFiles adds schema files which are not necessarily opened by the client, e.g. the schema of the gateway
+func (s *Server) LoadSchemaFiles(paths ...string) error {
+	for _, path := range paths {
+		absolutePath, err := filepath.Abs(path)
+		if err != nil {
+			return err
+		}
+		content, err := ioutil.ReadFile(absolutePath)
+		if err != nil {
+			return err
+		}
+		uri := pathToURI(absolutePath)
+		s.schemaFiles[uri] = absolutePath
+		s.documents[uri] = newDocument(uri, string(content), false)
+	}
+	s.schema = nil
+	return nil
+}
+
+// Serve handles the messages of a client read from reader and writes responses and notifications to writer
+// It returns when the client sends the exit notification or reader is closed.
+func (s *Server) Serve(reader io.Reader, writer io.Writer) error {
+	s.writer = writer
+	bufferedReader := bufio.NewReader(reader)
+
+	for {
+		content, err := readMessage(bufferedReader)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var req request
+		if err := json.Unmarshal(content, &req); err != nil {
+			if err := s.writeError(nil, &ResponseError{Code: codeParseError, Message: err.Error()}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if req.Method == "exit" {
+			return nil
+		}
+
+		result, responseErr := s.handleRecover(&req)
+		if req.isNotification() {
+			continue
+		}
+		if responseErr != nil {
+			err = s.writeError(req.ID, responseErr)
+		} else {
+			err = s.write(response{JSONRPC: "2.0", ID: req.ID, Result: result})
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// handleRecover handles a message and turns a panic into an internal error, so that the server keeps running
+// Editors send incomplete documents on every keystroke, a document which crashes a handler mustn't stop the server.
+// Like any other error of a notification, the error is dropped for notifications.
+func (s *Server) handleRecover(req *request) (result interface{}, responseErr *ResponseError) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = nil
+			responseErr = &ResponseError{Code: codeInternalError, Message: fmt.Sprintf("%s: %v", req.Method, r)}
+		}
+	}()
+	return s.handle(req)
+}
+
+func (s *Server) handle(req *request) (interface{}, *ResponseError) {
+	if s.shutdown {
+		return nil, &ResponseError{Code: codeInvalidRequest, Message: "server is shut down"}
+	}
+
+	switch req.Method {
+	case "initialize":
+		return s.initialize(), nil
+	case "initialized":
+		return nil, nil
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+	case "textDocument/didOpen":
+		var params DidOpenTextDocumentParams
+		if err := unmarshalParams(req, &params); err != nil {
+			return nil, err
+		}
+		return nil, s.responseError(s.didOpen(params))
+	case "textDocument/didChange":
+		var params DidChangeTextDocumentParams
+		if err := unmarshalParams(req, &params); err != nil {
+			return nil, err
+		}
+		return nil, s.responseError(s.didChange(params))
+	case "textDocument/didClose":
+		var params DidCloseTextDocumentParams
+		if err := unmarshalParams(req, &params); err != nil {
+			return nil, err
+		}
+		return nil, s.responseError(s.didClose(params))
+	case "textDocument/definition":
+		var params TextDocumentPositionParams
+		if err := unmarshalParams(req, &params); err != nil {
+			return nil, err
+		}
+		return s.definition(params), nil
+	case "textDocument/hover":
+		var params TextDocumentPositionParams
+		if err := unmarshalParams(req, &params); err != nil {
+			return nil, err
+		}
+		return s.hover(params), nil
+	case "textDocument/completion":
+		var params TextDocumentPositionParams
+		if err := unmarshalParams(req, &params); err != nil {
+			return nil, err
+		}
+		return s.completion(params), nil
+	case "textDocument/formatting":
+		var params DocumentFormattingParams
+		if err := unmarshalParams(req, &params); err != nil {
+			return nil, err
+		}
+		return s.formatting(params), nil
+	default:
+		return nil, &ResponseError{Code: codeMethodNotFound, Message: fmt.Sprintf("method not found: %s", req.Method)}
+	}
+}
+
+func (s *Server) initialize() InitializeResult {
+	return InitializeResult{
+		Capabilities: ServerCapabilities{
+			TextDocumentSync:   textDocumentSyncKindFull,
+			DefinitionProvider: true,
+			HoverProvider:      true,
+			CompletionProvider: CompletionOptions{
+				TriggerCharacters: []string{"{", "("},
+			},
+			DocumentFormattingProvider: true,
+		},
+		ServerInfo: ServerInfo{
+			Name: serverName,
+		},
+	}
+}
+
+func (s *Server) didOpen(params DidOpenTextDocumentParams) error {
+	return s.updateDocument(newDocument(params.TextDocument.URI, params.TextDocument.Text, true))
+}
+
+func (s *Server) didChange(params DidChangeTextDocumentParams) error {
+	if len(params.ContentChanges) == 0 {
+		return nil
+	}
+	// the server only supports full document sync, so the last change contains the complete text
+	text := params.ContentChanges[len(params.ContentChanges)-1].Text
+	return s.updateDocument(newDocument(params.TextDocument.URI, text, true))
+}
+
+func (s *Server) didClose(params DidCloseTextDocumentParams) error {
+	uri := params.TextDocument.URI
+	previous, ok := s.documents[uri]
+	if !ok {
+		return nil
+	}
+
+	if path, isSchemaFile := s.schemaFiles[uri]; isSchemaFile {
+		// schema files stay part of the schema with their content on disk
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		s.documents[uri] = newDocument(uri, string(content), false)
+	} else {
+		delete(s.documents, uri)
+	}
+
+	if err := s.publishDiagnostics(uri, []Diagnostic{}); err != nil {
+		return err
+	}
+	if !previous.isExecutable() {
+		s.schema = nil
+		return s.publishAllDiagnostics()
+	}
+	return nil
+}
+
+// updateDocument stores a new version of a document and publishes its diagnostics
+// When the document is part of the schema, the diagnostics of all documents get updated.
+func (s *Server) updateDocument(doc *document) error {
+	previous, existed := s.documents[doc.uri]
+	s.documents[doc.uri] = doc
+
+	affectsSchema := !doc.isExecutable() || (existed && !previous.isExecutable())
+	if !affectsSchema {
+		return s.publishDiagnostics(doc.uri, s.diagnostics(doc))
+	}
+
+	s.schema = nil
+	return s.publishAllDiagnostics()
+}
+
+func (s *Server) publishAllDiagnostics() error {
+	for _, uri := range s.sortedURIs() {
+		doc := s.documents[uri]
+		if !doc.open {
+			continue
+		}
+		if err := s.publishDiagnostics(uri, s.diagnostics(doc)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Server) publishDiagnostics(uri string, diagnostics []Diagnostic) error {
+	return s.write(notification{
+		JSONRPC: "2.0",
+		Method:  "textDocument/publishDiagnostics",
+		Params: PublishDiagnosticsParams{
+			URI:         uri,
+			Diagnostics: diagnostics,
+		},
+	})
+}
+
+// schemaDocument returns the merged schema of all schema documents including the base schema
+func (s *Server) schemaDocument() *ast.Document {
+	if s.schema != nil {
+		return s.schema
+	}
+
+	s.schema = s.buildSchema("")
+	return s.schema
+}
+
+// buildSchema merges all schema documents into a single schema
+// The document with the uri firstURI comes first, so that the positions of its definitions are unchanged.
+func (s *Server) buildSchema(firstURI string) *ast.Document {
+	var sdl strings.Builder
+	if doc, ok := s.documents[firstURI]; ok {
+		sdl.WriteString(doc.text)
+		sdl.WriteString("\n")
+	}
+	for _, uri := range s.sortedURIs() {
+		doc := s.documents[uri]
+		if uri == firstURI || doc.isExecutable() {
+			continue
+		}
+		sdl.WriteString(doc.text)
+		sdl.WriteString("\n")
+	}
+
+	schema := ast.NewDocument()
+	schema.Input.ResetInputString(sdl.String())
+	report := operationreport.Report{}
+	astparser.NewParser().ParseWithRecovery(schema, &report)
+	if err := asttransform.MergeDefinitionWithBaseSchema(schema); err != nil {
+		return schema
+	}
+	report.Reset()
+	astnormalization.NormalizeDefinition(schema, &report)
+	return schema
+}
+
+// hasSchema returns true when at least one document is part of the schema
+func (s *Server) hasSchema() bool {
+	for _, doc := range s.documents {
+		if !doc.isExecutable() && len(doc.ast.RootNodes) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) sortedURIs() []string {
+	uris := make([]string, 0, len(s.documents))
+	for uri := range s.documents {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+	return uris
+}
+
+func (s *Server) write(message interface{}) error {
+	s.writeMutex.Lock()
+	defer s.writeMutex.Unlock()
+	return writeMessage(s.writer, message)
+}
+
+func (s *Server) writeError(id *json.RawMessage, responseErr *ResponseError) error {
+	return s.write(errorResponse{JSONRPC: "2.0", ID: id, Error: *responseErr})
+}
+
+func (s *Server) responseError(err error) *ResponseError {
+	if err == nil {
+		return nil
+	}
+	return &ResponseError{Code: codeInternalError, Message: err.Error()}
+}
+
+func unmarshalParams(req *request, params interface{}) *ResponseError {
+	if err := json.Unmarshal(req.Params, params); err != nil {
+		return &ResponseError{Code: codeInvalidParams, Message: err.Error()}
+	}
+	return nil
+}
+
+func pathToURI(path string) string {
+	return "file://" + filepath.ToSlash(path)
+}
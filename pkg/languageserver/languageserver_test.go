@@ -0,0 +1,361 @@
+package languageserver
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	schemaURI    = "file:///workspace/schema.graphql"
+	operationURI = "file:///workspace/operation.graphql"
+)
+
+const testSchema = `"""
+A user of the application
+"""
+type User {
+	"the unique id"
+	id: ID!
+	"the name of the user"
+	name: String
+	friends(
+		"limits the number of friends"
+		first: Int
+	): [User!]!
+}
+
+type Query {
+	user(id: ID!): User
+}
+`
+
+// session drives the server with JSON-RPC messages in-process
+type session struct {
+	t      *testing.T
+	input  bytes.Buffer
+	nextID int
+}
+
+func (s *session) request(method string, params interface{}) int {
+	s.nextID++
+	id := json.RawMessage(mustMarshal(s.t, s.nextID))
+	s.write(request{JSONRPC: "2.0", ID: &id, Method: method, Params: mustMarshal(s.t, params)})
+	return s.nextID
+}
+
+func (s *session) notify(method string, params interface{}) {
+	s.write(request{JSONRPC: "2.0", Method: method, Params: mustMarshal(s.t, params)})
+}
+
+func (s *session) write(req request) {
+	require.NoError(s.t, writeMessage(&s.input, req))
+}
+
+func (s *session) open(uri, text string) {
+	s.notify("textDocument/didOpen", DidOpenTextDocumentParams{
+		TextDocument: TextDocumentItem{URI: uri, LanguageID: "graphql", Version: 1, Text: text},
+	})
+}
+
+// serve runs the server with all messages sent so far and returns the responses by id and the diagnostics by uri
+// Later diagnostics of a document replace earlier ones, so only the latest published diagnostics are returned.
+func (s *session) serve(server *Server) (responses map[int]json.RawMessage, diagnostics map[string][]Diagnostic) {
+	s.notify("exit", nil)
+	output := &bytes.Buffer{}
+	require.NoError(s.t, server.Serve(&s.input, output))
+
+	responses = map[int]json.RawMessage{}
+	diagnostics = map[string][]Diagnostic{}
+	reader := bufio.NewReader(output)
+	for {
+		content, err := readMessage(reader)
+		if err == io.EOF {
+			return responses, diagnostics
+		}
+		require.NoError(s.t, err)
+
+		var message struct {
+			ID     *int            `json:"id"`
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+			Result json.RawMessage `json:"result"`
+			Error  *ResponseError  `json:"error"`
+		}
+		require.NoError(s.t, json.Unmarshal(content, &message))
+
+		switch {
+		case message.Method == "textDocument/publishDiagnostics":
+			var params PublishDiagnosticsParams
+			require.NoError(s.t, json.Unmarshal(message.Params, &params))
+			diagnostics[params.URI] = params.Diagnostics
+		case message.Error != nil:
+			responses[*message.ID] = mustMarshal(s.t, message.Error)
+		default:
+			responses[*message.ID] = message.Result
+		}
+	}
+}
+
+func mustMarshal(t *testing.T, v interface{}) json.RawMessage {
+	out, err := json.Marshal(v)
+	require.NoError(t, err)
+	return out
+}
+
+func positionParams(uri string, line, character int) TextDocumentPositionParams {
+	return TextDocumentPositionParams{
+		TextDocument: TextDocumentIdentifier{URI: uri},
+		Position:     Position{Line: line, Character: character},
+	}
+}
+
+func unmarshalResult(t *testing.T, result json.RawMessage, v interface{}) {
+	t.Helper()
+	require.NoError(t, json.Unmarshal(result, v))
+}
+
+func TestServer(t *testing.T) {
+	t.Run("initialize", func(t *testing.T) {
+		s := &session{t: t}
+		initialize := s.request("initialize", map[string]interface{}{})
+		s.notify("initialized", map[string]interface{}{})
+		shutdown := s.request("shutdown", nil)
+		afterShutdown := s.request("textDocument/hover", positionParams(operationURI, 0, 0))
+
+		responses, _ := s.serve(NewServer())
+
+		var result InitializeResult
+		unmarshalResult(t, responses[initialize], &result)
+		assert.Equal(t, textDocumentSyncKindFull, result.Capabilities.TextDocumentSync)
+		assert.True(t, result.Capabilities.DefinitionProvider)
+		assert.True(t, result.Capabilities.HoverProvider)
+		assert.True(t, result.Capabilities.DocumentFormattingProvider)
+		assert.Equal(t, "null", string(responses[shutdown]))
+		assert.Equal(t, `{"code":-32600,"message":"server is shut down"}`, string(responses[afterShutdown]))
+	})
+
+	t.Run("unknown method", func(t *testing.T) {
+		s := &session{t: t}
+		id := s.request("workspace/symbol", map[string]interface{}{})
+
+		responses, _ := s.serve(NewServer())
+		assert.Equal(t, `{"code":-32601,"message":"method not found: workspace/symbol"}`, string(responses[id]))
+	})
+
+	t.Run("diagnostics", func(t *testing.T) {
+		t.Run("syntax errors", func(t *testing.T) {
+			s := &session{t: t}
+			s.open(operationURI, "query {\n\tuser(id: \"1\") {\n\t\tname\n\t\n}\nquery Other { user(id: \"2\") { id } }")
+
+			_, diagnostics := s.serve(NewServer())
+			require.Len(t, diagnostics[operationURI], 1)
+			assert.Equal(t, Range{Start: Position{Line: 5, Character: 0}, End: Position{Line: 5, Character: 5}}, diagnostics[operationURI][0].Range)
+			assert.Equal(t, diagnosticSeverityError, diagnostics[operationURI][0].Severity)
+		})
+
+		t.Run("operation validation errors", func(t *testing.T) {
+			s := &session{t: t}
+			s.open(schemaURI, testSchema)
+			s.open(operationURI, "query {\n\tuser(id: \"1\") {\n\t\tage\n\t}\n}")
+
+			_, diagnostics := s.serve(NewServer())
+			assert.Len(t, diagnostics[schemaURI], 0)
+			require.Len(t, diagnostics[operationURI], 1)
+			assert.Contains(t, diagnostics[operationURI][0].Message, "age")
+		})
+
+		t.Run("schema validation errors", func(t *testing.T) {
+			s := &session{t: t}
+			s.open(schemaURI, "type Query {\n\tuser: Account\n}")
+
+			_, diagnostics := s.serve(NewServer())
+			require.Len(t, diagnostics[schemaURI], 1)
+			assert.Contains(t, diagnostics[schemaURI][0].Message, "Account")
+		})
+
+		t.Run("changing the schema revalidates operations", func(t *testing.T) {
+			s := &session{t: t}
+			s.open(schemaURI, testSchema)
+			s.open(operationURI, "{ user(id: \"1\") { age } }")
+			s.notify("textDocument/didChange", DidChangeTextDocumentParams{
+				TextDocument:   TextDocumentIdentifier{URI: schemaURI},
+				ContentChanges: []TextDocumentContentChangeEvent{{Text: "type User { age: Int } type Query { user(id: ID!): User }"}},
+			})
+
+			_, diagnostics := s.serve(NewServer())
+			assert.Len(t, diagnostics[schemaURI], 0)
+			assert.Len(t, diagnostics[operationURI], 0)
+		})
+
+		t.Run("incomplete documents", func(t *testing.T) {
+			s := &session{t: t}
+			s.open(schemaURI, testSchema)
+			for _, text := range []string{"scalar \"\"\"x", "enum\n\"\"\"x", "type Query { a: Int }\ntype\n\"\"\"x"} {
+				s.notify("textDocument/didChange", DidChangeTextDocumentParams{
+					TextDocument:   TextDocumentIdentifier{URI: schemaURI},
+					ContentChanges: []TextDocumentContentChangeEvent{{Text: text}},
+				})
+			}
+			hover := s.request("textDocument/hover", positionParams(schemaURI, 0, 0))
+
+			responses, diagnostics := s.serve(NewServer())
+			require.Len(t, diagnostics[schemaURI], 2)
+			assert.Equal(t, "unexpected token - got: BLOCKSTRING want one of: [IDENT]", diagnostics[schemaURI][0].Message)
+			assert.Equal(t, "null", string(responses[hover]))
+		})
+
+		t.Run("closing a document clears its diagnostics", func(t *testing.T) {
+			s := &session{t: t}
+			s.open(operationURI, "query {")
+			s.notify("textDocument/didClose", DidCloseTextDocumentParams{TextDocument: TextDocumentIdentifier{URI: operationURI}})
+
+			_, diagnostics := s.serve(NewServer())
+			assert.Len(t, diagnostics[operationURI], 0)
+		})
+	})
+
+	t.Run("definition", func(t *testing.T) {
+		s := &session{t: t}
+		s.open(schemaURI, testSchema)
+		s.open(operationURI, "query {\n\tuser(id: \"1\") {\n\t\t...UserFields\n\t}\n}\n\nfragment UserFields on User {\n\tname\n}")
+		fragment := s.request("textDocument/definition", positionParams(operationURI, 2, 8))
+		typeCondition := s.request("textDocument/definition", positionParams(operationURI, 6, 24))
+		schemaType := s.request("textDocument/definition", positionParams(schemaURI, 15, 17))
+		unknown := s.request("textDocument/definition", positionParams(operationURI, 7, 2))
+
+		responses, _ := s.serve(NewServer())
+
+		var fragmentLocations, typeConditionLocations, schemaTypeLocations []Location
+		unmarshalResult(t, responses[fragment], &fragmentLocations)
+		assert.Equal(t, []Location{{URI: operationURI, Range: Range{Start: Position{Line: 6, Character: 9}, End: Position{Line: 6, Character: 19}}}}, fragmentLocations)
+
+		userLocation := Location{URI: schemaURI, Range: Range{Start: Position{Line: 3, Character: 5}, End: Position{Line: 3, Character: 9}}}
+		unmarshalResult(t, responses[typeCondition], &typeConditionLocations)
+		assert.Equal(t, []Location{userLocation}, typeConditionLocations)
+
+		unmarshalResult(t, responses[schemaType], &schemaTypeLocations)
+		assert.Equal(t, []Location{userLocation}, schemaTypeLocations)
+
+		assert.Equal(t, "null", string(responses[unknown]))
+	})
+
+	t.Run("hover", func(t *testing.T) {
+		s := &session{t: t}
+		s.open(schemaURI, testSchema)
+		s.open(operationURI, "query {\n\tuser(id: \"1\") {\n\t\tname\n\t\tfriends(first: 10) { id }\n\t}\n}")
+		field := s.request("textDocument/hover", positionParams(operationURI, 2, 3))
+		argument := s.request("textDocument/hover", positionParams(operationURI, 3, 11))
+		schemaType := s.request("textDocument/hover", positionParams(schemaURI, 15, 17))
+		nothing := s.request("textDocument/hover", positionParams(operationURI, 0, 6))
+
+		responses, _ := s.serve(NewServer())
+
+		var fieldHover, argumentHover, schemaTypeHover Hover
+		unmarshalResult(t, responses[field], &fieldHover)
+		assert.Equal(t, "```graphql\nUser.name: String\n```\n\nthe name of the user", fieldHover.Contents.Value)
+		assert.Equal(t, markupKindMarkdown, fieldHover.Contents.Kind)
+		assert.Equal(t, &Range{Start: Position{Line: 2, Character: 2}, End: Position{Line: 2, Character: 6}}, fieldHover.Range)
+
+		unmarshalResult(t, responses[argument], &argumentHover)
+		assert.Equal(t, "```graphql\nfirst: Int\n```\n\nlimits the number of friends", argumentHover.Contents.Value)
+
+		unmarshalResult(t, responses[schemaType], &schemaTypeHover)
+		assert.Equal(t, "```graphql\ntype User\n```\n\nA user of the application", schemaTypeHover.Contents.Value)
+
+		assert.Equal(t, "null", string(responses[nothing]))
+	})
+
+	t.Run("completion", func(t *testing.T) {
+		s := &session{t: t}
+		s.open(schemaURI, testSchema)
+		s.open(operationURI, "query {\n\tuser(id: \"1\") {\n\t\tfriends() {\n\t\t\t\n\t\t}\n\t}\n}")
+		fields := s.request("textDocument/completion", positionParams(operationURI, 3, 3))
+		arguments := s.request("textDocument/completion", positionParams(operationURI, 2, 10))
+		rootFields := s.request("textDocument/completion", positionParams(operationURI, 0, 7))
+
+		responses, _ := s.serve(NewServer())
+
+		var fieldItems, argumentItems, rootFieldItems []CompletionItem
+		unmarshalResult(t, responses[fields], &fieldItems)
+		assert.Equal(t, []CompletionItem{
+			{Label: "id", Kind: completionItemKindField, Detail: "ID!", Documentation: &MarkupContent{Kind: markupKindMarkdown, Value: "the unique id"}},
+			{Label: "name", Kind: completionItemKindField, Detail: "String", Documentation: &MarkupContent{Kind: markupKindMarkdown, Value: "the name of the user"}},
+			{Label: "friends", Kind: completionItemKindField, Detail: "[User!]!"},
+			{Label: "__typename", Kind: completionItemKindField, Detail: "String!"},
+		}, fieldItems)
+
+		unmarshalResult(t, responses[arguments], &argumentItems)
+		assert.Equal(t, []CompletionItem{
+			{Label: "first", Kind: completionItemKindProperty, Detail: "Int", Documentation: &MarkupContent{Kind: markupKindMarkdown, Value: "limits the number of friends"}},
+		}, argumentItems)
+
+		unmarshalResult(t, responses[rootFields], &rootFieldItems)
+		labels := make([]string, 0, len(rootFieldItems))
+		for _, item := range rootFieldItems {
+			labels = append(labels, item.Label)
+		}
+		assert.Equal(t, []string{"user", "__schema", "__type", "__typename"}, labels)
+	})
+
+	t.Run("formatting", func(t *testing.T) {
+		s := &session{t: t}
+		s.open(operationURI, "query   Q{ # the query\nuser(id:1){ name }}\n")
+		s.open("file:///workspace/invalid.graphql", "query {")
+		formatted := s.request("textDocument/formatting", DocumentFormattingParams{TextDocument: TextDocumentIdentifier{URI: operationURI}})
+		invalid := s.request("textDocument/formatting", DocumentFormattingParams{TextDocument: TextDocumentIdentifier{URI: "file:///workspace/invalid.graphql"}})
+
+		responses, _ := s.serve(NewServer())
+
+		var edits []TextEdit
+		unmarshalResult(t, responses[formatted], &edits)
+		assert.Equal(t, []TextEdit{{
+			Range:   Range{Start: Position{Line: 0, Character: 0}, End: Position{Line: 2, Character: 0}},
+			NewText: "query Q { # the query\n  user(id: 1) {\n    name\n  }\n}\n",
+		}}, edits)
+
+		assert.Equal(t, "[]", string(responses[invalid]))
+	})
+
+	t.Run("schema files", func(t *testing.T) {
+		server := NewServer()
+		require.NoError(t, server.LoadSchemaFiles("./testdata/schema.graphql"))
+
+		s := &session{t: t}
+		s.open(operationURI, "{ user(id: \"1\") { age } }")
+		_, diagnostics := s.serve(server)
+		require.Len(t, diagnostics[operationURI], 1)
+		assert.Contains(t, diagnostics[operationURI][0].Message, "age")
+	})
+}
+
+func TestServer_handleRecover(t *testing.T) {
+	// a server without documents panics when a document is opened
+	server := &Server{}
+	params := mustMarshal(t, DidOpenTextDocumentParams{TextDocument: TextDocumentItem{URI: schemaURI, Text: testSchema}})
+
+	result, responseErr := server.handleRecover(&request{JSONRPC: "2.0", Method: "textDocument/didOpen", Params: params})
+	assert.Nil(t, result)
+	require.NotNil(t, responseErr)
+	assert.Equal(t, codeInternalError, responseErr.Code)
+	assert.Equal(t, "textDocument/didOpen: assignment to entry in nil map", responseErr.Message)
+}
+
+func TestDocument_Positions(t *testing.T) {
+	doc := newDocument(operationURI, "{\n\tä: user(name: \"𝄞\") { id }\n}", true)
+
+	assert.Equal(t, Position{Line: 1, Character: 4}, doc.lspPosition(2, 6))
+	assert.Equal(t, Position{Line: 1, Character: 19}, doc.lspPosition(2, 23))
+	assert.Equal(t, 7, doc.offset(Position{Line: 1, Character: 4}))
+	assert.Equal(t, Position{Line: 1, Character: 4}, doc.positionAt(7))
+	assert.Equal(t, Position{Line: 2, Character: 1}, doc.end())
+
+	word, start := doc.wordAt(9)
+	assert.Equal(t, "user", word)
+	assert.Equal(t, 7, start)
+}
@@ -0,0 +1,160 @@
+package languageserver
+
+import (
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+)
+
+// rootTypeName returns the name of the root type for an operation type, e.g. query
+func rootTypeName(schema *ast.Document, operationType string) string {
+	switch operationType {
+	case "query":
+		if len(schema.Index.QueryTypeName) > 0 {
+			return string(schema.Index.QueryTypeName)
+		}
+		return "Query"
+	case "mutation":
+		if len(schema.Index.MutationTypeName) > 0 {
+			return string(schema.Index.MutationTypeName)
+		}
+		return "Mutation"
+	case "subscription":
+		if len(schema.Index.SubscriptionTypeName) > 0 {
+			return string(schema.Index.SubscriptionTypeName)
+		}
+		return "Subscription"
+	default:
+		return ""
+	}
+}
+
+// typeNode returns the definition of a type of the schema
+func typeNode(schema *ast.Document, typeName string) (ast.Node, bool) {
+	if typeName == "" {
+		return ast.InvalidNode, false
+	}
+	return schema.Index.FirstNonExtensionNodeByNameBytes([]byte(typeName))
+}
+
+// fieldDefinition returns the definition of a field of an object or interface type
+func fieldDefinition(schema *ast.Document, typeName, fieldName string) (int, bool) {
+	node, ok := typeNode(schema, typeName)
+	if !ok {
+		return -1, false
+	}
+	return schema.NodeFieldDefinitionByName(node, []byte(fieldName))
+}
+
+// fieldTypeName returns the name of the underlying type of a field, e.g. User for [User!]!
+func fieldTypeName(schema *ast.Document, typeName, fieldName string) string {
+	if fieldName == "__typename" {
+		return "String"
+	}
+	ref, ok := fieldDefinition(schema, typeName, fieldName)
+	if !ok {
+		return ""
+	}
+	return schema.ResolveTypeNameString(schema.FieldDefinitionType(ref))
+}
+
+// argumentDefinition returns the definition of an argument of a field
+func argumentDefinition(schema *ast.Document, typeName, fieldName, argumentName string) (int, bool) {
+	ref, ok := fieldDefinition(schema, typeName, fieldName)
+	if !ok {
+		return -1, false
+	}
+	for _, argument := range schema.FieldDefinitionArgumentsDefinitions(ref) {
+		if schema.InputValueDefinitionNameString(argument) == argumentName {
+			return argument, true
+		}
+	}
+	return -1, false
+}
+
+// nodeNameReference returns the reference to the name of a root node
+func nodeNameReference(doc *ast.Document, node ast.Node) (ast.ByteSliceReference, bool) {
+	switch node.Kind {
+	case ast.NodeKindObjectTypeDefinition:
+		return doc.ObjectTypeDefinitions[node.Ref].Name, true
+	case ast.NodeKindObjectTypeExtension:
+		return doc.ObjectTypeExtensions[node.Ref].Name, true
+	case ast.NodeKindInterfaceTypeDefinition:
+		return doc.InterfaceTypeDefinitions[node.Ref].Name, true
+	case ast.NodeKindInterfaceTypeExtension:
+		return doc.InterfaceTypeExtensions[node.Ref].Name, true
+	case ast.NodeKindUnionTypeDefinition:
+		return doc.UnionTypeDefinitions[node.Ref].Name, true
+	case ast.NodeKindUnionTypeExtension:
+		return doc.UnionTypeExtensions[node.Ref].Name, true
+	case ast.NodeKindEnumTypeDefinition:
+		return doc.EnumTypeDefinitions[node.Ref].Name, true
+	case ast.NodeKindEnumTypeExtension:
+		return doc.EnumTypeExtensions[node.Ref].Name, true
+	case ast.NodeKindInputObjectTypeDefinition:
+		return doc.InputObjectTypeDefinitions[node.Ref].Name, true
+	case ast.NodeKindInputObjectTypeExtension:
+		return doc.InputObjectTypeExtensions[node.Ref].Name, true
+	case ast.NodeKindScalarTypeDefinition:
+		return doc.ScalarTypeDefinitions[node.Ref].Name, true
+	case ast.NodeKindScalarTypeExtension:
+		return doc.ScalarTypeExtensions[node.Ref].Name, true
+	case ast.NodeKindDirectiveDefinition:
+		return doc.DirectiveDefinitions[node.Ref].Name, true
+	case ast.NodeKindFragmentDefinition:
+		return doc.FragmentDefinitions[node.Ref].Name, true
+	default:
+		return ast.ByteSliceReference{}, false
+	}
+}
+
+// nodeDescription returns the description of a type or directive definition
+func nodeDescription(doc *ast.Document, node ast.Node) string {
+	switch node.Kind {
+	case ast.NodeKindObjectTypeDefinition:
+		return doc.ObjectTypeDescriptionNameString(node.Ref)
+	case ast.NodeKindInterfaceTypeDefinition:
+		return doc.InterfaceTypeDefinitionDescriptionString(node.Ref)
+	case ast.NodeKindUnionTypeDefinition:
+		return doc.UnionTypeDefinitionDescriptionString(node.Ref)
+	case ast.NodeKindEnumTypeDefinition:
+		return doc.EnumTypeDefinitionDescriptionString(node.Ref)
+	case ast.NodeKindInputObjectTypeDefinition:
+		return doc.InputObjectTypeDefinitionDescriptionString(node.Ref)
+	case ast.NodeKindScalarTypeDefinition:
+		return doc.ScalarTypeDefinitionDescriptionString(node.Ref)
+	case ast.NodeKindDirectiveDefinition:
+		return doc.DirectiveDefinitionDescriptionString(node.Ref)
+	default:
+		return ""
+	}
+}
+
+// nodeKeyword returns the keyword of a type definition as used in the SDL, e.g. type or input
+func nodeKeyword(node ast.Node) string {
+	switch node.Kind {
+	case ast.NodeKindObjectTypeDefinition:
+		return "type"
+	case ast.NodeKindInterfaceTypeDefinition:
+		return "interface"
+	case ast.NodeKindUnionTypeDefinition:
+		return "union"
+	case ast.NodeKindEnumTypeDefinition:
+		return "enum"
+	case ast.NodeKindInputObjectTypeDefinition:
+		return "input"
+	case ast.NodeKindScalarTypeDefinition:
+		return "scalar"
+	case ast.NodeKindDirectiveDefinition:
+		return "directive"
+	default:
+		return ""
+	}
+}
+
+// typeString prints a type reference, e.g. [User!]!
+func typeString(schema *ast.Document, ref int) string {
+	printed, err := schema.PrintTypeBytes(ref, nil)
+	if err != nil {
+		return ""
+	}
+	return string(printed)
+}
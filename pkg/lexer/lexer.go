@@ -2,23 +2,131 @@
 package lexer
 
 import (
+	"io"
+	"os"
+
 	"github.com/wundergraph/graphql-go-tools/pkg/ast"
 	"github.com/wundergraph/graphql-go-tools/pkg/lexer/keyword"
 	"github.com/wundergraph/graphql-go-tools/pkg/lexer/runes"
 	"github.com/wundergraph/graphql-go-tools/pkg/lexer/token"
 )
 
+// readChunkSize is the maximum amount of bytes read from an input reader at once
+const readChunkSize = 32 * 1024
+
 // Lexer emits tokens from a input reader
 type Lexer struct {
 	input *ast.Input
+	// reader streams the input, it's nil when the whole input is in memory or the reader is drained
+	reader  io.Reader
+	readErr error
+	// reachedEnd is set when the lexer looked at the end of the input, so that streamed tokens can be completed
+	reachedEnd bool
 }
 
 func (l *Lexer) SetInput(input *ast.Input) {
 	l.input = input
+	l.reader = nil
+	l.readErr = nil
+}
+
+// SetInputReader resets the input and streams its content from reader.
+// The lexer reads the content incrementally in chunks whenever it runs out of bytes and appends it to input.RawBytes,
+// which saves reading the whole document into a separate buffer upfront.
+// This is not a sliding buffer, memory is not bounded: tokens and AST nodes reference the bytes by position,
+// so the whole input stays in input.RawBytes and the consumed part can't be released.
+// Unless the size of the reader is known upfront the buffer grows by doubling,
+// so the old and the new buffer are held at the same time while growing.
+// Read errors stop the lexer as if it reached the end of the input, use Err to check for them.
+func (l *Lexer) SetInputReader(input *ast.Input, reader io.Reader) {
+	input.Reset()
+	input.Length = 0
+	if size := readerSize(reader); size > 0 && cap(input.RawBytes) < size+1 {
+		// one extra byte so that reading io.EOF doesn't grow the buffer
+		input.RawBytes = make([]byte, 0, size+1)
+	}
+	l.input = input
+	l.reader = reader
+	l.readErr = nil
+}
+
+// Err returns the first error other than io.EOF returned by the input reader
+func (l *Lexer) Err() error {
+	return l.readErr
+}
+
+// readerSize returns the size of the content of reader if it's known upfront, e.g. for files, or 0 otherwise
+func readerSize(reader io.Reader) int {
+	switch r := reader.(type) {
+	case interface{ Len() int }:
+		return r.Len()
+	case *os.File:
+		info, err := r.Stat()
+		if err != nil || !info.Mode().IsRegular() {
+			return 0
+		}
+		return int(info.Size())
+	default:
+		return 0
+	}
 }
 
 // Read emits the next token
 func (l *Lexer) Read() (tok token.Token) {
+	if l.reader != nil {
+		return l.readStreamed()
+	}
+	return l.read()
+}
+
+// readStreamed emits the next token of a streamed input
+// If the lexer reached the end of the bytes read so far the token might continue in the unread input,
+// in this case the next chunk gets read and the token is lexed again.
+func (l *Lexer) readStreamed() (tok token.Token) {
+	for {
+		inputPosition, textPosition := l.input.InputPosition, l.input.TextPosition
+		l.reachedEnd = false
+		tok = l.read()
+		if !l.reachedEnd || l.reader == nil {
+			return tok
+		}
+		l.input.InputPosition, l.input.TextPosition = inputPosition, textPosition
+		// read at least as much as the token read so far to lex long tokens in linear time
+		amount := l.input.Length - inputPosition
+		if amount < readChunkSize {
+			amount = readChunkSize
+		}
+		l.fill(amount)
+	}
+}
+
+// fill reads amount bytes from the input reader and appends them to the input unless the reader is drained before
+// The input is never truncated at the front as token positions are offsets into input.RawBytes.
+func (l *Lexer) fill(amount int) {
+	end := l.input.Length + amount
+	for l.reader != nil && l.input.Length < end {
+		if len(l.input.RawBytes) == cap(l.input.RawBytes) {
+			grown := make([]byte, len(l.input.RawBytes), 2*cap(l.input.RawBytes)+readChunkSize)
+			copy(grown, l.input.RawBytes)
+			l.input.RawBytes = grown
+		}
+		chunkEnd := len(l.input.RawBytes) + readChunkSize
+		if chunkEnd > cap(l.input.RawBytes) {
+			chunkEnd = cap(l.input.RawBytes)
+		}
+		n, err := l.reader.Read(l.input.RawBytes[len(l.input.RawBytes):chunkEnd])
+		l.input.RawBytes = l.input.RawBytes[:len(l.input.RawBytes)+n]
+		l.input.Length = len(l.input.RawBytes)
+		if err != nil {
+			if err != io.EOF {
+				l.readErr = err
+			}
+			l.reader = nil
+		}
+	}
+}
+
+func (l *Lexer) read() (tok token.Token) {
 
 	var next byte
 
@@ -100,17 +208,14 @@ func (l *Lexer) matchSingleRuneToken(r byte, tok *token.Token) bool {
 }
 
 func (l *Lexer) readIdent() {
-	for {
-		if l.input.InputPosition < l.input.Length {
-			if !l.runeIsIdent(l.input.RawBytes[l.input.InputPosition]) {
-				return
-			}
-			l.input.TextPosition.CharStart++
-			l.input.InputPosition++
-		} else {
+	for l.input.InputPosition < l.input.Length {
+		if !l.runeIsIdent(l.input.RawBytes[l.input.InputPosition]) {
 			return
 		}
+		l.input.TextPosition.CharStart++
+		l.input.InputPosition++
 	}
+	l.reachedEnd = true
 }
 
 func (l *Lexer) readDotOrSpread(tok *token.Token) {
@@ -173,6 +278,7 @@ func (l *Lexer) peekEquals(ignoreWhitespace bool, equals ...byte) bool {
 	end := l.input.InputPosition + len(equals) + whitespaceOffset
 
 	if end > l.input.Length {
+		l.reachedEnd = true
 		return false
 	}
 
@@ -194,6 +300,10 @@ func (l *Lexer) peekWhitespaceLength() (amount int) {
 		}
 	}
 
+	if l.input.InputPosition+amount == l.input.Length {
+		l.reachedEnd = true
+	}
+
 	return amount
 }
 
@@ -277,6 +387,7 @@ func (l *Lexer) readRune() (r byte) {
 		l.input.InputPosition++
 	} else {
 		r = runes.EOF
+		l.reachedEnd = true
 	}
 
 	return
@@ -293,6 +404,7 @@ func (l *Lexer) peekRune(ignoreWhitespace bool) (r byte) {
 		}
 	}
 
+	l.reachedEnd = true
 	return runes.EOF
 }
 
@@ -351,6 +463,10 @@ func (l *Lexer) readBlockString(tok *token.Token) {
 			quoteCount = 0
 			whitespaceCount++
 		case runes.EOF:
+			// an unterminated block string ends at the end of the input, so that its literal stays within the input
+			tok.SetEnd(l.input.InputPosition, l.input.TextPosition)
+			tok.Literal.Start += uint32(leadingWhitespaceToken)
+			tok.Literal.End -= uint32(whitespaceCount)
 			return
 		case runes.QUOTE:
 			if escaped {
@@ -1,10 +1,15 @@
 package lexer
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/jensneuse/diffview"
 	"github.com/sebdah/goldie"
@@ -655,6 +660,101 @@ func TestLexerRegressions(t *testing.T) {
 	}
 }
 
+func TestLexer_SetInputReader(t *testing.T) {
+	readAll := func(lexer *Lexer, input *ast.Input) (tokens []token.Token, literals []string) {
+		for {
+			tok := lexer.Read()
+			if tok.Keyword == keyword.EOF {
+				return
+			}
+			tokens = append(tokens, tok)
+			literals = append(literals, string(input.ByteSlice(tok.Literal)))
+		}
+	}
+
+	longName := strings.Repeat("a", 2*readChunkSize)
+	document := introspectionQuery + `
+		"""
+		block string spanning
+		multiple chunks ` + strings.Repeat("x", 3*readChunkSize) + `
+		"""
+		type Foo { bar(a: 1.5e3, b: "baz") : [String!]! } # comment
+		# ` + strings.Repeat("#", readChunkSize) + `
+		` + longName + `: ` + strings.Repeat("9", readChunkSize) + strings.Repeat(" ", readChunkSize) + `
+		...`
+
+	in := &ast.Input{}
+	in.ResetInputString(document)
+	lexer := &Lexer{}
+	lexer.SetInput(in)
+	wantTokens, wantLiterals := readAll(lexer, in)
+
+	run := func(name string, reader func() io.Reader) {
+		t.Run(name, func(t *testing.T) {
+			in := &ast.Input{}
+			lexer := &Lexer{}
+			lexer.SetInputReader(in, reader())
+			gotTokens, gotLiterals := readAll(lexer, in)
+
+			if lexer.Err() != nil {
+				t.Fatalf("want no error, got: %s", lexer.Err())
+			}
+			if string(in.RawBytes) != document {
+				t.Fatalf("want the input to contain the whole document, got: %s", string(in.RawBytes))
+			}
+			if len(wantTokens) != len(gotTokens) {
+				t.Fatalf("want %d tokens, got: %d", len(wantTokens), len(gotTokens))
+			}
+			for i := range wantTokens {
+				if wantTokens[i] != gotTokens[i] || wantLiterals[i] != gotLiterals[i] {
+					t.Fatalf("want token: %s (%s), got: %s (%s)", wantTokens[i], wantLiterals[i], gotTokens[i], gotLiterals[i])
+				}
+			}
+		})
+	}
+
+	run("reader with known size", func() io.Reader {
+		return strings.NewReader(document)
+	})
+	run("reader with unknown size", func() io.Reader {
+		return iotest.HalfReader(bytes.NewBufferString(document))
+	})
+	run("one byte per read", func() io.Reader {
+		return iotest.OneByteReader(strings.NewReader(document))
+	})
+	run("reader returning data with io.EOF", func() io.Reader {
+		return iotest.DataErrReader(strings.NewReader(document))
+	})
+
+	t.Run("reader error", func(t *testing.T) {
+		readErr := errors.New("connection reset")
+		in := &ast.Input{}
+		lexer := &Lexer{}
+		lexer.SetInputReader(in, io.MultiReader(strings.NewReader("query { a"), iotest.ErrReader(readErr)))
+		_, literals := readAll(lexer, in)
+
+		if !errors.Is(lexer.Err(), readErr) {
+			t.Fatalf("want error: %s, got: %v", readErr, lexer.Err())
+		}
+		if strings.Join(literals, " ") != "query { a" {
+			t.Fatalf("want the tokens read before the error, got: %v", literals)
+		}
+	})
+
+	t.Run("set input resets reader", func(t *testing.T) {
+		in := &ast.Input{}
+		lexer := &Lexer{}
+		lexer.SetInputReader(in, strings.NewReader("query"))
+		in.ResetInputString("{}")
+		lexer.SetInput(in)
+		_, literals := readAll(lexer, in)
+
+		if strings.Join(literals, "") != "{}" {
+			t.Fatalf("want {}, got: %v", literals)
+		}
+	})
+}
+
 func BenchmarkLexer(b *testing.B) {
 
 	in := &ast.Input{}
@@ -678,3 +778,28 @@ func BenchmarkLexer(b *testing.B) {
 		}
 	}
 }
+
+func BenchmarkLexerReader(b *testing.B) {
+
+	in := &ast.Input{}
+	lexer := &Lexer{}
+
+	inputBytes := []byte(introspectionQuery)
+	reader := bytes.NewReader(inputBytes)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.SetBytes(int64(len(inputBytes)))
+
+	for i := 0; i < b.N; i++ {
+
+		reader.Reset(inputBytes)
+		lexer.SetInputReader(in, reader)
+
+		var key keyword.Keyword
+
+		for key != keyword.EOF {
+			key = lexer.Read().Keyword
+		}
+	}
+}
@@ -99,6 +99,11 @@ var (
 	OP                            = []byte("op")
 	REPLACE                       = []byte("replace")
 	INITIAL_BATCH_SIZE            = []byte("initialBatchSize")
+	INITIAL_COUNT                 = []byte("initialCount")
+	LABEL                         = []byte("label")
+	HAS_NEXT                      = []byte("hasNext")
+	INCREMENTAL                   = []byte("incremental")
+	ITEMS                         = []byte("items")
 	MILLISECONDS                  = []byte("milliSeconds")
 	PATH                          = []byte("path")
 	VALUE                         = []byte("value")
@@ -5,6 +5,7 @@ import (
 
 	"github.com/wundergraph/graphql-go-tools/pkg/ast"
 	"github.com/wundergraph/graphql-go-tools/pkg/graphqlerrors"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/position"
 )
 
 type ExternalError struct {
@@ -13,63 +14,75 @@ type ExternalError struct {
 	Locations []graphqlerrors.Location `json:"locations"`
 }
 
-func ErrDocumentDoesntContainExecutableOperation() (err ExternalError) {
+func ErrDocumentDoesntContainExecutableOperation(position position.Position) (err ExternalError) {
 	err.Message = "document doesn't contain any executable operation"
-	return
+	err.Locations = LocationsFromPosition(position)
+	return err
 }
 
-func ErrFieldUndefinedOnType(fieldName, typeName ast.ByteSlice) (err ExternalError) {
+func ErrFieldUndefinedOnType(fieldName, typeName ast.ByteSlice, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("field: %s not defined on type: %s", fieldName, typeName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrFieldNameMustBeUniqueOnType(fieldName, typeName ast.ByteSlice) (err ExternalError) {
+func ErrFieldNameMustBeUniqueOnType(fieldName, typeName ast.ByteSlice, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("field '%s.%s' can only be defined once", typeName, fieldName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrTypeUndefined(typeName ast.ByteSlice) (err ExternalError) {
+func ErrTypeUndefined(typeName ast.ByteSlice, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("type not defined: %s", typeName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrScalarTypeUndefined(scalarName ast.ByteSlice) (err ExternalError) {
+func ErrScalarTypeUndefined(scalarName ast.ByteSlice, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("scalar not defined: %s", scalarName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrInterfaceTypeUndefined(interfaceName ast.ByteSlice) (err ExternalError) {
+func ErrInterfaceTypeUndefined(interfaceName ast.ByteSlice, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("interface type not defined: %s", interfaceName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrUnionTypeUndefined(unionName ast.ByteSlice) (err ExternalError) {
+func ErrUnionTypeUndefined(unionName ast.ByteSlice, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("union type not defined: %s", unionName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrEnumTypeUndefined(enumName ast.ByteSlice) (err ExternalError) {
+func ErrEnumTypeUndefined(enumName ast.ByteSlice, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("enum type not defined: %s", enumName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrInputObjectTypeUndefined(inputObjectName ast.ByteSlice) (err ExternalError) {
+func ErrInputObjectTypeUndefined(inputObjectName ast.ByteSlice, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("input object type not defined: %s", inputObjectName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrTypeNameMustBeUnique(typeName ast.ByteSlice) (err ExternalError) {
+func ErrTypeNameMustBeUnique(typeName ast.ByteSlice, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("there can be only one type named '%s'", typeName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrOperationNameMustBeUnique(operationName ast.ByteSlice) (err ExternalError) {
+func ErrOperationNameMustBeUnique(operationName ast.ByteSlice, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("operation name must be unique: %s", operationName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrAnonymousOperationMustBeTheOnlyOperationInDocument() (err ExternalError) {
+func ErrAnonymousOperationMustBeTheOnlyOperationInDocument(position position.Position) (err ExternalError) {
 	err.Message = "anonymous operation name the only operation in a graphql document"
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
@@ -83,223 +96,388 @@ func ErrOperationWithProvidedOperationNameNotFound(operationName string) (err Ex
 	return err
 }
 
-func ErrSubscriptionMustOnlyHaveOneRootSelection(subscriptionName ast.ByteSlice) (err ExternalError) {
+func ErrSubscriptionMustOnlyHaveOneRootSelection(subscriptionName ast.ByteSlice, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("subscription: %s must only have one root selection", subscriptionName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrFieldSelectionOnUnion(fieldName, unionName ast.ByteSlice) (err ExternalError) {
-
+func ErrFieldSelectionOnUnion(fieldName, unionName ast.ByteSlice, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("cannot select field: %s on union: %s", fieldName, unionName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrFieldsConflict(objectName, leftType, rightType ast.ByteSlice) (err ExternalError) {
+func ErrFieldsConflict(objectName, leftType, rightType ast.ByteSlice, left, right position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("fields '%s' conflict because they return conflicting types '%s' and '%s'", objectName, leftType, rightType)
+	err.Locations = LocationsFromPositions(left, right)
 	return err
 }
 
-func ErrTypesForFieldMismatch(objectName, leftType, rightType ast.ByteSlice) (err ExternalError) {
+func ErrTypesForFieldMismatch(objectName, leftType, rightType ast.ByteSlice, left, right position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("differing types '%s' and '%s' for objectName '%s'", leftType, rightType, objectName)
+	err.Locations = LocationsFromPositions(left, right)
 	return err
 }
 
-func ErrResponseOfDifferingTypesMustBeOfSameShape(leftObjectName, rightObjectName ast.ByteSlice) (err ExternalError) {
+func ErrResponseOfDifferingTypesMustBeOfSameShape(leftObjectName, rightObjectName ast.ByteSlice, left, right position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("objects '%s' and '%s' on differing response types must be of same response shape", leftObjectName, rightObjectName)
+	err.Locations = LocationsFromPositions(left, right)
 	return err
 }
 
-func ErrDifferingFieldsOnPotentiallySameType(objectName ast.ByteSlice) (err ExternalError) {
+func ErrDifferingFieldsOnPotentiallySameType(objectName ast.ByteSlice, left, right position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("differing fields for objectName '%s' on (potentially) same type", objectName)
+	err.Locations = LocationsFromPositions(left, right)
 	return err
 }
 
-func ErrFieldSelectionOnScalar(fieldName, scalarTypeName ast.ByteSlice) (err ExternalError) {
+func ErrFieldSelectionOnScalar(fieldName, scalarTypeName ast.ByteSlice, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("cannot select field: %s on scalar %s", fieldName, scalarTypeName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrMissingFieldSelectionOnNonScalar(fieldName, enclosingTypeName ast.ByteSlice) (err ExternalError) {
+func ErrMissingFieldSelectionOnNonScalar(fieldName, enclosingTypeName ast.ByteSlice, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("non scalar field: %s on type: %s must have selections", fieldName, enclosingTypeName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrArgumentNotDefinedOnNode(argName, node ast.ByteSlice) (err ExternalError) {
+func ErrArgumentNotDefinedOnNode(argName, node ast.ByteSlice, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("argument: %s not defined on node: %s", argName, node)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrValueDoesntSatisfyInputValueDefinition(value, inputType ast.ByteSlice) (err ExternalError) {
+func ErrValueDoesntSatisfyInputValueDefinition(value, inputType ast.ByteSlice, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("value: %s doesn't satisfy inputType: %s", value, inputType)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrVariableNotDefinedOnOperation(variableName, operationName ast.ByteSlice) (err ExternalError) {
+func ErrVariableNotDefinedOnOperation(variableName, operationName ast.ByteSlice, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("variable: %s not defined on operation: %s", variableName, operationName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrVariableDefinedButNeverUsed(variableName, operationName ast.ByteSlice) (err ExternalError) {
+func ErrVariableDefinedButNeverUsed(variableName, operationName ast.ByteSlice, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("variable: %s defined on operation: %s but never used", variableName, operationName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrVariableMustBeUnique(variableName, operationName ast.ByteSlice) (err ExternalError) {
+func ErrVariableMustBeUnique(variableName, operationName ast.ByteSlice, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("variable: %s must be unique per operation: %s", variableName, operationName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrVariableNotDefinedOnArgument(variableName, argumentName ast.ByteSlice) (err ExternalError) {
+func ErrVariableNotDefinedOnArgument(variableName, argumentName ast.ByteSlice, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("variable: %s not defined on argument: %s", variableName, argumentName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrVariableOfTypeIsNoValidInputValue(variableName, ofTypeName ast.ByteSlice) (err ExternalError) {
+func ErrVariableOfTypeIsNoValidInputValue(variableName, ofTypeName ast.ByteSlice, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("variable: %s of type: %s is no valid input value type", variableName, ofTypeName)
+	err.Locations = LocationsFromPosition(position)
+	return err
+}
+
+func ErrVariableOfRequiredTypeNotProvided(variableName, typeName ast.ByteSlice, position position.Position) (err ExternalError) {
+	err.Message = fmt.Sprintf(`variable "$%s" of required type "%s" was not provided`, variableName, typeName)
+	err.Locations = LocationsFromPosition(position)
+	return err
+}
+
+func ErrVariableOfNonNullTypeMustNotBeNull(variableName, typeName ast.ByteSlice, position position.Position) (err ExternalError) {
+	err.Message = fmt.Sprintf(`variable "$%s" of non-null type "%s" must not be null`, variableName, typeName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrArgumentMustBeUnique(argName ast.ByteSlice) (err ExternalError) {
+func ErrVariableGotInvalidValue(variableName, path ast.ByteSlice, reason string, position position.Position) (err ExternalError) {
+	err.Message = fmt.Sprintf(`variable "$%s" got invalid value at "%s"; %s`, variableName, path, reason)
+	err.Locations = LocationsFromPosition(position)
+	return err
+}
+
+func ErrArgumentMustBeUnique(argName ast.ByteSlice, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("argument: %s must be unique", argName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrArgumentRequiredOnField(argName, fieldName ast.ByteSlice) (err ExternalError) {
+func ErrArgumentRequiredOnField(argName, fieldName ast.ByteSlice, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("argument: %s is required on field: %s but missing", argName, fieldName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrArgumentOnFieldMustNotBeNull(argName, fieldName ast.ByteSlice) (err ExternalError) {
+func ErrArgumentOnFieldMustNotBeNull(argName, fieldName ast.ByteSlice, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("argument: %s on field: %s must not be null", argName, fieldName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrFragmentSpreadFormsCycle(spreadName ast.ByteSlice) (err ExternalError) {
+func ErrFragmentSpreadFormsCycle(spreadName ast.ByteSlice, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("fragment spread: %s forms fragment cycle", spreadName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrFragmentDefinedButNotUsed(fragmentName ast.ByteSlice) (err ExternalError) {
+func ErrFragmentDefinedButNotUsed(fragmentName ast.ByteSlice, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("fragment: %s defined but not used", fragmentName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrFragmentUndefined(fragmentName ast.ByteSlice) (err ExternalError) {
+func ErrFragmentUndefined(fragmentName ast.ByteSlice, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("fragment: %s undefined", fragmentName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrInlineFragmentOnTypeDisallowed(onTypeName ast.ByteSlice) (err ExternalError) {
+func ErrInlineFragmentOnTypeDisallowed(onTypeName ast.ByteSlice, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("inline fragment on type: %s disallowed", onTypeName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrInlineFragmentOnTypeMismatchEnclosingType(fragmentTypeName, enclosingTypeName ast.ByteSlice) (err ExternalError) {
+func ErrInlineFragmentOnTypeMismatchEnclosingType(fragmentTypeName, enclosingTypeName ast.ByteSlice, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("inline fragment on type: %s mismatches enclosing type: %s", fragmentTypeName, enclosingTypeName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrFragmentDefinitionOnTypeDisallowed(fragmentName, onTypeName ast.ByteSlice) (err ExternalError) {
+func ErrFragmentDefinitionOnTypeDisallowed(fragmentName, onTypeName ast.ByteSlice, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("fragment: %s on type: %s disallowed", fragmentName, onTypeName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrFragmentDefinitionMustBeUnique(fragmentName ast.ByteSlice) (err ExternalError) {
+func ErrFragmentDefinitionMustBeUnique(fragmentName ast.ByteSlice, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("fragment: %s must be unique per document", fragmentName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrDirectiveUndefined(directiveName ast.ByteSlice) (err ExternalError) {
+func ErrDirectiveUndefined(directiveName ast.ByteSlice, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("directive: %s undefined", directiveName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrDirectiveNotAllowedOnNode(directiveName, nodeKindName ast.ByteSlice) (err ExternalError) {
+func ErrDirectiveNotAllowedOnNode(directiveName, nodeKindName ast.ByteSlice, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("directive: %s not allowed on node of kind: %s", directiveName, nodeKindName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrDirectiveMustBeUniquePerLocation(directiveName ast.ByteSlice) (err ExternalError) {
+func ErrDirectiveMustBeUniquePerLocation(directiveName ast.ByteSlice, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("directive: %s must be unique per location", directiveName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrOnlyOneQueryTypeAllowed() (err ExternalError) {
+func ErrOnlyOneQueryTypeAllowed(position position.Position) (err ExternalError) {
 	err.Message = "there can be only one query type in schema"
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrOnlyOneMutationTypeAllowed() (err ExternalError) {
+func ErrOnlyOneMutationTypeAllowed(position position.Position) (err ExternalError) {
 	err.Message = "there can be only one mutation type in schema"
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrOnlyOneSubscriptionTypeAllowed() (err ExternalError) {
+func ErrOnlyOneSubscriptionTypeAllowed(position position.Position) (err ExternalError) {
 	err.Message = "there can be only one subscription type in schema"
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrEnumValueNameMustBeUnique(enumName, enumValueName ast.ByteSlice) (err ExternalError) {
+func ErrEnumValueNameMustBeUnique(enumName, enumValueName ast.ByteSlice, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("enum value '%s.%s' can only be defined once", enumName, enumValueName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrUnionMembersMustBeUnique(unionName, memberName ast.ByteSlice) (err ExternalError) {
+func ErrUnionMembersMustBeUnique(unionName, memberName ast.ByteSlice, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("union member '%s.%s' can only be defined once", unionName, memberName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrTransitiveInterfaceNotImplemented(typeName, transitiveInterfaceName ast.ByteSlice) (err ExternalError) {
+func ErrTransitiveInterfaceNotImplemented(typeName, transitiveInterfaceName ast.ByteSlice, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("type %s does not implement transitive interface %s", typeName, transitiveInterfaceName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrTransitiveInterfaceExtensionImplementingWithoutBody(interfaceExtensionName ast.ByteSlice) (err ExternalError) {
+func ErrTransitiveInterfaceExtensionImplementingWithoutBody(interfaceExtensionName ast.ByteSlice, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("interface extension %s implementing interface without body", interfaceExtensionName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrTypeDoesNotImplementFieldFromInterface(typeName, interfaceName, fieldName ast.ByteSlice) (err ExternalError) {
+func ErrTypeDoesNotImplementFieldFromInterface(typeName, interfaceName, fieldName ast.ByteSlice, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("type '%s' does not implement field '%s' from interface '%s'", typeName, fieldName, interfaceName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrImplementingTypeDoesNotHaveFields(typeName ast.ByteSlice) (err ExternalError) {
+func ErrImplementingTypeDoesNotHaveFields(typeName ast.ByteSlice, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("type '%s' implements an interface but does not have any fields defined", typeName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrSharedTypesMustBeIdenticalToFederate(typeName string) (err ExternalError) {
+func ErrSharedTypesMustBeIdenticalToFederate(typeName string, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("the shared type named '%s' must be identical in any subgraphs to federate", typeName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrEntitiesMustNotBeDuplicated(typeName string) (err ExternalError) {
+func ErrEntitiesMustNotBeDuplicated(typeName string, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("the entity named '%s' is defined in the subgraph(s) more than once", typeName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrSharedTypesMustNotBeExtended(typeName string) (err ExternalError) {
+func ErrSharedTypesMustNotBeExtended(typeName string, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("the type named '%s' cannot be extended because it is a shared type", typeName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrExtensionOrphansMustResolveInSupergraph(extensionNameBytes []byte) (err ExternalError) {
+func ErrExtensionOrphansMustResolveInSupergraph(extensionNameBytes []byte, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("the extension orphan named '%s' was never resolved in the supergraph", extensionNameBytes)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrTypeBodyMustNotBeEmpty(definitionType, typeName string) (err ExternalError) {
+func ErrTypeBodyMustNotBeEmpty(definitionType, typeName string, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("the %s named '%s' is invalid due to an empty body", definitionType, typeName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrEntityExtensionMustHaveKeyDirective(typeName string) (err ExternalError) {
+func ErrEntityExtensionMustHaveKeyDirective(typeName string, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("an extension of the entity named '%s' does not have a key directive", typeName)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
 
-func ErrExtensionWithKeyDirectiveMustExtendEntity(typeName string) (err ExternalError) {
+func ErrExtensionWithKeyDirectiveMustExtendEntity(typeName string, position position.Position) (err ExternalError) {
 	err.Message = fmt.Sprintf("the extension named '%s' has a key directive but there is no entity of the same name", typeName)
+	err.Locations = LocationsFromPosition(position)
+	return err
+}
+
+// LocationsFromPosition returns the locations of an error which starts at the given position
+// Positions of nodes which were not parsed from the input, e.g. added during normalization, are unknown and have no location.
+func LocationsFromPosition(position position.Position) []graphqlerrors.Location {
+	return LocationsFromPositions(position)
+}
+
+// LocationsFromPositions returns the locations of an error which refers to multiple nodes, e.g. two conflicting fields
+func LocationsFromPositions(positions ...position.Position) (locations []graphqlerrors.Location) {
+	for _, position := range positions {
+		if position.LineStart == 0 {
+			continue
+		}
+		locations = append(locations, graphqlerrors.Location{
+			Line:   position.LineStart,
+			Column: position.CharStart,
+		})
+	}
+	return locations
+}
+
+func ErrInputValueMustBeInputType(coordinate string, typeName ast.ByteSlice, position position.Position) (err ExternalError) {
+	err.Message = fmt.Sprintf("the type of %s must be an input type but got: %s", coordinate, typeName)
+	err.Locations = LocationsFromPosition(position)
+	return err
+}
+
+func ErrFieldMustBeOutputType(coordinate string, typeName ast.ByteSlice, position position.Position) (err ExternalError) {
+	err.Message = fmt.Sprintf("the type of %s must be an output type but got: %s", coordinate, typeName)
+	err.Locations = LocationsFromPosition(position)
+	return err
+}
+
+func ErrUnionMemberMustBeObjectType(unionName, memberName ast.ByteSlice, position position.Position) (err ExternalError) {
+	err.Message = fmt.Sprintf("union '%s' can only include object types, it cannot include '%s'", unionName, memberName)
+	err.Locations = LocationsFromPosition(position)
+	return err
+}
+
+func ErrInputObjectMustNotReferenceItself(inputObjectName ast.ByteSlice, fieldPath string, position position.Position) (err ExternalError) {
+	err.Message = fmt.Sprintf("input object '%s' cannot reference itself through a series of non-null fields: '%s'", inputObjectName, fieldPath)
+	err.Locations = LocationsFromPosition(position)
+	return err
+}
+
+func ErrNameIsReserved(name ast.ByteSlice, position position.Position) (err ExternalError) {
+	err.Message = fmt.Sprintf("name '%s' must not begin with '__', which is reserved by GraphQL introspection", name)
+	err.Locations = LocationsFromPosition(position)
+	return err
+}
+
+func ErrDefaultValueDoesntSatisfyType(coordinate string, value, inputType ast.ByteSlice, position position.Position) (err ExternalError) {
+	err.Message = fmt.Sprintf("default value %s of %s doesn't satisfy type: %s", value, coordinate, inputType)
+	err.Locations = LocationsFromPosition(position)
+	return err
+}
+
+func ErrDirectiveNotAllowedOnLocation(directiveName ast.ByteSlice, location ast.ByteSlice, position position.Position) (err ExternalError) {
+	err.Message = fmt.Sprintf("directive '@%s' is not allowed on location: %s", directiveName, location)
+	err.Locations = LocationsFromPosition(position)
+	return err
+}
+
+func ErrDirectiveArgumentNotDefined(directiveName, argumentName ast.ByteSlice, position position.Position) (err ExternalError) {
+	err.Message = fmt.Sprintf("argument '%s' is not defined on directive '@%s'", argumentName, directiveName)
+	err.Locations = LocationsFromPosition(position)
+	return err
+}
+
+func ErrDirectiveArgumentRequired(directiveName, argumentName, argumentType ast.ByteSlice, position position.Position) (err ExternalError) {
+	err.Message = fmt.Sprintf("argument '%s' of type '%s' is required on directive '@%s' but missing", argumentName, argumentType, directiveName)
+	err.Locations = LocationsFromPosition(position)
+	return err
+}
+
+func ErrDirectiveArgumentValueDoesntSatisfyType(directiveName, argumentName, value, argumentType ast.ByteSlice, position position.Position) (err ExternalError) {
+	err.Message = fmt.Sprintf("value %s of argument '%s' on directive '@%s' doesn't satisfy type: %s", value, argumentName, directiveName, argumentType)
+	err.Locations = LocationsFromPosition(position)
+	return err
+}
+
+func ErrDirectiveNameMustBeUnique(directiveName ast.ByteSlice, position position.Position) (err ExternalError) {
+	err.Message = fmt.Sprintf("there can be only one directive named '@%s'", directiveName)
+	err.Locations = LocationsFromPosition(position)
+	return err
+}
+
+func ErrArgumentDefinitionMustBeUnique(coordinate string, position position.Position) (err ExternalError) {
+	err.Message = fmt.Sprintf("argument '%s' can only be defined once", coordinate)
+	err.Locations = LocationsFromPosition(position)
+	return err
+}
+
+func ErrRootOperationTypeMustBeObjectType(operationType, typeName ast.ByteSlice, position position.Position) (err ExternalError) {
+	err.Message = fmt.Sprintf("the %s root type must be an object type but got: %s", operationType, typeName)
+	err.Locations = LocationsFromPosition(position)
+	return err
+}
+
+func ErrDirectiveMustNotReferenceItself(directiveName ast.ByteSlice, referencePath string, position position.Position) (err ExternalError) {
+	err.Message = fmt.Sprintf("directive '@%s' cannot reference itself: '%s'", directiveName, referencePath)
+	err.Locations = LocationsFromPosition(position)
 	return err
 }
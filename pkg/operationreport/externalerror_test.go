@@ -3,9 +3,12 @@ package operationreport
 import (
 	"bytes"
 	"encoding/json"
+	"reflect"
 	"testing"
 
 	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/graphqlerrors"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/position"
 )
 
 func TestPath_MarshalJSON(t *testing.T) {
@@ -69,3 +72,19 @@ func TestPath_MarshalJSON(t *testing.T) {
 		t.Fatalf("want err, got nil")
 	}
 }
+
+func TestLocationsFromPositions(t *testing.T) {
+	left := position.Position{LineStart: 3, CharStart: 5, LineEnd: 3, CharEnd: 9}
+	right := position.Position{LineStart: 6, CharStart: 3, LineEnd: 6, CharEnd: 7}
+
+	err := ErrDifferingFieldsOnPotentiallySameType([]byte("a"), left, right)
+	want := []graphqlerrors.Location{{Line: 3, Column: 5}, {Line: 6, Column: 3}}
+	if !reflect.DeepEqual(err.Locations, want) {
+		t.Fatalf("want %+v, got: %+v", want, err.Locations)
+	}
+
+	err = ErrFieldUndefinedOnType([]byte("nam"), []byte("Country"), position.Position{})
+	if err.Locations != nil {
+		t.Fatalf("want no locations for an unknown position, got: %+v", err.Locations)
+	}
+}
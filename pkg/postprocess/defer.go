@@ -58,6 +58,16 @@ func (p *ProcessDefer) traverseNode(node resolve.Node) {
 	case *resolve.Object:
 		p.objects = append(p.objects, n)
 		for i := range n.Fields {
+			if n.Fields[i].DeferredFragment != nil {
+				// the value is resolved by the patch of the fragment
+				continue
+			}
+			if n.Fields[i].Defer != nil && (n.Fields[i].Defer.Fragment || n.Fields[i].Defer.IfVariableName != "") {
+				p.updated = true
+				patchIndex := p.createFragmentPatch(n, i)
+				p.traverseNode(p.out.Response.Patches[patchIndex].Value)
+				continue
+			}
 			if n.Fields[i].Defer != nil {
 				p.updated = true
 				patchIndex, ok := p.createPatch(n, i)
@@ -84,6 +94,7 @@ func (p *ProcessDefer) traverseNode(node resolve.Node) {
 
 func (p *ProcessDefer) createPatch(object *resolve.Object, field int) (int, bool) {
 	oldValue := object.Fields[field].Value
+	label := object.Fields[field].Defer.Label
 	var patch *resolve.GraphQLResponsePatch
 	if object.Fields[field].HasBuffer && !p.bufferUsedOnNonDeferField(object, field, object.Fields[field].BufferID) {
 		patchFetch, ok := p.processFieldSetBuffer(object, field)
@@ -94,6 +105,7 @@ func (p *ProcessDefer) createPatch(object *resolve.Object, field int) (int, bool
 			Value:     oldValue,
 			Fetch:     &patchFetch,
 			Operation: literal.REPLACE,
+			Label:     label,
 		}
 		object.Fields[field].HasBuffer = false
 		object.Fields[field].BufferID = 0
@@ -101,6 +113,7 @@ func (p *ProcessDefer) createPatch(object *resolve.Object, field int) (int, bool
 		patch = &resolve.GraphQLResponsePatch{
 			Value:     oldValue,
 			Operation: literal.REPLACE,
+			Label:     label,
 		}
 	}
 	p.out.Response.Patches = append(p.out.Response.Patches, patch)
@@ -108,6 +121,72 @@ func (p *ProcessDefer) createPatch(object *resolve.Object, field int) (int, bool
 	return patchIndex, true
 }
 
+// createFragmentPatch creates a single patch for all fields of the deferred fragment of the field
+// The fields stay in the object, they are resolved as null in the initial response and their values are resolved by the patch.
+// Deferred fields with a variable "if" argument are handled as a fragment as well, so that they can be resolved in place.
+func (p *ProcessDefer) createFragmentPatch(object *resolve.Object, field int) int {
+	deferField := object.Fields[field].Defer
+	fragment := &resolve.DeferredFragment{
+		IfVariableName: deferField.IfVariableName,
+	}
+	value := &resolve.Object{
+		Nullable: true,
+	}
+	for i := field; i < len(object.Fields); i++ {
+		if object.Fields[i].Defer != deferField {
+			continue
+		}
+		patchField := *object.Fields[i]
+		patchField.Defer = nil
+		value.Fields = append(value.Fields, &patchField)
+		object.Fields[i].Defer = nil
+		object.Fields[i].DeferredFragment = fragment
+	}
+	patch := &resolve.GraphQLResponsePatch{
+		Value:            value,
+		Operation:        literal.REPLACE,
+		Label:            deferField.Label,
+		DeferredFragment: true,
+	}
+	// the fetch has to stay in the object if the fields might be resolved in place
+	if bufferField, ok := p.fragmentBufferField(object, fragment); ok && fragment.IfVariableName == "" {
+		bufferID := object.Fields[bufferField].BufferID
+		if patchFetch, ok := p.processFieldSetBuffer(object, bufferField); ok {
+			// the fields of the patch keep their buffer, so the fetch has to load into the same buffer
+			patchFetch.BufferId = bufferID
+			patch.Fetch = &patchFetch
+		}
+	}
+	p.out.Response.Patches = append(p.out.Response.Patches, patch)
+	fragment.PatchIndex = len(p.out.Response.Patches) - 1
+	return fragment.PatchIndex
+}
+
+// fragmentBufferField returns a field of the fragment with a buffer if the fetch of the buffer can be moved into the patch of the fragment
+// That's the case if all fields of the fragment with a buffer use the same buffer and no other field uses it.
+// Otherwise the fetches stay in the object and the patch is resolved with the buffers of the object.
+func (p *ProcessDefer) fragmentBufferField(object *resolve.Object, fragment *resolve.DeferredFragment) (field int, ok bool) {
+	field = -1
+	for i := range object.Fields {
+		if !object.Fields[i].HasBuffer || object.Fields[i].DeferredFragment != fragment {
+			continue
+		}
+		if field != -1 && object.Fields[field].BufferID != object.Fields[i].BufferID {
+			return -1, false
+		}
+		field = i
+	}
+	if field == -1 {
+		return -1, false
+	}
+	for i := range object.Fields {
+		if object.Fields[i].HasBuffer && object.Fields[i].BufferID == object.Fields[field].BufferID && object.Fields[i].DeferredFragment != fragment {
+			return -1, false
+		}
+	}
+	return field, true
+}
+
 func (p *ProcessDefer) bufferUsedOnNonDeferField(object *resolve.Object, field, bufferID int) bool {
 	for i := range object.Fields {
 		if object.Fields[i].BufferID != bufferID {
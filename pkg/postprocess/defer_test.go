@@ -661,6 +661,130 @@ func TestProcessDefer_Process_KeepFetchIfUsedUndeferred(t *testing.T) {
 	assert.Equal(t, expected, actual)
 }
 
+func TestProcessDefer_Process_DeferredFragment(t *testing.T) {
+
+	postsService := &fakeService{}
+
+	posts := &resolve.Array{
+		Path: []string{"posts"},
+		Item: &resolve.Object{
+			Fields: []*resolve.Field{
+				{
+					Name: []byte("title"),
+					Value: &resolve.String{
+						Path: []string{"title"},
+					},
+				},
+			},
+		},
+	}
+	fragment := &resolve.DeferField{
+		Label:    []byte("details"),
+		Fragment: true,
+	}
+
+	original := &plan.SynchronousResponsePlan{
+		Response: &resolve.GraphQLResponse{
+			Data: &resolve.Object{
+				Fetch: &resolve.SingleFetch{
+					BufferId:   1,
+					DataSource: postsService,
+				},
+				Fields: []*resolve.Field{
+					{
+						Name: []byte("id"),
+						Value: &resolve.Integer{
+							Path: []string{"id"},
+						},
+					},
+					{
+						Name:  []byte("name"),
+						Defer: fragment,
+						Value: &resolve.String{
+							Path: []string{"name"},
+						},
+					},
+					{
+						HasBuffer: true,
+						BufferID:  1,
+						Name:      []byte("posts"),
+						Defer:     fragment,
+						Value:     posts,
+					},
+				},
+			},
+		},
+	}
+
+	deferredFragment := &resolve.DeferredFragment{
+		PatchIndex: 0,
+	}
+
+	expected := &plan.StreamingResponsePlan{
+		Response: &resolve.GraphQLStreamingResponse{
+			InitialResponse: &resolve.GraphQLResponse{
+				Data: &resolve.Object{
+					Fields: []*resolve.Field{
+						{
+							Name: []byte("id"),
+							Value: &resolve.Integer{
+								Path: []string{"id"},
+							},
+						},
+						{
+							Name:             []byte("name"),
+							DeferredFragment: deferredFragment,
+							Value: &resolve.String{
+								Path: []string{"name"},
+							},
+						},
+						{
+							HasBuffer:        true,
+							BufferID:         1,
+							Name:             []byte("posts"),
+							DeferredFragment: deferredFragment,
+							Value:            posts,
+						},
+					},
+				},
+			},
+			Patches: []*resolve.GraphQLResponsePatch{
+				{
+					Operation:        literal.REPLACE,
+					Label:            []byte("details"),
+					DeferredFragment: true,
+					Fetch: &resolve.SingleFetch{
+						BufferId:   1,
+						DataSource: postsService,
+					},
+					Value: &resolve.Object{
+						Nullable: true,
+						Fields: []*resolve.Field{
+							{
+								Name: []byte("name"),
+								Value: &resolve.String{
+									Path: []string{"name"},
+								},
+							},
+							{
+								HasBuffer: true,
+								BufferID:  1,
+								Name:      []byte("posts"),
+								Value:     posts,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	proc := &ProcessDefer{}
+	actual := proc.Process(original)
+
+	assert.Equal(t, expected, actual)
+}
+
 func TestProcessDefer_Process_ParallelFetch(t *testing.T) {
 
 	userService := &fakeService{}
@@ -54,11 +54,17 @@ func (p *ProcessStream) traverseNode(node resolve.Node) {
 	switch n := node.(type) {
 	case *resolve.Object:
 		for i := range n.Fields {
+			if n.Fields[i].DeferredFragment != nil {
+				// the value is resolved by the patch of the fragment
+				continue
+			}
 			if n.Fields[i].Stream != nil {
 				switch array := n.Fields[i].Value.(type) {
 				case *resolve.Array:
 					array.Stream.Enabled = true
 					array.Stream.InitialBatchSize = n.Fields[i].Stream.InitialBatchSize
+					array.Stream.Label = n.Fields[i].Stream.Label
+					array.Stream.IfVariableName = n.Fields[i].Stream.IfVariableName
 					n.Fields[i].Stream = nil
 				}
 			}
@@ -70,8 +76,10 @@ func (p *ProcessStream) traverseNode(node resolve.Node) {
 			patch := &resolve.GraphQLResponsePatch{
 				Value:     n.Item,
 				Operation: literal.ADD,
+				Label:     n.Stream.Label,
 			}
-			if n.Stream.InitialBatchSize == 0 {
+			// the item is required to resolve all items in place if the variable of the "if" argument is false
+			if n.Stream.InitialBatchSize == 0 && n.Stream.IfVariableName == "" {
 				n.Item = nil
 			}
 			p.out.Response.Patches = append(p.out.Response.Patches, patch)
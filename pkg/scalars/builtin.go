@@ -0,0 +1,93 @@
+package scalars
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/buger/jsonparser"
+
+	"github.com/wundergraph/graphql-go-tools/internal/pkg/unsafebytes"
+)
+
+var (
+	bigIntRegex = regexp.MustCompile(`^-?[0-9]+$`)
+	uuidRegex   = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+)
+
+// DateTime is a date-time string as defined by RFC 3339, e.g. 2022-10-19T10:00:00Z
+func DateTime() Scalar {
+	return New("DateTime", `{"type":"string","format":"date-time"}`, func(value []byte, dataType jsonparser.ValueType) ([]byte, error) {
+		if dataType != jsonparser.String {
+			return nil, fmt.Errorf("DateTime cannot represent a non string value: %s", value)
+		}
+		if _, err := time.Parse(time.RFC3339Nano, unsafebytes.BytesToString(value)); err != nil {
+			return nil, fmt.Errorf(`DateTime cannot represent an invalid date-time string: "%s"`, value)
+		}
+		return quote(value), nil
+	})
+}
+
+// Date is a full-date string as defined by RFC 3339, e.g. 2022-10-19
+func Date() Scalar {
+	return New("Date", `{"type":"string","format":"date"}`, func(value []byte, dataType jsonparser.ValueType) ([]byte, error) {
+		if dataType != jsonparser.String {
+			return nil, fmt.Errorf("Date cannot represent a non string value: %s", value)
+		}
+		if _, err := time.Parse("2006-01-02", unsafebytes.BytesToString(value)); err != nil {
+			return nil, fmt.Errorf(`Date cannot represent an invalid date string: "%s"`, value)
+		}
+		return quote(value), nil
+	})
+}
+
+// JSON is an arbitrary JSON value
+func JSON() Scalar {
+	return New("JSON", `{}`, func(value []byte, dataType jsonparser.ValueType) ([]byte, error) {
+		if dataType == jsonparser.String {
+			return quote(value), nil
+		}
+		return value, nil
+	})
+}
+
+// BigInt is an integer without size limit, represented as JSON number or as string of digits
+func BigInt() Scalar {
+	return New("BigInt", `{"type":["integer","string"],"pattern":"^-?[0-9]+$"}`, func(value []byte, dataType jsonparser.ValueType) ([]byte, error) {
+		switch dataType {
+		case jsonparser.Number:
+			if !bigIntRegex.Match(value) {
+				return nil, fmt.Errorf("BigInt cannot represent non-integer value: %s", value)
+			}
+			return value, nil
+		case jsonparser.String:
+			if !bigIntRegex.Match(value) {
+				return nil, fmt.Errorf(`BigInt cannot represent non-integer value: "%s"`, value)
+			}
+			return quote(value), nil
+		default:
+			return nil, fmt.Errorf("BigInt cannot represent non-integer value: %s", value)
+		}
+	})
+}
+
+// UUID is a universally unique identifier as defined by RFC 4122, it's coerced to lower case
+func UUID() Scalar {
+	return New("UUID", `{"type":"string","pattern":"^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$"}`, func(value []byte, dataType jsonparser.ValueType) ([]byte, error) {
+		if dataType != jsonparser.String {
+			return nil, fmt.Errorf("UUID cannot represent a non string value: %s", value)
+		}
+		if !uuidRegex.Match(value) {
+			return nil, fmt.Errorf(`UUID cannot represent an invalid UUID string: "%s"`, value)
+		}
+		return quote(bytes.ToLower(value)), nil
+	})
+}
+
+func quote(value []byte) []byte {
+	quoted := make([]byte, 0, len(value)+2)
+	quoted = append(quoted, '"')
+	quoted = append(quoted, value...)
+	return append(quoted, '"')
+}
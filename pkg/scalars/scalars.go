@@ -0,0 +1,118 @@
+// Package scalars contains a registry of custom scalars with their input coercion and output serialization.
+//
+// The registry is used during validation of literals and variables, to generate JSON schemas of input values
+// and to serialize resolved values of custom scalars, which are opaque to the engine otherwise.
+package scalars
+
+import (
+	"fmt"
+
+	"github.com/buger/jsonparser"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+)
+
+// Scalar provides input coercion and output serialization of a custom scalar
+// Values are passed the same way jsonparser returns them: strings without quotes, but still escaped.
+// Returned values are valid JSON, i.e. strings are quoted.
+type Scalar interface {
+	// Name is the name of the scalar in the schema
+	Name() string
+	// ParseValue validates an input value of the scalar, e.g. a variable, and returns the coerced value
+	ParseValue(value []byte, dataType jsonparser.ValueType) ([]byte, error)
+	// ParseLiteral validates an inline value of the scalar in an operation
+	ParseLiteral(operation *ast.Document, value ast.Value) error
+	// Serialize validates a value of the scalar resolved from a data source and returns its response representation
+	Serialize(value []byte, dataType jsonparser.ValueType) ([]byte, error)
+	// JSONSchema returns the JSON schema of the input representation of the scalar
+	JSONSchema() []byte
+}
+
+// Registry holds the custom scalars of a schema by name
+// A nil Registry is valid and doesn't contain any scalar.
+type Registry struct {
+	scalars map[string]Scalar
+}
+
+func NewRegistry(scalars ...Scalar) *Registry {
+	registry := &Registry{
+		scalars: make(map[string]Scalar, len(scalars)),
+	}
+	for i := range scalars {
+		registry.Register(scalars[i])
+	}
+	return registry
+}
+
+// DefaultRegistry returns a Registry containing all built-in custom scalars
+func DefaultRegistry() *Registry {
+	return NewRegistry(DateTime(), Date(), JSON(), BigInt(), UUID())
+}
+
+// Register adds a scalar to the registry, an existing scalar with the same name gets replaced
+func (r *Registry) Register(scalar Scalar) {
+	r.scalars[scalar.Name()] = scalar
+}
+
+// Scalar returns the scalar with the given name
+func (r *Registry) Scalar(name string) (Scalar, bool) {
+	if r == nil {
+		return nil, false
+	}
+	scalar, ok := r.scalars[name]
+	return scalar, ok
+}
+
+// ParseFunc validates a value of a scalar and returns its coerced JSON representation
+type ParseFunc func(value []byte, dataType jsonparser.ValueType) ([]byte, error)
+
+// New creates a Scalar which uses the same ParseFunc for values, literals and serialization
+func New(name string, jsonSchema string, parse ParseFunc) Scalar {
+	return &scalar{
+		name:       name,
+		jsonSchema: []byte(jsonSchema),
+		parse:      parse,
+	}
+}
+
+type scalar struct {
+	name       string
+	jsonSchema []byte
+	parse      ParseFunc
+}
+
+func (s *scalar) Name() string {
+	return s.name
+}
+
+func (s *scalar) ParseValue(value []byte, dataType jsonparser.ValueType) ([]byte, error) {
+	return s.parse(value, dataType)
+}
+
+func (s *scalar) ParseLiteral(operation *ast.Document, value ast.Value) error {
+	if operation.ValueContainsVariable(value) {
+		// variables are validated with their values, e.g. {a: $x} can't be validated without the value of $x
+		return nil
+	}
+	if value.Kind == ast.ValueKindEnum {
+		return fmt.Errorf("%s cannot represent an enum value: %s", s.name, operation.EnumValueNameBytes(value.Ref))
+	}
+	literal, err := operation.ValueToJSON(value)
+	if err != nil {
+		return err
+	}
+	literalValue, dataType, _, err := jsonparser.Get(literal)
+	if err != nil {
+		return err
+	}
+	_, err = s.parse(literalValue, dataType)
+	return err
+}
+
+func (s *scalar) Serialize(value []byte, dataType jsonparser.ValueType) ([]byte, error) {
+	return s.parse(value, dataType)
+}
+
+func (s *scalar) JSONSchema() []byte {
+	return s.jsonSchema
+}
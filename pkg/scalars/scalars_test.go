@@ -0,0 +1,88 @@
+package scalars
+
+import (
+	"testing"
+
+	"github.com/buger/jsonparser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wundergraph/graphql-go-tools/internal/pkg/unsafeparser"
+)
+
+func TestRegistry(t *testing.T) {
+	t.Run("default registry", func(t *testing.T) {
+		registry := DefaultRegistry()
+		for _, name := range []string{"DateTime", "Date", "JSON", "BigInt", "UUID"} {
+			scalar, ok := registry.Scalar(name)
+			require.True(t, ok, name)
+			assert.Equal(t, name, scalar.Name())
+		}
+		_, ok := registry.Scalar("String")
+		assert.False(t, ok)
+	})
+
+	t.Run("register replaces existing scalar", func(t *testing.T) {
+		registry := NewRegistry(JSON())
+		registry.Register(New("JSON", `{"type":"object"}`, func(value []byte, dataType jsonparser.ValueType) ([]byte, error) {
+			return value, nil
+		}))
+		scalar, ok := registry.Scalar("JSON")
+		require.True(t, ok)
+		assert.Equal(t, `{"type":"object"}`, string(scalar.JSONSchema()))
+	})
+
+	t.Run("nil registry", func(t *testing.T) {
+		var registry *Registry
+		_, ok := registry.Scalar("JSON")
+		assert.False(t, ok)
+	})
+}
+
+func TestBuiltinScalars(t *testing.T) {
+	run := func(scalar Scalar, input string, expectedOutput string, expectedErr string) func(t *testing.T) {
+		return func(t *testing.T) {
+			value, dataType, _, err := jsonparser.Get([]byte(input))
+			require.NoError(t, err)
+			output, err := scalar.ParseValue(value, dataType)
+			if expectedErr != "" {
+				assert.EqualError(t, err, expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, expectedOutput, string(output))
+		}
+	}
+
+	t.Run("DateTime", run(DateTime(), `"2022-10-19T10:00:00.5+02:00"`, `"2022-10-19T10:00:00.5+02:00"`, ""))
+	t.Run("DateTime invalid", run(DateTime(), `"2022-10-19"`, "", `DateTime cannot represent an invalid date-time string: "2022-10-19"`))
+	t.Run("DateTime non string", run(DateTime(), `1`, "", `DateTime cannot represent a non string value: 1`))
+	t.Run("Date", run(Date(), `"2022-10-19"`, `"2022-10-19"`, ""))
+	t.Run("Date invalid", run(Date(), `"2022-13-19"`, "", `Date cannot represent an invalid date string: "2022-13-19"`))
+	t.Run("JSON object", run(JSON(), `{"a":[1,"b"]}`, `{"a":[1,"b"]}`, ""))
+	t.Run("JSON string", run(JSON(), `"a"`, `"a"`, ""))
+	t.Run("BigInt number", run(BigInt(), `12345678901234567890`, `12345678901234567890`, ""))
+	t.Run("BigInt string", run(BigInt(), `"-12345678901234567890"`, `"-12345678901234567890"`, ""))
+	t.Run("BigInt float", run(BigInt(), `1.5`, "", `BigInt cannot represent non-integer value: 1.5`))
+	t.Run("BigInt boolean", run(BigInt(), `true`, "", `BigInt cannot represent non-integer value: true`))
+	t.Run("UUID is lower cased", run(UUID(), `"9B2E3F6A-1C4D-4E5F-8A9B-0C1D2E3F4A5B"`, `"9b2e3f6a-1c4d-4e5f-8a9b-0c1d2e3f4a5b"`, ""))
+	t.Run("UUID invalid", run(UUID(), `"9b2e3f6a"`, "", `UUID cannot represent an invalid UUID string: "9b2e3f6a"`))
+}
+
+func TestScalar_ParseLiteral(t *testing.T) {
+	operation := unsafeparser.ParseGraphqlDocumentString(`{ a(valid: "2022-10-19", invalid: "yesterday", number: 1, variable: $date, enum: TODAY, object: {a: $x}, list: [1, $x]) }`)
+	arguments := operation.FieldArguments(0)
+	require.Len(t, arguments, 7)
+
+	date := Date()
+	assert.NoError(t, date.ParseLiteral(&operation, operation.ArgumentValue(arguments[0])))
+	assert.EqualError(t, date.ParseLiteral(&operation, operation.ArgumentValue(arguments[1])), `Date cannot represent an invalid date string: "yesterday"`)
+	assert.EqualError(t, date.ParseLiteral(&operation, operation.ArgumentValue(arguments[2])), `Date cannot represent a non string value: 1`)
+	assert.NoError(t, date.ParseLiteral(&operation, operation.ArgumentValue(arguments[3])), "variables are validated with their values")
+	assert.EqualError(t, date.ParseLiteral(&operation, operation.ArgumentValue(arguments[4])), `Date cannot represent an enum value: TODAY`)
+
+	json := JSON()
+	assert.NoError(t, json.ParseLiteral(&operation, operation.ArgumentValue(arguments[5])), "literals containing variables are validated with the values of the variables")
+	assert.NoError(t, json.ParseLiteral(&operation, operation.ArgumentValue(arguments[6])), "literals containing variables are validated with the values of the variables")
+	assert.EqualError(t, json.ParseLiteral(&operation, operation.ArgumentValue(arguments[4])), `JSON cannot represent an enum value: TODAY`)
+}
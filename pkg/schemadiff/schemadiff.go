@@ -0,0 +1,542 @@
+// Package schemadiff compares two GraphQL schemas and classifies the changes by their impact on clients.
+//
+// The classification follows graphql-js (findBreakingChanges/findDangerousChanges):
+// breaking changes fail existing operations, dangerous changes may change the behaviour of existing operations
+// and safe changes don't affect existing operations.
+// Type extensions have to be merged into their types before diffing, e.g. with astnormalization.NormalizeDefinition.
+package schemadiff
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+)
+
+type Criticality string
+
+const (
+	CriticalityBreaking  Criticality = "BREAKING"
+	CriticalityDangerous Criticality = "DANGEROUS"
+	CriticalitySafe      Criticality = "SAFE"
+)
+
+type ChangeType string
+
+const (
+	ChangeTypeTypeRemoved                   ChangeType = "TYPE_REMOVED"
+	ChangeTypeTypeAdded                     ChangeType = "TYPE_ADDED"
+	ChangeTypeTypeKindChanged               ChangeType = "TYPE_KIND_CHANGED"
+	ChangeTypeFieldRemoved                  ChangeType = "FIELD_REMOVED"
+	ChangeTypeFieldAdded                    ChangeType = "FIELD_ADDED"
+	ChangeTypeFieldTypeChanged              ChangeType = "FIELD_TYPE_CHANGED"
+	ChangeTypeArgumentRemoved               ChangeType = "ARGUMENT_REMOVED"
+	ChangeTypeArgumentAdded                 ChangeType = "ARGUMENT_ADDED"
+	ChangeTypeArgumentTypeChanged           ChangeType = "ARGUMENT_TYPE_CHANGED"
+	ChangeTypeArgumentDefaultValueChanged   ChangeType = "ARGUMENT_DEFAULT_VALUE_CHANGED"
+	ChangeTypeInputFieldRemoved             ChangeType = "INPUT_FIELD_REMOVED"
+	ChangeTypeInputFieldAdded               ChangeType = "INPUT_FIELD_ADDED"
+	ChangeTypeInputFieldTypeChanged         ChangeType = "INPUT_FIELD_TYPE_CHANGED"
+	ChangeTypeInputFieldDefaultValueChanged ChangeType = "INPUT_FIELD_DEFAULT_VALUE_CHANGED"
+	ChangeTypeEnumValueRemoved              ChangeType = "ENUM_VALUE_REMOVED"
+	ChangeTypeEnumValueAdded                ChangeType = "ENUM_VALUE_ADDED"
+	ChangeTypeUnionMemberRemoved            ChangeType = "UNION_MEMBER_REMOVED"
+	ChangeTypeUnionMemberAdded              ChangeType = "UNION_MEMBER_ADDED"
+	ChangeTypeImplementedInterfaceRemoved   ChangeType = "IMPLEMENTED_INTERFACE_REMOVED"
+	ChangeTypeImplementedInterfaceAdded     ChangeType = "IMPLEMENTED_INTERFACE_ADDED"
+	ChangeTypeDirectiveRemoved              ChangeType = "DIRECTIVE_REMOVED"
+	ChangeTypeDirectiveAdded                ChangeType = "DIRECTIVE_ADDED"
+	ChangeTypeDirectiveLocationRemoved      ChangeType = "DIRECTIVE_LOCATION_REMOVED"
+	ChangeTypeDirectiveLocationAdded        ChangeType = "DIRECTIVE_LOCATION_ADDED"
+)
+
+// Change is a single difference between two schemas
+// Path is the coordinate of the changed element, e.g. "User.name", "Query.user.id", "Color.RED" or "@cached.ttl".
+type Change struct {
+	Type        ChangeType  `json:"type"`
+	Criticality Criticality `json:"criticality"`
+	Path        string      `json:"path"`
+	Message     string      `json:"message"`
+}
+
+type Changes []Change
+
+// HasBreaking reports whether at least one of the changes is breaking
+func (c Changes) HasBreaking() bool {
+	for i := range c {
+		if c[i].Criticality == CriticalityBreaking {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter returns the changes with the given criticality
+func (c Changes) Filter(criticality Criticality) Changes {
+	filtered := Changes{}
+	for i := range c {
+		if c[i].Criticality == criticality {
+			filtered = append(filtered, c[i])
+		}
+	}
+	return filtered
+}
+
+// Diff compares the old with the new schema and returns all changes
+// Changes of the old schema are reported in the order of its definitions, followed by additions in the order of the new schema.
+// Introspection types, e.g. __Type, are ignored.
+func Diff(oldSchema, newSchema *ast.Document) Changes {
+	d := &differ{
+		old:     newDefinitions(oldSchema),
+		new:     newDefinitions(newSchema),
+		changes: Changes{},
+	}
+	d.diffTypes()
+	d.diffDirectives()
+	return d.changes
+}
+
+// definitions indexes the type and directive definitions of a schema by name
+type definitions struct {
+	doc            *ast.Document
+	types          map[string]ast.Node
+	typeNames      []string
+	directives     map[string]int
+	directiveNames []string
+}
+
+func newDefinitions(doc *ast.Document) *definitions {
+	defs := &definitions{
+		doc:        doc,
+		types:      map[string]ast.Node{},
+		directives: map[string]int{},
+	}
+	for _, node := range doc.RootNodes {
+		switch node.Kind {
+		case ast.NodeKindObjectTypeDefinition,
+			ast.NodeKindInterfaceTypeDefinition,
+			ast.NodeKindUnionTypeDefinition,
+			ast.NodeKindEnumTypeDefinition,
+			ast.NodeKindInputObjectTypeDefinition,
+			ast.NodeKindScalarTypeDefinition:
+			name := doc.NodeNameString(node)
+			if strings.HasPrefix(name, "__") {
+				continue
+			}
+			if _, exists := defs.types[name]; exists {
+				continue
+			}
+			defs.types[name] = node
+			defs.typeNames = append(defs.typeNames, name)
+		case ast.NodeKindDirectiveDefinition:
+			name := doc.DirectiveDefinitionNameString(node.Ref)
+			if _, exists := defs.directives[name]; exists {
+				continue
+			}
+			defs.directives[name] = node.Ref
+			defs.directiveNames = append(defs.directiveNames, name)
+		}
+	}
+	return defs
+}
+
+// inputValues returns the input value definitions of a list by name and in order
+func (d *definitions) inputValues(refs []int) (byName map[string]int, names []string) {
+	byName = make(map[string]int, len(refs))
+	for _, ref := range refs {
+		name := d.doc.InputValueDefinitionNameString(ref)
+		byName[name] = ref
+		names = append(names, name)
+	}
+	return byName, names
+}
+
+func (d *definitions) fields(node ast.Node) (byName map[string]int, names []string) {
+	var refs []int
+	switch node.Kind {
+	case ast.NodeKindObjectTypeDefinition:
+		refs = d.doc.ObjectTypeDefinitions[node.Ref].FieldsDefinition.Refs
+	case ast.NodeKindInterfaceTypeDefinition:
+		refs = d.doc.InterfaceTypeDefinitions[node.Ref].FieldsDefinition.Refs
+	}
+	byName = make(map[string]int, len(refs))
+	for _, ref := range refs {
+		name := d.doc.FieldDefinitionNameString(ref)
+		byName[name] = ref
+		names = append(names, name)
+	}
+	return byName, names
+}
+
+func (d *definitions) interfaces(node ast.Node) []string {
+	var refs []int
+	switch node.Kind {
+	case ast.NodeKindObjectTypeDefinition:
+		refs = d.doc.ObjectTypeDefinitions[node.Ref].ImplementsInterfaces.Refs
+	case ast.NodeKindInterfaceTypeDefinition:
+		refs = d.doc.InterfaceTypeDefinitions[node.Ref].ImplementsInterfaces.Refs
+	}
+	return d.namedTypes(refs)
+}
+
+func (d *definitions) namedTypes(typeRefs []int) []string {
+	names := make([]string, 0, len(typeRefs))
+	for _, ref := range typeRefs {
+		names = append(names, d.doc.TypeNameString(ref))
+	}
+	return names
+}
+
+func (d *definitions) enumValues(ref int) []string {
+	refs := d.doc.EnumTypeDefinitions[ref].EnumValuesDefinition.Refs
+	names := make([]string, 0, len(refs))
+	for _, valueRef := range refs {
+		names = append(names, d.doc.EnumValueDefinitionNameString(valueRef))
+	}
+	return names
+}
+
+func (d *definitions) directiveLocations(ref int) []string {
+	var locations []string
+	iter := d.doc.DirectiveDefinitions[ref].DirectiveLocations.Iterable()
+	for iter.Next() {
+		locations = append(locations, string(iter.Value().LiteralBytes()))
+	}
+	return locations
+}
+
+func (d *definitions) printType(ref int) string {
+	out, _ := d.doc.PrintTypeBytes(ref, nil)
+	return string(out)
+}
+
+// defaultValue returns the printed default value of an input value definition and whether it has one
+func (d *definitions) defaultValue(ref int) (string, bool) {
+	if !d.doc.InputValueDefinitionHasDefaultValue(ref) {
+		return "", false
+	}
+	out, _ := d.doc.PrintValueBytes(d.doc.InputValueDefinitionDefaultValue(ref), nil)
+	return string(out), true
+}
+
+type differ struct {
+	old, new *definitions
+	changes  Changes
+}
+
+func (d *differ) report(changeType ChangeType, criticality Criticality, path, format string, args ...interface{}) {
+	d.changes = append(d.changes, Change{
+		Type:        changeType,
+		Criticality: criticality,
+		Path:        path,
+		Message:     fmt.Sprintf(format, args...),
+	})
+}
+
+func (d *differ) diffTypes() {
+	for _, name := range d.old.typeNames {
+		oldNode := d.old.types[name]
+		newNode, exists := d.new.types[name]
+		if !exists {
+			d.report(ChangeTypeTypeRemoved, CriticalityBreaking, name, "Type '%s' was removed", name)
+			continue
+		}
+		if oldNode.Kind != newNode.Kind {
+			d.report(ChangeTypeTypeKindChanged, CriticalityBreaking, name, "Type '%s' changed from %s to %s", name, kindName(oldNode.Kind), kindName(newNode.Kind))
+			continue
+		}
+
+		switch oldNode.Kind {
+		case ast.NodeKindObjectTypeDefinition, ast.NodeKindInterfaceTypeDefinition:
+			d.diffFields(name, oldNode, newNode)
+			d.diffImplementedInterfaces(name, oldNode, newNode)
+		case ast.NodeKindInputObjectTypeDefinition:
+			d.diffInputFields(name, oldNode.Ref, newNode.Ref)
+		case ast.NodeKindEnumTypeDefinition:
+			d.diffEnumValues(name, oldNode.Ref, newNode.Ref)
+		case ast.NodeKindUnionTypeDefinition:
+			d.diffUnionMembers(name, oldNode.Ref, newNode.Ref)
+		}
+	}
+
+	for _, name := range d.new.typeNames {
+		if _, exists := d.old.types[name]; !exists {
+			d.report(ChangeTypeTypeAdded, CriticalitySafe, name, "Type '%s' was added", name)
+		}
+	}
+}
+
+func (d *differ) diffFields(typeName string, oldNode, newNode ast.Node) {
+	oldFields, oldNames := d.old.fields(oldNode)
+	newFields, newNames := d.new.fields(newNode)
+	isInterface := oldNode.Kind == ast.NodeKindInterfaceTypeDefinition
+
+	for _, name := range oldNames {
+		path := typeName + "." + name
+		oldRef := oldFields[name]
+		newRef, exists := newFields[name]
+		if !exists {
+			d.report(ChangeTypeFieldRemoved, CriticalityBreaking, path, "Field '%s' was removed from %s '%s'", name, kindName(oldNode.Kind), typeName)
+			continue
+		}
+
+		oldType, newType := d.old.doc.FieldDefinitionType(oldRef), d.new.doc.FieldDefinitionType(newRef)
+		if oldTypeString, newTypeString := d.old.printType(oldType), d.new.printType(newType); oldTypeString != newTypeString {
+			criticality := CriticalityBreaking
+			if d.isSafeOutputTypeChange(oldType, newType) {
+				criticality = CriticalitySafe
+			}
+			d.report(ChangeTypeFieldTypeChanged, criticality, path, "Field '%s' changed type from '%s' to '%s'", path, oldTypeString, newTypeString)
+		}
+
+		d.diffArguments(path, "field", isInterface,
+			d.old.doc.FieldDefinitionArgumentsDefinitions(oldRef),
+			d.new.doc.FieldDefinitionArgumentsDefinitions(newRef),
+		)
+	}
+
+	for _, name := range newNames {
+		if _, exists := oldFields[name]; !exists {
+			d.report(ChangeTypeFieldAdded, CriticalitySafe, typeName+"."+name, "Field '%s' was added to %s '%s'", name, kindName(newNode.Kind), typeName)
+		}
+	}
+}
+
+// diffArguments compares the arguments of a field or directive
+// Adding an optional argument to an interface field is dangerous because implementations might not support it yet.
+func (d *differ) diffArguments(parentPath, parentKind string, isInterfaceField bool, oldRefs, newRefs []int) {
+	oldArguments, oldNames := d.old.inputValues(oldRefs)
+	newArguments, newNames := d.new.inputValues(newRefs)
+
+	for _, name := range oldNames {
+		path := parentPath + "." + name
+		oldRef := oldArguments[name]
+		newRef, exists := newArguments[name]
+		if !exists {
+			d.report(ChangeTypeArgumentRemoved, CriticalityBreaking, path, "Argument '%s' was removed from %s '%s'", name, parentKind, parentPath)
+			continue
+		}
+
+		oldType, newType := d.old.doc.InputValueDefinitionType(oldRef), d.new.doc.InputValueDefinitionType(newRef)
+		if oldTypeString, newTypeString := d.old.printType(oldType), d.new.printType(newType); oldTypeString != newTypeString {
+			criticality := CriticalityBreaking
+			if d.isSafeInputTypeChange(oldType, newType) {
+				criticality = CriticalitySafe
+			}
+			d.report(ChangeTypeArgumentTypeChanged, criticality, path, "Argument '%s' on %s '%s' changed type from '%s' to '%s'", name, parentKind, parentPath, oldTypeString, newTypeString)
+		}
+
+		d.diffDefaultValue(ChangeTypeArgumentDefaultValueChanged, path, "Argument", oldRef, newRef)
+	}
+
+	for _, name := range newNames {
+		if _, exists := oldArguments[name]; exists {
+			continue
+		}
+		path := parentPath + "." + name
+		newRef := newArguments[name]
+		switch {
+		case d.isRequired(newRef):
+			d.report(ChangeTypeArgumentAdded, CriticalityBreaking, path, "Required argument '%s' was added to %s '%s'", name, parentKind, parentPath)
+		case isInterfaceField:
+			d.report(ChangeTypeArgumentAdded, CriticalityDangerous, path, "Optional argument '%s' was added to interface field '%s'", name, parentPath)
+		default:
+			d.report(ChangeTypeArgumentAdded, CriticalitySafe, path, "Optional argument '%s' was added to %s '%s'", name, parentKind, parentPath)
+		}
+	}
+}
+
+func (d *differ) diffInputFields(typeName string, oldRef, newRef int) {
+	oldFields, oldNames := d.old.inputValues(d.old.doc.InputObjectTypeDefinitions[oldRef].InputFieldsDefinition.Refs)
+	newFields, newNames := d.new.inputValues(d.new.doc.InputObjectTypeDefinitions[newRef].InputFieldsDefinition.Refs)
+
+	for _, name := range oldNames {
+		path := typeName + "." + name
+		oldFieldRef := oldFields[name]
+		newFieldRef, exists := newFields[name]
+		if !exists {
+			d.report(ChangeTypeInputFieldRemoved, CriticalityBreaking, path, "Input field '%s' was removed from input object type '%s'", name, typeName)
+			continue
+		}
+
+		oldType, newType := d.old.doc.InputValueDefinitionType(oldFieldRef), d.new.doc.InputValueDefinitionType(newFieldRef)
+		if oldTypeString, newTypeString := d.old.printType(oldType), d.new.printType(newType); oldTypeString != newTypeString {
+			criticality := CriticalityBreaking
+			if d.isSafeInputTypeChange(oldType, newType) {
+				criticality = CriticalitySafe
+			}
+			d.report(ChangeTypeInputFieldTypeChanged, criticality, path, "Input field '%s' changed type from '%s' to '%s'", path, oldTypeString, newTypeString)
+		}
+
+		d.diffDefaultValue(ChangeTypeInputFieldDefaultValueChanged, path, "Input field", oldFieldRef, newFieldRef)
+	}
+
+	for _, name := range newNames {
+		if _, exists := oldFields[name]; exists {
+			continue
+		}
+		path := typeName + "." + name
+		if d.isRequired(newFields[name]) {
+			d.report(ChangeTypeInputFieldAdded, CriticalityBreaking, path, "Required input field '%s' was added to input object type '%s'", name, typeName)
+			continue
+		}
+		d.report(ChangeTypeInputFieldAdded, CriticalitySafe, path, "Optional input field '%s' was added to input object type '%s'", name, typeName)
+	}
+}
+
+// diffDefaultValue reports changed default values as dangerous, because operations relying on the default behave differently
+func (d *differ) diffDefaultValue(changeType ChangeType, path, subject string, oldRef, newRef int) {
+	oldDefault, oldHasDefault := d.old.defaultValue(oldRef)
+	newDefault, newHasDefault := d.new.defaultValue(newRef)
+	switch {
+	case oldHasDefault && newHasDefault && oldDefault != newDefault:
+		d.report(changeType, CriticalityDangerous, path, "%s '%s' changed default value from '%s' to '%s'", subject, path, oldDefault, newDefault)
+	case oldHasDefault && !newHasDefault:
+		d.report(changeType, CriticalityDangerous, path, "%s '%s' default value '%s' was removed", subject, path, oldDefault)
+	case !oldHasDefault && newHasDefault:
+		d.report(changeType, CriticalityDangerous, path, "%s '%s' default value '%s' was added", subject, path, newDefault)
+	}
+}
+
+func (d *differ) diffEnumValues(typeName string, oldRef, newRef int) {
+	oldValues, newValues := d.old.enumValues(oldRef), d.new.enumValues(newRef)
+	for _, value := range missing(oldValues, newValues) {
+		d.report(ChangeTypeEnumValueRemoved, CriticalityBreaking, typeName+"."+value, "Enum value '%s' was removed from enum '%s'", value, typeName)
+	}
+	for _, value := range missing(newValues, oldValues) {
+		d.report(ChangeTypeEnumValueAdded, CriticalityDangerous, typeName+"."+value, "Enum value '%s' was added to enum '%s'", value, typeName)
+	}
+}
+
+func (d *differ) diffUnionMembers(typeName string, oldRef, newRef int) {
+	oldMembers := d.old.namedTypes(d.old.doc.UnionTypeDefinitions[oldRef].UnionMemberTypes.Refs)
+	newMembers := d.new.namedTypes(d.new.doc.UnionTypeDefinitions[newRef].UnionMemberTypes.Refs)
+	for _, member := range missing(oldMembers, newMembers) {
+		d.report(ChangeTypeUnionMemberRemoved, CriticalityBreaking, typeName, "Member '%s' was removed from union '%s'", member, typeName)
+	}
+	for _, member := range missing(newMembers, oldMembers) {
+		d.report(ChangeTypeUnionMemberAdded, CriticalityDangerous, typeName, "Member '%s' was added to union '%s'", member, typeName)
+	}
+}
+
+func (d *differ) diffImplementedInterfaces(typeName string, oldNode, newNode ast.Node) {
+	oldInterfaces, newInterfaces := d.old.interfaces(oldNode), d.new.interfaces(newNode)
+	for _, name := range missing(oldInterfaces, newInterfaces) {
+		d.report(ChangeTypeImplementedInterfaceRemoved, CriticalityBreaking, typeName, "'%s' no longer implements interface '%s'", typeName, name)
+	}
+	for _, name := range missing(newInterfaces, oldInterfaces) {
+		d.report(ChangeTypeImplementedInterfaceAdded, CriticalityDangerous, typeName, "'%s' implements interface '%s' now", typeName, name)
+	}
+}
+
+func (d *differ) diffDirectives() {
+	for _, name := range d.old.directiveNames {
+		path := "@" + name
+		oldRef := d.old.directives[name]
+		newRef, exists := d.new.directives[name]
+		if !exists {
+			d.report(ChangeTypeDirectiveRemoved, CriticalityBreaking, path, "Directive '%s' was removed", path)
+			continue
+		}
+
+		oldLocations, newLocations := d.old.directiveLocations(oldRef), d.new.directiveLocations(newRef)
+		for _, location := range missing(oldLocations, newLocations) {
+			d.report(ChangeTypeDirectiveLocationRemoved, CriticalityBreaking, path, "Location '%s' was removed from directive '%s'", location, path)
+		}
+		for _, location := range missing(newLocations, oldLocations) {
+			d.report(ChangeTypeDirectiveLocationAdded, CriticalitySafe, path, "Location '%s' was added to directive '%s'", location, path)
+		}
+
+		d.diffArguments(path, "directive", false,
+			d.old.doc.DirectiveDefinitions[oldRef].ArgumentsDefinition.Refs,
+			d.new.doc.DirectiveDefinitions[newRef].ArgumentsDefinition.Refs,
+		)
+	}
+
+	for _, name := range d.new.directiveNames {
+		if _, exists := d.old.directives[name]; !exists {
+			d.report(ChangeTypeDirectiveAdded, CriticalitySafe, "@"+name, "Directive '@%s' was added", name)
+		}
+	}
+}
+
+// isRequired reports whether an argument or input field has to be provided
+func (d *differ) isRequired(inputValueRef int) bool {
+	return d.new.doc.TypeIsNonNull(d.new.doc.InputValueDefinitionType(inputValueRef)) &&
+		!d.new.doc.InputValueDefinitionHasDefaultValue(inputValueRef)
+}
+
+// isSafeOutputTypeChange reports whether the type of a field can change without breaking operations
+// Output types may become stricter, e.g. String to String!.
+func (d *differ) isSafeOutputTypeChange(oldRef, newRef int) bool {
+	oldType, newType := d.old.doc.Types[oldRef], d.new.doc.Types[newRef]
+	switch oldType.TypeKind {
+	case ast.TypeKindNamed:
+		if newType.TypeKind == ast.TypeKindNamed {
+			return d.old.doc.TypeNameString(oldRef) == d.new.doc.TypeNameString(newRef)
+		}
+		return newType.TypeKind == ast.TypeKindNonNull && d.isSafeOutputTypeChange(oldRef, newType.OfType)
+	case ast.TypeKindList:
+		if newType.TypeKind == ast.TypeKindList {
+			return d.isSafeOutputTypeChange(oldType.OfType, newType.OfType)
+		}
+		return newType.TypeKind == ast.TypeKindNonNull && d.isSafeOutputTypeChange(oldRef, newType.OfType)
+	case ast.TypeKindNonNull:
+		return newType.TypeKind == ast.TypeKindNonNull && d.isSafeOutputTypeChange(oldType.OfType, newType.OfType)
+	}
+	return false
+}
+
+// isSafeInputTypeChange reports whether the type of an argument or input field can change without breaking operations
+// Input types may become less strict, e.g. String! to String.
+func (d *differ) isSafeInputTypeChange(oldRef, newRef int) bool {
+	oldType, newType := d.old.doc.Types[oldRef], d.new.doc.Types[newRef]
+	switch oldType.TypeKind {
+	case ast.TypeKindNamed:
+		return newType.TypeKind == ast.TypeKindNamed && d.old.doc.TypeNameString(oldRef) == d.new.doc.TypeNameString(newRef)
+	case ast.TypeKindList:
+		return newType.TypeKind == ast.TypeKindList && d.isSafeInputTypeChange(oldType.OfType, newType.OfType)
+	case ast.TypeKindNonNull:
+		if newType.TypeKind == ast.TypeKindNonNull {
+			return d.isSafeInputTypeChange(oldType.OfType, newType.OfType)
+		}
+		return d.isSafeInputTypeChange(oldType.OfType, newRef)
+	}
+	return false
+}
+
+// missing returns the names of a which aren't part of b
+func missing(a, b []string) []string {
+	var result []string
+	for _, name := range a {
+		found := false
+		for _, other := range b {
+			if name == other {
+				found = true
+				break
+			}
+		}
+		if !found {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+func kindName(kind ast.NodeKind) string {
+	switch kind {
+	case ast.NodeKindObjectTypeDefinition:
+		return "object type"
+	case ast.NodeKindInterfaceTypeDefinition:
+		return "interface"
+	case ast.NodeKindUnionTypeDefinition:
+		return "union"
+	case ast.NodeKindEnumTypeDefinition:
+		return "enum"
+	case ast.NodeKindInputObjectTypeDefinition:
+		return "input object type"
+	case ast.NodeKindScalarTypeDefinition:
+		return "scalar"
+	}
+	return kind.String()
+}
@@ -0,0 +1,175 @@
+package schemadiff
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/astparser"
+)
+
+func TestDiff(t *testing.T) {
+	run := func(t *testing.T, oldSchema, newSchema string, expected Changes) {
+		t.Helper()
+		oldDoc, report := astparser.ParseGraphqlDocumentString(oldSchema)
+		require.False(t, report.HasErrors(), report.Error())
+		newDoc, report := astparser.ParseGraphqlDocumentString(newSchema)
+		require.False(t, report.HasErrors(), report.Error())
+
+		assert.Equal(t, expected, Diff(&oldDoc, &newDoc))
+	}
+
+	t.Run("no changes", func(t *testing.T) {
+		schema := `
+			type Query { user(id: ID!): User }
+			type User { id: ID! name: String }`
+		run(t, schema, schema, Changes{})
+	})
+
+	t.Run("types", func(t *testing.T) {
+		run(t, `
+			type Query { a: String }
+			type Removed { a: String }
+			scalar Changed
+			type __Ignored { a: String }`, `
+			type Query { a: String }
+			enum Changed { A }
+			type Added { a: String }`,
+			Changes{
+				{Type: ChangeTypeTypeRemoved, Criticality: CriticalityBreaking, Path: "Removed", Message: "Type 'Removed' was removed"},
+				{Type: ChangeTypeTypeKindChanged, Criticality: CriticalityBreaking, Path: "Changed", Message: "Type 'Changed' changed from scalar to enum"},
+				{Type: ChangeTypeTypeAdded, Criticality: CriticalitySafe, Path: "Added", Message: "Type 'Added' was added"},
+			})
+	})
+
+	t.Run("fields", func(t *testing.T) {
+		run(t, `
+			type Query {
+				removed: String
+				stricter: String
+				list: [String]
+				changed: String
+				looser: String!
+			}`, `
+			type Query {
+				stricter: String!
+				list: [String!]!
+				changed: Int
+				looser: String
+				added: String
+			}`,
+			Changes{
+				{Type: ChangeTypeFieldRemoved, Criticality: CriticalityBreaking, Path: "Query.removed", Message: "Field 'removed' was removed from object type 'Query'"},
+				{Type: ChangeTypeFieldTypeChanged, Criticality: CriticalitySafe, Path: "Query.stricter", Message: "Field 'Query.stricter' changed type from 'String' to 'String!'"},
+				{Type: ChangeTypeFieldTypeChanged, Criticality: CriticalitySafe, Path: "Query.list", Message: "Field 'Query.list' changed type from '[String]' to '[String!]!'"},
+				{Type: ChangeTypeFieldTypeChanged, Criticality: CriticalityBreaking, Path: "Query.changed", Message: "Field 'Query.changed' changed type from 'String' to 'Int'"},
+				{Type: ChangeTypeFieldTypeChanged, Criticality: CriticalityBreaking, Path: "Query.looser", Message: "Field 'Query.looser' changed type from 'String!' to 'String'"},
+				{Type: ChangeTypeFieldAdded, Criticality: CriticalitySafe, Path: "Query.added", Message: "Field 'added' was added to object type 'Query'"},
+			})
+	})
+
+	t.Run("arguments", func(t *testing.T) {
+		run(t, `
+			type Query {
+				a(removed: String, required: String, optional: String!, default: Int = 1): String
+			}
+			interface Node {
+				a: String
+			}`, `
+			type Query {
+				a(required: String!, optional: String, default: Int = 2, added: String, addedRequired: String!, addedWithDefault: Int! = 1): String
+			}
+			interface Node {
+				a(added: String): String
+			}`,
+			Changes{
+				{Type: ChangeTypeArgumentRemoved, Criticality: CriticalityBreaking, Path: "Query.a.removed", Message: "Argument 'removed' was removed from field 'Query.a'"},
+				{Type: ChangeTypeArgumentTypeChanged, Criticality: CriticalityBreaking, Path: "Query.a.required", Message: "Argument 'required' on field 'Query.a' changed type from 'String' to 'String!'"},
+				{Type: ChangeTypeArgumentTypeChanged, Criticality: CriticalitySafe, Path: "Query.a.optional", Message: "Argument 'optional' on field 'Query.a' changed type from 'String!' to 'String'"},
+				{Type: ChangeTypeArgumentDefaultValueChanged, Criticality: CriticalityDangerous, Path: "Query.a.default", Message: "Argument 'Query.a.default' changed default value from '1' to '2'"},
+				{Type: ChangeTypeArgumentAdded, Criticality: CriticalitySafe, Path: "Query.a.added", Message: "Optional argument 'added' was added to field 'Query.a'"},
+				{Type: ChangeTypeArgumentAdded, Criticality: CriticalityBreaking, Path: "Query.a.addedRequired", Message: "Required argument 'addedRequired' was added to field 'Query.a'"},
+				{Type: ChangeTypeArgumentAdded, Criticality: CriticalitySafe, Path: "Query.a.addedWithDefault", Message: "Optional argument 'addedWithDefault' was added to field 'Query.a'"},
+				{Type: ChangeTypeArgumentAdded, Criticality: CriticalityDangerous, Path: "Node.a.added", Message: "Optional argument 'added' was added to interface field 'Node.a'"},
+			})
+	})
+
+	t.Run("input fields", func(t *testing.T) {
+		run(t, `
+			input Filter {
+				removed: String
+				required: String
+				default: String = "a"
+			}`, `
+			input Filter {
+				required: String!
+				default: String
+				optional: String
+				addedRequired: String!
+			}`,
+			Changes{
+				{Type: ChangeTypeInputFieldRemoved, Criticality: CriticalityBreaking, Path: "Filter.removed", Message: "Input field 'removed' was removed from input object type 'Filter'"},
+				{Type: ChangeTypeInputFieldTypeChanged, Criticality: CriticalityBreaking, Path: "Filter.required", Message: "Input field 'Filter.required' changed type from 'String' to 'String!'"},
+				{Type: ChangeTypeInputFieldDefaultValueChanged, Criticality: CriticalityDangerous, Path: "Filter.default", Message: "Input field 'Filter.default' default value '\"a\"' was removed"},
+				{Type: ChangeTypeInputFieldAdded, Criticality: CriticalitySafe, Path: "Filter.optional", Message: "Optional input field 'optional' was added to input object type 'Filter'"},
+				{Type: ChangeTypeInputFieldAdded, Criticality: CriticalityBreaking, Path: "Filter.addedRequired", Message: "Required input field 'addedRequired' was added to input object type 'Filter'"},
+			})
+	})
+
+	t.Run("enums, unions and interfaces", func(t *testing.T) {
+		run(t, `
+			enum Color { RED GREEN }
+			union Pet = Cat | Dog
+			interface Named { name: String }
+			type Cat implements Named { name: String }
+			type Dog { name: String }`, `
+			enum Color { GREEN BLUE }
+			union Pet = Cat | Bird
+			interface Named { name: String }
+			type Cat { name: String }
+			type Dog implements Named { name: String }
+			type Bird { name: String }`,
+			Changes{
+				{Type: ChangeTypeEnumValueRemoved, Criticality: CriticalityBreaking, Path: "Color.RED", Message: "Enum value 'RED' was removed from enum 'Color'"},
+				{Type: ChangeTypeEnumValueAdded, Criticality: CriticalityDangerous, Path: "Color.BLUE", Message: "Enum value 'BLUE' was added to enum 'Color'"},
+				{Type: ChangeTypeUnionMemberRemoved, Criticality: CriticalityBreaking, Path: "Pet", Message: "Member 'Dog' was removed from union 'Pet'"},
+				{Type: ChangeTypeUnionMemberAdded, Criticality: CriticalityDangerous, Path: "Pet", Message: "Member 'Bird' was added to union 'Pet'"},
+				{Type: ChangeTypeImplementedInterfaceRemoved, Criticality: CriticalityBreaking, Path: "Cat", Message: "'Cat' no longer implements interface 'Named'"},
+				{Type: ChangeTypeImplementedInterfaceAdded, Criticality: CriticalityDangerous, Path: "Dog", Message: "'Dog' implements interface 'Named' now"},
+				{Type: ChangeTypeTypeAdded, Criticality: CriticalitySafe, Path: "Bird", Message: "Type 'Bird' was added"},
+			})
+	})
+
+	t.Run("directives", func(t *testing.T) {
+		run(t, `
+			directive @removed on FIELD
+			directive @cached(ttl: Int) on FIELD_DEFINITION | OBJECT`, `
+			directive @cached(ttl: Int, scope: String!) on FIELD_DEFINITION | INTERFACE
+			directive @added on FIELD`,
+			Changes{
+				{Type: ChangeTypeDirectiveRemoved, Criticality: CriticalityBreaking, Path: "@removed", Message: "Directive '@removed' was removed"},
+				{Type: ChangeTypeDirectiveLocationRemoved, Criticality: CriticalityBreaking, Path: "@cached", Message: "Location 'OBJECT' was removed from directive '@cached'"},
+				{Type: ChangeTypeDirectiveLocationAdded, Criticality: CriticalitySafe, Path: "@cached", Message: "Location 'INTERFACE' was added to directive '@cached'"},
+				{Type: ChangeTypeArgumentAdded, Criticality: CriticalityBreaking, Path: "@cached.scope", Message: "Required argument 'scope' was added to directive '@cached'"},
+				{Type: ChangeTypeDirectiveAdded, Criticality: CriticalitySafe, Path: "@added", Message: "Directive '@added' was added"},
+			})
+	})
+}
+
+func TestChanges(t *testing.T) {
+	changes := Changes{
+		{Type: ChangeTypeFieldAdded, Criticality: CriticalitySafe, Path: "Query.b", Message: "Field 'b' was added to object type 'Query'"},
+		{Type: ChangeTypeFieldRemoved, Criticality: CriticalityBreaking, Path: "Query.a", Message: "Field 'a' was removed from object type 'Query'"},
+	}
+
+	assert.True(t, changes.HasBreaking())
+	assert.False(t, changes.Filter(CriticalitySafe).HasBreaking())
+	assert.Equal(t, Changes{changes[1]}, changes.Filter(CriticalityBreaking))
+	assert.Equal(t, Changes{}, changes.Filter(CriticalityDangerous))
+
+	out, err := json.Marshal(changes[1:])
+	require.NoError(t, err)
+	assert.Equal(t, `[{"type":"FIELD_REMOVED","criticality":"BREAKING","path":"Query.a","message":"Field 'a' was removed from object type 'Query'"}]`, string(out))
+}
@@ -0,0 +1,195 @@
+package schemadiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/astparser"
+	"github.com/wundergraph/graphql-go-tools/pkg/astprinter"
+	"github.com/wundergraph/graphql-go-tools/pkg/asttransform"
+	"github.com/wundergraph/graphql-go-tools/pkg/astvalidation"
+	"github.com/wundergraph/graphql-go-tools/pkg/graphqlerrors"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+)
+
+// Operation is a recorded operation of a client
+// Name identifies the operation in reports, e.g. the file it was loaded from or its persisted query hash.
+type Operation struct {
+	Name  string
+	Query string
+}
+
+// LoadOperations loads a corpus of operations
+// path is either a directory, which is searched recursively for .graphql files,
+// or a JSON manifest which maps the names of the operations to their queries, e.g. a persisted queries manifest.
+// Operations are sorted by name.
+func LoadOperations(path string) ([]Operation, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	var operations []Operation
+	if info.IsDir() {
+		operations, err = loadOperationsDirectory(path)
+	} else {
+		operations, err = loadOperationsManifest(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(operations, func(i, j int) bool {
+		return operations[i].Name < operations[j].Name
+	})
+	return operations, nil
+}
+
+func loadOperationsDirectory(dir string) ([]Operation, error) {
+	var operations []Operation
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || filepath.Ext(path) != ".graphql" {
+			return nil
+		}
+		query, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		operations = append(operations, Operation{
+			Name:  filepath.ToSlash(name),
+			Query: string(query),
+		})
+		return nil
+	})
+	return operations, err
+}
+
+func loadOperationsManifest(path string) ([]Operation, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var manifest map[string]string
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid operations manifest %s: %w", path, err)
+	}
+	operations := make([]Operation, 0, len(manifest))
+	for name, query := range manifest {
+		operations = append(operations, Operation{
+			Name:  name,
+			Query: query,
+		})
+	}
+	return operations, nil
+}
+
+type OperationError struct {
+	Message   string                   `json:"message"`
+	Locations []graphqlerrors.Location `json:"locations,omitempty"`
+}
+
+// OperationResult is an operation which fails to validate and the reasons why
+type OperationResult struct {
+	Name   string           `json:"name"`
+	Errors []OperationError `json:"errors"`
+}
+
+// UsageReport is the result of checking a new schema against a corpus of operations
+// Only BrokenOperations are breaking, schema changes which don't affect any of the operations are just reported.
+// InvalidOperations already failed to validate against the old schema, e.g. outdated operations of the corpus.
+type UsageReport struct {
+	Changes           Changes           `json:"changes"`
+	BrokenOperations  []OperationResult `json:"brokenOperations"`
+	InvalidOperations []OperationResult `json:"invalidOperations"`
+}
+
+// IsBreaking reports whether at least one of the operations breaks with the new schema
+func (r UsageReport) IsBreaking() bool {
+	return len(r.BrokenOperations) != 0
+}
+
+// CheckUsage diffs the schemas and validates each operation against the old and the new schema
+// with astvalidation.DefaultOperationValidator. An operation breaks if it's valid against the old but not against the new schema.
+// The schemas aren't modified.
+func CheckUsage(oldSchema, newSchema *ast.Document, operations []Operation) (UsageReport, error) {
+	report := UsageReport{
+		Changes:           Diff(oldSchema, newSchema),
+		BrokenOperations:  []OperationResult{},
+		InvalidOperations: []OperationResult{},
+	}
+
+	oldDefinition, err := validationDefinition(oldSchema)
+	if err != nil {
+		return UsageReport{}, fmt.Errorf("old schema: %w", err)
+	}
+	newDefinition, err := validationDefinition(newSchema)
+	if err != nil {
+		return UsageReport{}, fmt.Errorf("new schema: %w", err)
+	}
+
+	validator := astvalidation.DefaultOperationValidator()
+	for _, operation := range operations {
+		if errs := validateOperation(validator, operation, oldDefinition); len(errs) != 0 {
+			report.InvalidOperations = append(report.InvalidOperations, OperationResult{Name: operation.Name, Errors: errs})
+			continue
+		}
+		if errs := validateOperation(validator, operation, newDefinition); len(errs) != 0 {
+			report.BrokenOperations = append(report.BrokenOperations, OperationResult{Name: operation.Name, Errors: errs})
+		}
+	}
+	return report, nil
+}
+
+// validationDefinition copies the schema and merges it with the base schema, which is required for validation
+func validationDefinition(schema *ast.Document) (*ast.Document, error) {
+	printed, err := astprinter.PrintString(schema, nil)
+	if err != nil {
+		return nil, err
+	}
+	definition, report := astparser.ParseGraphqlDocumentString(printed)
+	if report.HasErrors() {
+		return nil, report
+	}
+	if err := asttransform.MergeDefinitionWithBaseSchema(&definition); err != nil {
+		return nil, err
+	}
+	return &definition, nil
+}
+
+func validateOperation(validator *astvalidation.OperationValidator, operation Operation, definition *ast.Document) []OperationError {
+	doc, report := astparser.ParseGraphqlDocumentString(operation.Query)
+	if !report.HasErrors() {
+		validator.Validate(&doc, definition, &report)
+	}
+	if !report.HasErrors() {
+		return nil
+	}
+	return operationErrors(report)
+}
+
+func operationErrors(report operationreport.Report) []OperationError {
+	errs := make([]OperationError, 0, len(report.ExternalErrors)+len(report.InternalErrors))
+	for _, externalErr := range report.ExternalErrors {
+		errs = append(errs, OperationError{
+			Message:   externalErr.Message,
+			Locations: externalErr.Locations,
+		})
+	}
+	for _, internalErr := range report.InternalErrors {
+		errs = append(errs, OperationError{
+			Message: internalErr.Error(),
+		})
+	}
+	return errs
+}
@@ -0,0 +1,134 @@
+package schemadiff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wundergraph/graphql-go-tools/pkg/astparser"
+	"github.com/wundergraph/graphql-go-tools/pkg/astprinter"
+	"github.com/wundergraph/graphql-go-tools/pkg/graphqlerrors"
+)
+
+func TestLoadOperations(t *testing.T) {
+	t.Run("directory", func(t *testing.T) {
+		operations, err := LoadOperations("./testdata/operations")
+		require.NoError(t, err)
+		require.Len(t, operations, 3)
+		assert.Equal(t, "outdated.graphql", operations[0].Name)
+		assert.Equal(t, "users/user.graphql", operations[1].Name)
+		assert.Equal(t, "users/userEmail.graphql", operations[2].Name)
+		assert.Contains(t, operations[1].Query, "query User($id: ID!)")
+	})
+
+	t.Run("manifest", func(t *testing.T) {
+		operations, err := LoadOperations("./testdata/manifest.json")
+		require.NoError(t, err)
+		assert.Equal(t, []Operation{
+			{Name: "1a2b3c", Query: `query Users { users { id } }`},
+			{Name: "2b1c3a", Query: `query UserName { user(id: "1") { name } }`},
+		}, operations)
+	})
+
+	t.Run("missing path", func(t *testing.T) {
+		_, err := LoadOperations("./testdata/missing")
+		assert.Error(t, err)
+	})
+}
+
+func TestCheckUsage(t *testing.T) {
+	oldSchema, report := astparser.ParseGraphqlDocumentString(`
+		type Query {
+			user(id: ID!): User
+			users: [User!]!
+		}
+		type User {
+			id: ID!
+			name: String
+			email: String
+			age: Int
+		}`)
+	require.False(t, report.HasErrors(), report.Error())
+	oldSchemaPrinted, err := astprinter.PrintString(&oldSchema, nil)
+	require.NoError(t, err)
+
+	check := func(t *testing.T, newSchemaInput string) UsageReport {
+		newSchema, report := astparser.ParseGraphqlDocumentString(newSchemaInput)
+		require.False(t, report.HasErrors(), report.Error())
+
+		operations, err := LoadOperations("./testdata/operations")
+		require.NoError(t, err)
+		usageReport, err := CheckUsage(&oldSchema, &newSchema, operations)
+		require.NoError(t, err)
+
+		// the schemas are copied for validation
+		actualOldSchema, err := astprinter.PrintString(&oldSchema, nil)
+		require.NoError(t, err)
+		assert.Equal(t, oldSchemaPrinted, actualOldSchema)
+		return usageReport
+	}
+
+	t.Run("removed field which isn't used doesn't break", func(t *testing.T) {
+		usageReport := check(t, `
+			type Query {
+				user(id: ID!): User
+				users: [User!]!
+			}
+			type User {
+				id: ID!
+				name: String
+				email: String
+			}`)
+
+		assert.False(t, usageReport.IsBreaking())
+		assert.True(t, usageReport.Changes.HasBreaking())
+		assert.Equal(t, Changes{
+			{Type: ChangeTypeFieldRemoved, Criticality: CriticalityBreaking, Path: "User.age", Message: "Field 'age' was removed from object type 'User'"},
+		}, usageReport.Changes)
+		assert.Equal(t, []OperationResult{}, usageReport.BrokenOperations)
+	})
+
+	t.Run("removed field which is used breaks", func(t *testing.T) {
+		usageReport := check(t, `
+			type Query {
+				user(id: ID!): User
+				users: [User!]!
+			}
+			type User {
+				id: ID!
+				name: String
+			}`)
+
+		assert.True(t, usageReport.IsBreaking())
+		assert.Equal(t, []OperationResult{
+			{
+				Name: "users/userEmail.graphql",
+				Errors: []OperationError{
+					{
+						Message:   "field: email not defined on type: User",
+						Locations: []graphqlerrors.Location{{Line: 3, Column: 5}},
+					},
+				},
+			},
+		}, usageReport.BrokenOperations)
+	})
+
+	t.Run("operations which are invalid against the old schema don't break", func(t *testing.T) {
+		usageReport := check(t, `
+			type Query {
+				user(id: ID!): User
+				users: [User!]!
+			}
+			type User {
+				id: ID!
+				name: String
+				email: String
+				age: Int
+			}`)
+
+		assert.False(t, usageReport.IsBreaking())
+		require.Len(t, usageReport.InvalidOperations, 1)
+		assert.Equal(t, "outdated.graphql", usageReport.InvalidOperations[0].Name)
+	})
+}
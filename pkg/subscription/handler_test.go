@@ -161,7 +161,7 @@ func TestHandler_Handle(t *testing.T) {
 				assert.Len(t, messagesFromServer, 1)
 				assert.Equal(t, "1", messagesFromServer[0].Id)
 				assert.Equal(t, MessageTypeError, messagesFromServer[0].Type)
-				assert.Equal(t, `[{"message":"field: invalid not defined on type: Character","path":["query","hero","invalid"]}]`, string(messagesFromServer[0].Payload))
+				assert.Equal(t, `[{"message":"field: invalid not defined on type: Character","locations":[{"line":3,"column":9}],"path":["query","hero","invalid"]}]`, string(messagesFromServer[0].Payload))
 				assert.Equal(t, 0, subscriptionHandler.ActiveSubscriptions())
 			})
 
@@ -420,7 +420,7 @@ func TestHandler_Handle(t *testing.T) {
 				expectedErrorMessage := Message{
 					Id:      "1",
 					Type:    MessageTypeError,
-					Payload: []byte(`[{"message":"field: serverName not defined on type: Query","path":["query","serverName"]}]`),
+					Payload: []byte(`[{"message":"field: serverName not defined on type: Query","locations":[{"line":2,"column":2}],"path":["query","serverName"]}]`),
 				}
 
 				messagesFromServer := client.readFromServer()
@@ -574,7 +574,7 @@ func TestHandler_Handle(t *testing.T) {
 				assert.Len(t, messagesFromServer, 1)
 				assert.Equal(t, "1", messagesFromServer[0].Id)
 				assert.Equal(t, MessageTypeError, messagesFromServer[0].Type)
-				assert.Equal(t, `[{"message":"differing fields for objectName 'a' on (potentially) same type","path":["subscription","messageAdded"]}]`, string(messagesFromServer[0].Payload))
+				assert.Equal(t, `[{"message":"differing fields for objectName 'a' on (potentially) same type","locations":[{"line":3,"column":3},{"line":6,"column":3}],"path":["subscription","messageAdded"]}]`, string(messagesFromServer[0].Payload))
 				assert.Equal(t, 1, subscriptionHandler.ActiveSubscriptions())
 			})
 
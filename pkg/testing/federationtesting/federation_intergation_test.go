@@ -81,6 +81,44 @@ func TestFederationIntegrationTest(t *testing.T) {
 		assert.Equal(t, `{"data":{"topProducts":[{"name":"Trilby","reviews":[{"body":"A highly effective form of birth control.","author":{"username":"Me"}}]},{"name":"Fedora","reviews":[{"body":"Fedoras are one of the most fashionable hats around and can look great with a variety of outfits.","author":{"username":"Me"}}]},{"name":"Boater","reviews":[{"body":"This is the last straw. Hat you will wear. 11/10","author":{"username":"User 7777"}}]}]}}`, string(resp))
 	})
 
+	t.Run("query with deferred fragment delivered as multipart/mixed", func(t *testing.T) {
+		payloads := gqlClient.QueryIncremental(ctx, setup.gatewayServer.URL, path.Join("testdata", "queries/defer.query"), nil, t)
+		assert.Equal(t, []string{
+			`{"data":{"topProducts":[{"name":"Trilby"},{"name":"Fedora"},{"name":"Boater"}]},"hasNext":true}`,
+			`{"incremental":[{"data":{"price":11,"reviews":[{"body":"A highly effective form of birth control."}]},"path":["topProducts",0],"label":"reviews"}],"hasNext":true}`,
+			`{"incremental":[{"data":{"price":22,"reviews":[{"body":"Fedoras are one of the most fashionable hats around and can look great with a variety of outfits."}]},"path":["topProducts",1],"label":"reviews"}],"hasNext":true}`,
+			`{"incremental":[{"data":{"price":33,"reviews":[{"body":"This is the last straw. Hat you will wear. 11/10"}]},"path":["topProducts",2],"label":"reviews"}],"hasNext":false}`,
+		}, payloads)
+	})
+
+	t.Run("query with fragment deferred by variable", func(t *testing.T) {
+		payloads := gqlClient.QueryIncremental(ctx, setup.gatewayServer.URL, path.Join("testdata", "queries/defer_variable.query"), queryVariables{
+			"defer": false,
+		}, t)
+		assert.Equal(t, []string{
+			`{"data":{"topProducts":[{"name":"Trilby","reviews":[{"body":"A highly effective form of birth control."}]},{"name":"Fedora","reviews":[{"body":"Fedoras are one of the most fashionable hats around and can look great with a variety of outfits."}]},{"name":"Boater","reviews":[{"body":"This is the last straw. Hat you will wear. 11/10"}]}]},"hasNext":false}`,
+		}, payloads)
+
+		payloads = gqlClient.QueryIncremental(ctx, setup.gatewayServer.URL, path.Join("testdata", "queries/defer_variable.query"), queryVariables{
+			"defer": true,
+		}, t)
+		assert.Equal(t, []string{
+			`{"data":{"topProducts":[{"name":"Trilby"},{"name":"Fedora"},{"name":"Boater"}]},"hasNext":true}`,
+			`{"incremental":[{"data":{"reviews":[{"body":"A highly effective form of birth control."}]},"path":["topProducts",0]}],"hasNext":true}`,
+			`{"incremental":[{"data":{"reviews":[{"body":"Fedoras are one of the most fashionable hats around and can look great with a variety of outfits."}]},"path":["topProducts",1]}],"hasNext":true}`,
+			`{"incremental":[{"data":{"reviews":[{"body":"This is the last straw. Hat you will wear. 11/10"}]},"path":["topProducts",2]}],"hasNext":false}`,
+		}, payloads)
+	})
+
+	t.Run("query with streamed list delivered as multipart/mixed", func(t *testing.T) {
+		payloads := gqlClient.QueryIncremental(ctx, setup.gatewayServer.URL, path.Join("testdata", "queries/stream.query"), nil, t)
+		assert.Equal(t, []string{
+			`{"data":{"topProducts":[{"upc":"top-1","name":"Trilby"}]},"hasNext":true}`,
+			`{"incremental":[{"items":[{"upc":"top-2","name":"Fedora"}],"path":["topProducts",1],"label":"products"}],"hasNext":true}`,
+			`{"incremental":[{"items":[{"upc":"top-3","name":"Boater"}],"path":["topProducts",2],"label":"products"}],"hasNext":false}`,
+		}, payloads)
+	})
+
 	t.Run("mutation operation with variables", func(t *testing.T) {
 		resp := gqlClient.Query(ctx, setup.gatewayServer.URL, path.Join("testdata", "mutations/mutation_with_variables.query"), queryVariables{
 			"authorID": "3210",
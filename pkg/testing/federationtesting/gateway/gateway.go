@@ -76,6 +76,7 @@ func (g *Gateway) UpdateDataSources(newDataSourcesConfig []graphqlDataSource.Con
 		newDataSourcesConfig,
 		graphqlDataSource.NewBatchFactory(),
 		graphql.WithFederationHttpClient(g.httpClient),
+		graphql.WithFederationIncrementalDelivery(),
 	)
 
 	schema, err := engineConfigFactory.MergedSchema()
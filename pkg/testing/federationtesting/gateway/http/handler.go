@@ -7,6 +7,7 @@ import (
 	log "github.com/jensneuse/abstractlogger"
 
 	"github.com/wundergraph/graphql-go-tools/pkg/graphql"
+	graphqlhttp "github.com/wundergraph/graphql-go-tools/pkg/http"
 )
 
 const (
@@ -20,18 +21,20 @@ func NewGraphqlHTTPHandler(
 	logger log.Logger,
 ) http.Handler {
 	return &GraphQLHTTPRequestHandler{
-		schema:     schema,
-		engine:     engine,
-		wsUpgrader: upgrader,
-		log:        logger,
+		schema:        schema,
+		engine:        engine,
+		engineHandler: graphqlhttp.NewEngineHTTPHandler(engine, logger),
+		wsUpgrader:    upgrader,
+		log:           logger,
 	}
 }
 
 type GraphQLHTTPRequestHandler struct {
-	log        log.Logger
-	wsUpgrader *ws.HTTPUpgrader
-	engine     *graphql.ExecutionEngineV2
-	schema     *graphql.Schema
+	log           log.Logger
+	wsUpgrader    *ws.HTTPUpgrader
+	engine        *graphql.ExecutionEngineV2
+	engineHandler http.Handler
+	schema        *graphql.Schema
 }
 
 func (g *GraphQLHTTPRequestHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
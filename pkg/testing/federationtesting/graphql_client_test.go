@@ -4,7 +4,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"io"
 	"io/ioutil"
+	"mime"
+	"mime/multipart"
 	"net"
 	"net/http"
 	"testing"
@@ -72,6 +75,39 @@ func (g *GraphqlClient) Query(ctx context.Context, addr, queryFilePath string, v
 	return responseBodyBytes
 }
 
+// QueryIncremental sends the query accepting multipart/mixed and returns the payloads of all parts
+func (g *GraphqlClient) QueryIncremental(ctx context.Context, addr, queryFilePath string, variables queryVariables, t *testing.T) []string {
+	reqBody := loadQuery(t, queryFilePath, variables)
+	req, err := http.NewRequest(http.MethodPost, addr, bytes.NewBuffer(reqBody))
+	require.NoError(t, err)
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "multipart/mixed; deferSpec=20220824, application/json")
+	resp, err := g.httpClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	mediaType, params, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/mixed", mediaType)
+
+	var payloads []string
+	reader := multipart.NewReader(resp.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		assert.Equal(t, "application/json; charset=utf-8", part.Header.Get("Content-Type"))
+		payload, err := ioutil.ReadAll(part)
+		require.NoError(t, err)
+		payloads = append(payloads, string(payload))
+	}
+
+	return payloads
+}
+
 func (g *GraphqlClient) Subscription(ctx context.Context, addr, queryFilePath string, variables queryVariables, t *testing.T) chan []byte {
 	messageCh := make(chan []byte)
 
@@ -0,0 +1,308 @@
+// Package variablesvalidation validates the variables of a request against the variable definitions of an operation.
+//
+// It implements CoerceVariableValues of the GraphQL specification: https://spec.graphql.org/October2021/#sec-Coercing-Variable-Values
+// Default values and list coercion are applied during normalization (see astnormalization),
+// so the validator expects the variables of a normalized operation and only checks them.
+package variablesvalidation
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/buger/jsonparser"
+
+	"github.com/wundergraph/graphql-go-tools/internal/pkg/unsafebytes"
+	"github.com/wundergraph/graphql-go-tools/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/pkg/lexer/position"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+	"github.com/wundergraph/graphql-go-tools/pkg/scalars"
+)
+
+// VariablesValidator validates variables against the variable definitions of an operation
+// A VariablesValidator is not safe for concurrent use.
+type VariablesValidator struct {
+	operation, definition *ast.Document
+	report                *operationreport.Report
+	scalars               *scalars.Registry
+
+	variableName     []byte
+	variableTypeRef  int
+	variablePosition position.Position
+	path             []byte
+	// keys is the path of the current value as jsonparser keys, e.g. ["input", "items", "[2]", "qty"]
+	keys      []string
+	coercions []coercion
+}
+
+// coercion is a value of a custom scalar which has to be replaced with its coerced value
+type coercion struct {
+	keys  []string
+	value []byte
+}
+
+type Option func(validator *VariablesValidator)
+
+// WithScalarRegistry validates and coerces values of custom scalars using the scalars of the registry
+func WithScalarRegistry(registry *scalars.Registry) Option {
+	return func(validator *VariablesValidator) {
+		validator.scalars = registry
+	}
+}
+
+func NewVariablesValidator(opts ...Option) *VariablesValidator {
+	validator := &VariablesValidator{
+		path: make([]byte, 0, 64),
+		keys: make([]string, 0, 8),
+	}
+	for _, opt := range opts {
+		opt(validator)
+	}
+	return validator
+}
+
+// Validate validates the variables against the variable definitions of the operation with the given name
+// If operationName is empty the first operation of the document gets validated.
+// All errors are added as external errors to the report.
+// The returned variables contain the coerced values of custom scalars.
+func (v *VariablesValidator) Validate(operation, definition *ast.Document, operationName, variables []byte, report *operationreport.Report) []byte {
+	v.operation, v.definition, v.report = operation, definition, report
+	v.coercions = v.coercions[:0]
+
+	for i := range operation.OperationDefinitions {
+		if len(operationName) != 0 && !bytes.Equal(operation.OperationDefinitionNameBytes(i), operationName) {
+			continue
+		}
+		for _, ref := range operation.OperationDefinitions[i].VariableDefinitions.Refs {
+			v.validateVariable(ref, variables)
+		}
+		break
+	}
+
+	if report.HasErrors() || len(v.coercions) == 0 {
+		return variables
+	}
+
+	coerced := append([]byte(nil), variables...)
+	for i := range v.coercions {
+		var err error
+		coerced, err = jsonparser.Set(coerced, v.coercions[i].value, v.coercions[i].keys...)
+		if err != nil {
+			report.AddInternalError(err)
+			return variables
+		}
+	}
+	return coerced
+}
+
+func (v *VariablesValidator) validateVariable(variableDefinitionRef int, variables []byte) {
+	v.variableName = v.operation.VariableDefinitionNameBytes(variableDefinitionRef)
+	v.variableTypeRef = v.operation.VariableDefinitions[variableDefinitionRef].Type
+	v.variablePosition = v.operation.VariableDefinitions[variableDefinitionRef].Position
+	v.path = append(v.path[:0], v.variableName...)
+	v.keys = append(v.keys[:0], string(v.variableName))
+
+	value, dataType, _, err := jsonparser.Get(variables, unsafebytes.BytesToString(v.variableName))
+	if err == jsonparser.KeyPathNotFoundError {
+		if v.operation.TypeIsNonNull(v.variableTypeRef) {
+			v.report.AddExternalError(operationreport.ErrVariableOfRequiredTypeNotProvided(v.variableName, v.printType(v.operation, v.variableTypeRef), v.variablePosition))
+		}
+		return
+	}
+	if err != nil {
+		v.report.AddInternalError(err)
+		return
+	}
+	if dataType == jsonparser.Null && v.operation.TypeIsNonNull(v.variableTypeRef) {
+		v.report.AddExternalError(operationreport.ErrVariableOfNonNullTypeMustNotBeNull(v.variableName, v.printType(v.operation, v.variableTypeRef), v.variablePosition))
+		return
+	}
+
+	v.validateValue(v.operation, v.variableTypeRef, value, dataType)
+}
+
+// validateValue validates a value against a type of the given document
+// The type of a variable is defined in the operation, types of input object fields are defined in the definition.
+func (v *VariablesValidator) validateValue(document *ast.Document, typeRef int, value []byte, dataType jsonparser.ValueType) {
+	if document.TypeIsNonNull(typeRef) {
+		if dataType == jsonparser.Null {
+			v.invalidValue(fmt.Sprintf(`Expected non-nullable type "%s" not to be null.`, v.printType(document, typeRef)))
+			return
+		}
+		typeRef = document.Types[typeRef].OfType
+	}
+
+	if dataType == jsonparser.Null {
+		return
+	}
+
+	if document.TypeIsList(typeRef) {
+		if dataType != jsonparser.Array {
+			// a single value is coerced into a list containing the value
+			v.validateValue(document, document.Types[typeRef].OfType, value, dataType)
+			return
+		}
+		pathLength, keysLength := len(v.path), len(v.keys)
+		index := 0
+		_, _ = jsonparser.ArrayEach(value, func(item []byte, itemDataType jsonparser.ValueType, _ int, _ error) {
+			v.path = append(v.path, '[')
+			v.path = strconv.AppendInt(v.path, int64(index), 10)
+			v.path = append(v.path, ']')
+			v.keys = append(v.keys, string(v.path[pathLength:]))
+			v.validateValue(document, document.Types[typeRef].OfType, item, itemDataType)
+			v.path, v.keys = v.path[:pathLength], v.keys[:keysLength]
+			index++
+		})
+		return
+	}
+
+	typeName := document.ResolveTypeNameBytes(typeRef)
+	node, ok := v.definition.Index.FirstNodeByNameBytes(typeName)
+	if !ok {
+		v.invalidValue(fmt.Sprintf(`Unknown type "%s".`, typeName))
+		return
+	}
+
+	switch node.Kind {
+	case ast.NodeKindScalarTypeDefinition:
+		v.validateScalar(typeName, value, dataType)
+	case ast.NodeKindEnumTypeDefinition:
+		v.validateEnum(node.Ref, typeName, value, dataType)
+	case ast.NodeKindInputObjectTypeDefinition:
+		v.validateInputObject(node.Ref, typeName, value, dataType)
+	default:
+		v.invalidValue(fmt.Sprintf(`Type "%s" is not an input type.`, typeName))
+	}
+}
+
+func (v *VariablesValidator) validateScalar(typeName, value []byte, dataType jsonparser.ValueType) {
+	switch unsafebytes.BytesToString(typeName) {
+	case "Int":
+		if dataType != jsonparser.Number {
+			v.invalidValue(fmt.Sprintf("Int cannot represent non-integer value: %s", printValue(value, dataType)))
+			return
+		}
+		number, err := strconv.ParseFloat(unsafebytes.BytesToString(value), 64)
+		if err != nil || number != math.Trunc(number) {
+			v.invalidValue(fmt.Sprintf("Int cannot represent non-integer value: %s", value))
+			return
+		}
+		if number > math.MaxInt32 || number < math.MinInt32 {
+			v.invalidValue(fmt.Sprintf("Int cannot represent non 32-bit signed integer value: %s", value))
+		}
+	case "Float":
+		if dataType != jsonparser.Number {
+			v.invalidValue(fmt.Sprintf("Float cannot represent non numeric value: %s", printValue(value, dataType)))
+		}
+	case "String":
+		if dataType != jsonparser.String {
+			v.invalidValue(fmt.Sprintf("String cannot represent a non string value: %s", printValue(value, dataType)))
+		}
+	case "Boolean":
+		if dataType != jsonparser.Boolean {
+			v.invalidValue(fmt.Sprintf("Boolean cannot represent a non boolean value: %s", printValue(value, dataType)))
+		}
+	case "ID":
+		switch dataType {
+		case jsonparser.String:
+		case jsonparser.Number:
+			if _, err := strconv.ParseInt(unsafebytes.BytesToString(value), 10, 64); err != nil {
+				v.invalidValue(fmt.Sprintf("ID cannot represent value: %s", value))
+			}
+		default:
+			v.invalidValue(fmt.Sprintf("ID cannot represent value: %s", printValue(value, dataType)))
+		}
+	default:
+		v.validateCustomScalar(typeName, value, dataType)
+	}
+}
+
+func (v *VariablesValidator) validateCustomScalar(typeName, value []byte, dataType jsonparser.ValueType) {
+	scalar, ok := v.scalars.Scalar(unsafebytes.BytesToString(typeName))
+	if !ok {
+		return
+	}
+	coerced, err := scalar.ParseValue(value, dataType)
+	if err != nil {
+		v.invalidValue(err.Error())
+		return
+	}
+	if len(coerced) == 0 || bytes.Equal(coerced, []byte(printValue(value, dataType))) {
+		return
+	}
+	v.coercions = append(v.coercions, coercion{
+		keys:  append([]string(nil), v.keys...),
+		value: coerced,
+	})
+}
+
+func (v *VariablesValidator) validateEnum(enumTypeDefinitionRef int, typeName, value []byte, dataType jsonparser.ValueType) {
+	if dataType != jsonparser.String {
+		v.invalidValue(fmt.Sprintf(`Enum "%s" cannot represent non-string value: %s.`, typeName, printValue(value, dataType)))
+		return
+	}
+	if !v.definition.EnumTypeDefinitionContainsEnumValue(enumTypeDefinitionRef, value) {
+		v.invalidValue(fmt.Sprintf(`Value "%s" does not exist in "%s" enum.`, value, typeName))
+	}
+}
+
+func (v *VariablesValidator) validateInputObject(inputObjectTypeDefinitionRef int, typeName, value []byte, dataType jsonparser.ValueType) {
+	if dataType != jsonparser.Object {
+		v.invalidValue(fmt.Sprintf(`Expected type "%s" to be an object.`, typeName))
+		return
+	}
+
+	inputFields := v.definition.InputObjectTypeDefinitions[inputObjectTypeDefinitionRef].InputFieldsDefinition.Refs
+
+	_ = jsonparser.ObjectEach(value, func(key []byte, _ []byte, _ jsonparser.ValueType, _ int) error {
+		for _, ref := range inputFields {
+			if bytes.Equal(v.definition.InputValueDefinitionNameBytes(ref), key) {
+				return nil
+			}
+		}
+		v.invalidValue(fmt.Sprintf(`Field "%s" is not defined by type "%s".`, key, typeName))
+		return nil
+	})
+
+	pathLength, keysLength := len(v.path), len(v.keys)
+	for _, ref := range inputFields {
+		fieldName := v.definition.InputValueDefinitionNameBytes(ref)
+		fieldTypeRef := v.definition.InputValueDefinitionType(ref)
+
+		v.path = append(append(v.path, '.'), fieldName...)
+		v.keys = append(v.keys, string(fieldName))
+
+		fieldValue, fieldDataType, _, err := jsonparser.Get(value, unsafebytes.BytesToString(fieldName))
+		switch {
+		case err == jsonparser.KeyPathNotFoundError:
+			if v.definition.TypeIsNonNull(fieldTypeRef) && !v.definition.InputValueDefinitionHasDefaultValue(ref) {
+				v.path, v.keys = v.path[:pathLength], v.keys[:keysLength]
+				v.invalidValue(fmt.Sprintf(`Field "%s" of required type "%s" was not provided.`, fieldName, v.printType(v.definition, fieldTypeRef)))
+			}
+		case err != nil:
+			v.report.AddInternalError(err)
+		default:
+			v.validateValue(v.definition, fieldTypeRef, fieldValue, fieldDataType)
+		}
+
+		v.path, v.keys = v.path[:pathLength], v.keys[:keysLength]
+	}
+}
+
+func (v *VariablesValidator) invalidValue(reason string) {
+	v.report.AddExternalError(operationreport.ErrVariableGotInvalidValue(v.variableName, v.path, reason, v.variablePosition))
+}
+
+func (v *VariablesValidator) printType(document *ast.Document, typeRef int) []byte {
+	printed, _ := document.PrintTypeBytes(typeRef, nil)
+	return printed
+}
+
+func printValue(value []byte, dataType jsonparser.ValueType) string {
+	if dataType == jsonparser.String {
+		// string values are still escaped, so they only need to be quoted
+		return `"` + string(value) + `"`
+	}
+	return string(value)
+}
@@ -0,0 +1,164 @@
+package variablesvalidation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wundergraph/graphql-go-tools/internal/pkg/unsafeparser"
+	"github.com/wundergraph/graphql-go-tools/pkg/asttransform"
+	"github.com/wundergraph/graphql-go-tools/pkg/operationreport"
+	"github.com/wundergraph/graphql-go-tools/pkg/scalars"
+)
+
+const testDefinition = `
+schema {
+	query: Query
+}
+
+type Query {
+	items(input: ItemsInput): [String]
+	item(id: ID!, color: Color): String
+	numbers(values: [[Int!]]!): [Int]
+}
+
+enum Color {
+	RED
+	GREEN
+}
+
+input ItemsInput {
+	name: String
+	items: [Item!]!
+	limit: Int = 10
+	price: Float
+	active: Boolean
+}
+
+input Item {
+	qty: Int!
+	color: Color
+}
+`
+
+func TestVariablesValidator_Validate(t *testing.T) {
+	run := func(operation, variables string, expectedErrors ...string) func(t *testing.T) {
+		return func(t *testing.T) {
+			definitionDocument := unsafeparser.ParseGraphqlDocumentString(testDefinition)
+			require.NoError(t, asttransform.MergeDefinitionWithBaseSchema(&definitionDocument))
+			operationDocument := unsafeparser.ParseGraphqlDocumentString(operation)
+
+			report := operationreport.Report{}
+			NewVariablesValidator().Validate(&operationDocument, &definitionDocument, nil, []byte(variables), &report)
+			require.Empty(t, report.InternalErrors)
+
+			actualErrors := make([]string, 0, len(report.ExternalErrors))
+			for _, externalError := range report.ExternalErrors {
+				actualErrors = append(actualErrors, externalError.Message)
+			}
+			if len(expectedErrors) == 0 {
+				expectedErrors = []string{}
+			}
+			assert.Equal(t, expectedErrors, actualErrors)
+		}
+	}
+
+	itemsOperation := `query Items($input: ItemsInput) { items(input: $input) }`
+	itemOperation := `query Item($id: ID!, $color: Color) { item(id: $id, color: $color) }`
+	numbersOperation := `query Numbers($values: [[Int!]]!) { numbers(values: $values) }`
+
+	t.Run("valid input object", run(itemsOperation,
+		`{"input":{"name":"a","items":[{"qty":1,"color":"RED"},{"qty":2}],"price":1,"active":true}}`))
+	t.Run("nullable variable not provided", run(itemsOperation, `{}`))
+	t.Run("nullable variable is null", run(itemsOperation, `{"input":null}`))
+	t.Run("required variable not provided", run(itemOperation, `{}`,
+		`variable "$id" of required type "ID!" was not provided`))
+	t.Run("non-null variable is null", run(itemOperation, `{"id":null}`,
+		`variable "$id" of non-null type "ID!" must not be null`))
+	t.Run("id as integer", run(itemOperation, `{"id":1}`))
+	t.Run("id as float", run(itemOperation, `{"id":1.5}`,
+		`variable "$id" got invalid value at "id"; ID cannot represent value: 1.5`))
+	t.Run("id as boolean", run(itemOperation, `{"id":true}`,
+		`variable "$id" got invalid value at "id"; ID cannot represent value: true`))
+	t.Run("unknown enum value", run(itemOperation, `{"id":"1","color":"BLUE"}`,
+		`variable "$color" got invalid value at "color"; Value "BLUE" does not exist in "Color" enum.`))
+	t.Run("enum value is no string", run(itemOperation, `{"id":"1","color":1}`,
+		`variable "$color" got invalid value at "color"; Enum "Color" cannot represent non-string value: 1.`))
+	t.Run("invalid nested value", run(itemsOperation, `{"input":{"items":[{"qty":1},{"qty":2},{"qty":1.5,"color":"BLUE"}]}}`,
+		`variable "$input" got invalid value at "input.items[2].qty"; Int cannot represent non-integer value: 1.5`,
+		`variable "$input" got invalid value at "input.items[2].color"; Value "BLUE" does not exist in "Color" enum.`))
+	t.Run("missing required input field", run(itemsOperation, `{"input":{"items":[{}]}}`,
+		`variable "$input" got invalid value at "input.items[0]"; Field "qty" of required type "Int!" was not provided.`))
+	t.Run("missing required input field with list", run(itemsOperation, `{"input":{}}`,
+		`variable "$input" got invalid value at "input"; Field "items" of required type "[Item!]!" was not provided.`))
+	t.Run("null in non-null list", run(itemsOperation, `{"input":{"items":[null]}}`,
+		`variable "$input" got invalid value at "input.items[0]"; Expected non-nullable type "Item!" not to be null.`))
+	t.Run("unknown input field", run(itemsOperation, `{"input":{"items":[],"unknown":1}}`,
+		`variable "$input" got invalid value at "input"; Field "unknown" is not defined by type "ItemsInput".`))
+	t.Run("input object is no object", run(itemsOperation, `{"input":"items"}`,
+		`variable "$input" got invalid value at "input"; Expected type "ItemsInput" to be an object.`))
+	t.Run("invalid built-in scalars", run(itemsOperation, `{"input":{"items":[],"name":1,"price":"1","active":"true","limit":"10"}}`,
+		`variable "$input" got invalid value at "input.name"; String cannot represent a non string value: 1`,
+		`variable "$input" got invalid value at "input.limit"; Int cannot represent non-integer value: "10"`,
+		`variable "$input" got invalid value at "input.price"; Float cannot represent non numeric value: "1"`,
+		`variable "$input" got invalid value at "input.active"; Boolean cannot represent a non boolean value: "true"`))
+	t.Run("int out of 32-bit range", run(numbersOperation, `{"values":[[2147483647,-2147483648,2147483648]]}`,
+		`variable "$values" got invalid value at "values[0][2]"; Int cannot represent non 32-bit signed integer value: 2147483648`))
+	t.Run("single value for list", run(numbersOperation, `{"values":1}`))
+	t.Run("single invalid value for list", run(numbersOperation, `{"values":"a"}`,
+		`variable "$values" got invalid value at "values"; Int cannot represent non-integer value: "a"`))
+
+	t.Run("custom scalars", func(t *testing.T) {
+		definition := `
+			schema { query: Query }
+			scalar UUID
+			scalar DateTime
+			scalar Unregistered
+			type Query { node(id: UUID!, ids: [UUID!], after: DateTime, other: Unregistered): String }`
+		operation := `query Node($id: UUID!, $ids: [UUID!], $after: DateTime, $other: Unregistered) { node(id: $id, ids: $ids, after: $after, other: $other) }`
+
+		validate := func(t *testing.T, variables string) ([]byte, operationreport.Report) {
+			definitionDocument := unsafeparser.ParseGraphqlDocumentString(definition)
+			require.NoError(t, asttransform.MergeDefinitionWithBaseSchema(&definitionDocument))
+			operationDocument := unsafeparser.ParseGraphqlDocumentString(operation)
+
+			report := operationreport.Report{}
+			validator := NewVariablesValidator(WithScalarRegistry(scalars.DefaultRegistry()))
+			coerced := validator.Validate(&operationDocument, &definitionDocument, nil, []byte(variables), &report)
+			return coerced, report
+		}
+
+		t.Run("values are coerced", func(t *testing.T) {
+			coerced, report := validate(t, `{"id":"9B2E3F6A-1C4D-4E5F-8A9B-0C1D2E3F4A5B","ids":["00000000-0000-0000-0000-00000000000A"],"after":"2022-10-19T10:00:00Z","other":1}`)
+			require.False(t, report.HasErrors())
+			assert.JSONEq(t, `{"id":"9b2e3f6a-1c4d-4e5f-8a9b-0c1d2e3f4a5b","ids":["00000000-0000-0000-0000-00000000000a"],"after":"2022-10-19T10:00:00Z","other":1}`, string(coerced))
+		})
+
+		t.Run("unchanged variables are returned as is", func(t *testing.T) {
+			variables := `{"id":"9b2e3f6a-1c4d-4e5f-8a9b-0c1d2e3f4a5b"}`
+			coerced, report := validate(t, variables)
+			require.False(t, report.HasErrors())
+			assert.Equal(t, variables, string(coerced))
+		})
+
+		t.Run("invalid values", func(t *testing.T) {
+			_, report := validate(t, `{"id":"1","ids":[1],"after":"yesterday"}`)
+			require.Len(t, report.ExternalErrors, 3)
+			assert.Equal(t, `variable "$id" got invalid value at "id"; UUID cannot represent an invalid UUID string: "1"`, report.ExternalErrors[0].Message)
+			assert.Equal(t, `variable "$ids" got invalid value at "ids[0]"; UUID cannot represent a non string value: 1`, report.ExternalErrors[1].Message)
+			assert.Equal(t, `variable "$after" got invalid value at "after"; DateTime cannot represent an invalid date-time string: "yesterday"`, report.ExternalErrors[2].Message)
+		})
+	})
+
+	t.Run("validates the named operation", func(t *testing.T) {
+		definitionDocument := unsafeparser.ParseGraphqlDocumentString(testDefinition)
+		require.NoError(t, asttransform.MergeDefinitionWithBaseSchema(&definitionDocument))
+		operationDocument := unsafeparser.ParseGraphqlDocumentString(itemsOperation + " " + itemOperation)
+
+		report := operationreport.Report{}
+		NewVariablesValidator().Validate(&operationDocument, &definitionDocument, []byte("Item"), []byte(`{}`), &report)
+		require.Len(t, report.ExternalErrors, 1)
+		assert.Equal(t, `variable "$id" of required type "ID!" was not provided`, report.ExternalErrors[0].Message)
+	})
+}